@@ -0,0 +1,207 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"github.com/observiq/bindplane-op/store/search"
+	"github.com/observiq/bindplane-op/store/stats"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+var tracer = otel.Tracer("alerts")
+
+// Evaluator evaluates the conditions of all alerts in the store, updates their status, and sends notifications when
+// their state changes.
+type Evaluator interface {
+	// EvaluateAlerts evaluates all alerts. Errors evaluating individual alerts are logged so that one alert cannot
+	// prevent the others from being evaluated.
+	EvaluateAlerts(ctx context.Context) error
+}
+
+type evaluator struct {
+	store    store.Store
+	notifier Notifier
+	logger   *zap.Logger
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewEvaluator returns a new Evaluator that sends notifications with the notifier
+func NewEvaluator(s store.Store, notifier Notifier, logger *zap.Logger) Evaluator {
+	return &evaluator{
+		store:    s,
+		notifier: notifier,
+		logger:   logger.Named("alerts"),
+		now:      time.Now,
+	}
+}
+
+var _ Evaluator = (*evaluator)(nil)
+
+// EvaluateAlerts evaluates all alerts
+func (e *evaluator) EvaluateAlerts(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "alerts/EvaluateAlerts")
+	defer span.End()
+
+	// the urls of slack channels are needed to send notifications
+	alerts, err := e.store.Alerts(model.ContextWithoutSensitiveParameterMasking(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get alerts: %w", err)
+	}
+	for _, alert := range alerts {
+		if err := e.evaluateAlert(ctx, alert); err != nil {
+			e.logger.Error("failed to evaluate alert", zap.String("alert", alert.Name()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (e *evaluator) evaluateAlert(ctx context.Context, alert *model.Alert) error {
+	firing, message, err := e.evaluateCondition(ctx, &alert.Spec.Condition)
+	if err != nil {
+		return err
+	}
+
+	notification := alert.Transition(firing, message, e.now())
+	if notification == nil {
+		return nil
+	}
+
+	status := alert.Status
+	if err := e.notifier.Notify(ctx, alert, notification); err != nil {
+		e.logger.Error("failed to send alert notifications", zap.String("alert", alert.Name()), zap.Error(err))
+		status.NotificationError = err.Error()
+	}
+
+	if _, err := e.store.UpdateAlertStatus(ctx, alert.Name(), status); err != nil {
+		return fmt.Errorf("failed to update alert status: %w", err)
+	}
+	return nil
+}
+
+// evaluateCondition returns true and a message describing the condition if the condition is met
+func (e *evaluator) evaluateCondition(ctx context.Context, condition *model.AlertCondition) (firing bool, message string, err error) {
+	switch condition.Type {
+	case model.AlertConditionAgentStatus:
+		return e.evaluateAgentStatus(ctx, condition)
+	case model.AlertConditionRolloutStatus:
+		return e.evaluateRolloutStatus(ctx, condition)
+	case model.AlertConditionThroughput:
+		return e.evaluateThroughput(ctx, condition)
+	default:
+		return false, "", fmt.Errorf("unknown condition type %q", condition.Type)
+	}
+}
+
+func (e *evaluator) evaluateAgentStatus(ctx context.Context, condition *model.AlertCondition) (bool, string, error) {
+	index := e.store.AgentIndex(ctx)
+	ids, err := search.Field(ctx, index, "status", strconv.Quote(condition.AgentStatus))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to search agents: %w", err)
+	}
+	if condition.Selector != nil {
+		selected := index.Select(ctx, condition.Selector.MatchLabels)
+		matching := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if slices.Contains(selected, id) {
+				matching = append(matching, id)
+			}
+		}
+		ids = matching
+	}
+
+	switch len(ids) {
+	case 0:
+		return false, "", nil
+	case 1:
+		return true, fmt.Sprintf("1 agent has status %s", condition.AgentStatus), nil
+	default:
+		return true, fmt.Sprintf("%d agents have status %s", len(ids), condition.AgentStatus), nil
+	}
+}
+
+func (e *evaluator) evaluateRolloutStatus(ctx context.Context, condition *model.AlertCondition) (bool, string, error) {
+	names, err := search.Field(ctx, e.store.ConfigurationIndex(ctx), "rollout-status", condition.RolloutStatus)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to search configurations: %w", err)
+	}
+
+	var matching []string
+	for _, name := range names {
+		if condition.Configuration == "" || condition.Configuration == name {
+			matching = append(matching, name)
+		}
+	}
+	if len(matching) == 0 {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("rollout of %s has status %s", strings.Join(matching, ", "), condition.RolloutStatus), nil
+}
+
+func (e *evaluator) evaluateThroughput(ctx context.Context, condition *model.AlertCondition) (bool, string, error) {
+	period := condition.PeriodDuration()
+	metrics, err := e.store.Measurements().ConfigurationMetrics(ctx, condition.Configuration, stats.WithPeriod(period))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get configuration metrics: %w", err)
+	}
+
+	throughput := 0.0
+	for _, m := range metrics {
+		position, _, name := stats.ProcessorParsed(m)
+		if position != string(model.MeasurementPositionDestinationAfterProcessors) {
+			continue
+		}
+		if condition.Destination != "" && destinationName(name) != condition.Destination {
+			continue
+		}
+		if value, ok := stats.Value(m); ok {
+			throughput += value
+		}
+	}
+	if throughput > condition.Threshold {
+		return false, "", nil
+	}
+
+	subject := condition.Configuration
+	if condition.Destination != "" {
+		subject = fmt.Sprintf("%s destination %s", condition.Configuration, condition.Destination)
+	}
+	return true, fmt.Sprintf("throughput of %s over the last %s is %s B/s, at or below the threshold of %s B/s",
+		subject, period, formatFloat(throughput), formatFloat(condition.Threshold)), nil
+}
+
+// destinationName removes the index suffix from the name of a destination in a measurement processor, e.g.
+// "logging-0" => "logging"
+func destinationName(name string) string {
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
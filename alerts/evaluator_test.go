@@ -0,0 +1,223 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/otlp/record"
+	"github.com/observiq/bindplane-op/store"
+	"github.com/observiq/bindplane-op/store/search"
+	"github.com/observiq/bindplane-op/store/stats"
+	statsmocks "github.com/observiq/bindplane-op/store/stats/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testNotifier records alerts and notifications and returns err from Notify
+type testNotifier struct {
+	alerts        []*model.Alert
+	notifications []*model.AlertNotification
+	err           error
+}
+
+func (n *testNotifier) Notify(_ context.Context, alert *model.Alert, notification *model.AlertNotification) error {
+	n.alerts = append(n.alerts, alert)
+	n.notifications = append(n.notifications, notification)
+	return n.err
+}
+
+// measurementsStore replaces the measurements of the mapstore, which doesn't store measurements
+type measurementsStore struct {
+	store.Store
+	measurements stats.Measurements
+}
+
+func (s *measurementsStore) Measurements() stats.Measurements {
+	return s.measurements
+}
+
+func newTestEvaluator(t *testing.T, s store.Store, notifier Notifier) *evaluator {
+	e := NewEvaluator(s, notifier, zap.NewNop()).(*evaluator)
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	return e
+}
+
+func newTestStore(t *testing.T) store.Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s := store.NewMapStore(ctx, store.Options{SessionsSecret: "super-secret-key", MaxEventsToMerge: 1, DisableRolloutUpdater: true}, zap.NewNop())
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func applyAlert(t *testing.T, s store.Store, alert *model.Alert) {
+	_, err := s.ApplyResources(context.Background(), []model.Resource{alert})
+	require.NoError(t, err)
+}
+
+func throughputMetric(processor string, value float64) *record.Metric {
+	return &record.Metric{
+		Name:       stats.LogDataSizeMetricName,
+		Value:      value,
+		Attributes: map[string]any{stats.ProcessorAttributeName: processor},
+	}
+}
+
+func TestEvaluateAgentStatus(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	notifier := &testNotifier{}
+	e := newTestEvaluator(t, s, notifier)
+
+	applyAlert(t, s, model.NewAlert("prod-errors", model.AlertSpec{
+		Condition: model.AlertCondition{
+			Type:        model.AlertConditionAgentStatus,
+			AgentStatus: "Error",
+			Selector:    &model.AgentSelector{MatchLabels: model.MatchLabels{"env": "prod"}},
+		},
+		Channels: []model.AlertChannel{{Type: model.AlertChannelSlack, URL: "https://hooks.slack.com/services/T/B/X"}},
+	}))
+
+	// an agent with an error that doesn't match the selector
+	_, err := s.UpsertAgent(ctx, "dev", func(a *model.Agent) {
+		a.Status = model.Error
+		a.Labels = model.LabelsFromValidatedMap(map[string]string{"env": "dev"})
+	})
+	require.NoError(t, err)
+	_, err = s.UpsertAgent(ctx, "prod", func(a *model.Agent) {
+		a.Status = model.Connected
+		a.Labels = model.LabelsFromValidatedMap(map[string]string{"env": "prod"})
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Empty(t, notifier.notifications)
+
+	_, err = s.UpdateAgent(ctx, "prod", func(a *model.Agent) { a.Status = model.Error })
+	require.NoError(t, err)
+
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, model.AlertStateFiring, notifier.notifications[0].State)
+	require.Equal(t, "1 agent has status Error", notifier.notifications[0].Message)
+	// the notifier receives the slack url, which is masked when the alert is read
+	require.Equal(t, "https://hooks.slack.com/services/T/B/X", notifier.alerts[0].Spec.Channels[0].URL)
+
+	alert, err := s.Alert(ctx, "prod-errors")
+	require.NoError(t, err)
+	require.True(t, alert.Firing())
+	require.Equal(t, e.now(), *alert.Status.Since)
+
+	// no notification while it remains firing
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Len(t, notifier.notifications, 1)
+
+	_, err = s.UpdateAgent(ctx, "prod", func(a *model.Agent) { a.Status = model.Connected })
+	require.NoError(t, err)
+
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Len(t, notifier.notifications, 2)
+	require.Equal(t, model.AlertStateResolved, notifier.notifications[1].State)
+
+	alert, err = s.Alert(ctx, "prod-errors")
+	require.NoError(t, err)
+	require.Equal(t, model.AlertStateResolved, alert.Status.State)
+}
+
+func TestEvaluateRolloutStatus(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	notifier := &testNotifier{}
+	e := newTestEvaluator(t, s, notifier)
+
+	config := model.NewConfiguration("config")
+	_, err := s.ApplyResources(ctx, []model.Resource{config})
+	require.NoError(t, err)
+
+	status := model.RolloutStatusPending.String()
+	indexed, err := search.Field(ctx, s.ConfigurationIndex(ctx), "rollout-status", status)
+	require.NoError(t, err)
+	require.Equal(t, []string{"config"}, indexed)
+
+	applyAlert(t, s, model.NewAlert("other-rollout", model.AlertSpec{
+		Condition: model.AlertCondition{Type: model.AlertConditionRolloutStatus, RolloutStatus: status, Configuration: "other"},
+	}))
+	applyAlert(t, s, model.NewAlert("config-rollout", model.AlertSpec{
+		Condition: model.AlertCondition{Type: model.AlertConditionRolloutStatus, RolloutStatus: status, Configuration: "config"},
+	}))
+
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, "config-rollout", notifier.notifications[0].Alert)
+	require.Equal(t, "rollout of config has status Pending", notifier.notifications[0].Message)
+}
+
+func TestEvaluateThroughput(t *testing.T) {
+	ctx := context.Background()
+	measurements := statsmocks.NewMockMeasurements(t)
+	s := &measurementsStore{Store: newTestStore(t), measurements: measurements}
+	notifier := &testNotifier{err: errors.New("webhook channel: unexpected response status 500")}
+	e := newTestEvaluator(t, s, notifier)
+
+	applyAlert(t, s, model.NewAlert("low-throughput", model.AlertSpec{
+		Condition: model.AlertCondition{
+			Type:          model.AlertConditionThroughput,
+			Configuration: "config",
+			Destination:   "otlp",
+			Threshold:     100,
+			Period:        "1h",
+		},
+	}))
+
+	var metrics stats.MetricData
+	measurements.On("ConfigurationMetrics", mock.Anything, "config", mock.Anything).
+		Return(func(context.Context, string, ...stats.QueryOption) stats.MetricData { return metrics }, nil)
+
+	metrics = stats.MetricData{
+		throughputMetric("throughputmeasurement/_d1_logs_otlp-0", 80),
+		throughputMetric("throughputmeasurement/_d1_metrics_otlp-0", 40),
+		throughputMetric("throughputmeasurement/_d1_logs_logging-1", 0),
+		throughputMetric("throughputmeasurement/_s0_logs_source0", 0),
+	}
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Empty(t, notifier.notifications)
+
+	metrics = stats.MetricData{
+		throughputMetric("throughputmeasurement/_d1_logs_otlp-0", 60),
+		throughputMetric("throughputmeasurement/_d1_metrics_otlp-0", 40),
+		throughputMetric("throughputmeasurement/_d1_logs_logging-1", 500),
+	}
+	require.NoError(t, e.EvaluateAlerts(ctx))
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, "throughput of config destination otlp over the last 1h0m0s is 100 B/s, at or below the threshold of 100 B/s", notifier.notifications[0].Message)
+
+	alert, err := s.Alert(ctx, "low-throughput")
+	require.NoError(t, err)
+	require.True(t, alert.Firing())
+	require.Equal(t, "webhook channel: unexpected response status 500", alert.Status.NotificationError)
+}
+
+func TestDestinationName(t *testing.T) {
+	require.Equal(t, "otlp", destinationName("otlp-0"))
+	require.Equal(t, "my-otlp", destinationName("my-otlp-12"))
+	require.Equal(t, "otlp", destinationName("otlp"))
+}
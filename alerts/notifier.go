@@ -0,0 +1,151 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerts evaluates Alert resources and sends notifications to their channels when they fire and resolve.
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// notifyTimeout is the timeout used for webhook requests
+const notifyTimeout = 10 * time.Second
+
+// Notifier sends notifications to the channels of an alert
+type Notifier interface {
+	// Notify sends the notification to all of the channels of the alert. It returns the errors of any channels that
+	// failed.
+	Notify(ctx context.Context, alert *model.Alert, notification *model.AlertNotification) error
+}
+
+// sendMailFunc matches smtp.SendMail and is replaced in tests
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+type notifier struct {
+	client   *http.Client
+	smtp     config.SMTP
+	sendMail sendMailFunc
+}
+
+var _ Notifier = (*notifier)(nil)
+
+// NewNotifier returns a Notifier that posts to webhook and slack channels and sends email using the SMTP server. Email
+// channels fail if no SMTP server is configured.
+func NewNotifier(smtpConfig config.SMTP) Notifier {
+	return &notifier{
+		client:   &http.Client{Timeout: notifyTimeout},
+		smtp:     smtpConfig,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Notify sends the notification to all of the channels of the alert
+func (n *notifier) Notify(ctx context.Context, alert *model.Alert, notification *model.AlertNotification) error {
+	var errs []error
+	for _, channel := range alert.Spec.Channels {
+		if err := n.notifyChannel(ctx, channel, notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s channel: %w", channel.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *notifier) notifyChannel(ctx context.Context, channel model.AlertChannel, notification *model.AlertNotification) error {
+	switch channel.Type {
+	case model.AlertChannelWebhook:
+		return n.post(ctx, channel.URL, notification)
+	case model.AlertChannelSlack:
+		return n.post(ctx, channel.URL, slackMessage{Text: slackText(notification)})
+	case model.AlertChannelEmail:
+		return n.email(channel.To, notification)
+	default:
+		return fmt.Errorf("unknown channel type %q", channel.Type)
+	}
+}
+
+// post sends the body as JSON to the url and returns an error if the response is not successful
+func (n *notifier) post(ctx context.Context, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// email sends the notification to the recipients using the SMTP server
+func (n *notifier) email(to []string, notification *model.AlertNotification) error {
+	if !n.smtp.Enabled() {
+		return errors.New("no SMTP server is configured")
+	}
+
+	var auth smtp.Auth
+	if n.smtp.Username != "" {
+		auth = smtp.PlainAuth("", n.smtp.Username, n.smtp.Password, n.smtp.Host)
+	}
+	return n.sendMail(n.smtp.Address(), auth, n.smtp.From, to, emailMessage(n.smtp.From, to, notification))
+}
+
+// slackMessage is the payload of a Slack-compatible incoming webhook
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func subject(notification *model.AlertNotification) string {
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(string(notification.State)), notification.Alert)
+}
+
+func slackText(notification *model.AlertNotification) string {
+	return fmt.Sprintf("*%s*: %s", subject(notification), notification.Message)
+}
+
+func emailMessage(from string, to []string, notification *model.AlertNotification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: BindPlane alert %s\r\n", subject(notification))
+	fmt.Fprintf(&buf, "Date: %s\r\n", notification.Time.Format(time.RFC1123Z))
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	fmt.Fprintf(&buf, "%s\r\n", notification.Message)
+	return buf.Bytes()
+}
@@ -0,0 +1,107 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+var testNotification = &model.AlertNotification{
+	Alert:   "errors",
+	State:   model.AlertStateFiring,
+	Message: "1 agent has status Error",
+	Time:    time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestNotifyWebhooks(t *testing.T) {
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies[r.URL.Path] = string(body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	alert := model.NewAlert("errors", model.AlertSpec{
+		Channels: []model.AlertChannel{
+			{Type: model.AlertChannelWebhook, URL: server.URL + "/webhook"},
+			{Type: model.AlertChannelSlack, URL: server.URL + "/slack"},
+		},
+	})
+	n := NewNotifier(config.SMTP{})
+	require.NoError(t, n.Notify(context.Background(), alert, testNotification))
+
+	var webhook model.AlertNotification
+	require.NoError(t, json.Unmarshal([]byte(bodies["/webhook"]), &webhook))
+	require.Equal(t, *testNotification, webhook)
+	require.JSONEq(t, `{"text":"*[FIRING] errors*: 1 agent has status Error"}`, bodies["/slack"])
+
+	alert.Spec.Channels = []model.AlertChannel{{Type: model.AlertChannelWebhook, URL: server.URL + "/fail"}}
+	require.EqualError(t, n.Notify(context.Background(), alert, testNotification), "webhook channel: unexpected response status 500 Internal Server Error")
+}
+
+func TestNotifyEmail(t *testing.T) {
+	alert := model.NewAlert("errors", model.AlertSpec{
+		Channels: []model.AlertChannel{
+			{Type: model.AlertChannelEmail, To: []string{"ops@example.com", "dev@example.com"}},
+		},
+	})
+
+	t.Run("no smtp server", func(t *testing.T) {
+		n := NewNotifier(config.SMTP{})
+		require.EqualError(t, n.Notify(context.Background(), alert, testNotification), "email channel: no SMTP server is configured")
+	})
+
+	t.Run("sends email", func(t *testing.T) {
+		n := NewNotifier(config.SMTP{Host: "smtp.example.com", Port: "465", Username: "user", Password: "pass", From: "bindplane@example.com"}).(*notifier)
+
+		var sent struct {
+			addr string
+			auth smtp.Auth
+			from string
+			to   []string
+			msg  string
+		}
+		n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			sent.addr, sent.auth, sent.from, sent.to, sent.msg = addr, a, from, to, string(msg)
+			return nil
+		}
+
+		require.NoError(t, n.Notify(context.Background(), alert, testNotification))
+		require.Equal(t, "smtp.example.com:465", sent.addr)
+		require.NotNil(t, sent.auth)
+		require.Equal(t, "bindplane@example.com", sent.from)
+		require.Equal(t, []string{"ops@example.com", "dev@example.com"}, sent.to)
+		require.Contains(t, sent.msg, "To: ops@example.com, dev@example.com\r\n")
+		require.Contains(t, sent.msg, "Subject: BindPlane alert [FIRING] errors\r\n")
+		require.Contains(t, sent.msg, "\r\n\r\n1 agent has status Error\r\n")
+	})
+}
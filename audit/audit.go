@@ -0,0 +1,76 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the changes made through the REST and GraphQL APIs
+package audit
+
+import (
+	"context"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+type actorContextKey struct{}
+
+type actor struct {
+	name   string
+	origin string
+}
+
+// WithActor returns a copy of the context with the login ID of the user or API key making the request and the address
+// of the client that made it
+func WithActor(ctx context.Context, name, origin string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor{name: name, origin: origin})
+}
+
+// ActorFromContext returns the login ID and client address set by WithActor
+func ActorFromContext(ctx context.Context) (name, origin string) {
+	a, _ := ctx.Value(actorContextKey{}).(actor)
+	return a.name, a.origin
+}
+
+// NewEvent returns a new event for the actor of the request
+func NewEvent(ctx context.Context, action model.AuditAction, kind model.Kind, name string, version model.Version) *model.AuditEvent {
+	actor, origin := ActorFromContext(ctx)
+	return model.NewAuditEvent(actor, origin, action, kind, name, version)
+}
+
+// ResourceEvent returns a new event for a change to the resource by the actor of the request
+func ResourceEvent(ctx context.Context, action model.AuditAction, resource model.Resource) *model.AuditEvent {
+	return NewEvent(ctx, action, resource.GetKind(), resource.Name(), resource.Version())
+}
+
+// StatusEvents returns events for the resources that were created, configured, or deleted. Resources that were not
+// changed are skipped.
+func StatusEvents(ctx context.Context, statuses []model.ResourceStatus) []*model.AuditEvent {
+	events := []*model.AuditEvent{}
+	for _, status := range statuses {
+		var action model.AuditAction
+		switch status.Status {
+		case model.StatusCreated:
+			action = model.AuditActionCreate
+		case model.StatusConfigured:
+			action = model.AuditActionUpdate
+		case model.StatusDeleted:
+			action = model.AuditActionDelete
+		default:
+			continue
+		}
+		if status.Resource == nil {
+			continue
+		}
+		events = append(events, ResourceEvent(ctx, action, status.Resource))
+	}
+	return events
+}
@@ -0,0 +1,77 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorFromContext(t *testing.T) {
+	actor, origin := ActorFromContext(context.Background())
+	require.Empty(t, actor)
+	require.Empty(t, origin)
+
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+	actor, origin = ActorFromContext(ctx)
+	require.Equal(t, "admin", actor)
+	require.Equal(t, "10.0.0.1", origin)
+
+	event := NewEvent(ctx, model.AuditActionAgentUpgrade, model.KindAgent, "agent-1", 0)
+	require.Equal(t, "admin", event.Actor)
+	require.Equal(t, "10.0.0.1", event.Origin)
+	require.Equal(t, "agent-1", event.ResourceName)
+	require.NotEmpty(t, event.ID)
+}
+
+func TestStatusEvents(t *testing.T) {
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+
+	created := model.NewSource("created", "macos", nil)
+	configured := model.NewSource("configured", "macos", nil)
+	configured.SetVersion(2)
+	deleted := model.NewDestination("deleted", "cabin", nil)
+	unchanged := model.NewSource("unchanged", "macos", nil)
+	invalid := model.NewSource("invalid", "macos", nil)
+
+	events := StatusEvents(ctx, []model.ResourceStatus{
+		*model.NewResourceStatus(created, model.StatusCreated),
+		*model.NewResourceStatus(configured, model.StatusConfigured),
+		*model.NewResourceStatus(deleted, model.StatusDeleted),
+		*model.NewResourceStatus(unchanged, model.StatusUnchanged),
+		*model.NewResourceStatusWithReason(invalid, model.StatusInvalid, "invalid"),
+	})
+	require.Len(t, events, 3)
+
+	require.Equal(t, model.AuditActionCreate, events[0].Action)
+	require.Equal(t, model.KindSource, events[0].ResourceKind)
+	require.Equal(t, "created", events[0].ResourceName)
+
+	require.Equal(t, model.AuditActionUpdate, events[1].Action)
+	require.Equal(t, "configured", events[1].ResourceName)
+	require.Equal(t, model.Version(2), events[1].ResourceVersion)
+
+	require.Equal(t, model.AuditActionDelete, events[2].Action)
+	require.Equal(t, model.KindDestination, events[2].ResourceKind)
+	require.Equal(t, "deleted", events[2].ResourceName)
+
+	for _, event := range events {
+		require.Equal(t, "admin", event.Actor)
+		require.Equal(t, "10.0.0.1", event.Origin)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/observiq/bindplane-op/model"
+	"go.uber.org/zap"
+)
+
+// Store persists audit events. It is implemented by store.Store.
+type Store interface {
+	AddAuditEvents(ctx context.Context, events []*model.AuditEvent) error
+}
+
+// Recorder records audit events for changes that have been made. Changes have already succeeded when they are
+// recorded, so failures to record events are logged instead of being returned to the caller.
+type Recorder interface {
+	Record(ctx context.Context, events ...*model.AuditEvent)
+}
+
+type recorder struct {
+	store       Store
+	filePath    string
+	maxFileSize int64
+	logger      *zap.Logger
+	fileMtx     sync.Mutex
+}
+
+var _ Recorder = (*recorder)(nil)
+
+// NewRecorder returns a Recorder that adds events to the store and, if filePath is not empty, appends them to the file
+// as JSON lines. If maxFileSize is greater than 0, the file is renamed with a .1 suffix before it would grow beyond
+// maxFileSize bytes, replacing the previously rotated file.
+func NewRecorder(store Store, filePath string, maxFileSize int64, logger *zap.Logger) Recorder {
+	return &recorder{
+		store:       store,
+		filePath:    filePath,
+		maxFileSize: maxFileSize,
+		logger:      logger.Named("audit"),
+	}
+}
+
+// Record adds the events to the store and file
+func (r *recorder) Record(_ context.Context, events ...*model.AuditEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	// record the events even if the request is canceled after the change was made
+	if err := r.store.AddAuditEvents(context.Background(), events); err != nil {
+		r.logger.Error("failed to add audit events to the store", zap.Int("count", len(events)), zap.Error(err))
+	}
+
+	if r.filePath != "" {
+		if err := r.writeFile(events); err != nil {
+			r.logger.Error("failed to write audit events to file", zap.String("path", r.filePath), zap.Error(err))
+		}
+	}
+}
+
+// writeFile appends the events to the file, rotating it first if it would exceed the maximum size. The file is opened
+// for each write so that it can also be rotated externally.
+func (r *recorder) writeFile(events []*model.AuditEvent) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode audit event: %w", err)
+		}
+	}
+
+	r.fileMtx.Lock()
+	defer r.fileMtx.Unlock()
+
+	if err := r.rotateFile(int64(buf.Len())); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %w", err)
+	}
+
+	file, err := os.OpenFile(r.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// rotateFile renames the file with a .1 suffix if writing size bytes would make it larger than the maximum size. An
+// empty file is not rotated so that events larger than the maximum size are still written.
+func (r *recorder) rotateFile(size int64) error {
+	if r.maxFileSize <= 0 {
+		return nil
+	}
+	info, err := os.Stat(r.filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	case info.Size() == 0 || info.Size()+size <= r.maxFileSize:
+		return nil
+	}
+	return os.Rename(r.filePath, r.filePath+".1")
+}
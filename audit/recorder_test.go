@@ -0,0 +1,147 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testStore struct {
+	events []*model.AuditEvent
+	err    error
+}
+
+func (s *testStore) AddAuditEvents(_ context.Context, events []*model.AuditEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func TestRecorderStore(t *testing.T) {
+	store := &testStore{}
+	recorder := NewRecorder(store, "", 0, zap.NewNop())
+
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+	recorder.Record(ctx)
+	require.Empty(t, store.events)
+
+	event := NewEvent(ctx, model.AuditActionDelete, model.KindAgent, "agent-1", 0)
+	recorder.Record(ctx, event)
+	require.Equal(t, []*model.AuditEvent{event}, store.events)
+}
+
+func TestRecorderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	store := &testStore{}
+	recorder := NewRecorder(store, path, 0, zap.NewNop())
+
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+	first := NewEvent(ctx, model.AuditActionCreate, model.KindConfiguration, "linux", 1)
+	second := NewEvent(ctx, model.AuditActionRolloutStart, model.KindConfiguration, "linux", 1)
+	third := NewEvent(ctx, model.AuditActionDelete, model.KindConfiguration, "linux", 1)
+	recorder.Record(ctx, first, second)
+	recorder.Record(ctx, third)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []*model.AuditEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		event := &model.AuditEvent{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), event))
+		lines = append(lines, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 3)
+	require.Equal(t, first.ID, lines[0].ID)
+	require.Equal(t, second.Action, lines[1].Action)
+	require.Equal(t, third.Action, lines[2].Action)
+}
+
+func TestRecorderFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+	newEvent := func() *model.AuditEvent {
+		return NewEvent(ctx, model.AuditActionCreate, model.KindConfiguration, "linux", 1)
+	}
+	line, err := json.Marshal(newEvent())
+	require.NoError(t, err)
+	lineSize := int64(len(line) + 1)
+
+	// the file holds two events before it is rotated
+	recorder := NewRecorder(&testStore{}, path, 2*lineSize, zap.NewNop())
+	first, second, third, fourth := newEvent(), newEvent(), newEvent(), newEvent()
+	recorder.Record(ctx, first, second)
+	_, err = os.Stat(path + ".1")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	readIDs := func(path string) []string {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var ids []string
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			event := &model.AuditEvent{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), event))
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+
+	recorder.Record(ctx, third)
+	require.Equal(t, []string{first.ID, second.ID}, readIDs(path+".1"))
+	require.Equal(t, []string{third.ID}, readIDs(path))
+
+	// the rotated file is replaced
+	recorder.Record(ctx, fourth, newEvent())
+	require.Equal(t, []string{third.ID}, readIDs(path+".1"))
+	require.Len(t, readIDs(path), 2)
+	require.Equal(t, fourth.ID, readIDs(path)[0])
+}
+
+func TestRecorderErrors(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	store := &testStore{err: errors.New("store failure")}
+	path := filepath.Join(t.TempDir(), "missing", "audit.jsonl")
+	recorder := NewRecorder(store, path, 0, zap.New(core))
+
+	ctx := WithActor(context.Background(), "admin", "10.0.0.1")
+	recorder.Record(ctx, NewEvent(ctx, model.AuditActionDelete, model.KindAgent, "agent-1", 0))
+
+	// errors are logged and not returned because the change has already been made
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, "failed to add audit events to the store", logs.All()[0].Message)
+	require.Equal(t, "failed to write audit events to file", logs.All()[1].Message)
+}
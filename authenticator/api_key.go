@@ -0,0 +1,115 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticator
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// APIKeyLoginPrefix is prepended to the ID of an API key to form the loginID of requests authenticated with the key
+const APIKeyLoginPrefix = model.APIKeyLoginPrefix
+
+// APIKeyStore provides access to the API keys that can be used to authenticate. It is implemented by store.Store.
+type APIKeyStore interface {
+	APIKey(ctx context.Context, id string) (*model.APIKey, error)
+}
+
+// APIKeyAuthenticator authenticates requests with an API key sent as a bearer token in the Authorization header.
+// Requests without a bearer token and UI logins are handled by the wrapped Authenticator.
+type APIKeyAuthenticator struct {
+	Authenticator
+	apiKeys APIKeyStore
+}
+
+// NewAPIKeyAuthenticator creates an authenticator that accepts API keys in addition to the credentials accepted by
+// next
+func NewAPIKeyAuthenticator(next Authenticator, apiKeys APIKeyStore) Authenticator {
+	return &APIKeyAuthenticator{
+		Authenticator: next,
+		apiKeys:       apiKeys,
+	}
+}
+
+// Middleware returns Authentication middleware that verifies the bearer token if there is one and otherwise uses the
+// middleware of the wrapped Authenticator
+func (a *APIKeyAuthenticator) Middleware() gin.HandlerFunc {
+	next := a.Authenticator.Middleware()
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.Request)
+		if !ok {
+			next(c)
+			return
+		}
+
+		apiKey, err := a.apiKey(c.Request.Context(), token)
+		if err != nil {
+			_ = AbortWithError(c, err)
+			return
+		}
+
+		c.Set(LoginKey, APIKeyLoginPrefix+apiKey.ID)
+		c.Set(UsernameKey, apiKey.Name)
+		c.Set(AuthenticatedKey, true)
+	}
+}
+
+// Role returns the role of the API key for loginIDs with the APIKeyLoginPrefix and otherwise uses the wrapped
+// Authenticator
+func (a *APIKeyAuthenticator) Role(ctx context.Context, loginID string) (model.Role, error) {
+	id, ok := strings.CutPrefix(loginID, APIKeyLoginPrefix)
+	if !ok {
+		return a.Authenticator.Role(ctx, loginID)
+	}
+	apiKey, err := a.apiKeys.APIKey(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if apiKey == nil {
+		return "", ErrBadCreds
+	}
+	return apiKey.Role, nil
+}
+
+// apiKey returns the stored API key matching the key or ErrBadCreds if there is no match
+func (a *APIKeyAuthenticator) apiKey(ctx context.Context, key string) (*model.APIKey, error) {
+	id, secret, err := model.ParseAPIKey(key)
+	if err != nil {
+		return nil, ErrBadCreds
+	}
+	apiKey, err := a.apiKeys.APIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || !apiKey.CheckSecret(secret) {
+		return nil, ErrBadCreds
+	}
+	return apiKey, nil
+}
+
+// bearerToken returns the token from an Authorization: Bearer <token> header
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
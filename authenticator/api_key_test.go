@@ -0,0 +1,127 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	apiKey, key, err := model.NewAPIKey("ci", model.RoleUser, "admin")
+	require.NoError(t, err)
+	require.NoError(t, s.UpsertAPIKey(ctx, apiKey))
+	_, revokedKey, err := model.NewAPIKey("revoked", model.RoleUser, "admin")
+	require.NoError(t, err)
+
+	auth := NewAPIKeyAuthenticator(NewBasicAuthenticator("admin", "adminPassword", s), s)
+
+	tcs := []struct {
+		name          string
+		setup         func(t *testing.T) *gin.Context
+		expectedErr   error
+		expectedLogin string
+	}{
+		{
+			name: "valid api key",
+			setup: func(t *testing.T) *gin.Context {
+				c := testGinContext(t, nil)
+				c.Request.Header.Set("Authorization", "Bearer "+key)
+				return c
+			},
+			expectedLogin: APIKeyLoginPrefix + apiKey.ID,
+		},
+		{
+			name: "revoked api key",
+			setup: func(t *testing.T) *gin.Context {
+				c := testGinContext(t, nil)
+				c.Request.Header.Set("Authorization", "Bearer "+revokedKey)
+				return c
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "malformed api key",
+			setup: func(t *testing.T) *gin.Context {
+				c := testGinContext(t, nil)
+				c.Request.Header.Set("Authorization", "Bearer not-a-key")
+				return c
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "basic auth is delegated",
+			setup: func(t *testing.T) *gin.Context {
+				c := testGinContext(t, nil)
+				c.Request.SetBasicAuth("admin", "adminPassword")
+				return c
+			},
+			expectedLogin: "admin",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.setup(t)
+			auth.Middleware()(c)
+			if tc.expectedErr != nil {
+				require.Equal(t, tc.expectedErr, c.Errors.Last().Err)
+				return
+			}
+			require.Nil(t, c.Errors.Last())
+			require.Equal(t, true, c.Value(AuthenticatedKey))
+			require.Equal(t, tc.expectedLogin, c.Value(LoginKey))
+		})
+	}
+}
+
+func TestAPIKeyRole(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	apiKey, _, err := model.NewAPIKey("monitoring", model.RoleViewer, "admin")
+	require.NoError(t, err)
+	require.NoError(t, s.UpsertAPIKey(ctx, apiKey))
+
+	auth := NewAPIKeyAuthenticator(NewBasicAuthenticator("admin", "adminPassword", s), s)
+
+	role, err := auth.Role(ctx, APIKeyLoginPrefix+apiKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.RoleViewer, role)
+
+	role, err = auth.Role(ctx, "admin")
+	require.NoError(t, err)
+	require.Equal(t, model.RoleAdmin, role)
+
+	// a key that is revoked after the request is authenticated no longer has a role
+	_, err = s.DeleteAPIKey(ctx, apiKey.ID)
+	require.NoError(t, err)
+	_, err = auth.Role(ctx, APIKeyLoginPrefix+apiKey.ID)
+	require.ErrorIs(t, err, ErrBadCreds)
+}
@@ -16,11 +16,14 @@
 package authenticator
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/sessions"
+
+	"github.com/observiq/bindplane-op/model"
 )
 
 const (
@@ -34,6 +37,8 @@ const (
 	UsernameKey = "u"
 	// AuthenticatedKey is a key that is used to identify if the user has been authenticated in the middleware.
 	AuthenticatedKey = "authenticated"
+	// RoleKey is a key that is used to identify the role of the authenticated user in the middleware.
+	RoleKey = "role"
 )
 
 // ErrBadCreds for invalid authentication credentials
@@ -45,6 +50,10 @@ var ErrBadBindCreds = errors.New("incorrect username or password for BindUser")
 // ErrInvalidSession for when username or password are expected and not present
 var ErrInvalidSession = errors.New("failed to retrieve session")
 
+// ErrLoginRedirect is returned by Login when the user is redirected to an external identity provider to login. The
+// session must still be saved so that the login can be completed when the provider redirects back.
+var ErrLoginRedirect = errors.New("login redirected to identity provider")
+
 // ErrMissingCreds is an error for when a google authentication payload doesn't have credentials
 var ErrMissingCreds = errors.New("missing field 'credential'")
 
@@ -68,6 +77,29 @@ type Authenticator interface {
 	// once there is verification that the user is authenticated, authenticatedKey should be set to "true" on the context
 	// It is expected that loginID is set on the context to be used by the check user middleware. if the display name is different than the loginID, set usernameKey.
 	Middleware() gin.HandlerFunc
+
+	// Role returns the role of the user with the specified loginID. It returns ErrBadCreds if the user is not known to
+	// the authenticator.
+	Role(ctx context.Context, loginID string) (model.Role, error)
+}
+
+// UserStore provides access to the users that can login in addition to the server profile user. It is implemented by
+// store.Store.
+type UserStore interface {
+	User(ctx context.Context, name string) (*model.User, error)
+}
+
+type roleContextKey struct{}
+
+// WithRole returns a copy of the context with the role of the authenticated user
+func WithRole(ctx context.Context, role model.Role) context.Context {
+	return context.WithValue(ctx, roleContextKey{}, role)
+}
+
+// RoleFromContext returns the role of the authenticated user set by WithRole
+func RoleFromContext(ctx context.Context) (role model.Role, ok bool) {
+	role, ok = ctx.Value(roleContextKey{}).(model.Role)
+	return role, ok
 }
 
 // LoginInfo is a representation of user that has logged in.
@@ -15,30 +15,41 @@
 package authenticator
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/sessions"
+
+	"github.com/observiq/bindplane-op/model"
 )
 
-// BasicAuthenticator is an authenticator that uses the server profile username and password
+// BasicAuthenticator is an authenticator that uses the server profile username and password. The server profile user
+// is an admin. Additional users with their own roles can be provided by a UserStore.
 type BasicAuthenticator struct {
 	serverUsername string
 	serverPassword string
+	users          UserStore
 }
 
-// NewBasicAuthenticator creates an authenticator for internal server profile
-func NewBasicAuthenticator(username, password string) Authenticator {
+// NewBasicAuthenticator creates an authenticator for internal server profile. If users is nil, only the server profile
+// user can login.
+func NewBasicAuthenticator(username, password string, users UserStore) Authenticator {
 	return &BasicAuthenticator{
 		serverUsername: username,
 		serverPassword: password,
+		users:          users,
 	}
 }
 
 // Verify attempts to login in the user from the session
 func (a *BasicAuthenticator) Verify(c *gin.Context, session *sessions.Session) error {
 	username, password, inSession := LoginIDPasswordFromSession(session)
-	if !inSession || !(username == a.serverUsername && a.serverPassword == password) {
+	if !inSession {
 		return AbortWithError(c, ErrBadCreds)
 	}
+	if err := a.checkCredentials(c.Request.Context(), username, password); err != nil {
+		return AbortWithError(c, err)
+	}
 	return nil
 }
 
@@ -49,8 +60,8 @@ func (a *BasicAuthenticator) Login(c *gin.Context, session *sessions.Session) (*
 
 	loginInfo := &LoginInfo{Username: username}
 
-	if !(username == a.serverUsername && password == a.serverPassword) {
-		return loginInfo, AbortWithError(c, ErrBadCreds)
+	if err := a.checkCredentials(c.Request.Context(), username, password); err != nil {
+		return loginInfo, AbortWithError(c, err)
 	}
 
 	loginInfo.LoginID = username
@@ -69,8 +80,8 @@ func (a *BasicAuthenticator) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		if username != a.serverUsername || password != a.serverPassword {
-			_ = AbortWithError(c, ErrBadCreds)
+		if err := a.checkCredentials(c.Request.Context(), username, password); err != nil {
+			_ = AbortWithError(c, err)
 			return
 		}
 
@@ -78,3 +89,41 @@ func (a *BasicAuthenticator) Middleware() gin.HandlerFunc {
 		c.Set(AuthenticatedKey, true)
 	}
 }
+
+// Role returns admin for the server profile user and the stored role for all other users
+func (a *BasicAuthenticator) Role(ctx context.Context, loginID string) (model.Role, error) {
+	if loginID == a.serverUsername {
+		return model.RoleAdmin, nil
+	}
+	user, err := a.user(ctx, loginID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", ErrBadCreds
+	}
+	return user.Role, nil
+}
+
+// checkCredentials returns ErrBadCreds if the username and password do not match the server profile user or a user in
+// the UserStore
+func (a *BasicAuthenticator) checkCredentials(ctx context.Context, username, password string) error {
+	if username == a.serverUsername && password == a.serverPassword {
+		return nil
+	}
+	user, err := a.user(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.CheckPassword(password) {
+		return ErrBadCreds
+	}
+	return nil
+}
+
+func (a *BasicAuthenticator) user(ctx context.Context, name string) (*model.User, error) {
+	if a.users == nil || name == "" {
+		return nil, nil
+	}
+	return a.users.User(ctx, name)
+}
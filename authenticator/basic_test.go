@@ -15,6 +15,7 @@
 package authenticator
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/sessions"
+	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/store"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -40,7 +42,7 @@ func TestBasicLogin(t *testing.T) {
 	}{
 		{
 			name:            "Valid",
-			auth:            NewBasicAuthenticator("admin", "adminPassword"),
+			auth:            NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:        "admin",
 			password:        "adminPassword",
 			expectedLoginID: &LoginInfo{LoginID: "admin", Username: "admin"},
@@ -51,7 +53,7 @@ func TestBasicLogin(t *testing.T) {
 		},
 		{
 			name:            "Invalid username",
-			auth:            NewBasicAuthenticator("admin", "adminPassword"),
+			auth:            NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:        "bad-user",
 			password:        "adminPassword",
 			expectedLoginID: &LoginInfo{Username: "bad-user"},
@@ -63,7 +65,7 @@ func TestBasicLogin(t *testing.T) {
 		},
 		{
 			name:            "Invalid Password",
-			auth:            NewBasicAuthenticator("admin", "adminPassword"),
+			auth:            NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:        "admin",
 			password:        "bad-password",
 			expectedLoginID: &LoginInfo{Username: "admin"},
@@ -110,20 +112,20 @@ func TestBasicVerify(t *testing.T) {
 	}{
 		{
 			name:     "Valid",
-			auth:     NewBasicAuthenticator("admin", "adminPassword"),
+			auth:     NewBasicAuthenticator("admin", "adminPassword", nil),
 			username: "admin",
 			password: "adminPassword",
 		},
 		{
 			name:        "Invalid username",
-			auth:        NewBasicAuthenticator("admin", "adminPassword"),
+			auth:        NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:    "bad-user",
 			password:    "adminPassword",
 			expectedErr: ErrBadCreds,
 		},
 		{
 			name:        "Invalid password",
-			auth:        NewBasicAuthenticator("admin", "adminPassword"),
+			auth:        NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:    "admin",
 			password:    "bad-Password",
 			expectedErr: ErrBadCreds,
@@ -162,20 +164,20 @@ func TestBasicMiddlWare(t *testing.T) {
 	}{
 		{
 			name:     "Valid",
-			auth:     NewBasicAuthenticator("admin", "adminPassword"),
+			auth:     NewBasicAuthenticator("admin", "adminPassword", nil),
 			username: "admin",
 			password: "adminPassword",
 		},
 		{
 			name:        "Invalid username",
-			auth:        NewBasicAuthenticator("admin", "adminPassword"),
+			auth:        NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:    "bad-user",
 			password:    "adminPassword",
 			expectedErr: ErrBadCreds,
 		},
 		{
 			name:        "Invalid password",
-			auth:        NewBasicAuthenticator("admin", "adminPassword"),
+			auth:        NewBasicAuthenticator("admin", "adminPassword", nil),
 			username:    "admin",
 			password:    "bad-Password",
 			expectedErr: ErrBadCreds,
@@ -199,6 +201,73 @@ func TestBasicMiddlWare(t *testing.T) {
 	}
 }
 
+func TestBasicStoreUsers(t *testing.T) {
+	ctx := context.Background()
+	users := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	viewer, err := model.NewUser("viewer", model.RoleViewer, "viewerPassword")
+	require.NoError(t, err)
+	require.NoError(t, users.UpsertUser(ctx, viewer))
+
+	auth := NewBasicAuthenticator("admin", "adminPassword", users)
+
+	tcs := []struct {
+		name         string
+		username     string
+		password     string
+		expectedErr  error
+		expectedRole model.Role
+	}{
+		{
+			name:         "server user",
+			username:     "admin",
+			password:     "adminPassword",
+			expectedRole: model.RoleAdmin,
+		},
+		{
+			name:         "store user",
+			username:     "viewer",
+			password:     "viewerPassword",
+			expectedRole: model.RoleViewer,
+		},
+		{
+			name:        "store user invalid password",
+			username:    "viewer",
+			password:    "bad-password",
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name:        "unknown user",
+			username:    "unknown",
+			password:    "viewerPassword",
+			expectedErr: ErrBadCreds,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testGinContext(t, nil)
+			c.Request.SetBasicAuth(tc.username, tc.password)
+			auth.Middleware()(c)
+			if tc.expectedErr != nil {
+				require.Equal(t, tc.expectedErr, c.Errors.Last().Err)
+				return
+			}
+			require.Nil(t, c.Errors.Last())
+			require.Equal(t, tc.username, c.Value(LoginKey))
+
+			role, err := auth.Role(ctx, tc.username)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRole, role)
+		})
+	}
+
+	_, err = auth.Role(ctx, "unknown")
+	require.ErrorIs(t, err, ErrBadCreds)
+}
+
 func testGinContext(_ *testing.T, r io.Reader) *gin.Context {
 	// Make a login request
 	w := httptest.NewRecorder()
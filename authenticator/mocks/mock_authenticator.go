@@ -3,11 +3,16 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
+	context "context"
+
 	authenticator "github.com/observiq/bindplane-op/authenticator"
 
+	gin "github.com/gin-gonic/gin"
+
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/observiq/bindplane-op/model"
+
 	sessions "github.com/gorilla/sessions"
 )
 
@@ -58,6 +63,30 @@ func (_m *MockAuthenticator) Middleware() gin.HandlerFunc {
 	return r0
 }
 
+// Role provides a mock function with given fields: ctx, loginID
+func (_m *MockAuthenticator) Role(ctx context.Context, loginID string) (model.Role, error) {
+	ret := _m.Called(ctx, loginID)
+
+	var r0 model.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Role, error)); ok {
+		return rf(ctx, loginID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Role); ok {
+		r0 = rf(ctx, loginID)
+	} else {
+		r0 = ret.Get(0).(model.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loginID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Verify provides a mock function with given fields: ctx, session
 func (_m *MockAuthenticator) Verify(ctx *gin.Context, session *sessions.Session) error {
 	ret := _m.Called(ctx, session)
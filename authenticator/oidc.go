@@ -0,0 +1,383 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/sessions"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+)
+
+const (
+	// oidcSessionKey marks sessions that were created by an OIDC login
+	oidcSessionKey = "oidc"
+	// oidcStateKey stores the state parameter of a pending OIDC login
+	oidcStateKey = "oidc-state"
+	// oidcNonceKey stores the nonce expected in the ID token of a pending OIDC login
+	oidcNonceKey = "oidc-nonce"
+
+	// oidcLoginPath is the UI login page that users are sent to after a successful OIDC login
+	oidcLoginPath = "/login"
+)
+
+// oidcDefaultScopes are requested in addition to openid if no scopes are configured
+var oidcDefaultScopes = []string{"profile", "email"}
+
+// OIDCUserStore stores the users that login with OIDC so that their role can be resolved on later requests. It is
+// implemented by store.Store.
+type OIDCUserStore interface {
+	UserStore
+	UpsertUser(ctx context.Context, user *model.User) error
+}
+
+// errOIDCUserExists is returned by saveUser if the name of an OIDC user belongs to a different user
+var errOIDCUserExists = errors.New("user already exists")
+
+// OIDCAuthenticator authenticates UI logins with an OpenID Connect provider using the authorization code flow. Users
+// are stored in the OIDCUserStore with the issuer and subject of their ID token and the role from its claims on their
+// first login so that the wrapped Authenticator can resolve the role. Logins with a username and password, sessions created by those logins, and requests to the REST
+// endpoints are handled by the wrapped Authenticator.
+type OIDCAuthenticator struct {
+	Authenticator
+	config         config.OIDC
+	serverUsername string
+	users          OIDCUserStore
+	client         *http.Client
+	logger         *zap.Logger
+
+	// provider and keys are discovered on first use so that the server can start while the provider is unavailable
+	mtx          sync.Mutex
+	provider     *oidcProvider
+	keys         *jsonWebKeySet
+	keysFetched  time.Time
+	keysInterval time.Duration
+}
+
+// oidcProvider is the subset of the provider metadata served at /.well-known/openid-configuration that is used for the
+// authorization code flow
+type oidcProvider struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+// NewOIDCAuthenticator creates an authenticator that logs users in with the OpenID Connect provider in cfg in addition
+// to the logins accepted by next. The serverUsername of the server profile user can't be used by OIDC users.
+func NewOIDCAuthenticator(cfg config.OIDC, serverUsername string, next Authenticator, users OIDCUserStore, logger *zap.Logger) Authenticator {
+	return &OIDCAuthenticator{
+		Authenticator:  next,
+		config:         cfg,
+		serverUsername: serverUsername,
+		users:          users,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		logger:         logger,
+		keysInterval:   time.Minute,
+	}
+}
+
+// Login logs in a user with a username and password in the postform using the wrapped Authenticator. Otherwise it
+// redirects the user to the provider or completes the login when the provider redirects back with an authorization
+// code.
+func (a *OIDCAuthenticator) Login(c *gin.Context, session *sessions.Session) (*LoginInfo, error) {
+	if c.PostForm(UsernameKey) != "" {
+		return a.Authenticator.Login(c, session)
+	}
+	if c.Query("code") == "" && c.Query("error") == "" {
+		return a.redirect(c, session)
+	}
+	return a.callback(c, session)
+}
+
+// Verify checks that the OIDC user of an OIDC session still exists and uses the wrapped Authenticator for all other sessions
+func (a *OIDCAuthenticator) Verify(c *gin.Context, session *sessions.Session) error {
+	if isOIDC, _ := session.Values[oidcSessionKey].(bool); !isOIDC {
+		return a.Authenticator.Verify(c, session)
+	}
+
+	loginID, ok := LoginIDFromSession(session)
+	if !ok || loginID == "" {
+		return AbortWithError(c, ErrBadCreds)
+	}
+
+	// users that are deleted are logged out
+	user, err := a.users.User(c.Request.Context(), loginID)
+	if err != nil {
+		return AbortWithError(c, err)
+	}
+	if user == nil || user.Issuer != a.config.Issuer {
+		return AbortWithError(c, ErrBadCreds)
+	}
+	return nil
+}
+
+// redirect starts the authorization code flow by redirecting the user to the provider
+func (a *OIDCAuthenticator) redirect(c *gin.Context, session *sessions.Session) (*LoginInfo, error) {
+	provider, err := a.discover(c.Request.Context())
+	if err != nil {
+		return &LoginInfo{}, AbortWithError(c, err)
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return &LoginInfo{}, AbortWithError(c, err)
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return &LoginInfo{}, AbortWithError(c, err)
+	}
+	session.Values[oidcStateKey] = state
+	session.Values[oidcNonceKey] = nonce
+
+	// The provider redirects back with a cross-site navigation which does not include SameSite=Strict cookies. The
+	// session only contains the state and nonce until the login is complete.
+	options := *session.Options
+	options.SameSite = http.SameSiteLaxMode
+	session.Options = &options
+
+	// Location is set without writing the response so that the session cookie can still be saved
+	c.Header("Location", a.oauth2Config(provider).AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)))
+	c.Status(http.StatusFound)
+	return &LoginInfo{}, ErrLoginRedirect
+}
+
+// callback completes the authorization code flow by exchanging the code for an ID token and storing the user
+func (a *OIDCAuthenticator) callback(c *gin.Context, session *sessions.Session) (*LoginInfo, error) {
+	ctx := c.Request.Context()
+	loginInfo := &LoginInfo{}
+
+	state, _ := session.Values[oidcStateKey].(string)
+	nonce, _ := session.Values[oidcNonceKey].(string)
+	delete(session.Values, oidcStateKey)
+	delete(session.Values, oidcNonceKey)
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		a.logger.Info("oidc provider returned an error", zap.String("error", providerErr), zap.String("description", c.Query("error_description")))
+		return loginInfo, AbortWithError(c, ErrBadCreds)
+	}
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(c.Query("state"))) != 1 {
+		return loginInfo, AbortWithError(c, ErrInvalidSession)
+	}
+
+	provider, err := a.discover(ctx)
+	if err != nil {
+		return loginInfo, AbortWithError(c, err)
+	}
+
+	token, err := a.oauth2Config(provider).Exchange(context.WithValue(ctx, oauth2.HTTPClient, a.client), c.Query("code"))
+	if err != nil {
+		a.logger.Info("failed to exchange oidc authorization code", zap.Error(err))
+		return loginInfo, AbortWithError(c, ErrBadCreds)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		a.logger.Info("oidc token response did not include an id_token")
+		return loginInfo, AbortWithError(c, ErrBadCreds)
+	}
+
+	claims, err := a.verifyIDToken(ctx, provider, rawIDToken, nonce)
+	if err != nil {
+		a.logger.Info("invalid oidc id token", zap.Error(err))
+		return loginInfo, AbortWithError(c, ErrBadCreds)
+	}
+
+	user, err := a.userFromClaims(claims)
+	if err != nil {
+		a.logger.Info("oidc id token cannot be mapped to a user", zap.Error(err))
+		return loginInfo, AbortWithError(c, ErrBadCreds)
+	}
+	loginInfo.Username = user.Name
+
+	if err := a.saveUser(ctx, user); err != nil {
+		if errors.Is(err, errOIDCUserExists) {
+			a.logger.Info("oidc user cannot login", zap.String("user", user.Name), zap.Error(err))
+			return loginInfo, AbortWithError(c, ErrBadCreds)
+		}
+		return loginInfo, AbortWithError(c, err)
+	}
+
+	loginInfo.LoginID = user.Name
+	session.Values[LoginKey] = user.Name
+	session.Values[oidcSessionKey] = true
+	delete(session.Values, PasswordKey)
+
+	// send the user back to the UI which marks them as logged in
+	c.Header("Location", oidcLoginPath+"?"+url.Values{"user": {user.Name}}.Encode())
+	c.Status(http.StatusFound)
+	return loginInfo, nil
+}
+
+// userFromClaims returns a user with the name and role from the configured claims and the issuer and subject of the ID
+// token. Names that would be resolved as the server profile user or an API key are rejected.
+func (a *OIDCAuthenticator) userFromClaims(claims map[string]any) (*model.User, error) {
+	usernameClaim := a.config.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = config.DefaultOIDCUsernameClaim
+	}
+	name, _ := claims[usernameClaim].(string)
+	if name == "" {
+		return nil, fmt.Errorf("missing %s claim", usernameClaim)
+	}
+	if err := model.ValidateUserName(name, a.serverUsername); err != nil {
+		return nil, fmt.Errorf("%s claim: %w", usernameClaim, err)
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("missing sub claim")
+	}
+
+	role := model.Role(a.config.DefaultRole)
+	if a.config.RoleClaim != "" {
+		if claimRole, ok := roleFromClaim(claims[a.config.RoleClaim]); ok {
+			role = claimRole
+		}
+	}
+	if !role.Valid() {
+		return nil, fmt.Errorf("user %s does not have a valid %s claim and no default role is configured", name, a.config.RoleClaim)
+	}
+
+	return &model.User{Name: name, Role: role, Issuer: a.config.Issuer, Subject: subject}, nil
+}
+
+// roleFromClaim returns the role in a string claim or the role with the most access in a list claim, e.g. groups
+func roleFromClaim(claim any) (role model.Role, ok bool) {
+	switch v := claim.(type) {
+	case string:
+		role = model.Role(v)
+		return role, role.Valid()
+	case []any:
+		for _, item := range v {
+			if itemRole, itemOK := roleFromClaim(item); itemOK && (!ok || itemRole.Allows(role)) {
+				role, ok = itemRole, true
+			}
+		}
+	}
+	return role, ok
+}
+
+// saveUser stores the user on their first login. A user that already exists keeps the role assigned in BindPlane. The
+// name can't be used by a user with a password or another OIDC identity.
+func (a *OIDCAuthenticator) saveUser(ctx context.Context, user *model.User) error {
+	existing, err := a.users.User(ctx, user.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if existing != nil {
+		if existing.Issuer != user.Issuer || existing.Subject != user.Subject {
+			return fmt.Errorf("%w with the name %s", errOIDCUserExists, user.Name)
+		}
+		return nil
+	}
+	if err := a.users.UpsertUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// oauth2Config returns the configuration of the authorization code flow
+func (a *OIDCAuthenticator) oauth2Config(provider *oidcProvider) *oauth2.Config {
+	scopes := a.config.Scopes
+	if len(scopes) == 0 {
+		scopes = oidcDefaultScopes
+	}
+	allScopes := []string{"openid"}
+	for _, scope := range scopes {
+		if scope != "openid" {
+			allScopes = append(allScopes, scope)
+		}
+	}
+
+	return &oauth2.Config{
+		ClientID:     a.config.ClientID,
+		ClientSecret: a.config.ClientSecret,
+		RedirectURL:  a.config.RedirectURL,
+		Scopes:       allScopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  provider.AuthURL,
+			TokenURL: provider.TokenURL,
+		},
+	}
+}
+
+// discover returns the provider metadata, fetching it on first use
+func (a *OIDCAuthenticator) discover(ctx context.Context) (*oidcProvider, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.provider != nil {
+		return a.provider, nil
+	}
+
+	provider := &oidcProvider{}
+	discoveryURL := strings.TrimSuffix(a.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := a.getJSON(ctx, discoveryURL, provider); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider: %w", err)
+	}
+	if provider.Issuer != a.config.Issuer {
+		return nil, fmt.Errorf("oidc provider issuer %s does not match the configured issuer %s", provider.Issuer, a.config.Issuer)
+	}
+	if provider.AuthURL == "" || provider.TokenURL == "" || provider.JWKSURL == "" {
+		return nil, errors.New("oidc provider metadata is missing an authorization, token, or jwks endpoint")
+	}
+
+	a.provider = provider
+	return provider, nil
+}
+
+// getJSON decodes the JSON response of a GET request to the url into v
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// randomToken returns a random value for the state and nonce parameters
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,609 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticator
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/sessions"
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubIssuer is an OpenID Connect provider that issues an ID token with the claims set by the test for any
+// authorization code
+type stubIssuer struct {
+	*httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	// idToken returns the ID token issued by the token endpoint
+	idToken func() string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issuer := &stubIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.URL,
+			"authorization_endpoint": issuer.URL + "/authorize",
+			"token_endpoint":         issuer.URL + "/token",
+			"jwks_uri":               issuer.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+					"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     issuer.idToken(),
+		})
+	})
+
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+// claims returns valid claims for the user
+func (s *stubIssuer) claims(nonce string) map[string]any {
+	return map[string]any{
+		"iss":   s.URL,
+		"aud":   "bindplane",
+		"sub":   "1234",
+		"email": "jane@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": nonce,
+	}
+}
+
+// sign returns an ID token with the claims signed with the key with the specified ID
+func (s *stubIssuer) sign(t *testing.T, kid string, claims map[string]any) string {
+	alg := "RS256"
+	if kid == "ec" {
+		alg = "ES256"
+	}
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	if kid == "ec" {
+		r, s, err := ecdsa.Sign(rand.Reader, s.ecKey, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	} else {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func testOIDCConfig(issuer *stubIssuer) config.OIDC {
+	return config.OIDC{
+		Issuer:        issuer.URL,
+		ClientID:      "bindplane",
+		ClientSecret:  "secret",
+		RedirectURL:   "http://localhost:3001/login/sso",
+		UsernameClaim: "email",
+		RoleClaim:     "bindplane_role",
+	}
+}
+
+// startOIDCLogin starts a login and returns the state and nonce sent to the provider
+func startOIDCLogin(t *testing.T, auth Authenticator, session *sessions.Session) (state, nonce string) {
+	ctx := testGinContext(t, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/login/sso", nil)
+
+	_, err := auth.Login(ctx, session)
+	require.ErrorIs(t, err, ErrLoginRedirect)
+	require.Equal(t, http.StatusFound, ctx.Writer.Status())
+	require.Equal(t, http.SameSiteLaxMode, session.Options.SameSite)
+
+	location, err := url.Parse(ctx.Writer.Header().Get("Location"))
+	require.NoError(t, err)
+	query := location.Query()
+	require.Equal(t, "/authorize", location.Path)
+	require.Equal(t, "bindplane", query.Get("client_id"))
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "http://localhost:3001/login/sso", query.Get("redirect_uri"))
+	require.Equal(t, "openid profile email", query.Get("scope"))
+	require.Equal(t, session.Values[oidcStateKey], query.Get("state"))
+	require.Equal(t, session.Values[oidcNonceKey], query.Get("nonce"))
+
+	return query.Get("state"), query.Get("nonce")
+}
+
+// completeOIDCLogin completes a login with the callback from the provider
+func completeOIDCLogin(t *testing.T, auth Authenticator, session *sessions.Session, state string) (*gin.Context, *LoginInfo, error) {
+	ctx := testGinContext(t, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/login/sso?"+url.Values{"code": {"code"}, "state": {state}}.Encode(), nil)
+	info, err := auth.Login(ctx, session)
+	return ctx, info, err
+}
+
+func TestOIDCLogin(t *testing.T) {
+	issuer := newStubIssuer(t)
+
+	tcs := []struct {
+		name         string
+		config       func(*config.OIDC)
+		idToken      func(nonce string) string
+		state        func(state string) string
+		expectedErr  error
+		expectedRole model.Role
+	}{
+		{
+			name: "role claim",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedRole: model.RoleAdmin,
+		},
+		{
+			name: "role list claim uses the role with the most access",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = []string{"viewer", "engineering", "user"}
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedRole: model.RoleUser,
+		},
+		{
+			name: "ec signing key",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "user"
+				claims["aud"] = []string{"other", "bindplane"}
+				claims["azp"] = "bindplane"
+				return issuer.sign(t, "ec", claims)
+			},
+			expectedRole: model.RoleUser,
+		},
+		{
+			name:   "default role",
+			config: func(c *config.OIDC) { c.DefaultRole = "viewer" },
+			idToken: func(nonce string) string {
+				return issuer.sign(t, "rsa", issuer.claims(nonce))
+			},
+			expectedRole: model.RoleViewer,
+		},
+		{
+			name: "no role",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "owner"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name:   "missing username claim",
+			config: func(c *config.OIDC) { c.UsernameClaim = "preferred_username" },
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "wrong nonce",
+			idToken: func(nonce string) string {
+				claims := issuer.claims("other-nonce")
+				claims["bindplane_role"] = "admin"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "wrong audience",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				claims["aud"] = "other"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "wrong issuer",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				claims["iss"] = "https://other.example.com"
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "expired",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return issuer.sign(t, "rsa", claims)
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "invalid signature",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				token := issuer.sign(t, "rsa", claims)
+				// replace the claims without signing them
+				claims["bindplane_role"] = "viewer"
+				payload, _ := json.Marshal(claims)
+				parts := strings.Split(token, ".")
+				return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "unsigned",
+			idToken: func(nonce string) string {
+				claims := issuer.claims(nonce)
+				claims["bindplane_role"] = "admin"
+				header, _ := json.Marshal(map[string]string{"alg": "none"})
+				payload, _ := json.Marshal(claims)
+				return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+			},
+			expectedErr: ErrBadCreds,
+		},
+		{
+			name: "wrong state",
+			idToken: func(nonce string) string {
+				return issuer.sign(t, "rsa", issuer.claims(nonce))
+			},
+			state:       func(string) string { return "other-state" },
+			expectedErr: ErrInvalidSession,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := store.NewMapStore(ctx, store.Options{
+				SessionsSecret:   "super-secret-key",
+				MaxEventsToMerge: 1,
+			}, zap.NewNop())
+
+			cfg := testOIDCConfig(issuer)
+			if tc.config != nil {
+				tc.config(&cfg)
+			}
+			auth := NewOIDCAuthenticator(cfg, "admin", NewBasicAuthenticator("admin", "adminPassword", users), users, zap.NewNop())
+
+			session := sessions.NewSession(nil, CookieName)
+			state, nonce := startOIDCLogin(t, auth, session)
+			issuer.idToken = func() string { return tc.idToken(nonce) }
+			if tc.state != nil {
+				state = tc.state(state)
+			}
+
+			c, info, err := completeOIDCLogin(t, auth, session, state)
+			require.NotContains(t, session.Values, oidcStateKey)
+			require.NotContains(t, session.Values, oidcNonceKey)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, session.Values[LoginKey])
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &LoginInfo{LoginID: "jane@example.com", Username: "jane@example.com"}, info)
+			require.Equal(t, "jane@example.com", session.Values[LoginKey])
+			require.Equal(t, http.StatusFound, c.Writer.Status())
+			require.Equal(t, "/login?user=jane%40example.com", c.Writer.Header().Get("Location"))
+
+			role, err := auth.Role(ctx, "jane@example.com")
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRole, role)
+
+			stored, err := users.User(ctx, "jane@example.com")
+			require.NoError(t, err)
+			require.Equal(t, issuer.URL, stored.Issuer)
+			require.Equal(t, "1234", stored.Subject)
+
+			verifyCtx := testGinContext(t, nil)
+			require.NoError(t, auth.Verify(verifyCtx, session))
+		})
+	}
+}
+
+func TestVerifyIDTokenClaims(t *testing.T) {
+	issuer := newStubIssuer(t)
+	auth := NewOIDCAuthenticator(testOIDCConfig(issuer), "admin", NewBasicAuthenticator("admin", "adminPassword", nil), nil, zap.NewNop()).(*OIDCAuthenticator)
+	provider, err := auth.discover(context.Background())
+	require.NoError(t, err)
+
+	now := time.Now()
+	tcs := []struct {
+		name        string
+		claims      func(claims map[string]any)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			claims: func(map[string]any) {},
+		},
+		{
+			name: "times within the leeway",
+			claims: func(claims map[string]any) {
+				claims["iat"] = now.Add(30 * time.Second).Unix()
+				claims["nbf"] = now.Add(30 * time.Second).Unix()
+			},
+		},
+		{
+			name:   "nbf in the past",
+			claims: func(claims map[string]any) { claims["nbf"] = now.Add(-time.Minute).Unix() },
+		},
+		{
+			name:        "nbf in the future",
+			claims:      func(claims map[string]any) { claims["nbf"] = now.Add(time.Hour).Unix() },
+			expectedErr: "id token is not valid yet",
+		},
+		{
+			name:        "nbf is not a number",
+			claims:      func(claims map[string]any) { claims["nbf"] = "now" },
+			expectedErr: "id token nbf is not a number",
+		},
+		{
+			name:        "missing iat",
+			claims:      func(claims map[string]any) { delete(claims, "iat") },
+			expectedErr: "id token is missing iat",
+		},
+		{
+			name:        "iat in the future",
+			claims:      func(claims map[string]any) { claims["iat"] = now.Add(10 * time.Minute).Unix() },
+			expectedErr: "id token was issued in the future",
+		},
+		{
+			name: "iat after exp",
+			claims: func(claims map[string]any) {
+				claims["iat"] = now.Unix()
+				claims["exp"] = now.Add(-30 * time.Second).Unix()
+			},
+			expectedErr: "id token was issued after it expired",
+		},
+		{
+			name: "azp with multiple audiences",
+			claims: func(claims map[string]any) {
+				claims["aud"] = []string{"other", "bindplane"}
+				claims["azp"] = "bindplane"
+			},
+		},
+		{
+			name:        "missing azp with multiple audiences",
+			claims:      func(claims map[string]any) { claims["aud"] = []string{"other", "bindplane"} },
+			expectedErr: "id token with multiple audiences is missing azp",
+		},
+		{
+			name:        "azp of another client",
+			claims:      func(claims map[string]any) { claims["aud"] = []string{"other", "bindplane"}; claims["azp"] = "other" },
+			expectedErr: "id token authorized party other is not bindplane",
+		},
+		{
+			name:        "azp of another client with a single audience",
+			claims:      func(claims map[string]any) { claims["azp"] = "other" },
+			expectedErr: "id token authorized party other is not bindplane",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := issuer.claims("nonce")
+			tc.claims(claims)
+			_, err := auth.verifyIDToken(context.Background(), provider, issuer.sign(t, "rsa", claims), "nonce")
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOIDCLoginExistingUser(t *testing.T) {
+	issuer := newStubIssuer(t)
+	ctx := context.Background()
+	users := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	require.NoError(t, users.UpsertUser(ctx, &model.User{Name: "jane@example.com", Role: model.RoleViewer, Issuer: issuer.URL, Subject: "1234"}))
+
+	auth := NewOIDCAuthenticator(testOIDCConfig(issuer), "admin", NewBasicAuthenticator("admin", "adminPassword", users), users, zap.NewNop())
+
+	session := sessions.NewSession(nil, CookieName)
+	state, nonce := startOIDCLogin(t, auth, session)
+	issuer.idToken = func() string {
+		claims := issuer.claims(nonce)
+		claims["bindplane_role"] = "admin"
+		return issuer.sign(t, "rsa", claims)
+	}
+	_, _, err := completeOIDCLogin(t, auth, session, state)
+	require.NoError(t, err)
+
+	// the role assigned in BindPlane is kept
+	role, err := auth.Role(ctx, "jane@example.com")
+	require.NoError(t, err)
+	require.Equal(t, model.RoleViewer, role)
+
+	// deleted users are logged out
+	_, err = users.DeleteUser(ctx, "jane@example.com")
+	require.NoError(t, err)
+	require.ErrorIs(t, auth.Verify(testGinContext(t, nil), session), ErrBadCreds)
+
+	// and the session can't be used by a user with a password and the same name
+	user, err := model.NewUser("jane@example.com", model.RoleAdmin, "janePassword")
+	require.NoError(t, err)
+	require.NoError(t, users.UpsertUser(ctx, user))
+	require.ErrorIs(t, auth.Verify(testGinContext(t, nil), session), ErrBadCreds)
+}
+
+func TestOIDCLoginCollisions(t *testing.T) {
+	issuer := newStubIssuer(t)
+
+	tcs := []struct {
+		name     string
+		username string
+		existing *model.User
+	}{
+		{
+			name:     "user with a password",
+			username: "jane@example.com",
+			existing: &model.User{Name: "jane@example.com", Role: model.RoleViewer},
+		},
+		{
+			name:     "user of another issuer",
+			username: "jane@example.com",
+			existing: &model.User{Name: "jane@example.com", Role: model.RoleViewer, Issuer: "https://other.example.com", Subject: "1234"},
+		},
+		{
+			name:     "user with another subject",
+			username: "jane@example.com",
+			existing: &model.User{Name: "jane@example.com", Role: model.RoleViewer, Issuer: issuer.URL, Subject: "5678"},
+		},
+		{
+			name:     "server profile user",
+			username: "admin",
+		},
+		{
+			name:     "api key",
+			username: APIKeyLoginPrefix + "1",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := store.NewMapStore(ctx, store.Options{
+				SessionsSecret:   "super-secret-key",
+				MaxEventsToMerge: 1,
+			}, zap.NewNop())
+			if tc.existing != nil {
+				require.NoError(t, tc.existing.SetPassword("janePassword"))
+				require.NoError(t, users.UpsertUser(ctx, tc.existing))
+			}
+
+			auth := NewOIDCAuthenticator(testOIDCConfig(issuer), "admin", NewBasicAuthenticator("admin", "adminPassword", users), users, zap.NewNop())
+
+			session := sessions.NewSession(nil, CookieName)
+			state, nonce := startOIDCLogin(t, auth, session)
+			issuer.idToken = func() string {
+				claims := issuer.claims(nonce)
+				claims["email"] = tc.username
+				claims["bindplane_role"] = "admin"
+				return issuer.sign(t, "rsa", claims)
+			}
+			_, _, err := completeOIDCLogin(t, auth, session, state)
+			require.ErrorIs(t, err, ErrBadCreds)
+			require.Nil(t, session.Values[LoginKey])
+
+			// the existing user is unchanged and no user is created for reserved names
+			stored, err := users.User(ctx, tc.username)
+			require.NoError(t, err)
+			require.Equal(t, tc.existing, stored)
+		})
+	}
+}
+
+func TestOIDCPasswordLogin(t *testing.T) {
+	issuer := newStubIssuer(t)
+	auth := NewOIDCAuthenticator(testOIDCConfig(issuer), "admin", NewBasicAuthenticator("admin", "adminPassword", nil), nil, zap.NewNop())
+
+	ctx := testGinContext(t, nil)
+	ctx.Request.PostForm.Add(UsernameKey, "admin")
+	ctx.Request.PostForm.Add(PasswordKey, "adminPassword")
+
+	session := sessions.NewSession(nil, CookieName)
+	info, err := auth.Login(ctx, session)
+	require.NoError(t, err)
+	require.Equal(t, &LoginInfo{LoginID: "admin", Username: "admin"}, info)
+	require.NoError(t, auth.Verify(testGinContext(t, nil), session))
+}
+
+func TestOIDCProviderError(t *testing.T) {
+	issuer := newStubIssuer(t)
+	auth := NewOIDCAuthenticator(testOIDCConfig(issuer), "admin", NewBasicAuthenticator("admin", "adminPassword", nil), nil, zap.NewNop())
+
+	session := sessions.NewSession(nil, CookieName)
+	state, _ := startOIDCLogin(t, auth, session)
+
+	ctx := testGinContext(t, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/login/sso?"+url.Values{"error": {"access_denied"}, "state": {state}}.Encode(), nil)
+	_, err := auth.Login(ctx, session)
+	require.ErrorIs(t, err, ErrBadCreds)
+	require.Equal(t, http.StatusUnauthorized, ctx.Writer.Status())
+}
@@ -0,0 +1,326 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticator
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	// register the hashes used by the supported signing algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// idTokenLeeway is the clock skew allowed when checking the exp, iat, and nbf times of an ID token
+const idTokenLeeway = time.Minute
+
+// signingHashes are the hashes of the supported ID token signing algorithms. HMAC algorithms and none are not supported
+// because the token must be signed by the provider's private key.
+var signingHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+// verifyIDToken verifies the signature and claims of an ID token and returns the claims
+func (a *OIDCAuthenticator) verifyIDToken(ctx context.Context, provider *oidcProvider, rawIDToken, nonce string) (map[string]any, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed id token header: %w", err)
+	}
+
+	key, err := a.signingKey(ctx, provider, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token signature: %w", err)
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed id token claims: %w", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != provider.Issuer {
+		return nil, fmt.Errorf("id token issuer %q does not match %s", iss, provider.Issuer)
+	}
+	if !audienceContains(claims["aud"], a.config.ClientID) {
+		return nil, fmt.Errorf("id token audience does not include %s", a.config.ClientID)
+	}
+	// the client must be the authorized party if there are other audiences
+	azp, hasAZP := claims["azp"]
+	if !hasAZP && audienceCount(claims["aud"]) > 1 {
+		return nil, errors.New("id token with multiple audiences is missing azp")
+	}
+	if hasAZP && azp != a.config.ClientID {
+		return nil, fmt.Errorf("id token authorized party %v is not %s", azp, a.config.ClientID)
+	}
+
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("id token is missing exp")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(idTokenLeeway)) {
+		return nil, errors.New("id token is expired")
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return nil, errors.New("id token is missing iat")
+	}
+	if time.Unix(int64(iat), 0).After(now.Add(idTokenLeeway)) {
+		return nil, errors.New("id token was issued in the future")
+	}
+	if iat > exp {
+		return nil, errors.New("id token was issued after it expired")
+	}
+	if nbf, ok := claims["nbf"]; ok {
+		notBefore, ok := nbf.(float64)
+		if !ok {
+			return nil, errors.New("id token nbf is not a number")
+		}
+		if time.Unix(int64(notBefore), 0).After(now.Add(idTokenLeeway)) {
+			return nil, errors.New("id token is not valid yet")
+		}
+	}
+	if tokenNonce, _ := claims["nonce"].(string); nonce == "" || subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce does not match")
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the provider's key with the specified key ID. The keys are fetched again if there is no match in
+// case the provider has rotated its keys.
+func (a *OIDCAuthenticator) signingKey(ctx context.Context, provider *oidcProvider, kid string) (crypto.PublicKey, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if key, ok := a.keys.find(kid); ok {
+		return key, nil
+	}
+
+	// limit how often the keys are fetched so that tokens with unknown key IDs can't be used to flood the provider
+	if a.keys != nil && time.Since(a.keysFetched) < a.keysInterval {
+		return nil, fmt.Errorf("no oidc signing key with id %q", kid)
+	}
+
+	keys := &jsonWebKeySet{}
+	if err := a.getJSON(ctx, provider.JWKSURL, keys); err != nil {
+		return nil, fmt.Errorf("failed to get oidc signing keys: %w", err)
+	}
+	a.keys = keys
+	a.keysFetched = time.Now()
+
+	if key, ok := a.keys.find(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no oidc signing key with id %q", kid)
+}
+
+// verifySignature returns an error if the signature of the signed bytes is not valid for the algorithm and key
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	hash, ok := signingHashes[alg]
+	if !ok {
+		return fmt.Errorf("unsupported id token signing algorithm %q", alg)
+	}
+	hasher := hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("signing algorithm %s cannot be used with an RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, signature); err != nil {
+			return errors.New("invalid id token signature")
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("signing algorithm %s cannot be used with an EC key", alg)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid id token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid id token signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signing key type %T", key)
+}
+
+// audienceContains returns true if the aud claim, which is either a string or a list of strings, contains the client ID
+func audienceContains(aud any, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []any:
+		for _, item := range v {
+			if item == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// audienceCount returns the number of audiences in the aud claim
+func audienceCount(aud any) int {
+	switch v := aud.(type) {
+	case string:
+		return 1
+	case []any:
+		return len(v)
+	}
+	return 0
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ----------------------------------------------------------------------
+
+// jsonWebKeySet is the JWKS document served by the provider's jwks_uri
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// jsonWebKey is an RSA or EC public key in a JWKS
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// find returns the signing key with the specified key ID. If the key ID is empty, the set must contain a single
+// signing key.
+func (s *jsonWebKeySet) find(kid string) (crypto.PublicKey, bool) {
+	if s == nil {
+		return nil, false
+	}
+	var found []jsonWebKey
+	for _, key := range s.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if kid == "" || key.Kid == kid {
+			found = append(found, key)
+		}
+	}
+	if len(found) != 1 {
+		return nil, false
+	}
+	publicKey, err := found[0].publicKey()
+	if err != nil {
+		return nil, false
+	}
+	return publicKey, true
+}
+
+// publicKey returns the *rsa.PublicKey or *ecdsa.PublicKey represented by the key
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,99 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Backuper is an interface for backing up and restoring the store of the server.
+type Backuper interface {
+	// BackupToFile writes a backup to the file, replacing it if it exists.
+	BackupToFile(ctx context.Context, filename string, includeMeasurements bool) error
+
+	// BackupToWriter writes a backup to the writer.
+	BackupToWriter(ctx context.Context, writer io.Writer, includeMeasurements bool) error
+
+	// RestoreFromFile restores the backup in the file.
+	RestoreFromFile(ctx context.Context, filename string) (*model.RestoreResponse, error)
+
+	// RestoreFromReader restores the backup read from the reader.
+	RestoreFromReader(ctx context.Context, reader io.Reader) (*model.RestoreResponse, error)
+}
+
+// Builder is an interface for building a Backuper.
+type Builder interface {
+	// BuildBackuper returns a new Backuper.
+	BuildBackuper(ctx context.Context) (Backuper, error)
+}
+
+// NewBackuper returns a new Backuper.
+func NewBackuper(client client.BindPlane) Backuper {
+	return &defaultBackuper{
+		client: client,
+	}
+}
+
+// defaultBackuper is the default implementation of Backuper.
+type defaultBackuper struct {
+	client client.BindPlane
+}
+
+// BackupToFile writes a backup to the file. The file is only readable by the current user because backups contain
+// sensitive parameter values.
+func (b *defaultBackuper) BackupToFile(ctx context.Context, filename string, includeMeasurements bool) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600) // #nosec G304 -- the file is specified by the user running the command
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	err = b.client.Backup(ctx, includeMeasurements, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// don't leave an incomplete backup behind
+		return errors.Join(err, os.Remove(filename))
+	}
+	return nil
+}
+
+// BackupToWriter writes a backup to the writer.
+func (b *defaultBackuper) BackupToWriter(ctx context.Context, writer io.Writer, includeMeasurements bool) error {
+	return b.client.Backup(ctx, includeMeasurements, writer)
+}
+
+// RestoreFromFile restores the backup in the file.
+func (b *defaultBackuper) RestoreFromFile(ctx context.Context, filename string) (*model.RestoreResponse, error) {
+	file, err := os.Open(filename) // #nosec G304 -- the file is specified by the user running the command
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	return b.client.Restore(ctx, file)
+}
+
+// RestoreFromReader restores the backup read from the reader.
+func (b *defaultBackuper) RestoreFromReader(ctx context.Context, reader io.Reader) (*model.RestoreResponse, error) {
+	return b.client.Restore(ctx, reader)
+}
@@ -0,0 +1,161 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/client/mocks"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupToFile(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clientFunc   func() client.BindPlane
+		expectedFile string
+		expectedErr  error
+	}{
+		{
+			name: "backup",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("Backup", mock.Anything, true, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					_, _ = args.Get(2).(io.Writer).Write([]byte("backup"))
+				})
+				return c
+			},
+			expectedFile: "backup",
+		},
+		{
+			name: "error removes the file",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("Backup", mock.Anything, true, mock.Anything).Return(errors.New("unable to backup"))
+				return c
+			},
+			expectedErr: errors.New("unable to backup"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backuper := NewBackuper(tc.clientFunc())
+			filename := filepath.Join(t.TempDir(), "backup.tar.gz")
+			err := backuper.BackupToFile(context.Background(), filename, true)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				require.NoFileExists(t, filename)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(filename)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFile, string(data))
+
+			info, err := os.Stat(filename)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		})
+	}
+}
+
+func TestRestoreFromFile(t *testing.T) {
+	testCases := []struct {
+		name             string
+		clientFunc       func() client.BindPlane
+		setupFunc        func(path string) error
+		expectedResponse *model.RestoreResponse
+		expectedErr      error
+	}{
+		{
+			name: "missing file",
+			clientFunc: func() client.BindPlane {
+				return mocks.NewMockBindPlane(t)
+			},
+			setupFunc:   func(path string) error { return nil },
+			expectedErr: errors.New("failed to open backup file"),
+		},
+		{
+			name: "restore",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("Restore", mock.Anything, mock.MatchedBy(func(r io.Reader) bool {
+					data, err := io.ReadAll(r)
+					return err == nil && string(data) == "backup"
+				})).Return(&model.RestoreResponse{Restored: model.BackupSummary{Resources: 2, Agents: 1}}, nil)
+				return c
+			},
+			setupFunc: func(path string) error {
+				return os.WriteFile(path, []byte("backup"), 0600)
+			},
+			expectedResponse: &model.RestoreResponse{Restored: model.BackupSummary{Resources: 2, Agents: 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backuper := NewBackuper(tc.clientFunc())
+			filename := filepath.Join(t.TempDir(), "backup.tar.gz")
+			require.NoError(t, tc.setupFunc(filename))
+
+			response, err := backuper.RestoreFromFile(context.Background(), filename)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func TestRestoreCommand(t *testing.T) {
+	c := mocks.NewMockBindPlane(t)
+	c.On("Restore", mock.Anything, mock.Anything).Return(&model.RestoreResponse{
+		Restored: model.BackupSummary{Resources: 2, Agents: 1},
+		Error:    "failed to restore agents",
+	}, nil)
+
+	cmd := RestoreCommand(testBuilder{NewBackuper(c)})
+	// the root command silences usage on errors
+	cmd.SilenceUsage = true
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(bytes.NewBufferString("backup"))
+	cmd.SetArgs([]string{"-"})
+
+	err := cmd.Execute()
+	require.EqualError(t, err, "failed to restore agents")
+	require.Equal(t, "Restored 2 resources, 1 agents, and 0 measurements\n", out.String())
+}
+
+type testBuilder struct {
+	backuper Backuper
+}
+
+func (b testBuilder) BuildBackuper(_ context.Context) (Backuper, error) {
+	return b.backuper, nil
+}
@@ -0,0 +1,106 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup provides the backup and restore commands, which backup the resources and agents on the server to a
+// file and restore them.
+package backup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Command returns the bindplane backup cobra command.
+func Command(builder Builder) *cobra.Command {
+	var measurementsFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "backup [file]",
+		Short: "Backup resources and agents",
+		Long: `Backup every version of every resource and all agents on the server to a gzip compressed tar archive. Use
+'bindplane backup -' to write the backup to stdout. Backups contain the values of sensitive parameters and should be
+stored securely. Users, API keys, and audit events are not included.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			ctx := cmd.Context()
+			backuper, err := builder.BuildBackuper(ctx)
+			if err != nil {
+				return err
+			}
+
+			if args[0] == "-" {
+				return backuper.BackupToWriter(ctx, cmd.OutOrStdout(), measurementsFlag)
+			}
+			if err := backuper.BackupToFile(ctx, args[0], measurementsFlag); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backup written to %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&measurementsFlag, "measurements", false, "include the measurements reported by agents")
+
+	return cmd
+}
+
+// RestoreCommand returns the bindplane restore cobra command.
+func RestoreCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [file]",
+		Short: "Restore a backup",
+		Long: `Restore a backup made with 'bindplane backup'. Resources and agents in the backup replace resources and agents
+with the same name or ID on the server. Use 'bindplane restore -' to read the backup from stdin.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			ctx := cmd.Context()
+			backuper, err := builder.BuildBackuper(ctx)
+			if err != nil {
+				return err
+			}
+
+			var response *model.RestoreResponse
+			if args[0] == "-" {
+				response, err = backuper.RestoreFromReader(ctx, cmd.InOrStdin())
+			} else {
+				response, err = backuper.RestoreFromFile(ctx, args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %s\n", response.Restored)
+			if response.Error != "" {
+				return errors.New(response.Error)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -89,6 +89,8 @@ func Command(builder Builder) *cobra.Command {
 		deleteResourceCommand(builder, "processor-type", model.KindProcessorType, []string{"processor-types", "processorType", "processorTypes"}),
 		deleteResourceCommand(builder, "destination", model.KindDestination, []string{"destinations"}),
 		deleteResourceCommand(builder, "destination-type", model.KindDestinationType, []string{"destination-types", "destinationType", "destinationTypes"}),
+		deleteResourceCommand(builder, "alert", model.KindAlert, []string{"alerts"}),
+		deleteResourceCommand(builder, "webhook", model.KindWebhook, []string{"webhooks"}),
 	)
 
 	return cmd
@@ -88,6 +88,10 @@ func (d *DefaultDeleter) deleteResource(ctx context.Context, kind model.Kind, id
 		return d.client.DeleteDestinationType(ctx, id)
 	case model.KindAgentVersion:
 		return d.client.DeleteAgentVersion(ctx, id)
+	case model.KindAlert:
+		return d.client.DeleteAlert(ctx, id)
+	case model.KindWebhook:
+		return d.client.DeleteWebhook(ctx, id)
 	default:
 		return fmt.Errorf("unsupported resource kind: %s", kind)
 	}
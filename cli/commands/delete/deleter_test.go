@@ -118,6 +118,26 @@ func TestDeleteResources(t *testing.T) {
 			kind: model.KindAgentVersion,
 			ids:  []string{"123"},
 		},
+		{
+			name: "delete alert",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("DeleteAlert", mock.Anything, "123").Return(nil)
+				return c
+			},
+			kind: model.KindAlert,
+			ids:  []string{"123"},
+		},
+		{
+			name: "delete webhook",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("DeleteWebhook", mock.Anything, "123").Return(nil)
+				return c
+			},
+			kind: model.KindWebhook,
+			ids:  []string{"123"},
+		},
 		{
 			name: "delete agents",
 			clientFunc: func() client.BindPlane {
@@ -0,0 +1,87 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff provides the diff command, which shows how applying resources from a file would change the store.
+package diff
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Command returns the bindplane diff cobra command.
+func Command(builder Builder) *cobra.Command {
+	var fileFlag []string
+
+	cmd := &cobra.Command{
+		Use:   "diff [file]",
+		Short: "Diff resources",
+		Long: `Show the changes that applying resources from a file would make without applying them. Use 'bindplane diff -'
+to read resources from stdin.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// any positional args are treated as if they were prefixed with -f/--file, the same as apply
+			fileArgs := fileFlag
+			fileArgs = append(fileArgs, args...)
+
+			if len(fileArgs) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			ctx := cmd.Context()
+			differ, err := builder.BuildDiffer(ctx)
+			if err != nil {
+				return err
+			}
+
+			var diffs []*model.ResourceDiff
+			switch fileArgs[0] {
+			case "-":
+				diffs, err = differ.DiffResourcesFromReader(ctx, cmd.InOrStdin())
+			default:
+				diffs, err = differ.DiffResourcesFromFiles(ctx, fileArgs)
+			}
+			if err != nil {
+				return err
+			}
+
+			writer := cmd.OutOrStdout()
+			for _, diff := range diffs {
+				printDiff(writer, diff)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&fileFlag, "file", "f", []string{}, "path to a yaml file that specifies bindplane resources")
+
+	return cmd
+}
+
+// printDiff prints the status of the resource followed by a unified diff if it would be created or configured
+func printDiff(writer io.Writer, diff *model.ResourceDiff) {
+	fmt.Fprintf(writer, "%s %s %s\n", diff.Kind, diff.Name, diff.Status)
+	if diff.Reason != "" {
+		fmt.Fprintf(writer, "\t%s\n", diff.Reason)
+	}
+	switch diff.Status {
+	case model.StatusCreated, model.StatusConfigured:
+		fmt.Fprint(writer, diff.Unified())
+	}
+}
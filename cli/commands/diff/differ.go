@@ -0,0 +1,76 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Differ is an interface for comparing resources with the resources on the server.
+type Differ interface {
+	// DiffResourcesFromFiles compares all resources from a list of files.
+	DiffResourcesFromFiles(ctx context.Context, filenames []string) ([]*model.ResourceDiff, error)
+
+	// DiffResourcesFromReader compares all resources from a reader.
+	DiffResourcesFromReader(ctx context.Context, reader io.Reader) ([]*model.ResourceDiff, error)
+}
+
+// Builder is an interface for building a Differ.
+type Builder interface {
+	// BuildDiffer returns a new Differ.
+	BuildDiffer(ctx context.Context) (Differ, error)
+}
+
+// NewDiffer returns a new Differ.
+func NewDiffer(client client.BindPlane) Differ {
+	return &defaultDiffer{
+		client: client,
+	}
+}
+
+// defaultDiffer is the default implementation of Differ.
+type defaultDiffer struct {
+	client client.BindPlane
+}
+
+// DiffResourcesFromFiles compares all resources from a list of files.
+func (d *defaultDiffer) DiffResourcesFromFiles(ctx context.Context, filenames []string) ([]*model.ResourceDiff, error) {
+	var resources []*model.AnyResource
+	for _, filename := range filenames {
+		r, err := model.ResourcesFromFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read resources: %w", err)
+		}
+
+		resources = append(resources, r...)
+	}
+
+	return d.client.ApplyDryRun(ctx, resources)
+}
+
+// DiffResourcesFromReader compares all resources from a reader.
+func (d *defaultDiffer) DiffResourcesFromReader(ctx context.Context, reader io.Reader) ([]*model.ResourceDiff, error) {
+	resources, err := model.ResourcesFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resources: %w", err)
+	}
+
+	return d.client.ApplyDryRun(ctx, resources)
+}
@@ -0,0 +1,157 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/client/mocks"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testResource = `apiVersion: bindplane.observiq.com/v1
+kind: Webhook
+metadata:
+  name: changes
+spec:
+  url: https://example.com/hook
+`
+
+func TestDiffResourcesFromFiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clientFunc    func() client.BindPlane
+		setupFunc     func(path string) error
+		expectedDiffs []*model.ResourceDiff
+		expectedErr   error
+	}{
+		{
+			name: "missing file",
+			clientFunc: func() client.BindPlane {
+				return mocks.NewMockBindPlane(t)
+			},
+			setupFunc:   func(path string) error { return nil },
+			expectedErr: errors.New("failed to read resources"),
+		},
+		{
+			name: "resource",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("ApplyDryRun", mock.Anything, mock.MatchedBy(func(r []*model.AnyResource) bool {
+					return len(r) == 1 && r[0].Name() == "changes"
+				})).Return([]*model.ResourceDiff{{Kind: model.KindWebhook, Name: "changes", Status: model.StatusCreated}}, nil)
+				return c
+			},
+			setupFunc: func(path string) error {
+				return os.WriteFile(path, []byte(testResource), 0600)
+			},
+			expectedDiffs: []*model.ResourceDiff{{Kind: model.KindWebhook, Name: "changes", Status: model.StatusCreated}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			differ := NewDiffer(tc.clientFunc())
+			filename := filepath.Join(t.TempDir(), tc.name)
+			err := tc.setupFunc(filename)
+			require.NoError(t, err)
+
+			diffs, err := differ.DiffResourcesFromFiles(context.Background(), []string{filename})
+			switch tc.expectedErr {
+			case nil:
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedDiffs, diffs)
+			default:
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestDiffResourcesFromReader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clientFunc    func() client.BindPlane
+		readerFunc    func() io.Reader
+		expectedDiffs []*model.ResourceDiff
+		expectedErr   error
+	}{
+		{
+			name: "failed reader",
+			clientFunc: func() client.BindPlane {
+				return mocks.NewMockBindPlane(t)
+			},
+			readerFunc: func() io.Reader {
+				return strings.NewReader("invalid")
+			},
+			expectedErr: errors.New("failed to read resources"),
+		},
+		{
+			name: "client error",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("ApplyDryRun", mock.Anything, mock.Anything).Return(nil, errors.New("unable to diff resources"))
+				return c
+			},
+			readerFunc: func() io.Reader {
+				return strings.NewReader(testResource)
+			},
+			expectedErr: errors.New("unable to diff resources"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			differ := NewDiffer(tc.clientFunc())
+			diffs, err := differ.DiffResourcesFromReader(context.Background(), tc.readerFunc())
+			switch tc.expectedErr {
+			case nil:
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedDiffs, diffs)
+			default:
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	var buf bytes.Buffer
+	printDiff(&buf, &model.ResourceDiff{Kind: model.KindWebhook, Name: "unchanged", Status: model.StatusUnchanged, Current: "a\n", Proposed: "a\n"})
+	printDiff(&buf, &model.ResourceDiff{Kind: model.KindWebhook, Name: "invalid", Status: model.StatusInvalid, Reason: "webhook must specify a url"})
+	printDiff(&buf, &model.ResourceDiff{Kind: model.KindWebhook, Name: "changes", Status: model.StatusConfigured, CurrentVersion: 1, Current: "url: a\n", Proposed: "url: b\n"})
+	require.Equal(t, `Webhook unchanged unchanged
+Webhook invalid invalid
+	webhook must specify a url
+Webhook changes configured
+--- Webhook/changes:1
++++ Webhook/changes
+@@ -1 +1 @@
+-url: a
++url: b
+`, buf.String())
+}
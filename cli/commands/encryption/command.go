@@ -0,0 +1,66 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encryption provides the encryption command, which manages the encryption of sensitive parameter values
+// stored on the server.
+package encryption
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// Command returns the bindplane encryption cobra command.
+func Command(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encryption",
+		Short: "Manage the encryption of sensitive parameter values",
+	}
+
+	cmd.AddCommand(
+		RotateCommand(builder),
+	)
+
+	return cmd
+}
+
+// RotateCommand returns the bindplane encryption rotate cobra command.
+func RotateCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Re-encrypt sensitive parameter values with the current encryption key",
+		Long: `Re-encrypts the sensitive parameter values of every resource and archived version on the server with the
+current encryption key. To rotate the key, configure the server with the new key in store.encryption.key and the old key
+in store.encryption.previousKeys, restart it, and run this command. The old key can be removed afterwards.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			rotator, err := builder.BuildRotator(ctx)
+			if err != nil {
+				return err
+			}
+
+			count, err := rotator.RotateKey(ctx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Re-encrypted %d resources\n", count)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/client/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateCommand(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clientFunc     func() client.BindPlane
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name: "rotate",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("RotateEncryptionKey", mock.Anything).Return(3, nil)
+				return c
+			},
+			expectedOutput: "Re-encrypted 3 resources\n",
+		},
+		{
+			name: "error",
+			clientFunc: func() client.BindPlane {
+				c := mocks.NewMockBindPlane(t)
+				c.On("RotateEncryptionKey", mock.Anything).Return(0, errors.New("no encryption key is configured"))
+				return c
+			},
+			expectedErr: "failed to rotate encryption key: no encryption key is configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := RotateCommand(testBuilder{NewRotator(tc.clientFunc())})
+			// the root command silences usage on errors
+			cmd.SilenceUsage = true
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{})
+
+			err := cmd.Execute()
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
+
+type testBuilder struct {
+	rotator Rotator
+}
+
+func (b testBuilder) BuildRotator(_ context.Context) (Rotator, error) {
+	return b.rotator, nil
+}
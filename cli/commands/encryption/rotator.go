@@ -0,0 +1,56 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/observiq/bindplane-op/client"
+)
+
+// Rotator is an interface for rotating the key used to encrypt sensitive parameter values on the server.
+type Rotator interface {
+	// RotateKey re-encrypts the sensitive parameter values on the server with the current encryption key and returns the
+	// number of resources and versions that were re-encrypted.
+	RotateKey(ctx context.Context) (int, error)
+}
+
+// Builder is an interface for building a Rotator.
+type Builder interface {
+	// BuildRotator returns a new Rotator.
+	BuildRotator(ctx context.Context) (Rotator, error)
+}
+
+// NewRotator returns a new Rotator.
+func NewRotator(client client.BindPlane) Rotator {
+	return &defaultRotator{
+		client: client,
+	}
+}
+
+// defaultRotator is the default implementation of Rotator.
+type defaultRotator struct {
+	client client.BindPlane
+}
+
+// RotateKey re-encrypts the sensitive parameter values on the server with the current encryption key.
+func (r *defaultRotator) RotateKey(ctx context.Context) (int, error) {
+	count, err := r.client.RotateEncryptionKey(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rotate encryption key: %w", err)
+	}
+	return count, nil
+}
@@ -19,6 +19,9 @@ package get
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/observiq/bindplane-op/client"
 	"github.com/observiq/bindplane-op/model"
@@ -53,6 +56,9 @@ func Command(builder Builder) *cobra.Command {
 		SourcesCommand(builder),
 		SourceTypesCommand(builder),
 		RolloutsCommand(builder),
+		AlertsCommand(builder),
+		WebhooksCommand(builder),
+		AuditCommand(builder),
 	)
 
 	cmd.PersistentFlags().BoolVar(&HistoryFlag, "history", false, "If true, list the history of the resource.")
@@ -144,19 +150,54 @@ func AgentVersionsCommand(builder Builder) *cobra.Command {
 
 // ConfigurationsCommand returns the BindPlane get configurations cobra command
 func ConfigurationsCommand(builder Builder) *cobra.Command {
+	var (
+		diff  string
+		agent string
+	)
+
 	cmd := &cobra.Command{
 		Use:     "configurations [name]",
 		Aliases: []string{"configuration", "configs", "config"},
 		Short:   "Displays the configurations",
 		Long:    "A configuration provides a complete agent configuration to ship logs, metrics, and traces",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Resources(cmd.Context(), builder, model.KindConfiguration, args)
+			if diff == "" {
+				return Resources(cmd.Context(), builder, model.KindConfiguration, args)
+			}
+			if len(args) != 1 {
+				return errors.New("--diff requires the name of a configuration")
+			}
+
+			from, to, err := parseVersionRange(diff)
+			if err != nil {
+				return fmt.Errorf("invalid --diff: %w", err)
+			}
+			getter, err := builder.BuildGetter(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return getter.GetConfigurationDiff(cmd.Context(), args[0], from, to, agent)
 		},
 	}
 	cmd.PersistentFlags().BoolVar(&ExportFlag, "export", false, "If true, export the resource in an importable format.")
+	cmd.Flags().StringVar(&diff, "diff", "", "compare the rendered agent configuration of two versions, e.g. v3..v5, or of a version and the latest version, e.g. v3")
+	cmd.Flags().StringVar(&agent, "agent", "", "ID of the agent to render the configuration for with --diff, an agent using the configuration if not specified")
 	return cmd
 }
 
+// parseVersionRange parses a range of versions like v3..v5. The second version is latest if it is not specified.
+func parseVersionRange(value string) (from, to model.Version, err error) {
+	fromValue, toValue, _ := strings.Cut(value, "..")
+	if from, err = model.ParseVersion(fromValue); err != nil {
+		return
+	}
+	to = model.VersionLatest
+	if toValue != "" {
+		to, err = model.ParseVersion(toValue)
+	}
+	return
+}
+
 // DestinationTypesCommand returns the BindPlane get destination-types cobra command
 func DestinationTypesCommand(builder Builder) *cobra.Command {
 	cmd := &cobra.Command{
@@ -171,6 +212,34 @@ func DestinationTypesCommand(builder Builder) *cobra.Command {
 	return cmd
 }
 
+// AlertsCommand returns the BindPlane get alerts cobra command
+func AlertsCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "alerts [name]",
+		Aliases: []string{"alert"},
+		Short:   "Displays the alerts",
+		Long:    `An alert sends notifications when agents, rollouts, or throughput meet its condition.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Resources(cmd.Context(), builder, model.KindAlert, args)
+		},
+	}
+	return cmd
+}
+
+// WebhooksCommand returns the BindPlane get webhooks cobra command
+func WebhooksCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "webhooks [name]",
+		Aliases: []string{"webhook"},
+		Short:   "Displays the webhooks",
+		Long:    `A webhook delivers changes to resources as signed JSON payloads to a URL.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Resources(cmd.Context(), builder, model.KindWebhook, args)
+		},
+	}
+	return cmd
+}
+
 // DestinationsCommand returns the BindPlane get destinations cobra command
 func DestinationsCommand(builder Builder) *cobra.Command {
 	cmd := &cobra.Command{
@@ -262,6 +331,69 @@ func RolloutsCommand(builder Builder) *cobra.Command {
 	return cmd
 }
 
+// AuditCommand returns the BindPlane get audit cobra command
+func AuditCommand(builder Builder) *cobra.Command {
+	var (
+		actor  string
+		action string
+		kind   string
+		name   string
+		since  string
+		until  string
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Displays the audit log",
+		Long:  `The audit log records the changes made to resources, agents, users, and API keys, starting with the most recent.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := model.AuditEventFilter{
+				Actor:        actor,
+				Action:       model.AuditAction(action),
+				ResourceKind: model.Kind(kind),
+				ResourceName: name,
+				Limit:        limit,
+			}
+
+			var err error
+			if filter.Since, err = parseAuditTime(since); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			if filter.Until, err = parseAuditTime(until); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+
+			getter, err := builder.BuildGetter(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return getter.GetAuditEvents(cmd.Context(), filter)
+		},
+	}
+
+	cmd.Flags().StringVar(&actor, "actor", "", "only display changes by this user or API key, e.g. admin or apikey:<id>")
+	cmd.Flags().StringVar(&action, "action", "", "only display changes with this action, e.g. create, delete, or rollout.start")
+	cmd.Flags().StringVar(&kind, "kind", "", "only display changes to this kind of resource, e.g. configuration")
+	cmd.Flags().StringVar(&name, "name", "", "only display changes to resources with this name")
+	cmd.Flags().StringVar(&since, "since", "", "only display changes after this time, as RFC3339 or a duration before now, e.g. 24h")
+	cmd.Flags().StringVar(&until, "until", "", "only display changes before this time, as RFC3339 or a duration before now, e.g. 1h")
+	cmd.Flags().IntVar(&limit, "limit", 100, "maximum number of changes to display")
+
+	return cmd
+}
+
+// parseAuditTime parses an RFC3339 time or a duration before now. An empty value returns the zero time.
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 // Resources gets resources based on the kind and args
 func Resources(ctx context.Context, builder Builder, kind model.Kind, args []string) error {
 	getter, err := builder.BuildGetter(ctx)
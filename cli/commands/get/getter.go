@@ -48,6 +48,13 @@ type Getter interface {
 
 	// GetResourceHistory gets and prints the history for a single resource
 	GetResourceHistory(ctx context.Context, kind model.Kind, id string) error
+
+	// GetAuditEvents gets and prints the audit events that match the filter
+	GetAuditEvents(ctx context.Context, filter model.AuditEventFilter) error
+
+	// GetConfigurationDiff gets and prints the difference between the rendered agent configurations of two versions of
+	// a configuration
+	GetConfigurationDiff(ctx context.Context, name string, from, to model.Version, agentID string) error
 }
 
 // Builder is an interface for building a Getter.
@@ -196,6 +203,53 @@ func (g *DefaultGetter) GetResourceHistory(ctx context.Context, kind model.Kind,
 	return nil
 }
 
+// GetAuditEvents gets and prints the audit events that match the filter
+func (g *DefaultGetter) GetAuditEvents(ctx context.Context, filter model.AuditEventFilter) error {
+	events, err := g.client.AuditEvents(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	printables := make([]model.Printable, len(events))
+	for i, event := range events {
+		printables[i] = event
+	}
+
+	g.printer.PrintResources(printables)
+	return nil
+}
+
+// GetConfigurationDiff gets and prints the difference between the rendered agent configurations of two versions of a
+// configuration
+func (g *DefaultGetter) GetConfigurationDiff(ctx context.Context, name string, from, to model.Version, agentID string) error {
+	diff, err := g.client.ConfigurationDiff(ctx, name, from, to, agentID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(formatConfigurationDiff(diff))
+	return nil
+}
+
+// formatConfigurationDiff returns the unified diff preceded by the agent the configurations were rendered for
+func formatConfigurationDiff(diff *model.ConfigurationDiff) string {
+	var sb strings.Builder
+	if diff.Agent != "" {
+		fmt.Fprintf(&sb, "Rendered for agent %s\n", diff.Agent)
+	} else {
+		sb.WriteString("Rendered without an agent\n")
+	}
+
+	fromName := model.JoinVersion(diff.Name, diff.From)
+	toName := model.JoinVersion(diff.Name, diff.To)
+	if diff.Diff == "" {
+		fmt.Fprintf(&sb, "No changes between %s and %s\n", fromName, toName)
+	} else {
+		sb.WriteString(diff.Diff)
+	}
+	return sb.String()
+}
+
 // GetRawResource gets and prints the raw version of a resource
 func (g *DefaultGetter) GetRawResource(ctx context.Context, kind model.Kind, id string) error {
 	var rawConfig string
@@ -236,6 +290,10 @@ func (g *DefaultGetter) getPrintableResource(ctx context.Context, kind model.Kin
 		}
 	case model.KindDestinationType:
 		resource, err = g.client.DestinationType(ctx, id)
+	case model.KindAlert:
+		resource, err = g.client.Alert(ctx, id)
+	case model.KindWebhook:
+		resource, err = g.client.Webhook(ctx, id)
 	case model.KindDestination:
 		d := &model.Destination{}
 		d, err = g.client.Destination(ctx, id)
@@ -325,6 +383,18 @@ func (g *DefaultGetter) getAllPrintableResources(ctx context.Context, kind model
 			resources = append(resources, destinationType)
 		}
 		return resources, err
+	case model.KindAlert:
+		alerts, err := g.client.Alerts(ctx)
+		for _, alert := range alerts {
+			resources = append(resources, alert)
+		}
+		return resources, err
+	case model.KindWebhook:
+		webhooks, err := g.client.Webhooks(ctx)
+		for _, webhook := range webhooks {
+			resources = append(resources, webhook)
+		}
+		return resources, err
 	case model.KindDestination:
 		destination, err := g.client.Destinations(ctx)
 		for _, destination := range destination {
@@ -141,6 +141,34 @@ func TestGetResource(t *testing.T) {
 			id:               "test-id",
 			expectedContents: "DestinationType=test-id",
 		},
+		{
+			name: "valid alert",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				alert := &model.Alert{}
+				alert.Metadata.ID = "test-id"
+				c.On("Alert", mock.Anything, "test-id").Return(alert, nil)
+				return c
+			},
+			kind:             model.KindAlert,
+			format:           "table",
+			id:               "test-id",
+			expectedContents: "Alert=test-id",
+		},
+		{
+			name: "valid webhook",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				webhook := &model.Webhook{}
+				webhook.Metadata.ID = "test-id"
+				c.On("Webhook", mock.Anything, "test-id").Return(webhook, nil)
+				return c
+			},
+			kind:             model.KindWebhook,
+			format:           "table",
+			id:               "test-id",
+			expectedContents: "Webhook=test-id",
+		},
 		{
 			name: "valid destination",
 			clientFunc: func() client.BindPlane {
@@ -493,6 +521,30 @@ func TestGetResourcesOfKind(t *testing.T) {
 			kind:             model.KindDestinationType,
 			expectedContents: "DestinationType=test-id",
 		},
+		{
+			name: "valid alerts",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				alert := &model.Alert{}
+				alert.Metadata.ID = "test-id"
+				c.On("Alerts", mock.Anything).Return([]*model.Alert{alert}, nil)
+				return c
+			},
+			kind:             model.KindAlert,
+			expectedContents: "Alert=test-id",
+		},
+		{
+			name: "valid webhooks",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				webhook := &model.Webhook{}
+				webhook.Metadata.ID = "test-id"
+				c.On("Webhooks", mock.Anything).Return([]*model.Webhook{webhook}, nil)
+				return c
+			},
+			kind:             model.KindWebhook,
+			expectedContents: "Webhook=test-id",
+		},
 		{
 			name: "valid destinations",
 			clientFunc: func() client.BindPlane {
@@ -778,3 +830,142 @@ func (t *testPrinter) PrintResources(list []model.Printable) {
 		t.PrintResource(item)
 	}
 }
+
+func TestGetAuditEvents(t *testing.T) {
+	filter := model.AuditEventFilter{Actor: "admin", ResourceKind: model.KindConfiguration, Limit: 10}
+
+	testCases := []struct {
+		name        string
+		mockSetup   func(t *testing.T) (client.BindPlane, printer.Printer)
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			mockSetup: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("AuditEvents", mock.Anything, filter).Return(nil, errors.New("bad"))
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			mockSetup: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				event := model.NewAuditEvent("admin", "127.0.0.1", model.AuditActionCreate, model.KindConfiguration, "config1", 1)
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("AuditEvents", mock.Anything, filter).Return([]*model.AuditEvent{event}, nil)
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+				mockPrinter.On("PrintResources", []model.Printable{event})
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient, mockPrinter := tc.mockSetup(t)
+			getter := NewGetter(mockClient, mockPrinter, "table")
+			err := getter.GetAuditEvents(context.Background(), filter)
+			switch tc.expectedErr {
+			case nil:
+				require.NoError(t, err)
+			default:
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetConfigurationDiff(t *testing.T) {
+	testCases := []struct {
+		name        string
+		clientFunc  func() client.BindPlane
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				c.On("ConfigurationDiff", mock.Anything, "config1", model.Version(3), model.Version(5), "").Return(nil, errors.New("bad"))
+				return c
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			clientFunc: func() client.BindPlane {
+				c := clientmocks.NewMockBindPlane(t)
+				c.On("ConfigurationDiff", mock.Anything, "config1", model.Version(3), model.Version(5), "").Return(&model.ConfigurationDiff{Name: "config1", From: 3, To: 5}, nil)
+				return c
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := NewGetter(tc.clientFunc(), printermocks.NewMockPrinter(t), "table")
+			err := getter.GetConfigurationDiff(context.Background(), "config1", 3, 5, "")
+			switch tc.expectedErr {
+			case nil:
+				require.NoError(t, err)
+			default:
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestFormatConfigurationDiff(t *testing.T) {
+	require.Equal(t, "Rendered without an agent\nNo changes between config1:3 and config1\n", formatConfigurationDiff(&model.ConfigurationDiff{
+		Name: "config1",
+		From: 3,
+		To:   model.VersionLatest,
+	}))
+
+	diff := "--- config1:3\n+++ config1:5\n@@ -1 +1 @@\n-a: 1\n+a: 2\n"
+	require.Equal(t, "Rendered for agent agent-1\n"+diff, formatConfigurationDiff(&model.ConfigurationDiff{
+		Name:  "config1",
+		From:  3,
+		To:    5,
+		Agent: "agent-1",
+		Diff:  diff,
+	}))
+}
+
+func TestParseVersionRange(t *testing.T) {
+	testCases := []struct {
+		value      string
+		expectFrom model.Version
+		expectTo   model.Version
+		expectErr  bool
+	}{
+		{value: "v3..v5", expectFrom: 3, expectTo: 5},
+		{value: "3..5", expectFrom: 3, expectTo: 5},
+		{value: "v3", expectFrom: 3, expectTo: model.VersionLatest},
+		{value: "current..", expectFrom: model.VersionCurrent, expectTo: model.VersionLatest},
+		{value: "v3..pending", expectFrom: 3, expectTo: model.VersionPending},
+		{value: "..v5", expectErr: true},
+		{value: "v3..five", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			from, to, err := parseVersionRange(tc.value)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectFrom, from)
+			require.Equal(t, tc.expectTo, to)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package rollout
 import (
 	"errors"
 
+	"github.com/observiq/bindplane-op/model"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +37,7 @@ func Command(builder Builder) *cobra.Command {
 		PauseCommand(builder),
 		ResumeCommand(builder),
 		StatusCommand(builder),
+		RevertCommand(builder),
 	)
 
 	return cmd
@@ -135,7 +137,7 @@ func ResumeCommand(builder Builder) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "resume <configuration>",
 		Short: "Resumes the rollout",
-		Long:  "A rollout resume resumes the rollout.",
+		Long:  "A rollout resume resumes the rollout. A rollout paused after its canary phase is approved and continues with the remaining agents.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				_ = cmd.Help()
@@ -178,3 +180,39 @@ func StatusCommand(builder Builder) *cobra.Command {
 
 	return cmd
 }
+
+// RevertCommand the revert command reverts a configuration to a previous version
+func RevertCommand(builder Builder) *cobra.Command {
+	var (
+		version int
+		start   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revert <configuration>",
+		Short: "Reverts a configuration to a previous version",
+		Long:  "Revert creates a new version of the configuration with the spec of a previous version. The new version is not rolled out unless --start is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+			if version < 1 {
+				return errors.New("--to-version must specify a version number")
+			}
+
+			rollouter, err := builder.BuildRollouter(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			rolloutName := args[0]
+			return rollouter.RevertRollout(cmd.Context(), rolloutName, model.Version(version), start)
+		},
+	}
+
+	cmd.Flags().IntVar(&version, "to-version", 0, "version of the configuration to revert to")
+	cmd.Flags().BoolVar(&start, "start", false, "start a rollout of the reverted configuration")
+
+	return cmd
+}
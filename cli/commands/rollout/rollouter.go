@@ -46,6 +46,9 @@ type Rollouter interface {
 
 	// UpdateRollouts updates all rollouts
 	UpdateRollouts(ctx context.Context) error
+
+	// RevertRollout reverts the configuration with rolloutName to a previous version and optionally starts a rollout
+	RevertRollout(ctx context.Context, rolloutName string, version model.Version, start bool) error
 }
 
 // Builder is an interface fo building a Rollouter
@@ -161,3 +164,14 @@ func (d *defaultRollouter) RolloutStatus(ctx context.Context, rolloutName string
 	d.printer.PrintResource(cfg.Rollout())
 	return nil
 }
+
+// RevertRollout reverts the configuration with rolloutName to a previous version and optionally starts a rollout
+func (d *defaultRollouter) RevertRollout(ctx context.Context, rolloutName string, version model.Version, start bool) error {
+	response, err := d.client.RevertConfiguration(ctx, rolloutName, version, start)
+	if err != nil {
+		return fmt.Errorf("failed to revert %s to version %d: %w", rolloutName, version, err)
+	}
+
+	d.printer.PrintResource(response.Configuration.Rollout())
+	return nil
+}
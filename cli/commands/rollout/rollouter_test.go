@@ -495,3 +495,67 @@ func TestRolloutStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRevertRollout(t *testing.T) {
+	rolloutName := "my_rollout"
+	testCases := []struct {
+		name        string
+		mockFunc    func(t *testing.T) (client.BindPlane, printer.Printer)
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("RevertConfiguration", mock.Anything, rolloutName, model.Version(2), true).Return(nil, errors.New("bad"))
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				cfg := &model.Configuration{
+					ResourceMeta: model.ResourceMeta{
+						APIVersion: version.V1,
+						Kind:       model.KindConfiguration,
+						Metadata: model.Metadata{
+							Name:    rolloutName,
+							Version: 4,
+						},
+					},
+				}
+
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("RevertConfiguration", mock.Anything, rolloutName, model.Version(2), true).Return(&model.RevertConfigurationResponse{
+					Status:        model.StatusConfigured,
+					Configuration: cfg,
+				}, nil)
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+				mockPrinter.On("PrintResource", cfg.Rollout()).Return()
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient, mockPrinter := tc.mockFunc(t)
+			rollouter := NewRollouter(mockClient, mockPrinter)
+			err := rollouter.RevertRollout(context.Background(), rolloutName, 2, true)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
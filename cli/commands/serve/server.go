@@ -28,16 +28,21 @@ import (
 	"github.com/gin-gonic/gin"
 	cors "github.com/itsjamie/gin-cors"
 	"github.com/observiq/bindplane-op/agent"
+	"github.com/observiq/bindplane-op/alerts"
 	"github.com/observiq/bindplane-op/config"
 	bpserver "github.com/observiq/bindplane-op/internal/server"
 	"github.com/observiq/bindplane-op/metrics"
+	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/resources"
 	exposedserver "github.com/observiq/bindplane-op/server"
 	"github.com/observiq/bindplane-op/stopqueue"
 	"github.com/observiq/bindplane-op/store"
+	"github.com/observiq/bindplane-op/store/search"
 	"github.com/observiq/bindplane-op/store/stats"
 	"github.com/observiq/bindplane-op/tracer"
+	"github.com/observiq/bindplane-op/webhooks"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
@@ -153,11 +158,15 @@ func (s *defaultServer) Serve(ctx context.Context) error {
 	}
 
 	s.startScheduler(ctx)
+	s.startWebhookDispatcher(ctx)
+	s.startGitSync(ctx, bindplane)
 
 	s.startTracer(ctx)
 
 	s.startMetrics(ctx)
 
+	s.setupMetrics()
+
 	serverErr := make(chan error, 1)
 	go func() {
 		switch httpServer.TLSConfig {
@@ -220,7 +229,10 @@ func (s *defaultServer) createAgentVersions(ctx context.Context) agent.Versions
 
 // createMeasurementBatcher creates a measurement batcher and adds it to the stop queue
 func (s *defaultServer) createMeasurementBatcher(ctx context.Context) stats.MeasurementBatcher {
-	batcher := stats.NewDefaultBatcher(ctx, s.logger, s.store.Measurements())
+	var batcher stats.MeasurementBatcher = stats.NewDefaultBatcher(ctx, s.logger, s.store.Measurements())
+	if s.cfg.Metrics.Throughput {
+		batcher = stats.NewThroughputExporter(s.logger, batcher)
+	}
 
 	s.stopQueue.Add(func(stopCtx context.Context) error {
 		return batcher.Shutdown(stopCtx)
@@ -262,6 +274,11 @@ func (s *defaultServer) createRouter(bindplane exposedserver.BindPlane) (*gin.En
 		c.Status(http.StatusOK)
 	})
 
+	// metrics providers that are scraped serve their metrics on the router
+	if handler, ok := s.mp.(metrics.Handler); ok {
+		router.GET("/metrics", gin.WrapH(handler))
+	}
+
 	if err := s.routeBuilder.AddRoutes(router, bindplane); err != nil {
 		return nil, fmt.Errorf("failed to add routes: %w", err)
 	}
@@ -316,6 +333,72 @@ func (s *defaultServer) startMetrics(ctx context.Context) {
 	)
 }
 
+// metricsAgentStatuses are the agent statuses reported by the agents metric
+var metricsAgentStatuses = []model.AgentStatus{
+	model.Disconnected,
+	model.Connected,
+	model.Error,
+	model.Configuring,
+	model.Upgrading,
+}
+
+// metricsRolloutStatuses are the rollout statuses reported by the rollouts metric
+var metricsRolloutStatuses = []model.RolloutStatus{
+	model.RolloutStatusPending,
+	model.RolloutStatusStarted,
+	model.RolloutStatusPaused,
+	model.RolloutStatusError,
+	model.RolloutStatusStable,
+	model.RolloutStatusReplaced,
+}
+
+// setupMetrics creates the gauges for agents by status, configurations by rollout status, and eventbus subscribers
+func (s *defaultServer) setupMetrics() {
+	_, err := mp.Int64ObservableGauge("agents",
+		metric.WithDescription("Number of agents by status"),
+		metric.WithInt64Callback(func(ctx context.Context, io metric.Int64Observer) error {
+			index := s.store.AgentIndex(ctx)
+			for _, status := range metricsAgentStatuses {
+				ids, err := search.Field(ctx, index, "status", status.DisplayText())
+				if err != nil {
+					return fmt.Errorf("failed to count agents with status %s: %w", status.DisplayText(), err)
+				}
+				io.Observe(int64(len(ids)), metric.WithAttributes(attribute.String("status", status.DisplayText())))
+			}
+			return nil
+		}))
+	if err != nil {
+		s.logger.Warn("failed to create agents metric", zap.Error(err))
+	}
+
+	_, err = mp.Int64ObservableGauge("rollouts",
+		metric.WithDescription("Number of configuration rollouts by status"),
+		metric.WithInt64Callback(func(ctx context.Context, io metric.Int64Observer) error {
+			index := s.store.ConfigurationIndex(ctx)
+			for _, status := range metricsRolloutStatuses {
+				names, err := search.Field(ctx, index, "rollout-status", status.String())
+				if err != nil {
+					return fmt.Errorf("failed to count rollouts with status %s: %w", status, err)
+				}
+				io.Observe(int64(len(names)), metric.WithAttributes(attribute.String("status", status.String())))
+			}
+			return nil
+		}))
+	if err != nil {
+		s.logger.Warn("failed to create rollouts metric", zap.Error(err))
+	}
+
+	_, err = mp.Int64ObservableGauge("eventbus_subscribers",
+		metric.WithDescription("Number of subscribers to store updates"),
+		metric.WithInt64Callback(func(ctx context.Context, io metric.Int64Observer) error {
+			io.Observe(int64(s.store.Updates(ctx).Subscribers()))
+			return nil
+		}))
+	if err != nil {
+		s.logger.Warn("failed to create eventbus_subscribers metric", zap.Error(err))
+	}
+}
+
 // startManager starts the bindplane manager
 func (s *defaultServer) startManager(ctx context.Context, bindplane exposedserver.BindPlane) {
 	bindplane.Manager().Start(ctx)
@@ -328,7 +411,10 @@ func (s *defaultServer) startManager(ctx context.Context, bindplane exposedserve
 }
 
 func (s *defaultServer) startScheduler(ctx context.Context) {
-	scheduler := exposedserver.NewScheduler(s.store, s.logger, s.cfg.RolloutsInterval)
+	evaluator := alerts.NewEvaluator(s.store, alerts.NewNotifier(s.cfg.Alerts.SMTP), s.logger)
+	scheduler := exposedserver.NewScheduler(s.store, s.logger, s.cfg.RolloutsInterval,
+		exposedserver.WithAlertEvaluator(evaluator, s.cfg.Alerts.Interval),
+	)
 	scheduler.Start(ctx)
 
 	s.stopQueue.Add(
@@ -337,3 +423,26 @@ func (s *defaultServer) startScheduler(ctx context.Context) {
 		},
 	)
 }
+
+// startGitSync starts syncing resources from the git repository if one is configured
+func (s *defaultServer) startGitSync(ctx context.Context, bindplane exposedserver.BindPlane) {
+	bindplane.GitSync().Start(ctx)
+
+	s.stopQueue.Add(
+		func(stopCtx context.Context) error {
+			return bindplane.GitSync().Stop(stopCtx)
+		},
+	)
+}
+
+// startWebhookDispatcher starts delivering store updates to webhooks
+func (s *defaultServer) startWebhookDispatcher(ctx context.Context) {
+	dispatcher := webhooks.NewDispatcher(s.store, s.logger)
+	dispatcher.Start(ctx)
+
+	s.stopQueue.Add(
+		func(stopCtx context.Context) error {
+			return dispatcher.Stop(stopCtx)
+		},
+	)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/observiq/bindplane-op/store"
 	storeMocks "github.com/observiq/bindplane-op/store/mocks"
 	searchMocks "github.com/observiq/bindplane-op/store/search/mocks"
+	"github.com/observiq/bindplane-op/store/stats"
 	statsmocks "github.com/observiq/bindplane-op/store/stats/mocks"
 	traceMocks "github.com/observiq/bindplane-op/tracer/mocks"
 	"github.com/stretchr/testify/mock"
@@ -169,6 +170,44 @@ func TestSeed(t *testing.T) {
 	}
 }
 
+func TestCreateMeasurementBatcher(t *testing.T) {
+	testCases := []struct {
+		name       string
+		throughput bool
+		expected   stats.MeasurementBatcher
+	}{
+		{
+			name:     "default",
+			expected: &stats.DefaultBatcher{},
+		},
+		{
+			name:       "throughput",
+			throughput: true,
+			expected:   &stats.ThroughputExporter{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Metrics: config.Metrics{
+					Throughput: tc.throughput,
+				},
+			}
+
+			st := storeMocks.NewMockStore(t)
+			st.On("Measurements").Return(statsmocks.NewMockMeasurements(t))
+
+			s := NewServer(cfg, st, nil, zap.NewNop(), nil, nil).(*defaultServer)
+			batcher := s.createMeasurementBatcher(context.Background())
+			require.IsType(t, tc.expected, batcher)
+
+			// the batcher is shut down with the server
+			require.NoError(t, s.stopQueue.StopAll(context.Background()))
+		})
+	}
+}
+
 func TestServeWithClient(t *testing.T) {
 	logger := zap.NewNop()
 	cfg := &config.Config{
@@ -0,0 +1,169 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot provides the snapshot commands, which save the telemetry captured by the snapshot processors of an
+// agent so that it can be downloaded and compared.
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/model/otel"
+)
+
+// Command returns the bindplane snapshot cobra command.
+func Command(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save, download, and compare snapshots of agent telemetry",
+		Long: `Snapshots are the recent logs, metrics, or traces captured by an agent. Saved snapshots can be downloaded as
+OTLP JSON and compared, e.g. to see the changes made by the processors of a source.`,
+	}
+	cmd.AddCommand(
+		SaveCommand(builder),
+		GetCommand(builder),
+		DiffCommand(builder),
+		DeleteCommand(builder),
+	)
+
+	return cmd
+}
+
+// SaveCommand the save command captures a snapshot from an agent and saves it
+func SaveCommand(builder Builder) *cobra.Command {
+	var pipelineType, position, resourceName string
+
+	cmd := &cobra.Command{
+		Use:   "save <agent-id>",
+		Short: "Captures and saves a snapshot from an agent",
+		Long: `Captures and saves a snapshot from an agent. By default, the snapshot is captured at the end of each pipeline
+after all processors. Use --position s0 or d0 with --resource to capture the snapshot before the processors of a source
+or destination in the configuration of the agent.`,
+		Example: `bindplane snapshot save 01H2ZBRV3MAXZ0Q4R2JX5V6P3T --type logs --position s0 --resource source0
+bindplane snapshot save 01H2ZBRV3MAXZ0Q4R2JX5V6P3T --type logs`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			snapshotter, err := builder.BuildSnapshotter(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return snapshotter.SaveSnapshot(cmd.Context(), model.PostSnapshotRequest{
+				AgentID:      args[0],
+				PipelineType: otel.PipelineType(pipelineType),
+				Position:     model.MeasurementPosition(position),
+				ResourceName: resourceName,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&pipelineType, "type", string(otel.Logs), "type of telemetry, one of: logs, metrics, traces")
+	cmd.Flags().StringVar(&position, "position", "", "position of the snapshot processor, s0 before the processors of a source or d0 before the processors of a destination")
+	cmd.Flags().StringVar(&resourceName, "resource", "", "name of the source or destination in the configuration, e.g. source0")
+
+	return cmd
+}
+
+// GetCommand the get command lists saved snapshots or downloads the telemetry of one
+func GetCommand(builder Builder) *cobra.Command {
+	var agentID, outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "get [id]",
+		Short: "Lists saved snapshots or downloads a snapshot",
+		Long: `Lists saved snapshots, starting with the most recent. If an ID is specified, the telemetry of the snapshot is
+written to stdout or --output-file as OTLP JSON.`,
+		Example: `bindplane snapshot get --agent 01H2ZBRV3MAXZ0Q4R2JX5V6P3T
+bindplane snapshot get 1685620800000000000-3f2a9c1e --output-file logs.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			snapshotter, err := builder.BuildSnapshotter(ctx)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case len(args) == 0:
+				return snapshotter.ListSnapshots(ctx, agentID)
+			case outputFile != "":
+				if err := snapshotter.WriteSnapshotToFile(ctx, args[0], outputFile); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Snapshot written to %s\n", outputFile)
+				return nil
+			default:
+				return snapshotter.WriteSnapshot(ctx, args[0], cmd.OutOrStdout())
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&agentID, "agent", "", "only list snapshots of the agent with this ID")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "file to write the telemetry of the snapshot to instead of stdout")
+
+	return cmd
+}
+
+// DiffCommand the diff command compares the records of two saved snapshots
+func DiffCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <from-id> <to-id>",
+		Short: "Compares two saved snapshots",
+		Long: `Compares the records of two saved snapshots of the same type, e.g. a snapshot saved with --position s0 before the
+processors of a source and a snapshot saved at the end of the pipeline. Records are matched by their timestamp, the
+name and timestamp of metric data points, or the trace and span ID of spans.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			snapshotter, err := builder.BuildSnapshotter(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return snapshotter.DiffSnapshots(cmd.Context(), args[0], args[1], cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+// DeleteCommand the delete command deletes a saved snapshot
+func DeleteCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Deletes a saved snapshot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			snapshotter, err := builder.BuildSnapshotter(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return snapshotter.DeleteSnapshot(cmd.Context(), args[0])
+		},
+	}
+
+	return cmd
+}
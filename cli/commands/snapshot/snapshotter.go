@@ -0,0 +1,178 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/observiq/bindplane-op/cli/printer"
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Snapshotter is an interface for saving, downloading, and comparing snapshots of agent telemetry.
+type Snapshotter interface {
+	// SaveSnapshot captures a snapshot from an agent, saves it, and prints it.
+	SaveSnapshot(ctx context.Context, request model.PostSnapshotRequest) error
+
+	// ListSnapshots prints the saved snapshots. If agentID is not empty, only snapshots of that agent are printed.
+	ListSnapshots(ctx context.Context, agentID string) error
+
+	// WriteSnapshot writes the telemetry of the snapshot as OTLP JSON to the writer.
+	WriteSnapshot(ctx context.Context, id string, writer io.Writer) error
+
+	// WriteSnapshotToFile writes the telemetry of the snapshot as OTLP JSON to the file, replacing it if it exists.
+	WriteSnapshotToFile(ctx context.Context, id string, filename string) error
+
+	// DiffSnapshots writes the differences between the records of two snapshots to the writer.
+	DiffSnapshots(ctx context.Context, fromID, toID string, writer io.Writer) error
+
+	// DeleteSnapshot deletes the snapshot.
+	DeleteSnapshot(ctx context.Context, id string) error
+}
+
+// Builder is an interface for building a Snapshotter.
+type Builder interface {
+	// BuildSnapshotter returns a new Snapshotter.
+	BuildSnapshotter(ctx context.Context) (Snapshotter, error)
+}
+
+// NewSnapshotter returns a new Snapshotter.
+func NewSnapshotter(client client.BindPlane, printer printer.Printer) Snapshotter {
+	return &defaultSnapshotter{
+		client:  client,
+		printer: printer,
+	}
+}
+
+// defaultSnapshotter is the default implementation of Snapshotter.
+type defaultSnapshotter struct {
+	client  client.BindPlane
+	printer printer.Printer
+}
+
+// SaveSnapshot captures a snapshot from an agent, saves it, and prints it without the telemetry.
+func (s *defaultSnapshotter) SaveSnapshot(ctx context.Context, request model.PostSnapshotRequest) error {
+	snapshot, err := s.client.SaveSnapshot(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot of agent %s: %w", request.AgentID, err)
+	}
+
+	s.printer.PrintResource(snapshot.Summary())
+	return nil
+}
+
+// ListSnapshots prints the saved snapshots.
+func (s *defaultSnapshotter) ListSnapshots(ctx context.Context, agentID string) error {
+	snapshots, err := s.client.Snapshots(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	printables := make([]model.Printable, len(snapshots))
+	for i, snapshot := range snapshots {
+		printables[i] = snapshot
+	}
+
+	s.printer.PrintResources(printables)
+	return nil
+}
+
+// WriteSnapshot writes the telemetry of the snapshot as OTLP JSON to the writer.
+func (s *defaultSnapshotter) WriteSnapshot(ctx context.Context, id string, writer io.Writer) error {
+	snapshot, err := s.client.Snapshot(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshot %s: %w", id, err)
+	}
+
+	_, err = writer.Write(snapshot.Data)
+	return err
+}
+
+// WriteSnapshotToFile writes the telemetry of the snapshot as OTLP JSON to the file.
+func (s *defaultSnapshotter) WriteSnapshotToFile(ctx context.Context, id string, filename string) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600) // #nosec G304 -- the file is specified by the user running the command
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	err = s.WriteSnapshot(ctx, id, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// don't leave an incomplete snapshot behind
+		return errors.Join(err, os.Remove(filename))
+	}
+	return nil
+}
+
+// DiffSnapshots writes the differences between the records of two snapshots to the writer. Removed records are
+// prefixed with -, added records with +, and changed records with ~ followed by the fields that changed.
+func (s *defaultSnapshotter) DiffSnapshots(ctx context.Context, fromID, toID string, writer io.Writer) error {
+	diff, err := s.client.SnapshotDiff(ctx, fromID, toID)
+	if err != nil {
+		return fmt.Errorf("failed to compare snapshots %s and %s: %w", fromID, toID, err)
+	}
+
+	fmt.Fprintf(writer, "--- %s\n", describeSnapshot(diff.From))
+	fmt.Fprintf(writer, "+++ %s\n", describeSnapshot(diff.To))
+	for _, removed := range diff.Removed {
+		fmt.Fprintf(writer, "- %s %s\n", removed.Key, jsonString(removed.Record))
+	}
+	for _, added := range diff.Added {
+		fmt.Fprintf(writer, "+ %s %s\n", added.Key, jsonString(added.Record))
+	}
+	for _, changed := range diff.Changed {
+		fmt.Fprintf(writer, "~ %s\n", changed.Key)
+		for _, change := range changed.Changes {
+			fmt.Fprintf(writer, "    %s: %s -> %s\n", change.Field, jsonString(change.From), jsonString(change.To))
+		}
+	}
+	fmt.Fprintf(writer, "%d unchanged, %d removed, %d added, %d changed\n",
+		diff.Unchanged, len(diff.Removed), len(diff.Added), len(diff.Changed))
+	return nil
+}
+
+// DeleteSnapshot deletes the snapshot.
+func (s *defaultSnapshotter) DeleteSnapshot(ctx context.Context, id string) error {
+	if err := s.client.DeleteSnapshot(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %w", id, err)
+	}
+	return nil
+}
+
+// describeSnapshot returns the ID, pipeline type, and snapshot processor of the snapshot
+func describeSnapshot(snapshot *model.Snapshot) string {
+	return fmt.Sprintf("%s %s at %s of agent %s (%d records)",
+		snapshot.ID, snapshot.PipelineType, snapshot.Processor(), snapshot.AgentID, snapshot.Count)
+}
+
+// jsonString returns the value as compact JSON or <none> if it is nil
+func jsonString(value any) string {
+	if value == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
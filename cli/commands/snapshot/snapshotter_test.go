@@ -0,0 +1,127 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	printermocks "github.com/observiq/bindplane-op/cli/printer/mocks"
+	"github.com/observiq/bindplane-op/client/mocks"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveSnapshot(t *testing.T) {
+	request := model.PostSnapshotRequest{AgentID: "1", PipelineType: otel.Logs}
+	snapshot := &model.Snapshot{ID: "s1", AgentID: "1", PipelineType: otel.Logs, Count: 1, Data: []byte(`{"resourceLogs":[]}`)}
+
+	t.Run("prints the snapshot without data", func(t *testing.T) {
+		c := mocks.NewMockBindPlane(t)
+		c.On("SaveSnapshot", mock.Anything, request).Return(snapshot, nil)
+		p := printermocks.NewMockPrinter(t)
+		p.On("PrintResource", snapshot.Summary()).Return()
+
+		require.NoError(t, NewSnapshotter(c, p).SaveSnapshot(context.Background(), request))
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		c := mocks.NewMockBindPlane(t)
+		c.On("SaveSnapshot", mock.Anything, request).Return(nil, errors.New("got 504 Gateway Timeout"))
+
+		err := NewSnapshotter(c, printermocks.NewMockPrinter(t)).SaveSnapshot(context.Background(), request)
+		require.ErrorContains(t, err, "failed to save snapshot of agent 1: got 504 Gateway Timeout")
+	})
+}
+
+func TestWriteSnapshotToFile(t *testing.T) {
+	testCases := []struct {
+		name         string
+		snapshot     *model.Snapshot
+		err          error
+		expectedFile string
+		expectedErr  string
+	}{
+		{
+			name:         "writes the data",
+			snapshot:     &model.Snapshot{ID: "s1", Data: []byte(`{"resourceLogs":[]}`)},
+			expectedFile: `{"resourceLogs":[]}`,
+		},
+		{
+			name:        "error removes the file",
+			err:         errors.New("got 404 Not Found"),
+			expectedErr: "failed to get snapshot s1: got 404 Not Found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := mocks.NewMockBindPlane(t)
+			c.On("Snapshot", mock.Anything, "s1").Return(tc.snapshot, tc.err)
+
+			filename := filepath.Join(t.TempDir(), "snapshot.json")
+			err := NewSnapshotter(c, printermocks.NewMockPrinter(t)).WriteSnapshotToFile(context.Background(), "s1", filename)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				require.NoFileExists(t, filename)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(filename)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFile, string(data))
+		})
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	diff := &model.SnapshotDiff{
+		From:      &model.Snapshot{ID: "s1", AgentID: "1", PipelineType: otel.Logs, Position: "s0", ResourceName: "source0", Count: 3},
+		To:        &model.Snapshot{ID: "s2", AgentID: "1", PipelineType: otel.Logs, Count: 2},
+		Unchanged: 1,
+		Removed:   []model.SnapshotRecord{{Key: "2023-06-01T00:00:00Z", Record: map[string]any{"body": "a"}}},
+		Added:     []model.SnapshotRecord{},
+		Changed: []model.SnapshotRecordChange{
+			{
+				Key: "2023-06-01T00:00:01Z",
+				Changes: []model.SnapshotFieldChange{
+					{Field: "attributes.drop", From: "true"},
+					{Field: "body", From: "b", To: "B"},
+				},
+			},
+		},
+	}
+
+	c := mocks.NewMockBindPlane(t)
+	c.On("SnapshotDiff", mock.Anything, "s1", "s2").Return(diff, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, NewSnapshotter(c, printermocks.NewMockPrinter(t)).DiffSnapshots(context.Background(), "s1", "s2", &out))
+	require.Equal(t, `--- s1 logs at snapshotprocessor/_s0_source0 of agent 1 (3 records)
++++ s2 logs at snapshotprocessor of agent 1 (2 records)
+- 2023-06-01T00:00:00Z {"body":"a"}
+~ 2023-06-01T00:00:01Z
+    attributes.drop: "true" -> <none>
+    body: "b" -> "B"
+1 unchanged, 1 removed, 0 added, 1 changed
+`, out.String())
+}
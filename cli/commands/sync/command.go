@@ -13,29 +13,34 @@
 // limitations under the License.
 
 // Package sync provides the sync command, which synchronizes an agent-version from github
-// with the store.
+// or resources from a git repository with the store.
 package sync
 
 import (
 	"fmt"
+	"io"
+	"time"
 
+	"github.com/observiq/bindplane-op/model"
 	"github.com/spf13/cobra"
 )
 
 var (
 	versionFlag string
 	allFlag     bool
+	statusFlag  bool
 )
 
 // Command returns the iris sync cobra command
 func Command(builder Builder) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sync",
-		Short: "Sync an agent-version from github",
+		Short: "Sync an agent-version from github or resources from a git repository",
 	}
 
 	cmd.AddCommand(
 		AgentVersionCommand(builder),
+		GitCommand(builder),
 	)
 
 	return cmd
@@ -79,3 +84,73 @@ func AgentVersionCommand(builder Builder) *cobra.Command {
 
 	return cmd
 }
+
+// GitCommand returns the iris sync git cobra command
+func GitCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: "Sync resources from the git repository configured on the server",
+		Long: `Applies the resources in the git repository configured on the server and reports resources that were
+changed in the store since the last sync. Resources that are not in the repository are deleted if prune is enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			syncer, err := builder.BuildSyncer(ctx)
+			if err != nil {
+				return err
+			}
+
+			var status *model.GitSyncStatus
+			if statusFlag {
+				status, err = syncer.GitSyncStatus(ctx)
+			} else {
+				status, err = syncer.SyncGit(ctx)
+			}
+			if err != nil {
+				return err
+			}
+
+			printGitSyncStatus(cmd.OutOrStdout(), status)
+			if status.Error != "" {
+				return fmt.Errorf("git sync failed: %s", status.Error)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&statusFlag, "status", false, "display the status of the last sync without syncing")
+
+	return cmd
+}
+
+func printGitSyncStatus(writer io.Writer, status *model.GitSyncStatus) {
+	if !status.Enabled {
+		fmt.Fprintln(writer, "git sync is not configured")
+		return
+	}
+	fmt.Fprintf(writer, "Repository: %s\n", status.Repository)
+	if status.Branch != "" {
+		fmt.Fprintf(writer, "Branch: %s\n", status.Branch)
+	}
+	if status.Path != "" {
+		fmt.Fprintf(writer, "Path: %s\n", status.Path)
+	}
+	fmt.Fprintf(writer, "Prune: %t\n", status.Prune)
+	if status.LastSync == nil {
+		fmt.Fprintln(writer, "Last Sync: never")
+		return
+	}
+	fmt.Fprintf(writer, "Last Sync: %s\n", status.LastSync.Format(time.RFC3339))
+	if status.Commit != "" {
+		fmt.Fprintf(writer, "Commit: %s\n", status.Commit)
+	}
+
+	for _, r := range status.Resources {
+		fmt.Fprintf(writer, "%s %s %s\n", r.Kind, r.Name, r.Status)
+		if r.Reason != "" {
+			fmt.Fprintf(writer, "\t%s\n", r.Reason)
+		}
+	}
+	for _, d := range status.Drift {
+		fmt.Fprintf(writer, "%s %s drift: %s\n", d.Kind, d.Name, d.Reason)
+	}
+}
@@ -25,6 +25,12 @@ import (
 type Syncer interface {
 	// SyncAgentVersions syncs agent versions.
 	SyncAgentVersions(ctx context.Context, version string) ([]*model.AnyResourceStatus, error)
+
+	// SyncGit syncs resources from the git repository configured on the server.
+	SyncGit(ctx context.Context) (*model.GitSyncStatus, error)
+
+	// GitSyncStatus returns the status of the last sync of the git repository.
+	GitSyncStatus(ctx context.Context) (*model.GitSyncStatus, error)
 }
 
 // Builder is an interface for building a Syncer.
@@ -49,3 +55,13 @@ type defaultSyncer struct {
 func (s *defaultSyncer) SyncAgentVersions(ctx context.Context, version string) ([]*model.AnyResourceStatus, error) {
 	return s.client.SyncAgentVersions(ctx, version)
 }
+
+// SyncGit syncs resources from the git repository.
+func (s *defaultSyncer) SyncGit(ctx context.Context) (*model.GitSyncStatus, error) {
+	return s.client.SyncGit(ctx)
+}
+
+// GitSyncStatus returns the status of the last sync of the git repository.
+func (s *defaultSyncer) GitSyncStatus(ctx context.Context) (*model.GitSyncStatus, error) {
+	return s.client.GitSyncStatus(ctx)
+}
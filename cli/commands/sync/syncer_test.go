@@ -67,3 +67,19 @@ func TestSyncAgentVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncGit(t *testing.T) {
+	status := &model.GitSyncStatus{Enabled: true, Repository: "/var/lib/resources.git", Commit: "abc"}
+
+	c := mocks.NewMockBindPlane(t)
+	c.On("SyncGit", mock.Anything).Return(status, nil).Once()
+	c.On("GitSyncStatus", mock.Anything).Return(nil, errors.New("error")).Once()
+
+	s := NewSyncer(c)
+	result, err := s.SyncGit(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, status, result)
+
+	_, err = s.GitSyncStatus(context.Background())
+	require.Equal(t, errors.New("error"), err)
+}
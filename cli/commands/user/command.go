@@ -0,0 +1,143 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package user contains the user commands for the BindPlane CLI.
+package user
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Command returns the BindPlane user cobra command.
+func Command(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage users and their roles",
+		Long:  "Users can login to BindPlane with a password and are allowed to perform operations based on their role: admin, user, or viewer.",
+	}
+	cmd.AddCommand(
+		CreateCommand(builder),
+		RoleCommand(builder),
+		ListCommand(builder),
+		DeleteCommand(builder),
+	)
+
+	return cmd
+}
+
+// CreateCommand the create command creates a new user
+func CreateCommand(builder Builder) *cobra.Command {
+	var password, role string
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Creates a new user",
+		Example: "bindplane user create alice --password secret --role user",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+			if password == "" {
+				return errors.New("--password must be specified")
+			}
+			r, err := model.ParseRole(role)
+			if err != nil {
+				return err
+			}
+
+			manager, err := builder.BuildUserManager(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return manager.CreateUser(cmd.Context(), args[0], password, r)
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "password of the new user")
+	cmd.Flags().StringVar(&role, "role", model.RoleViewer.String(), "role of the new user, one of: admin, user, viewer")
+
+	return cmd
+}
+
+// RoleCommand the role command assigns a role to an existing user
+func RoleCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "role <name> <role>",
+		Short:   "Assigns a role to a user",
+		Example: "bindplane user role alice admin",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				_ = cmd.Help()
+				return nil
+			}
+			role, err := model.ParseRole(args[1])
+			if err != nil {
+				return err
+			}
+
+			manager, err := builder.BuildUserManager(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return manager.SetUserRole(cmd.Context(), args[0], role)
+		},
+	}
+
+	return cmd
+}
+
+// ListCommand the list command lists all users and their roles
+func ListCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists users and their roles",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			manager, err := builder.BuildUserManager(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return manager.ListUsers(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// DeleteCommand the delete command deletes a user
+func DeleteCommand(builder Builder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Deletes a user",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				_ = cmd.Help()
+				return nil
+			}
+
+			manager, err := builder.BuildUserManager(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return manager.DeleteUser(cmd.Context(), args[0])
+		},
+	}
+
+	return cmd
+}
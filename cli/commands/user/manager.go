@@ -0,0 +1,109 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/observiq/bindplane-op/cli/printer"
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// Manager is an interface for managing users
+type Manager interface {
+	// CreateUser creates a new user with the password and role and prints it
+	CreateUser(ctx context.Context, name, password string, role model.Role) error
+
+	// SetUserRole assigns the role to the user with name and prints it
+	SetUserRole(ctx context.Context, name string, role model.Role) error
+
+	// ListUsers prints all users
+	ListUsers(ctx context.Context) error
+
+	// DeleteUser deletes the user with name
+	DeleteUser(ctx context.Context, name string) error
+}
+
+// Builder is an interface for building a Manager
+type Builder interface {
+	BuildUserManager(ctx context.Context) (Manager, error)
+}
+
+// NewManager returns a new Manager. The serverUsername of the server profile user can't be used to create a user.
+func NewManager(client client.BindPlane, printer printer.Printer, serverUsername string) Manager {
+	return &defaultManager{
+		client:         client,
+		printer:        printer,
+		serverUsername: serverUsername,
+	}
+}
+
+// defaultManager is the default implementation of Manager
+type defaultManager struct {
+	client         client.BindPlane
+	printer        printer.Printer
+	serverUsername string
+}
+
+// CreateUser creates a new user with the password and role and prints it
+func (d *defaultManager) CreateUser(ctx context.Context, name, password string, role model.Role) error {
+	if err := model.ValidateUserName(name, d.serverUsername); err != nil {
+		return fmt.Errorf("failed to create user %s: %w", name, err)
+	}
+	user, err := d.client.CreateUser(ctx, name, password, role)
+	if err != nil {
+		return fmt.Errorf("failed to create user %s: %w", name, err)
+	}
+
+	d.printer.PrintResource(user)
+	return nil
+}
+
+// SetUserRole assigns the role to the user with name and prints it
+func (d *defaultManager) SetUserRole(ctx context.Context, name string, role model.Role) error {
+	user, err := d.client.SetUserRole(ctx, name, role)
+	if err != nil {
+		return fmt.Errorf("failed to set role of user %s: %w", name, err)
+	}
+
+	d.printer.PrintResource(user)
+	return nil
+}
+
+// ListUsers prints all users
+func (d *defaultManager) ListUsers(ctx context.Context) error {
+	users, err := d.client.Users(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	printables := make([]model.Printable, len(users))
+	for i, user := range users {
+		printables[i] = user
+	}
+
+	d.printer.PrintResources(printables)
+	return nil
+}
+
+// DeleteUser deletes the user with name
+func (d *defaultManager) DeleteUser(ctx context.Context, name string) error {
+	if err := d.client.DeleteUser(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete user %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/observiq/bindplane-op/cli/printer"
+	printermocks "github.com/observiq/bindplane-op/cli/printer/mocks"
+	"github.com/observiq/bindplane-op/client"
+	clientmocks "github.com/observiq/bindplane-op/client/mocks"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUser(t *testing.T) {
+	user := &model.User{Name: "alice", Role: model.RoleUser}
+	testCases := []struct {
+		name        string
+		mockFunc    func(t *testing.T) (client.BindPlane, printer.Printer)
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("CreateUser", mock.Anything, "alice", "secret", model.RoleUser).Return(nil, errors.New("bad"))
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("CreateUser", mock.Anything, "alice", "secret", model.RoleUser).Return(user, nil)
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+				mockPrinter.On("PrintResource", user).Return()
+
+				return mockClient, mockPrinter
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient, mockPrinter := tc.mockFunc(t)
+			manager := NewManager(mockClient, mockPrinter, "admin")
+			err := manager.CreateUser(context.Background(), "alice", "secret", model.RoleUser)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateUserReservedName(t *testing.T) {
+	for _, name := range []string{"admin", model.APIKeyLoginPrefix + "1"} {
+		t.Run(name, func(t *testing.T) {
+			// the user is not sent to the server
+			manager := NewManager(clientmocks.NewMockBindPlane(t), printermocks.NewMockPrinter(t), "admin")
+			err := manager.CreateUser(context.Background(), name, "secret", model.RoleViewer)
+			require.ErrorContains(t, err, "is reserved")
+		})
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	user := &model.User{Name: "alice", Role: model.RoleAdmin}
+	testCases := []struct {
+		name        string
+		mockFunc    func(t *testing.T) (client.BindPlane, printer.Printer)
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("SetUserRole", mock.Anything, "alice", model.RoleAdmin).Return(nil, errors.New("bad"))
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("SetUserRole", mock.Anything, "alice", model.RoleAdmin).Return(user, nil)
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+				mockPrinter.On("PrintResource", user).Return()
+
+				return mockClient, mockPrinter
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient, mockPrinter := tc.mockFunc(t)
+			manager := NewManager(mockClient, mockPrinter, "admin")
+			err := manager.SetUserRole(context.Background(), "alice", model.RoleAdmin)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	users := []*model.User{
+		{Name: "alice", Role: model.RoleAdmin},
+		{Name: "bob", Role: model.RoleViewer},
+	}
+	testCases := []struct {
+		name        string
+		mockFunc    func(t *testing.T) (client.BindPlane, printer.Printer)
+		expectedErr error
+	}{
+		{
+			name: "Client Error",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("Users", mock.Anything).Return(nil, errors.New("bad"))
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+
+				return mockClient, mockPrinter
+			},
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+			mockFunc: func(t *testing.T) (client.BindPlane, printer.Printer) {
+				t.Helper()
+				mockClient := clientmocks.NewMockBindPlane(t)
+				mockClient.On("Users", mock.Anything).Return(users, nil)
+
+				mockPrinter := printermocks.NewMockPrinter(t)
+				mockPrinter.On("PrintResources", []model.Printable{users[0], users[1]}).Return()
+
+				return mockClient, mockPrinter
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient, mockPrinter := tc.mockFunc(t)
+			manager := NewManager(mockClient, mockPrinter, "admin")
+			err := manager.ListUsers(context.Background())
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	testCases := []struct {
+		name        string
+		clientErr   error
+		expectedErr error
+	}{
+		{
+			name:        "Client Error",
+			clientErr:   errors.New("bad"),
+			expectedErr: errors.New("bad"),
+		},
+		{
+			name: "Success",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := clientmocks.NewMockBindPlane(t)
+			mockClient.On("DeleteUser", mock.Anything, "alice").Return(tc.clientErr)
+
+			manager := NewManager(mockClient, printermocks.NewMockPrinter(t), "admin")
+			err := manager.DeleteUser(context.Background(), "alice")
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -29,8 +29,11 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/observiq/bindplane-op/cli/commands/apply"
+	"github.com/observiq/bindplane-op/cli/commands/backup"
 	"github.com/observiq/bindplane-op/cli/commands/copy"
 	"github.com/observiq/bindplane-op/cli/commands/delete"
+	"github.com/observiq/bindplane-op/cli/commands/diff"
+	"github.com/observiq/bindplane-op/cli/commands/encryption"
 	"github.com/observiq/bindplane-op/cli/commands/get"
 	"github.com/observiq/bindplane-op/cli/commands/initialize"
 	"github.com/observiq/bindplane-op/cli/commands/install"
@@ -38,8 +41,10 @@ import (
 	"github.com/observiq/bindplane-op/cli/commands/profile"
 	"github.com/observiq/bindplane-op/cli/commands/rollout"
 	"github.com/observiq/bindplane-op/cli/commands/serve"
+	"github.com/observiq/bindplane-op/cli/commands/snapshot"
 	"github.com/observiq/bindplane-op/cli/commands/sync"
 	"github.com/observiq/bindplane-op/cli/commands/update"
+	"github.com/observiq/bindplane-op/cli/commands/user"
 	"github.com/observiq/bindplane-op/cli/commands/version"
 	"github.com/observiq/bindplane-op/cli/printer"
 	"github.com/observiq/bindplane-op/client"
@@ -226,6 +231,21 @@ func (f *Factory) BuildRollouter(ctx context.Context) (rollout.Rollouter, error)
 	return rollout.NewRollouter(c, printer), nil
 }
 
+// BuildUserManager builds a user manager.
+func (f *Factory) BuildUserManager(ctx context.Context) (user.Manager, error) {
+	c, err := f.BuildClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client: %w", err)
+	}
+
+	printer, err := f.BuildPrinter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build printer: %w", err)
+	}
+
+	return user.NewManager(c, printer, f.cfg.Auth.Username), nil
+}
+
 // BuildDeleter builds a deleter.
 func (f *Factory) BuildDeleter(ctx context.Context) (delete.Deleter, error) {
 	c, err := f.BuildClient(ctx)
@@ -256,6 +276,51 @@ func (f *Factory) BuildApplier(ctx context.Context) (apply.Applier, error) {
 	return apply.NewApplier(c), nil
 }
 
+// BuildDiffer builds a differ.
+func (f *Factory) BuildDiffer(ctx context.Context) (diff.Differ, error) {
+	c, err := f.BuildClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client: %w", err)
+	}
+
+	return diff.NewDiffer(c), nil
+}
+
+// BuildBackuper builds a backuper.
+func (f *Factory) BuildBackuper(ctx context.Context) (backup.Backuper, error) {
+	c, err := f.BuildClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client: %w", err)
+	}
+
+	return backup.NewBackuper(c), nil
+}
+
+// BuildSnapshotter builds a snapshotter.
+func (f *Factory) BuildSnapshotter(ctx context.Context) (snapshot.Snapshotter, error) {
+	c, err := f.BuildClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client: %w", err)
+	}
+
+	printer, err := f.BuildPrinter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build printer: %w", err)
+	}
+
+	return snapshot.NewSnapshotter(c, printer), nil
+}
+
+// BuildRotator builds an encryption key rotator.
+func (f *Factory) BuildRotator(ctx context.Context) (encryption.Rotator, error) {
+	c, err := f.BuildClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client: %w", err)
+	}
+
+	return encryption.NewRotator(c), nil
+}
+
 // BuildServer builds a server.
 func (f *Factory) BuildServer(ctx context.Context) (serve.Server, error) {
 	logger, err := f.BuildLogger(ctx)
@@ -355,6 +420,8 @@ func (f *Factory) BuildMetricsProvider(_ context.Context) (metrics.Provider, err
 	switch cfg.Type {
 	case config.MetricsTypeOTLP:
 		return metrics.NewOTLP(&cfg.OTLP, interval, common.DefaultResource())
+	case config.MetricsTypePrometheus:
+		return metrics.NewPrometheus(common.DefaultResource()), nil
 	case config.MetricsTypeNop:
 		return metrics.NewNop(), nil
 	default:
@@ -370,8 +437,28 @@ func (f *Factory) BuildStore(ctx context.Context) (store.Store, error) {
 	}
 
 	options := store.Options{
-		SessionsSecret:   f.cfg.Auth.SessionSecret,
-		MaxEventsToMerge: f.cfg.Store.MaxEvents,
+		SessionsSecret:       f.cfg.Auth.SessionSecret,
+		MaxEventsToMerge:     f.cfg.Store.MaxEvents,
+		AuditEventsRetention: f.cfg.Audit.Retention,
+	}
+	if f.cfg.EventBusType() == config.EventBusTypePostgres {
+		options.EventBroadcast = store.BuildPostgresEventBroadcast(f.cfg.Store.Postgres.ConnectionString())
+	}
+
+	options.MeasurementsRetention, err = f.cfg.Store.Measurements.RetentionTiers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse measurements retention: %w", err)
+	}
+
+	key, previousKeys, err := f.cfg.Store.Encryption.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+	if key != nil {
+		options.ParameterCipher, err = store.NewParameterCipher(key, previousKeys...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build parameter cipher: %w", err)
+		}
 	}
 
 	switch f.cfg.Store.Type {
@@ -383,6 +470,12 @@ func (f *Factory) BuildStore(ctx context.Context) (store.Store, error) {
 			return nil, fmt.Errorf("bbolt storage file failed to open: %w", err)
 		}
 		return store.NewBoltStore(ctx, db, options, logger), nil
+	case config.StoreTypePostgres:
+		db, err := store.InitPostgresDB(ctx, f.cfg.Store.Postgres.ConnectionString(), f.cfg.Store.Postgres.MaxConnections)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+		}
+		return store.NewPostgresStore(ctx, db, options, logger), nil
 	default:
 		return nil, fmt.Errorf("unknown store type: %s", f.cfg.Store.Type)
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -89,6 +90,11 @@ type BindPlane interface {
 	// If version is empty, it syncs the last 10 releases.
 	SyncAgentVersions(ctx context.Context, version string) ([]*model.AnyResourceStatus, error)
 
+	// SyncGit syncs resources from the git repository configured on the server and returns the status of the sync.
+	SyncGit(ctx context.Context) (*model.GitSyncStatus, error)
+	// GitSyncStatus returns the status of the last sync of the git repository configured on the server.
+	GitSyncStatus(ctx context.Context) (*model.GitSyncStatus, error)
+
 	// Configurations returns a list of Configuration resources.
 	Configurations(ctx context.Context) ([]*model.Configuration, error)
 	// Configuration returns a single Configuration resource from GET /v1/configurations/:name
@@ -101,6 +107,11 @@ type BindPlane interface {
 	RawConfiguration(ctx context.Context, name string) (string, error)
 	// CopyConfig creates a deep copy of an existing resource under a new name.
 	CopyConfig(ctx context.Context, name, copyName string) error
+	// RevertConfiguration applies the spec of a previous version of a configuration and optionally starts a rollout
+	RevertConfiguration(ctx context.Context, name string, version model.Version, startRollout bool) (*model.RevertConfigurationResponse, error)
+	// ConfigurationDiff renders two versions of a configuration for the agent with the specified ID and compares them.
+	// If agentID is empty, the server renders them for an agent using the configuration.
+	ConfigurationDiff(ctx context.Context, name string, from, to model.Version, agentID string) (*model.ConfigurationDiff, error)
 
 	// Sources returns a list of all Source resources.
 	Sources(ctx context.Context) ([]*model.Source, error)
@@ -144,8 +155,25 @@ type BindPlane interface {
 	// DeleteDestinationType deletes a single Destination resource by name.
 	DeleteDestinationType(ctx context.Context, name string) error
 
+	// Alerts returns a list of all Alert resources.
+	Alerts(ctx context.Context) ([]*model.Alert, error)
+	// Alert returns a single Alert resource by name.
+	Alert(ctx context.Context, name string) (*model.Alert, error)
+	// DeleteAlert deletes a single Alert resource by name.
+	DeleteAlert(ctx context.Context, name string) error
+
+	// Webhooks returns a list of all Webhook resources.
+	Webhooks(ctx context.Context) ([]*model.Webhook, error)
+	// Webhook returns a single Webhook resource by name.
+	Webhook(ctx context.Context, name string) (*model.Webhook, error)
+	// DeleteWebhook deletes a single Webhook resource by name.
+	DeleteWebhook(ctx context.Context, name string) error
+
 	// Apply upserts multiple resources of any kind.
 	Apply(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error)
+	// ApplyDryRun validates multiple resources of any kind and compares them with the current resources without
+	// applying them.
+	ApplyDryRun(ctx context.Context, r []*model.AnyResource) ([]*model.ResourceDiff, error)
 	// Delete deletes multiple resources, minimum required fields to delete are Kind and Metadata.Name.
 	Delete(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error)
 
@@ -185,6 +213,53 @@ type BindPlane interface {
 
 	// ResourceHistory retrieves the history of the rollout
 	ResourceHistory(ctx context.Context, kind model.Kind, name string) ([]*model.AnyResource, error)
+
+	// Users
+
+	// Users returns all users without their password hashes
+	Users(ctx context.Context) ([]*model.User, error)
+
+	// User returns the user with the specified name without the password hash
+	User(ctx context.Context, name string) (*model.User, error)
+
+	// CreateUser creates a new user with the specified password and role
+	CreateUser(ctx context.Context, name, password string, role model.Role) (*model.User, error)
+
+	// SetUserRole changes the role of an existing user
+	SetUserRole(ctx context.Context, name string, role model.Role) (*model.User, error)
+
+	// DeleteUser deletes the user with the specified name
+	DeleteUser(ctx context.Context, name string) error
+
+	// AuditEvents returns the audit events that match the filter, starting with the most recent
+	AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error)
+
+	// Snapshots returns the saved snapshots without their data, starting with the most recent. If agentID is not
+	// empty, only snapshots of that agent are returned.
+	Snapshots(ctx context.Context, agentID string) ([]*model.Snapshot, error)
+
+	// Snapshot returns the saved snapshot with the specified ID including its data
+	Snapshot(ctx context.Context, id string) (*model.Snapshot, error)
+
+	// SaveSnapshot requests a snapshot from an agent, waits for the agent to report it, and saves it
+	SaveSnapshot(ctx context.Context, request model.PostSnapshotRequest) (*model.Snapshot, error)
+
+	// SnapshotDiff compares the records of two saved snapshots, e.g. captured before and after processors
+	SnapshotDiff(ctx context.Context, fromID, toID string) (*model.SnapshotDiff, error)
+
+	// DeleteSnapshot deletes the saved snapshot with the specified ID
+	DeleteSnapshot(ctx context.Context, id string) error
+
+	// Backup writes a backup of the resources and agents on the server to w, optionally including measurements
+	Backup(ctx context.Context, includeMeasurements bool, w io.Writer) error
+
+	// Restore restores the backup read from r and returns what was restored. Resources, agents, and measurements that
+	// could not be restored are described by the Error of the response.
+	Restore(ctx context.Context, r io.Reader) (*model.RestoreResponse, error)
+
+	// RotateEncryptionKey re-encrypts the sensitive parameter values stored on the server with the current encryption
+	// key and returns the number of resources and versions that were re-encrypted
+	RotateEncryptionKey(ctx context.Context) (int, error)
 }
 
 // BindplaneClient is the implementation of the Bindplane interface
@@ -199,7 +274,11 @@ func NewBindPlane(config *config.Config, logger *zap.Logger) (BindPlane, error)
 	// Don't log warning if using HTTP
 	client.SetDisableWarn(true)
 	client.SetTimeout(time.Second * 20)
-	client.SetBasicAuth(config.Auth.Username, config.Auth.Password)
+	if config.Auth.APIKey != "" {
+		client.SetAuthToken(config.Auth.APIKey)
+	} else {
+		client.SetBasicAuth(config.Auth.Username, config.Auth.Password)
+	}
 	client.SetBaseURL(fmt.Sprintf("%s/v1", config.Network.ServerURL()))
 
 	tlsConfig, err := config.Network.Convert()
@@ -295,6 +374,27 @@ func (c *BindplaneClient) SyncAgentVersions(_ context.Context, version string) (
 	return ar.Updates, c.StatusError(resp, err, "unable to sync agent-versions")
 }
 
+// SyncGit syncs resources from the git repository
+func (c *BindplaneClient) SyncGit(ctx context.Context) (*model.GitSyncStatus, error) {
+	response := &model.GitSyncResponse{}
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetResult(response).
+		Post("/sync/git")
+	if err != nil {
+		LogRequestError(c.Logger, err, "/sync/git")
+		return nil, err
+	}
+	return response.Status, c.StatusError(resp, err, "unable to sync git repository")
+}
+
+// GitSyncStatus retrieves the status of the last sync of the git repository
+func (c *BindplaneClient) GitSyncStatus(ctx context.Context) (*model.GitSyncStatus, error) {
+	response := &model.GitSyncResponse{}
+	err := c.get(ctx, "/sync/git", response)
+	return response.Status, err
+}
+
 // Configurations retrieves all configurations
 func (c *BindplaneClient) Configurations(_ context.Context) ([]*model.Configuration, error) {
 	c.Debug("Configurations called")
@@ -323,6 +423,42 @@ func (c *BindplaneClient) RawConfiguration(ctx context.Context, name string) (st
 	return result.Raw, err
 }
 
+// RevertConfiguration applies the spec of a previous version of a configuration and optionally starts a rollout
+func (c *BindplaneClient) RevertConfiguration(ctx context.Context, name string, version model.Version, startRollout bool) (*model.RevertConfigurationResponse, error) {
+	var response model.RevertConfigurationResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetResult(&response).
+		SetBody(model.RevertConfigurationPayload{
+			Version:      version,
+			StartRollout: startRollout,
+		}).
+		Post(fmt.Sprintf("/configurations/%s/revert", name))
+
+	return &response, c.StatusError(resp, err, "unable to revert configuration")
+}
+
+// ConfigurationDiff retrieves the difference between the rendered agent configurations of two versions of a
+// configuration
+func (c *BindplaneClient) ConfigurationDiff(ctx context.Context, name string, from, to model.Version, agentID string) (*model.ConfigurationDiff, error) {
+	params := map[string]string{
+		"from": model.FormatVersion(from),
+		"to":   model.FormatVersion(to),
+	}
+	if agentID != "" {
+		params["agent"] = agentID
+	}
+
+	var response model.ConfigurationDiffResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&response).
+		Get(fmt.Sprintf("/configurations/%s/diff", name))
+
+	return response.Diff, c.StatusError(resp, err, "unable to compare configuration versions")
+}
+
 // Sources retrieves all sources
 func (c *BindplaneClient) Sources(ctx context.Context) ([]*model.Source, error) {
 	result := model.SourcesResponse{}
@@ -437,6 +573,44 @@ func (c *BindplaneClient) DeleteDestinationType(ctx context.Context, name string
 	return c.DeleteResource(ctx, "/destination-types", name)
 }
 
+// Alerts retrieves all alerts
+func (c *BindplaneClient) Alerts(ctx context.Context) ([]*model.Alert, error) {
+	result := model.AlertsResponse{}
+	err := c.Resources(ctx, "/alerts", &result)
+	return result.Alerts, err
+}
+
+// Alert retrieves alert with given name
+func (c *BindplaneClient) Alert(ctx context.Context, name string) (*model.Alert, error) {
+	result := model.AlertResponse{}
+	err := c.Resource(ctx, "/alerts", name, &result)
+	return result.Alert, err
+}
+
+// DeleteAlert deletes alert with given name
+func (c *BindplaneClient) DeleteAlert(ctx context.Context, name string) error {
+	return c.DeleteResource(ctx, "/alerts", name)
+}
+
+// Webhooks retrieves all webhooks
+func (c *BindplaneClient) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	result := model.WebhooksResponse{}
+	err := c.Resources(ctx, "/webhooks", &result)
+	return result.Webhooks, err
+}
+
+// Webhook retrieves webhook with given name
+func (c *BindplaneClient) Webhook(ctx context.Context, name string) (*model.Webhook, error) {
+	result := model.WebhookResponse{}
+	err := c.Resource(ctx, "/webhooks", name, &result)
+	return result.Webhook, err
+}
+
+// DeleteWebhook deletes webhook with given name
+func (c *BindplaneClient) DeleteWebhook(ctx context.Context, name string) error {
+	return c.DeleteResource(ctx, "/webhooks", name)
+}
+
 // Apply apply resources
 func (c *BindplaneClient) Apply(_ context.Context, resources []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
 	c.Debug("Apply called")
@@ -456,6 +630,26 @@ func (c *BindplaneClient) Apply(_ context.Context, resources []*model.AnyResourc
 	return ar.Updates, c.StatusError(resp, err, "unable to apply resources")
 }
 
+// ApplyDryRun validates and diffs resources without applying them
+func (c *BindplaneClient) ApplyDryRun(_ context.Context, resources []*model.AnyResource) ([]*model.ResourceDiff, error) {
+	c.Debug("ApplyDryRun called")
+
+	payload := model.ApplyPayload{
+		Resources: resources,
+	}
+
+	data, err := jsoniter.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("client apply dry run: %w", err)
+	}
+
+	ar := &model.ApplyResponseClientSide{}
+	resp, err := c.Client.R().SetHeader("Content-Type", "application/json").
+		SetQueryParam("dryRun", "true").
+		SetBody(data).SetResult(ar).Post("/apply")
+	return ar.Diffs, c.StatusError(resp, err, "unable to diff resources")
+}
+
 // Delete deletes passed in resources
 func (c *BindplaneClient) Delete(_ context.Context, resources []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
 	c.Debug("Batch Delete called")
@@ -748,6 +942,174 @@ func (c *BindplaneClient) ResourceHistory(ctx context.Context, kind model.Kind,
 	return response.Versions, c.StatusError(resp, err, "unable to get resource history")
 }
 
+// Users returns all users without their password hashes
+func (c *BindplaneClient) Users(ctx context.Context) ([]*model.User, error) {
+	var response model.UsersResponse
+	err := c.Resources(ctx, "/users", &response)
+	return response.Users, err
+}
+
+// User returns the user with the specified name without the password hash
+func (c *BindplaneClient) User(ctx context.Context, name string) (*model.User, error) {
+	var response model.UserResponse
+	err := c.Resource(ctx, "/users", name, &response)
+	return response.User, err
+}
+
+// CreateUser creates a new user with the specified password and role
+func (c *BindplaneClient) CreateUser(ctx context.Context, name, password string, role model.Role) (*model.User, error) {
+	var response model.UserResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(model.PostUserRequest{Name: name, Password: password, Role: role}).
+		SetResult(&response).
+		Post("/users")
+
+	return response.User, c.StatusError(resp, err, "unable to create user")
+}
+
+// SetUserRole changes the role of an existing user
+func (c *BindplaneClient) SetUserRole(ctx context.Context, name string, role model.Role) (*model.User, error) {
+	var response model.UserResponse
+	endpoint := fmt.Sprintf("/users/%s/role", name)
+
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(model.PutUserRoleRequest{Role: role}).
+		SetResult(&response).
+		Put(endpoint)
+
+	return response.User, c.StatusError(resp, err, "unable to set user role")
+}
+
+// DeleteUser deletes the user with the specified name
+func (c *BindplaneClient) DeleteUser(ctx context.Context, name string) error {
+	return c.DeleteResource(ctx, "/users", name)
+}
+
+// ----------------------------------------------------------------------
+
+// AuditEvents returns the audit events that match the filter, starting with the most recent
+func (c *BindplaneClient) AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	params := map[string]string{
+		"actor":  filter.Actor,
+		"action": string(filter.Action),
+		"kind":   string(filter.ResourceKind),
+		"name":   filter.ResourceName,
+		"limit":  fmt.Sprintf("%d", filter.Limit),
+	}
+	if !filter.Since.IsZero() {
+		params["since"] = filter.Since.Format(time.RFC3339)
+	}
+	if !filter.Until.IsZero() {
+		params["until"] = filter.Until.Format(time.RFC3339)
+	}
+
+	var response model.AuditEventsResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&response).
+		Get("/audit")
+
+	return response.Events, c.StatusError(resp, err, "unable to get audit events")
+}
+
+// ----------------------------------------------------------------------
+
+// Snapshots returns the saved snapshots without their data, starting with the most recent
+func (c *BindplaneClient) Snapshots(ctx context.Context, agentID string) ([]*model.Snapshot, error) {
+	var response model.SnapshotsResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetQueryParam("agent", agentID).
+		SetResult(&response).
+		Get("/snapshots")
+
+	return response.Snapshots, c.StatusError(resp, err, "unable to get snapshots")
+}
+
+// Snapshot returns the saved snapshot with the specified ID including its data
+func (c *BindplaneClient) Snapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	var response model.SnapshotResponse
+	err := c.Resource(ctx, "/snapshots", id, &response)
+	return response.Snapshot, err
+}
+
+// SaveSnapshot requests a snapshot from an agent, waits for the agent to report it, and saves it
+func (c *BindplaneClient) SaveSnapshot(ctx context.Context, request model.PostSnapshotRequest) (*model.Snapshot, error) {
+	var response model.SnapshotResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(request).
+		SetResult(&response).
+		Post("/snapshots")
+
+	return response.Snapshot, c.StatusError(resp, err, "unable to save snapshot")
+}
+
+// SnapshotDiff compares the records of two saved snapshots
+func (c *BindplaneClient) SnapshotDiff(ctx context.Context, fromID, toID string) (*model.SnapshotDiff, error) {
+	var response model.SnapshotDiffResponse
+	endpoint := fmt.Sprintf("/snapshots/%s/diff", fromID)
+
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetQueryParam("to", toID).
+		SetResult(&response).
+		Get(endpoint)
+
+	return response.Diff, c.StatusError(resp, err, "unable to compare snapshots")
+}
+
+// DeleteSnapshot deletes the saved snapshot with the specified ID
+func (c *BindplaneClient) DeleteSnapshot(ctx context.Context, id string) error {
+	return c.DeleteResource(ctx, "/snapshots", id)
+}
+
+// ----------------------------------------------------------------------
+
+// Backup writes a backup of the resources and agents on the server to w, optionally including measurements
+func (c *BindplaneClient) Backup(ctx context.Context, includeMeasurements bool, w io.Writer) error {
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetQueryParam("measurements", strconv.FormatBool(includeMeasurements)).
+		Get("/backup")
+
+	if err := c.StatusError(resp, err, "unable to backup"); err != nil {
+		return err
+	}
+	_, err = w.Write(resp.Body())
+	return err
+}
+
+// Restore restores the backup read from r and returns what was restored
+func (c *BindplaneClient) Restore(ctx context.Context, r io.Reader) (*model.RestoreResponse, error) {
+	var response model.RestoreResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/gzip").
+		SetBody(r).
+		SetResult(&response).
+		Post("/restore")
+
+	return &response, c.StatusError(resp, err, "unable to restore")
+}
+
+// RotateEncryptionKey re-encrypts the sensitive parameter values stored on the server with the current encryption key
+func (c *BindplaneClient) RotateEncryptionKey(ctx context.Context) (int, error) {
+	var response model.RotateEncryptionKeyResponse
+	resp, err := c.Client.R().
+		SetContext(ctx).
+		SetResult(&response).
+		Post("/encryption/rotate")
+
+	return response.Resources, c.StatusError(resp, err, "unable to rotate encryption key")
+}
+
 // ----------------------------------------------------------------------
 
 // Resources gets the Resources from the REST server and stores them in the provided result.
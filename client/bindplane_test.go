@@ -66,6 +66,22 @@ func TestNewBindPlane(t *testing.T) {
 			logger: zap.NewNop(),
 			expect: &BindplaneClient{},
 		},
+		{
+			name: "api-key",
+			cfg: &config.Config{
+				Network: config.Network{
+					Host: "localhost",
+					Port: "3001",
+				},
+				Auth: config.Auth{
+					Username: "admin",
+					Password: "admin",
+					APIKey:   "bp_id_secret",
+				},
+			},
+			logger: zap.NewNop(),
+			expect: &BindplaneClient{},
+		},
 		{
 			name: "tls",
 			cfg: &config.Config{
@@ -142,11 +158,14 @@ func TestNewBindPlane(t *testing.T) {
 			require.NotNil(t, out.(*BindplaneClient).Logger)
 			require.Equal(t, time.Second*20, out.(*BindplaneClient).Client.GetClient().Timeout)
 
-			if tc.cfg.Auth.Username != "" {
+			if tc.cfg.Auth.APIKey != "" {
+				require.Equal(t, tc.cfg.Auth.APIKey, out.(*BindplaneClient).Client.Token)
+				require.Nil(t, out.(*BindplaneClient).Client.UserInfo)
+			} else if tc.cfg.Auth.Username != "" {
 				require.Equal(t, tc.cfg.Auth.Username, out.(*BindplaneClient).Client.UserInfo.Username)
-			}
-			if tc.cfg.Auth.Password != "" {
-				require.Equal(t, tc.cfg.Auth.Password, out.(*BindplaneClient).Client.UserInfo.Password)
+				if tc.cfg.Auth.Password != "" {
+					require.Equal(t, tc.cfg.Auth.Password, out.(*BindplaneClient).Client.UserInfo.Password)
+				}
 			}
 
 			base := fmt.Sprintf("%s/v1", tc.cfg.Network.ServerURL())
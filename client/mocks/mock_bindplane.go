@@ -7,6 +7,8 @@ import (
 
 	client "github.com/observiq/bindplane-op/client"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/observiq/bindplane-op/model"
@@ -187,6 +189,58 @@ func (_m *MockBindPlane) Agents(ctx context.Context, options client.QueryOptions
 	return r0, r1
 }
 
+// Alert provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) Alert(ctx context.Context, name string) (*model.Alert, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Alert, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Alert); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Alerts provides a mock function with given fields: ctx
+func (_m *MockBindPlane) Alerts(ctx context.Context) ([]*model.Alert, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Alert, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Alert); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Apply provides a mock function with given fields: ctx, r
 func (_m *MockBindPlane) Apply(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
 	ret := _m.Called(ctx, r)
@@ -239,6 +293,72 @@ func (_m *MockBindPlane) ApplyAgentLabels(ctx context.Context, id string, labels
 	return r0, r1
 }
 
+// ApplyDryRun provides a mock function with given fields: ctx, r
+func (_m *MockBindPlane) ApplyDryRun(ctx context.Context, r []*model.AnyResource) ([]*model.ResourceDiff, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 []*model.ResourceDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AnyResource) ([]*model.ResourceDiff, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AnyResource) []*model.ResourceDiff); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ResourceDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*model.AnyResource) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditEvents provides a mock function with given fields: ctx, filter
+func (_m *MockBindPlane) AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*model.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) ([]*model.AuditEvent, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) []*model.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Backup provides a mock function with given fields: ctx, includeMeasurements, w
+func (_m *MockBindPlane) Backup(ctx context.Context, includeMeasurements bool, w io.Writer) error {
+	ret := _m.Called(ctx, includeMeasurements, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, io.Writer) error); ok {
+		r0 = rf(ctx, includeMeasurements, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Configuration provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) Configuration(ctx context.Context, name string) (*model.Configuration, error) {
 	ret := _m.Called(ctx, name)
@@ -265,6 +385,32 @@ func (_m *MockBindPlane) Configuration(ctx context.Context, name string) (*model
 	return r0, r1
 }
 
+// ConfigurationDiff provides a mock function with given fields: ctx, name, from, to, agentID
+func (_m *MockBindPlane) ConfigurationDiff(ctx context.Context, name string, from model.Version, to model.Version, agentID string) (*model.ConfigurationDiff, error) {
+	ret := _m.Called(ctx, name, from, to, agentID)
+
+	var r0 *model.ConfigurationDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Version, model.Version, string) (*model.ConfigurationDiff, error)); ok {
+		return rf(ctx, name, from, to, agentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Version, model.Version, string) *model.ConfigurationDiff); ok {
+		r0 = rf(ctx, name, from, to, agentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ConfigurationDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.Version, model.Version, string) error); ok {
+		r1 = rf(ctx, name, from, to, agentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Configurations provides a mock function with given fields: ctx
 func (_m *MockBindPlane) Configurations(ctx context.Context) ([]*model.Configuration, error) {
 	ret := _m.Called(ctx)
@@ -305,6 +451,32 @@ func (_m *MockBindPlane) CopyConfig(ctx context.Context, name string, copyName s
 	return r0
 }
 
+// CreateUser provides a mock function with given fields: ctx, name, password, role
+func (_m *MockBindPlane) CreateUser(ctx context.Context, name string, password string, role model.Role) (*model.User, error) {
+	ret := _m.Called(ctx, name, password, role)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.Role) (*model.User, error)); ok {
+		return rf(ctx, name, password, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.Role) *model.User); ok {
+		r0 = rf(ctx, name, password, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, model.Role) error); ok {
+		r1 = rf(ctx, name, password, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, r
 func (_m *MockBindPlane) Delete(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
 	ret := _m.Called(ctx, r)
@@ -371,6 +543,20 @@ func (_m *MockBindPlane) DeleteAgents(ctx context.Context, agentIDs []string) ([
 	return r0, r1
 }
 
+// DeleteAlert provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) DeleteAlert(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteConfiguration provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) DeleteConfiguration(ctx context.Context, name string) error {
 	ret := _m.Called(ctx, name)
@@ -441,6 +627,20 @@ func (_m *MockBindPlane) DeleteProcessorType(ctx context.Context, name string) e
 	return r0
 }
 
+// DeleteSnapshot provides a mock function with given fields: ctx, id
+func (_m *MockBindPlane) DeleteSnapshot(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSource provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) DeleteSource(ctx context.Context, name string) error {
 	ret := _m.Called(ctx, name)
@@ -469,6 +669,34 @@ func (_m *MockBindPlane) DeleteSourceType(ctx context.Context, name string) erro
 	return r0
 }
 
+// DeleteUser provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) DeleteUser(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) DeleteWebhook(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Destination provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) Destination(ctx context.Context, name string) (*model.Destination, error) {
 	ret := _m.Called(ctx, name)
@@ -573,6 +801,32 @@ func (_m *MockBindPlane) Destinations(ctx context.Context) ([]*model.Destination
 	return r0, r1
 }
 
+// GitSyncStatus provides a mock function with given fields: ctx
+func (_m *MockBindPlane) GitSyncStatus(ctx context.Context) (*model.GitSyncStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *model.GitSyncStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.GitSyncStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.GitSyncStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GitSyncStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PauseRollout provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) PauseRollout(ctx context.Context, name string) (*model.Configuration, error) {
 	ret := _m.Called(ctx, name)
@@ -753,6 +1007,32 @@ func (_m *MockBindPlane) ResourceHistory(ctx context.Context, kind model.Kind, n
 	return r0, r1
 }
 
+// Restore provides a mock function with given fields: ctx, r
+func (_m *MockBindPlane) Restore(ctx context.Context, r io.Reader) (*model.RestoreResponse, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 *model.RestoreResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) (*model.RestoreResponse, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) *model.RestoreResponse); ok {
+		r0 = rf(ctx, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RestoreResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResumeRollout provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) ResumeRollout(ctx context.Context, name string) (*model.Configuration, error) {
 	ret := _m.Called(ctx, name)
@@ -779,6 +1059,32 @@ func (_m *MockBindPlane) ResumeRollout(ctx context.Context, name string) (*model
 	return r0, r1
 }
 
+// RevertConfiguration provides a mock function with given fields: ctx, name, _a2, startRollout
+func (_m *MockBindPlane) RevertConfiguration(ctx context.Context, name string, _a2 model.Version, startRollout bool) (*model.RevertConfigurationResponse, error) {
+	ret := _m.Called(ctx, name, _a2, startRollout)
+
+	var r0 *model.RevertConfigurationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Version, bool) (*model.RevertConfigurationResponse, error)); ok {
+		return rf(ctx, name, _a2, startRollout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Version, bool) *model.RevertConfigurationResponse); ok {
+		r0 = rf(ctx, name, _a2, startRollout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RevertConfigurationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.Version, bool) error); ok {
+		r1 = rf(ctx, name, _a2, startRollout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RolloutStatus provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) RolloutStatus(ctx context.Context, name string) (*model.Configuration, error) {
 	ret := _m.Called(ctx, name)
@@ -805,6 +1111,160 @@ func (_m *MockBindPlane) RolloutStatus(ctx context.Context, name string) (*model
 	return r0, r1
 }
 
+// RotateEncryptionKey provides a mock function with given fields: ctx
+func (_m *MockBindPlane) RotateEncryptionKey(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSnapshot provides a mock function with given fields: ctx, request
+func (_m *MockBindPlane) SaveSnapshot(ctx context.Context, request model.PostSnapshotRequest) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.PostSnapshotRequest) (*model.Snapshot, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.PostSnapshotRequest) *model.Snapshot); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.PostSnapshotRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUserRole provides a mock function with given fields: ctx, name, role
+func (_m *MockBindPlane) SetUserRole(ctx context.Context, name string, role model.Role) (*model.User, error) {
+	ret := _m.Called(ctx, name, role)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Role) (*model.User, error)); ok {
+		return rf(ctx, name, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Role) *model.User); ok {
+		r0 = rf(ctx, name, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.Role) error); ok {
+		r1 = rf(ctx, name, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshot provides a mock function with given fields: ctx, id
+func (_m *MockBindPlane) Snapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Snapshot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Snapshot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SnapshotDiff provides a mock function with given fields: ctx, fromID, toID
+func (_m *MockBindPlane) SnapshotDiff(ctx context.Context, fromID string, toID string) (*model.SnapshotDiff, error) {
+	ret := _m.Called(ctx, fromID, toID)
+
+	var r0 *model.SnapshotDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.SnapshotDiff, error)); ok {
+		return rf(ctx, fromID, toID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.SnapshotDiff); ok {
+		r0 = rf(ctx, fromID, toID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SnapshotDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fromID, toID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshots provides a mock function with given fields: ctx, agentID
+func (_m *MockBindPlane) Snapshots(ctx context.Context, agentID string) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx, agentID)
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Snapshot, error)); ok {
+		return rf(ctx, agentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Snapshot); ok {
+		r0 = rf(ctx, agentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, agentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Source provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) Source(ctx context.Context, name string) (*model.Source, error) {
 	ret := _m.Called(ctx, name)
@@ -961,6 +1421,32 @@ func (_m *MockBindPlane) SyncAgentVersions(ctx context.Context, _a1 string) ([]*
 	return r0, r1
 }
 
+// SyncGit provides a mock function with given fields: ctx
+func (_m *MockBindPlane) SyncGit(ctx context.Context) (*model.GitSyncStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *model.GitSyncStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.GitSyncStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.GitSyncStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GitSyncStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateRollout provides a mock function with given fields: ctx, name
 func (_m *MockBindPlane) UpdateRollout(ctx context.Context, name string) (*model.Configuration, error) {
 	ret := _m.Called(ctx, name)
@@ -1013,6 +1499,58 @@ func (_m *MockBindPlane) UpdateRollouts(ctx context.Context) ([]*model.Configura
 	return r0, r1
 }
 
+// User provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) User(ctx context.Context, name string) (*model.User, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Users provides a mock function with given fields: ctx
+func (_m *MockBindPlane) Users(ctx context.Context) ([]*model.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields: ctx
 func (_m *MockBindPlane) Version(ctx context.Context) (version.Version, error) {
 	ret := _m.Called(ctx)
@@ -1037,6 +1575,58 @@ func (_m *MockBindPlane) Version(ctx context.Context) (version.Version, error) {
 	return r0, r1
 }
 
+// Webhook provides a mock function with given fields: ctx, name
+func (_m *MockBindPlane) Webhook(ctx context.Context, name string) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Webhooks provides a mock function with given fields: ctx
+func (_m *MockBindPlane) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockBindPlane creates a new instance of MockBindPlane. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockBindPlane(t interface {
@@ -23,7 +23,10 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/observiq/bindplane-op/cli"
 	"github.com/observiq/bindplane-op/cli/commands/apply"
+	"github.com/observiq/bindplane-op/cli/commands/backup"
 	"github.com/observiq/bindplane-op/cli/commands/delete"
+	"github.com/observiq/bindplane-op/cli/commands/diff"
+	"github.com/observiq/bindplane-op/cli/commands/encryption"
 	"github.com/observiq/bindplane-op/cli/commands/get"
 	"github.com/observiq/bindplane-op/cli/commands/initialize"
 	"github.com/observiq/bindplane-op/cli/commands/install"
@@ -32,8 +35,10 @@ import (
 	"github.com/observiq/bindplane-op/cli/commands/rollout"
 	"github.com/observiq/bindplane-op/cli/commands/root"
 	"github.com/observiq/bindplane-op/cli/commands/serve"
+	"github.com/observiq/bindplane-op/cli/commands/snapshot"
 	"github.com/observiq/bindplane-op/cli/commands/sync"
 	"github.com/observiq/bindplane-op/cli/commands/update"
+	"github.com/observiq/bindplane-op/cli/commands/user"
 	"github.com/observiq/bindplane-op/cli/commands/version"
 	"github.com/observiq/bindplane-op/routes"
 	"github.com/spf13/cobra"
@@ -57,6 +62,7 @@ func main() {
 
 	rootCmd.AddCommand(
 		cli.AddPrerunsToExistingCmd(apply.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(diff.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(get.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(label.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(delete.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
@@ -67,6 +73,11 @@ func main() {
 		cli.AddPrerunsToExistingCmd(sync.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(update.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(rollout.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(user.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(backup.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(backup.RestoreCommand(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(encryption.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
+		cli.AddPrerunsToExistingCmd(snapshot.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun),
 		cli.AddPrerunsToExistingCmd(serve.Command(factory), factory, cli.AddLoadConfigPrerun, cli.AddValidationPrerun))
 
 	cobra.CheckErr(rootCmd.Execute())
@@ -0,0 +1,93 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"time"
+)
+
+// DefaultAlertsInterval is the default interval at which alerts are evaluated
+const DefaultAlertsInterval = time.Minute
+
+// DefaultSMTPPort is the default port of the SMTP server used to send alert emails.
+const DefaultSMTPPort = "587"
+
+// Alerts is the configuration for sending alert notifications
+type Alerts struct {
+	// Interval is the interval at which alerts are evaluated
+	Interval time.Duration `mapstructure:"interval,omitempty" yaml:"interval,omitempty"`
+
+	// SMTP is the server used to send notifications to email channels
+	SMTP SMTP `mapstructure:"smtp,omitempty" yaml:"smtp,omitempty"`
+}
+
+// SMTP is the configuration of the SMTP server used to send alert emails. Email notifications are disabled if the
+// Host is empty.
+type SMTP struct {
+	// Host is the host of the SMTP server
+	Host string `mapstructure:"host,omitempty" yaml:"host,omitempty"`
+
+	// Port is the port of the SMTP server
+	Port string `mapstructure:"port,omitempty" yaml:"port,omitempty"`
+
+	// Username is used to authenticate with the SMTP server. If it is empty, no authentication is used.
+	Username string `mapstructure:"username,omitempty" yaml:"username,omitempty"`
+
+	// Password is used to authenticate with the SMTP server
+	Password string `mapstructure:"password,omitempty" yaml:"password,omitempty"`
+
+	// From is the address that alert emails are sent from
+	From string `mapstructure:"from,omitempty" yaml:"from,omitempty"`
+}
+
+// Validate validates the alerts configuration
+func (a *Alerts) Validate() error {
+	if a.Interval < 0 {
+		return fmt.Errorf("alerts interval must not be negative: %s", a.Interval)
+	}
+	return a.SMTP.Validate()
+}
+
+// Enabled returns true if an SMTP server is configured
+func (s *SMTP) Enabled() bool {
+	return s.Host != ""
+}
+
+// Address returns the host:port address of the SMTP server
+func (s *SMTP) Address() string {
+	port := s.Port
+	if port == "" {
+		port = DefaultSMTPPort
+	}
+	return net.JoinHostPort(s.Host, port)
+}
+
+// Validate validates the SMTP configuration if an SMTP server is configured
+func (s *SMTP) Validate() error {
+	if !s.Enabled() {
+		return nil
+	}
+	if s.From == "" {
+		return errors.New("smtp from address is required")
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return fmt.Errorf("invalid smtp from address: %w", err)
+	}
+	return nil
+}
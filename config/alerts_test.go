@@ -0,0 +1,70 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertsValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		alerts      Alerts
+		expectedErr bool
+	}{
+		{
+			name:   "no smtp",
+			alerts: Alerts{},
+		},
+		{
+			name:   "smtp",
+			alerts: Alerts{SMTP: SMTP{Host: "smtp.example.com", From: "BindPlane <bindplane@example.com>"}},
+		},
+		{
+			name:        "negative interval",
+			alerts:      Alerts{Interval: -time.Second},
+			expectedErr: true,
+		},
+		{
+			name:        "smtp without from",
+			alerts:      Alerts{SMTP: SMTP{Host: "smtp.example.com"}},
+			expectedErr: true,
+		},
+		{
+			name:        "smtp with invalid from",
+			alerts:      Alerts{SMTP: SMTP{Host: "smtp.example.com", From: "bindplane"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.alerts.Validate()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSMTPAddress(t *testing.T) {
+	require.Equal(t, "smtp.example.com:587", (&SMTP{Host: "smtp.example.com"}).Address())
+	require.Equal(t, "smtp.example.com:465", (&SMTP{Host: "smtp.example.com", Port: "465"}).Address())
+}
@@ -0,0 +1,71 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultAuditRetention is the default time that audit events are kept in the store
+const DefaultAuditRetention = 90 * 24 * time.Hour
+
+// DefaultAuditMaxFileSize is the default size in megabytes at which the audit file is rotated
+const DefaultAuditMaxFileSize = 100
+
+// Audit is the configuration for the audit log of changes made through the API. Audit events are always recorded in
+// the store.
+type Audit struct {
+	// FilePath is the path to a file where audit events are also written as JSON lines. If it is empty, events are only
+	// recorded in the store.
+	FilePath string `mapstructure:"filePath,omitempty" yaml:"filePath,omitempty"`
+
+	// Retention is how long audit events are kept in the store. Older events are removed. If it is 0, events are kept
+	// forever.
+	Retention time.Duration `mapstructure:"retention,omitempty" yaml:"retention,omitempty"`
+
+	// MaxFileSize is the size in megabytes at which the audit file is rotated. The file is renamed with a .1 suffix,
+	// replacing the previously rotated file. If it is 0, the file is not rotated.
+	MaxFileSize int `mapstructure:"maxFileSize,omitempty" yaml:"maxFileSize,omitempty"`
+}
+
+// MaxFileSizeBytes returns MaxFileSize in bytes
+func (a *Audit) MaxFileSizeBytes() int64 {
+	return int64(a.MaxFileSize) * 1024 * 1024
+}
+
+// Validate validates the audit configuration
+func (a *Audit) Validate() error {
+	if a.Retention < 0 {
+		return fmt.Errorf("audit retention must not be negative: %s", a.Retention)
+	}
+	if a.MaxFileSize < 0 {
+		return fmt.Errorf("audit maxFileSize must not be negative: %d", a.MaxFileSize)
+	}
+	if a.FilePath == "" {
+		return nil
+	}
+	dir := filepath.Dir(a.FilePath)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to stat audit file directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("audit file directory %s is not a directory", dir)
+	}
+	return nil
+}
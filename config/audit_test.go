@@ -0,0 +1,68 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditValidate(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name        string
+		audit       Audit
+		expectedErr bool
+	}{
+		{
+			name:  "no file",
+			audit: Audit{},
+		},
+		{
+			name:  "file in existing directory",
+			audit: Audit{FilePath: filepath.Join(dir, "audit.jsonl")},
+		},
+		{
+			name:        "negative retention",
+			audit:       Audit{Retention: -time.Hour},
+			expectedErr: true,
+		},
+		{
+			name:        "negative max file size",
+			audit:       Audit{FilePath: filepath.Join(dir, "audit.jsonl"), MaxFileSize: -1},
+			expectedErr: true,
+		},
+		{
+			name:        "file in missing directory",
+			audit:       Audit{FilePath: filepath.Join(dir, "missing", "audit.jsonl")},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.audit.Validate()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
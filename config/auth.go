@@ -16,6 +16,9 @@ package config
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/observiq/bindplane-op/model"
 )
 
 const (
@@ -24,6 +27,9 @@ const (
 
 	// DefaultPassword is the default password used for communication between client and server.
 	DefaultPassword = "admin"
+
+	// DefaultOIDCUsernameClaim is the default ID token claim used as the username of users that login with OIDC.
+	DefaultOIDCUsernameClaim = "email"
 )
 
 /* #nosec G101 -- these credentials are use to detect if we need to replace them and are only valid for first install */
@@ -50,6 +56,13 @@ type Auth struct {
 
 	// SessionSecret is the secret used to sign the session cookie.
 	SessionSecret string `mapstructure:"sessionSecret" yaml:"sessionSecret,omitempty"`
+
+	// APIKey is used by the client instead of the username and password when it is set. It is sent as a bearer token.
+	APIKey string `mapstructure:"apiKey" yaml:"apiKey,omitempty"`
+
+	// OIDC is the configuration for single sign-on with an OpenID Connect provider. It is disabled if the issuer is not
+	// set.
+	OIDC OIDC `mapstructure:"oidc" yaml:"oidc,omitempty"`
 }
 
 // Validate validates the auth configuration.
@@ -70,5 +83,64 @@ func (c *Auth) Validate() error {
 		return errors.New("session secret must be set")
 	}
 
+	if err := c.OIDC.Validate(); err != nil {
+		return fmt.Errorf("failed to validate oidc: %w", err)
+	}
+
+	return nil
+}
+
+// OIDC is the configuration for single sign-on with an OpenID Connect provider using the authorization code flow
+type OIDC struct {
+	// Issuer is the URL of the OpenID Connect provider. It must serve /.well-known/openid-configuration.
+	Issuer string `mapstructure:"issuer" yaml:"issuer,omitempty"`
+
+	// ClientID is the client ID registered with the provider.
+	ClientID string `mapstructure:"clientID" yaml:"clientID,omitempty"`
+
+	// ClientSecret is the client secret registered with the provider.
+	ClientSecret string `mapstructure:"clientSecret" yaml:"clientSecret,omitempty"`
+
+	// RedirectURL is the URL of the BindPlane login endpoint that the provider redirects to after authenticating the
+	// user, e.g. https://bindplane.example.com/login/sso
+	RedirectURL string `mapstructure:"redirectURL" yaml:"redirectURL,omitempty"`
+
+	// Scopes are the scopes requested in addition to openid. If unset, profile and email are requested.
+	Scopes []string `mapstructure:"scopes" yaml:"scopes,omitempty"`
+
+	// UsernameClaim is the ID token claim used as the username.
+	UsernameClaim string `mapstructure:"usernameClaim" yaml:"usernameClaim,omitempty"`
+
+	// RoleClaim is the ID token claim used as the role of the user. The claim must be one of admin, user, or viewer or
+	// the DefaultRole is used.
+	RoleClaim string `mapstructure:"roleClaim" yaml:"roleClaim,omitempty"`
+
+	// DefaultRole is the role of users without a valid RoleClaim. If it is not set, these users cannot login.
+	DefaultRole string `mapstructure:"defaultRole" yaml:"defaultRole,omitempty"`
+}
+
+// Enabled returns true if an issuer is configured
+func (o *OIDC) Enabled() bool {
+	return o.Issuer != ""
+}
+
+// Validate validates the OIDC configuration. An OIDC configuration without an issuer is valid because it is disabled.
+func (o *OIDC) Validate() error {
+	if !o.Enabled() {
+		return nil
+	}
+
+	if o.ClientID == "" {
+		return errors.New("client ID must be set")
+	}
+
+	if o.RedirectURL == "" {
+		return errors.New("redirect URL must be set")
+	}
+
+	if o.DefaultRole != "" && !model.Role(o.DefaultRole).Valid() {
+		return fmt.Errorf("invalid default role %q, must be one of: admin, user, viewer", o.DefaultRole)
+	}
+
 	return nil
 }
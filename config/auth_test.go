@@ -72,6 +72,65 @@ func TestAuthValidate(t *testing.T) {
 			},
 			expected: errors.New("session secret must be set"),
 		},
+		{
+			name: "valid oidc",
+			auth: Auth{
+				Username:      "user",
+				Password:      "pass",
+				SecretKey:     "secret",
+				SessionSecret: "session",
+				OIDC: OIDC{
+					Issuer:      "https://issuer.local",
+					ClientID:    "client",
+					RedirectURL: "http://localhost:3001/login/sso",
+					DefaultRole: "viewer",
+				},
+			},
+		},
+		{
+			name: "oidc missing client id",
+			auth: Auth{
+				Username:      "user",
+				Password:      "pass",
+				SecretKey:     "secret",
+				SessionSecret: "session",
+				OIDC: OIDC{
+					Issuer:      "https://issuer.local",
+					RedirectURL: "http://localhost:3001/login/sso",
+				},
+			},
+			expected: errors.New("client ID must be set"),
+		},
+		{
+			name: "oidc missing redirect url",
+			auth: Auth{
+				Username:      "user",
+				Password:      "pass",
+				SecretKey:     "secret",
+				SessionSecret: "session",
+				OIDC: OIDC{
+					Issuer:   "https://issuer.local",
+					ClientID: "client",
+				},
+			},
+			expected: errors.New("redirect URL must be set"),
+		},
+		{
+			name: "oidc invalid default role",
+			auth: Auth{
+				Username:      "user",
+				Password:      "pass",
+				SecretKey:     "secret",
+				SessionSecret: "session",
+				OIDC: OIDC{
+					Issuer:      "https://issuer.local",
+					ClientID:    "client",
+					RedirectURL: "http://localhost:3001/login/sso",
+					DefaultRole: "owner",
+				},
+			},
+			expected: errors.New(`invalid default role "owner"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -75,6 +75,9 @@ type Config struct {
 	// Store is the configuration for storage
 	Store Store `mapstructure:"store,omitempty" yaml:"store,omitempty"`
 
+	// EventBus is the configuration for delivering events between servers
+	EventBus EventBus `mapstructure:"eventBus,omitempty" yaml:"eventBus,omitempty"`
+
 	// Tracing is the tracer configuration for the server
 	Tracing Tracing `mapstructure:"tracing,omitempty" yaml:"tracing,omitempty"`
 
@@ -83,6 +86,15 @@ type Config struct {
 
 	// Metrics is the configuration for sending APM metrics
 	Metrics Metrics `yaml:"metrics,omitempty" mapstructure:"metrics,omitempty"`
+
+	// Audit contains configuration for the audit log
+	Audit Audit `yaml:"audit,omitempty" mapstructure:"audit,omitempty"`
+
+	// Alerts contains configuration for sending alert notifications
+	Alerts Alerts `yaml:"alerts,omitempty" mapstructure:"alerts,omitempty"`
+
+	// GitSync contains configuration for syncing resources from a git repository
+	GitSync GitSync `yaml:"gitSync,omitempty" mapstructure:"gitSync,omitempty"`
 }
 
 // Validate validates the configuration.
@@ -103,6 +115,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate store: %w", err)
 	}
 
+	if err := c.EventBus.Validate(); err != nil {
+		return fmt.Errorf("failed to validate event bus: %w", err)
+	}
+
+	// the postgres event bus uses the postgres store connection
+	if c.EventBus.Type == EventBusTypePostgres && c.Store.Type != StoreTypePostgres {
+		if err := c.Store.Postgres.Validate(); err != nil {
+			return fmt.Errorf("failed to validate event bus: %w", err)
+		}
+	}
+
 	if err := c.Tracing.Validate(); err != nil {
 		return fmt.Errorf("failed to validate tracing: %w", err)
 	}
@@ -115,6 +138,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("failed to validate metrics: %w", err)
 	}
 
+	if err := c.Audit.Validate(); err != nil {
+		return fmt.Errorf("failed to validate audit: %w", err)
+	}
+
+	if err := c.Alerts.Validate(); err != nil {
+		return fmt.Errorf("failed to validate alerts: %w", err)
+	}
+
+	if err := c.GitSync.Validate(); err != nil {
+		return fmt.Errorf("failed to validate git sync: %w", err)
+	}
+
 	return nil
 }
 
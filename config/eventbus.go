@@ -0,0 +1,52 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "fmt"
+
+const (
+	// EventBusTypeLocal is the event bus type that only delivers events to subscribers on the same server
+	EventBusTypeLocal = "local"
+
+	// EventBusTypePostgres is the event bus type that uses postgres LISTEN/NOTIFY to deliver events to every server
+	// sharing the database configured in store.postgres
+	EventBusTypePostgres = "postgres"
+)
+
+// EventBus is the configuration for delivering store updates and agent messages between BindPlane servers
+type EventBus struct {
+	// Type is the type of event bus to use. One of: local|postgres
+	Type string `mapstructure:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// Validate validates the event bus configuration
+func (e *EventBus) Validate() error {
+	switch e.Type {
+	case "", EventBusTypeLocal, EventBusTypePostgres:
+		return nil
+	default:
+		return fmt.Errorf("invalid eventBus.type: %s", e.Type)
+	}
+}
+
+// EventBusType returns the type of event bus used by the server. Servers sharing a postgres store keep their search
+// indexes and agent connections in sync with the events of the other servers, so the postgres event bus is always used
+// with the postgres store.
+func (c *Config) EventBusType() string {
+	if c.Store.Type == StoreTypePostgres {
+		return EventBusTypePostgres
+	}
+	return c.EventBus.Type
+}
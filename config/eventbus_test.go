@@ -0,0 +1,97 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBusValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		eventBus EventBus
+		expected error
+	}{
+		{
+			name:     "empty",
+			eventBus: EventBus{},
+		},
+		{
+			name: "local",
+			eventBus: EventBus{
+				Type: EventBusTypeLocal,
+			},
+		},
+		{
+			name: "postgres",
+			eventBus: EventBus{
+				Type: EventBusTypePostgres,
+			},
+		},
+		{
+			name: "invalid type",
+			eventBus: EventBus{
+				Type: "kafka",
+			},
+			expected: errors.New("invalid eventBus.type: kafka"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.eventBus.Validate()
+			switch tc.expected {
+			case nil:
+				require.NoError(t, err)
+			default:
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestConfigEventBusType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "bbolt store",
+			config:   Config{Store: Store{Type: StoreTypeBBolt}, EventBus: EventBus{Type: EventBusTypeLocal}},
+			expected: EventBusTypeLocal,
+		},
+		{
+			name:     "postgres store",
+			config:   Config{Store: Store{Type: StoreTypePostgres}, EventBus: EventBus{Type: EventBusTypeLocal}},
+			expected: EventBusTypePostgres,
+		},
+		{
+			name:     "postgres event bus",
+			config:   Config{Store: Store{Type: StoreTypeBBolt}, EventBus: EventBus{Type: EventBusTypePostgres}},
+			expected: EventBusTypePostgres,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.config.EventBusType())
+		})
+	}
+}
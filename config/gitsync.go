@@ -0,0 +1,65 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultGitSyncInterval is the default interval at which the git repository is synced
+const DefaultGitSyncInterval = time.Minute
+
+// GitSync is the configuration for syncing resources from a git repository. Syncing is disabled if the Repository is
+// empty.
+type GitSync struct {
+	// Repository is the URL or local path of the git repository
+	Repository string `mapstructure:"repository,omitempty" yaml:"repository,omitempty"`
+
+	// Branch is the branch to sync. The default branch of the repository is used if it is empty.
+	Branch string `mapstructure:"branch,omitempty" yaml:"branch,omitempty"`
+
+	// Path is the directory within the repository containing the resource files. The root of the repository is used if
+	// it is empty.
+	Path string `mapstructure:"path,omitempty" yaml:"path,omitempty"`
+
+	// Interval is the interval at which the repository is synced
+	Interval time.Duration `mapstructure:"interval,omitempty" yaml:"interval,omitempty"`
+
+	// Prune deletes resources that are not in the repository
+	Prune bool `mapstructure:"prune,omitempty" yaml:"prune,omitempty"`
+}
+
+// Enabled returns true if a repository is configured
+func (g *GitSync) Enabled() bool {
+	return g.Repository != ""
+}
+
+// Validate validates the git sync configuration if a repository is configured
+func (g *GitSync) Validate() error {
+	if !g.Enabled() {
+		return nil
+	}
+	if g.Interval <= 0 {
+		return fmt.Errorf("git sync interval must be positive: %s", g.Interval)
+	}
+	if filepath.IsAbs(g.Path) || strings.HasPrefix(filepath.Clean(g.Path), "..") {
+		return errors.New("git sync path must be relative to the root of the repository")
+	}
+	return nil
+}
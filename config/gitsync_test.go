@@ -0,0 +1,65 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitSyncValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gitSync     GitSync
+		expectedErr bool
+	}{
+		{
+			name:    "disabled",
+			gitSync: GitSync{},
+		},
+		{
+			name:    "enabled",
+			gitSync: GitSync{Repository: "https://github.com/example/resources.git", Path: "bindplane", Interval: time.Minute},
+		},
+		{
+			name:        "no interval",
+			gitSync:     GitSync{Repository: "/var/lib/resources.git"},
+			expectedErr: true,
+		},
+		{
+			name:        "absolute path",
+			gitSync:     GitSync{Repository: "/var/lib/resources.git", Path: "/bindplane", Interval: time.Minute},
+			expectedErr: true,
+		},
+		{
+			name:        "path outside repository",
+			gitSync:     GitSync{Repository: "/var/lib/resources.git", Path: "../bindplane", Interval: time.Minute},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.gitSync.Validate()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
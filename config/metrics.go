@@ -24,13 +24,15 @@ import (
 const (
 	// MetricsTypeOTLP is the OTLP metrics type
 	MetricsTypeOTLP = "otlp"
+	// MetricsTypePrometheus is the metrics type that exposes a /metrics endpoint to be scraped by Prometheus
+	MetricsTypePrometheus = "prometheus"
 	// MetricsTypeNop is the metrics type that does nothing
 	MetricsTypeNop = ""
 )
 
 // Metrics is the config for sending APM metrics
 type Metrics struct {
-	// Type is the type of metrics to send
+	// Type is the type of metrics to send or prometheus to expose metrics to be scraped
 	Type string `mapstructure:"type,omitempty" yaml:"type,omitempty"`
 
 	// Interval is the interval to send metrics at
@@ -38,6 +40,11 @@ type Metrics struct {
 
 	// OTLP is the config for sending OTLP metrics
 	OTLP OTLPMetrics `mapstructure:"otlp,omitempty" yaml:"otlp,omitempty"`
+
+	// Throughput exports the throughput measurements of agents with the server metrics. Each agent adds a series for
+	// every source and destination of its configuration, so the number of series grows with the number of agents. The
+	// number of series is limited to 10000.
+	Throughput bool `mapstructure:"throughput,omitempty" yaml:"throughput,omitempty"`
 }
 
 // OTLPMetrics is the config for sending OTLP metrics
@@ -54,7 +61,7 @@ func (m *Metrics) Validate() error {
 	switch m.Type {
 	case MetricsTypeOTLP:
 		return m.OTLP.validate()
-	case MetricsTypeNop:
+	case MetricsTypePrometheus, MetricsTypeNop:
 	default:
 		return fmt.Errorf("unknown metrics type: %s", m.Type)
 	}
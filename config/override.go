@@ -206,6 +206,15 @@ func DefaultOverrides() []*Override {
 		NewOverrideWithoutPrefix("auth.password", "password for basic auth", DefaultPassword),
 		NewOverrideWithoutPrefix("auth.secretKey", "secret key for agent auth", DefaultSecretKey),
 		NewOverrideWithoutPrefix("auth.sessionSecret", "secret used to encode sessions", DefaultSessionSecret),
+		NewOverrideWithoutPrefix("auth.apiKey", "API key used by the client instead of the username and password", ""),
+		NewOverride("auth.oidc.issuer", "the URL of the OpenID Connect provider used for single sign-on", ""),
+		NewOverride("auth.oidc.clientID", "the client ID registered with the OpenID Connect provider", ""),
+		NewOverride("auth.oidc.clientSecret", "the client secret registered with the OpenID Connect provider", ""),
+		NewOverride("auth.oidc.redirectURL", "the URL of the login endpoint that the OpenID Connect provider redirects to", ""),
+		NewOverride("auth.oidc.scopes", "the scopes requested from the OpenID Connect provider in addition to openid", []string{}),
+		NewOverride("auth.oidc.usernameClaim", "the ID token claim used as the username", DefaultOIDCUsernameClaim),
+		NewOverride("auth.oidc.roleClaim", "the ID token claim used as the role. One of: admin|user|viewer", ""),
+		NewOverride("auth.oidc.defaultRole", "the role of users without a valid role claim. If unset, these users cannot login", ""),
 
 		// Tracing overrides
 		NewOverride("tracing.type", "the type of tracing to use. One of: otlp|google", ""),
@@ -214,15 +223,50 @@ func DefaultOverrides() []*Override {
 		NewOverride("tracing.samplingRate", "ratio between 0 and 1 that determines what percentage of traces to keep", float64(0)),
 
 		// Metrics overrides
-		NewOverride("metrics.type", "the type of metrics to use. One of: otlp", MetricsTypeNop),
+		NewOverride("metrics.type", "the type of metrics to use. One of: otlp|prometheus", MetricsTypeNop),
 		NewOverride("metrics.interval", "interval to export metrics at", DefaultMetricsInterval),
 		NewOverride("metrics.otlp.endpoint", "the gRPC endpoint to send metrics to, if using OTLP", ""),
 		NewOverride("metrics.otlp.insecure", "whether to use insecure TLS for metrics", false),
+		NewOverride("metrics.throughput", "whether to export the throughput measurements of agents with the server metrics", false),
 
 		// Store overrides
-		NewOverride("store.type", "the type of store to use. One of: bbolt|mapstore", StoreTypeBBolt),
+		NewOverride("store.type", "the type of store to use. One of: bbolt|mapstore|postgres", StoreTypeBBolt),
 		NewOverride("store.bbolt.path", "the path to the store file", DefaultBBoltPath),
+		NewOverride("store.postgres.host", "the host of the postgres server", DefaultPostgresHost),
+		NewOverride("store.postgres.port", "the port of the postgres server", DefaultPostgresPort),
+		NewOverride("store.postgres.database", "the name of the postgres database", DefaultPostgresDatabase),
+		NewOverride("store.postgres.username", "the username used to connect to postgres", ""),
+		NewOverride("store.postgres.password", "the password used to connect to postgres", ""),
+		NewOverride("store.postgres.sslMode", "the sslmode used to connect to postgres. One of: disable|require|verify-ca|verify-full", DefaultPostgresSSLMode),
+		NewOverride("store.postgres.maxConnections", "the maximum number of open connections to postgres", DefaultPostgresMaxConnections),
 		NewOverride("store.maxEvents", "the maximum number of events to batch in a store operation", DefaultMaxEvents),
+		NewOverride("store.encryption.key", "the base64 encoded 32 byte key used to encrypt sensitive parameter values in the store", ""),
+		NewOverride("store.encryption.keyFile", "the path to a file containing the key used to encrypt sensitive parameter values in the store", ""),
+		NewOverride("store.encryption.previousKeys", "previous encryption keys, used to decrypt values until the encryption key is rotated", []string{}),
+		NewOverride("store.measurements.retention", "retention tiers of agent measurements of the form interval:retention, e.g. 10s:1h,1m:2d,1h:90d", []string{}),
+
+		// Event bus overrides
+		NewOverride("eventBus.type", "the type of event bus used to deliver events between servers, always postgres with the postgres store. One of: local|postgres", EventBusTypeLocal),
+
+		// Audit overrides
+		NewOverride("audit.filePath", "the path to a file where audit events are also written as JSON lines", ""),
+		NewOverride("audit.retention", "how long audit events are kept in the store, 0 to keep them forever", DefaultAuditRetention),
+		NewOverride("audit.maxFileSize", "the size in megabytes at which the audit file is rotated, 0 to never rotate it", DefaultAuditMaxFileSize),
+
+		// Alerts overrides
+		NewOverride("alerts.interval", "interval between evaluations of alerts", DefaultAlertsInterval),
+		NewOverride("alerts.smtp.host", "the host of the SMTP server used to send alert emails", ""),
+		NewOverride("alerts.smtp.port", "the port of the SMTP server used to send alert emails", DefaultSMTPPort),
+		NewOverride("alerts.smtp.username", "the username used to authenticate with the SMTP server", ""),
+		NewOverride("alerts.smtp.password", "the password used to authenticate with the SMTP server", ""),
+		NewOverride("alerts.smtp.from", "the address that alert emails are sent from", ""),
+
+		// Git sync overrides
+		NewOverride("gitSync.repository", "the URL or local path of a git repository to sync resources from", ""),
+		NewOverride("gitSync.branch", "the branch of the git repository to sync", ""),
+		NewOverride("gitSync.path", "the directory within the git repository containing resource files", ""),
+		NewOverride("gitSync.interval", "interval between syncs of the git repository", DefaultGitSyncInterval),
+		NewOverride("gitSync.prune", "whether to delete resources that are not in the git repository", false),
 
 		// Agent version overrides
 		NewOverride("agentVersions.syncInterval", "the interval at which to sync agent versions", DefaultSyncInterval),
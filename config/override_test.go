@@ -57,6 +57,10 @@ func TestOverrideDefaults(t *testing.T) {
 			Password:      DefaultPassword,
 			SecretKey:     DefaultSecretKey,
 			SessionSecret: DefaultSessionSecret,
+			OIDC: OIDC{
+				Scopes:        []string{},
+				UsernameClaim: DefaultOIDCUsernameClaim,
+			},
 		},
 		Store: Store{
 			Type:      StoreTypeBBolt,
@@ -64,10 +68,39 @@ func TestOverrideDefaults(t *testing.T) {
 			BBolt: BBolt{
 				Path: DefaultBBoltPath,
 			},
+			Postgres: Postgres{
+				Host:           DefaultPostgresHost,
+				Port:           DefaultPostgresPort,
+				Database:       DefaultPostgresDatabase,
+				SSLMode:        DefaultPostgresSSLMode,
+				MaxConnections: DefaultPostgresMaxConnections,
+			},
+			Encryption: Encryption{
+				PreviousKeys: []string{},
+			},
+			Measurements: Measurements{
+				Retention: []string{},
+			},
+		},
+		EventBus: EventBus{
+			Type: EventBusTypeLocal,
 		},
 		Metrics: Metrics{
 			Interval: DefaultMetricsInterval,
 		},
+		Audit: Audit{
+			Retention:   DefaultAuditRetention,
+			MaxFileSize: DefaultAuditMaxFileSize,
+		},
+		Alerts: Alerts{
+			Interval: DefaultAlertsInterval,
+			SMTP: SMTP{
+				Port: DefaultSMTPPort,
+			},
+		},
+		GitSync: GitSync{
+			Interval: DefaultGitSyncInterval,
+		},
 		AgentVersions: AgentVersions{
 			SyncInterval: DefaultSyncInterval,
 		},
@@ -96,6 +129,15 @@ func TestOverrideFlags(t *testing.T) {
 		"--password", "password",
 		"--secret-key", "secret",
 		"--session-secret", "session",
+		"--api-key", "key",
+		"--auth-oidc-issuer", "https://issuer.local",
+		"--auth-oidc-client-id", "client",
+		"--auth-oidc-client-secret", "client-secret",
+		"--auth-oidc-redirect-url", "http://localhost:8080/login/sso",
+		"--auth-oidc-scopes", "email,groups",
+		"--auth-oidc-username-claim", "preferred_username",
+		"--auth-oidc-role-claim", "bindplane_role",
+		"--auth-oidc-default-role", "viewer",
 		"--tracing-type", "otlp",
 		"--tracing-otlp-endpoint", "localhost:4317",
 		"--tracing-otlp-insecure", "true",
@@ -104,9 +146,35 @@ func TestOverrideFlags(t *testing.T) {
 		"--metrics-otlp-endpoint", "localhost:4317",
 		"--metrics-otlp-insecure", "true",
 		"--metrics-interval", "2m",
+		"--metrics-throughput",
 		"--store-type", "bbolt",
 		"--store-bbolt-path", "/tmp/store.db",
 		"--store-max-events", "200",
+		"--store-encryption-key", "key",
+		"--store-encryption-previous-keys", "old1,old2",
+		"--store-measurements-retention", "10s:1h,1h:90d",
+		"--event-bus-type", "postgres",
+		"--audit-file-path", "/tmp/audit.jsonl",
+		"--audit-retention", "720h",
+		"--audit-max-file-size", "10",
+		"--alerts-interval", "2m",
+		"--alerts-smtp-host", "smtp.example.com",
+		"--alerts-smtp-port", "465",
+		"--alerts-smtp-username", "alerts",
+		"--alerts-smtp-password", "smtppass",
+		"--alerts-smtp-from", "bindplane@example.com",
+		"--git-sync-repository", "/var/lib/resources.git",
+		"--git-sync-branch", "release",
+		"--git-sync-path", "bindplane",
+		"--git-sync-interval", "5m",
+		"--git-sync-prune",
+		"--store-postgres-host", "postgres.local",
+		"--store-postgres-port", "5433",
+		"--store-postgres-database", "bp",
+		"--store-postgres-username", "bpuser",
+		"--store-postgres-password", "bppass",
+		"--store-postgres-ssl-mode", "require",
+		"--store-postgres-max-connections", "10",
 		"--agent-versions-sync-interval", "2h",
 	}
 
@@ -148,6 +216,17 @@ func TestOverrideFlags(t *testing.T) {
 			Password:      "password",
 			SecretKey:     "secret",
 			SessionSecret: "session",
+			APIKey:        "key",
+			OIDC: OIDC{
+				Issuer:        "https://issuer.local",
+				ClientID:      "client",
+				ClientSecret:  "client-secret",
+				RedirectURL:   "http://localhost:8080/login/sso",
+				Scopes:        []string{"email", "groups"},
+				UsernameClaim: "preferred_username",
+				RoleClaim:     "bindplane_role",
+				DefaultRole:   "viewer",
+			},
 		},
 		Store: Store{
 			Type:      StoreTypeBBolt,
@@ -155,6 +234,47 @@ func TestOverrideFlags(t *testing.T) {
 			BBolt: BBolt{
 				Path: "/tmp/store.db",
 			},
+			Postgres: Postgres{
+				Host:           "postgres.local",
+				Port:           "5433",
+				Database:       "bp",
+				Username:       "bpuser",
+				Password:       "bppass",
+				SSLMode:        "require",
+				MaxConnections: 10,
+			},
+			Encryption: Encryption{
+				Key:          "key",
+				PreviousKeys: []string{"old1", "old2"},
+			},
+			Measurements: Measurements{
+				Retention: []string{"10s:1h", "1h:90d"},
+			},
+		},
+		EventBus: EventBus{
+			Type: EventBusTypePostgres,
+		},
+		Audit: Audit{
+			FilePath:    "/tmp/audit.jsonl",
+			Retention:   720 * time.Hour,
+			MaxFileSize: 10,
+		},
+		Alerts: Alerts{
+			Interval: 2 * time.Minute,
+			SMTP: SMTP{
+				Host:     "smtp.example.com",
+				Port:     "465",
+				Username: "alerts",
+				Password: "smtppass",
+				From:     "bindplane@example.com",
+			},
+		},
+		GitSync: GitSync{
+			Repository: "/var/lib/resources.git",
+			Branch:     "release",
+			Path:       "bindplane",
+			Interval:   5 * time.Minute,
+			Prune:      true,
 		},
 		Tracing: Tracing{
 			Type:         "otlp",
@@ -170,7 +290,8 @@ func TestOverrideFlags(t *testing.T) {
 				Endpoint: "localhost:4317",
 				Insecure: true,
 			},
-			Interval: time.Minute * 2,
+			Interval:   time.Minute * 2,
+			Throughput: true,
 		},
 		AgentVersions: AgentVersions{
 			SyncInterval: time.Hour * 2,
@@ -181,34 +302,69 @@ func TestOverrideFlags(t *testing.T) {
 
 func TestOverrideEnvs(t *testing.T) {
 	envs := map[string]string{
-		"BINDPLANE_ENV":                          "production",
-		"BINDPLANE_OUTPUT":                       "json",
-		"BINDPLANE_LOGGING_OUTPUT":               "file",
-		"BINDPLANE_LOGGING_FILE_PATH":            "/tmp/test.log",
-		"BINDPLANE_HOST":                         "localhost",
-		"BINDPLANE_PORT":                         "8080",
-		"BINDPLANE_REMOTE_URL":                   "http://localhost:8080",
-		"BINDPLANE_OFFLINE":                      "true",
-		"BINDPLANE_TLS_CERT":                     "/tmp/cert.pem",
-		"BINDPLANE_TLS_KEY":                      "/tmp/key.pem",
-		"BINDPLANE_TLS_CA":                       "/tmp/ca.pem",
-		"BINDPLANE_TLS_SKIP_VERIFY":              "true",
-		"BINDPLANE_ROLLOUTS_INTERVAL":            "50s",
-		"BINDPLANE_USERNAME":                     "user",
-		"BINDPLANE_PASSWORD":                     "password",
-		"BINDPLANE_SECRET_KEY":                   "secret",
-		"BINDPLANE_SESSION_SECRET":               "session",
-		"BINDPLANE_TRACING_TYPE":                 "otlp",
-		"BINDPLANE_TRACING_OTLP_ENDPOINT":        "localhost:4317",
-		"BINDPLANE_TRACING_OTLP_INSECURE":        "true",
-		"BINDPLANE_TRACING_SAMPLING_RATE":        "0.5",
-		"BINDPLANE_METRICS_TYPE":                 "otlp",
-		"BINDPLANE_METRICS_OTLP_ENDPOINT":        "localhost:4317",
-		"BINDPLANE_METRICS_OTLP_INSECURE":        "true",
-		"BINDPLANE_STORE_TYPE":                   "bbolt",
-		"BINDPLANE_STORE_BBOLT_PATH":             "/tmp/store.db",
-		"BINDPLANE_STORE_MAX_EVENTS":             "200",
-		"BINDPLANE_AGENT_VERSIONS_SYNC_INTERVAL": "2h",
+		"BINDPLANE_ENV":                            "production",
+		"BINDPLANE_OUTPUT":                         "json",
+		"BINDPLANE_LOGGING_OUTPUT":                 "file",
+		"BINDPLANE_LOGGING_FILE_PATH":              "/tmp/test.log",
+		"BINDPLANE_HOST":                           "localhost",
+		"BINDPLANE_PORT":                           "8080",
+		"BINDPLANE_REMOTE_URL":                     "http://localhost:8080",
+		"BINDPLANE_OFFLINE":                        "true",
+		"BINDPLANE_TLS_CERT":                       "/tmp/cert.pem",
+		"BINDPLANE_TLS_KEY":                        "/tmp/key.pem",
+		"BINDPLANE_TLS_CA":                         "/tmp/ca.pem",
+		"BINDPLANE_TLS_SKIP_VERIFY":                "true",
+		"BINDPLANE_ROLLOUTS_INTERVAL":              "50s",
+		"BINDPLANE_USERNAME":                       "user",
+		"BINDPLANE_PASSWORD":                       "password",
+		"BINDPLANE_SECRET_KEY":                     "secret",
+		"BINDPLANE_SESSION_SECRET":                 "session",
+		"BINDPLANE_API_KEY":                        "key",
+		"BINDPLANE_AUTH_OIDC_ISSUER":               "https://issuer.local",
+		"BINDPLANE_AUTH_OIDC_CLIENT_ID":            "client",
+		"BINDPLANE_AUTH_OIDC_CLIENT_SECRET":        "client-secret",
+		"BINDPLANE_AUTH_OIDC_REDIRECT_URL":         "http://localhost:8080/login/sso",
+		"BINDPLANE_AUTH_OIDC_SCOPES":               "email,groups",
+		"BINDPLANE_AUTH_OIDC_USERNAME_CLAIM":       "preferred_username",
+		"BINDPLANE_AUTH_OIDC_ROLE_CLAIM":           "bindplane_role",
+		"BINDPLANE_AUTH_OIDC_DEFAULT_ROLE":         "viewer",
+		"BINDPLANE_TRACING_TYPE":                   "otlp",
+		"BINDPLANE_TRACING_OTLP_ENDPOINT":          "localhost:4317",
+		"BINDPLANE_TRACING_OTLP_INSECURE":          "true",
+		"BINDPLANE_TRACING_SAMPLING_RATE":          "0.5",
+		"BINDPLANE_METRICS_TYPE":                   "otlp",
+		"BINDPLANE_METRICS_OTLP_ENDPOINT":          "localhost:4317",
+		"BINDPLANE_METRICS_OTLP_INSECURE":          "true",
+		"BINDPLANE_METRICS_THROUGHPUT":             "true",
+		"BINDPLANE_STORE_TYPE":                     "bbolt",
+		"BINDPLANE_STORE_BBOLT_PATH":               "/tmp/store.db",
+		"BINDPLANE_STORE_MAX_EVENTS":               "200",
+		"BINDPLANE_STORE_ENCRYPTION_KEY":           "key",
+		"BINDPLANE_STORE_ENCRYPTION_PREVIOUS_KEYS": "old1,old2",
+		"BINDPLANE_STORE_MEASUREMENTS_RETENTION":   "10s:1h,1h:90d",
+		"BINDPLANE_EVENT_BUS_TYPE":                 "postgres",
+		"BINDPLANE_AUDIT_FILE_PATH":                "/tmp/audit.jsonl",
+		"BINDPLANE_AUDIT_RETENTION":                "720h",
+		"BINDPLANE_AUDIT_MAX_FILE_SIZE":            "10",
+		"BINDPLANE_ALERTS_INTERVAL":                "2m",
+		"BINDPLANE_ALERTS_SMTP_HOST":               "smtp.example.com",
+		"BINDPLANE_ALERTS_SMTP_PORT":               "465",
+		"BINDPLANE_ALERTS_SMTP_USERNAME":           "alerts",
+		"BINDPLANE_ALERTS_SMTP_PASSWORD":           "smtppass",
+		"BINDPLANE_ALERTS_SMTP_FROM":               "bindplane@example.com",
+		"BINDPLANE_GIT_SYNC_REPOSITORY":            "/var/lib/resources.git",
+		"BINDPLANE_GIT_SYNC_BRANCH":                "release",
+		"BINDPLANE_GIT_SYNC_PATH":                  "bindplane",
+		"BINDPLANE_GIT_SYNC_INTERVAL":              "5m",
+		"BINDPLANE_GIT_SYNC_PRUNE":                 "true",
+		"BINDPLANE_STORE_POSTGRES_HOST":            "postgres.local",
+		"BINDPLANE_STORE_POSTGRES_PORT":            "5433",
+		"BINDPLANE_STORE_POSTGRES_DATABASE":        "bp",
+		"BINDPLANE_STORE_POSTGRES_USERNAME":        "bpuser",
+		"BINDPLANE_STORE_POSTGRES_PASSWORD":        "bppass",
+		"BINDPLANE_STORE_POSTGRES_SSL_MODE":        "require",
+		"BINDPLANE_STORE_POSTGRES_MAX_CONNECTIONS": "10",
+		"BINDPLANE_AGENT_VERSIONS_SYNC_INTERVAL":   "2h",
 	}
 	setEnvs(t, envs)
 	defer unsetEnvs(t, envs)
@@ -250,6 +406,17 @@ func TestOverrideEnvs(t *testing.T) {
 			Password:      "password",
 			SecretKey:     "secret",
 			SessionSecret: "session",
+			APIKey:        "key",
+			OIDC: OIDC{
+				Issuer:        "https://issuer.local",
+				ClientID:      "client",
+				ClientSecret:  "client-secret",
+				RedirectURL:   "http://localhost:8080/login/sso",
+				Scopes:        []string{"email", "groups"},
+				UsernameClaim: "preferred_username",
+				RoleClaim:     "bindplane_role",
+				DefaultRole:   "viewer",
+			},
 		},
 		Store: Store{
 			Type:      StoreTypeBBolt,
@@ -257,6 +424,47 @@ func TestOverrideEnvs(t *testing.T) {
 			BBolt: BBolt{
 				Path: "/tmp/store.db",
 			},
+			Postgres: Postgres{
+				Host:           "postgres.local",
+				Port:           "5433",
+				Database:       "bp",
+				Username:       "bpuser",
+				Password:       "bppass",
+				SSLMode:        "require",
+				MaxConnections: 10,
+			},
+			Encryption: Encryption{
+				Key:          "key",
+				PreviousKeys: []string{"old1", "old2"},
+			},
+			Measurements: Measurements{
+				Retention: []string{"10s:1h", "1h:90d"},
+			},
+		},
+		EventBus: EventBus{
+			Type: EventBusTypePostgres,
+		},
+		Audit: Audit{
+			FilePath:    "/tmp/audit.jsonl",
+			Retention:   720 * time.Hour,
+			MaxFileSize: 10,
+		},
+		Alerts: Alerts{
+			Interval: 2 * time.Minute,
+			SMTP: SMTP{
+				Host:     "smtp.example.com",
+				Port:     "465",
+				Username: "alerts",
+				Password: "smtppass",
+				From:     "bindplane@example.com",
+			},
+		},
+		GitSync: GitSync{
+			Repository: "/var/lib/resources.git",
+			Branch:     "release",
+			Path:       "bindplane",
+			Interval:   5 * time.Minute,
+			Prune:      true,
 		},
 		Tracing: Tracing{
 			Type:         "otlp",
@@ -267,8 +475,9 @@ func TestOverrideEnvs(t *testing.T) {
 			},
 		},
 		Metrics: Metrics{
-			Type:     "otlp",
-			Interval: DefaultMetricsInterval,
+			Type:       "otlp",
+			Interval:   DefaultMetricsInterval,
+			Throughput: true,
 			OTLP: OTLPMetrics{
 				Endpoint: "localhost:4317",
 				Insecure: true,
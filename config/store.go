@@ -15,10 +15,17 @@
 package config
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/store/stats"
 )
 
 const (
@@ -30,6 +37,27 @@ const (
 
 	// StoreTypeBBolt is the type of store that uses bbolt.
 	StoreTypeBBolt = "bbolt"
+
+	// StoreTypePostgres is the type of store that uses postgres.
+	StoreTypePostgres = "postgres"
+
+	// DefaultPostgresHost is the default host of the postgres server.
+	DefaultPostgresHost = "localhost"
+
+	// DefaultPostgresPort is the default port of the postgres server.
+	DefaultPostgresPort = "5432"
+
+	// DefaultPostgresDatabase is the default name of the postgres database.
+	DefaultPostgresDatabase = "bindplane"
+
+	// DefaultPostgresSSLMode is the default sslmode used to connect to postgres.
+	DefaultPostgresSSLMode = "disable"
+
+	// DefaultPostgresMaxConnections is the default maximum number of open connections to postgres.
+	DefaultPostgresMaxConnections = 100
+
+	// EncryptionKeySize is the size in bytes of a key used to encrypt sensitive parameter values.
+	EncryptionKeySize = 32
 )
 
 // DefaultBBoltPath is the default path to the bbolt file.
@@ -45,6 +73,15 @@ type Store struct {
 
 	// BBolt is the configuration for a bbolt store.
 	BBolt BBolt `mapstructure:"bbolt,omitempty" yaml:"bbolt,omitempty"`
+
+	// Postgres is the configuration for a postgres store.
+	Postgres Postgres `mapstructure:"postgres,omitempty" yaml:"postgres,omitempty"`
+
+	// Encryption is the configuration for encrypting sensitive parameter values at rest.
+	Encryption Encryption `mapstructure:"encryption,omitempty" yaml:"encryption,omitempty"`
+
+	// Measurements is the configuration for storing agent throughput measurements.
+	Measurements Measurements `mapstructure:"measurements,omitempty" yaml:"measurements,omitempty"`
 }
 
 // Validate validates the store configuration.
@@ -55,6 +92,10 @@ func (s *Store) Validate() error {
 		if err := s.BBolt.Validate(); err != nil {
 			return err
 		}
+	case StoreTypePostgres:
+		if err := s.Postgres.Validate(); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid store type: %s", s.Type)
 	}
@@ -63,9 +104,98 @@ func (s *Store) Validate() error {
 		return fmt.Errorf("maxEvents must be greater than 0")
 	}
 
+	if err := s.Encryption.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.Measurements.RetentionTiers(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// Measurements is the configuration for storing agent throughput measurements.
+type Measurements struct {
+	// Retention are the retention tiers of the form interval:retention, e.g. 1m:2d keeps one measurement per minute for
+	// 2 days. A 10s tier is required to keep the raw measurements. The default tiers are used if it is empty.
+	Retention []string `mapstructure:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+// RetentionTiers returns the parsed retention tiers or the default tiers if none are configured.
+func (m *Measurements) RetentionTiers() ([]stats.RetentionTier, error) {
+	return stats.ParseRetention(m.Retention)
+}
+
+// Encryption is the configuration for encrypting sensitive parameter values at rest. Keys are base64 encoded
+// 32 byte keys. Sensitive parameter values are stored in plaintext if no key is configured.
+type Encryption struct {
+	// Key is the key used to encrypt sensitive parameter values.
+	Key string `mapstructure:"key,omitempty" yaml:"key,omitempty"`
+
+	// KeyFile is the path to a file containing the key. It is used if Key is not set.
+	KeyFile string `mapstructure:"keyFile,omitempty" yaml:"keyFile,omitempty"`
+
+	// PreviousKeys are keys that were replaced by Key. They are only used to decrypt values until the encryption key
+	// is rotated.
+	PreviousKeys []string `mapstructure:"previousKeys,omitempty" yaml:"previousKeys,omitempty"`
+}
+
+// Enabled returns true if a key is configured
+func (e *Encryption) Enabled() bool {
+	return e.Key != "" || e.KeyFile != ""
+}
+
+// Validate validates the encryption configuration.
+func (e *Encryption) Validate() error {
+	if e.Key != "" && e.KeyFile != "" {
+		return errors.New("only one of encryption key and keyFile may be set")
+	}
+	if !e.Enabled() && len(e.PreviousKeys) > 0 {
+		return errors.New("encryption previousKeys requires a key or keyFile")
+	}
+	_, _, err := e.Keys()
+	return err
+}
+
+// Keys returns the decoded key and previous keys. The key is nil if encryption is not enabled.
+func (e *Encryption) Keys() (key []byte, previousKeys [][]byte, err error) {
+	encoded := e.Key
+	if e.KeyFile != "" {
+		data, err := os.ReadFile(e.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read encryption keyFile: %w", err)
+		}
+		encoded = string(data)
+	}
+	if encoded == "" {
+		return nil, nil, nil
+	}
+
+	if key, err = decodeEncryptionKey(encoded); err != nil {
+		return nil, nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	for i, previous := range e.PreviousKeys {
+		previousKey, err := decodeEncryptionKey(previous)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid encryption previousKeys[%d]: %w", i, err)
+		}
+		previousKeys = append(previousKeys, previousKey)
+	}
+	return key, previousKeys, nil
+}
+
+func decodeEncryptionKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("must be base64 encoded")
+	}
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
 // BBolt is the configuration for a bbolt store.
 type BBolt struct {
 	// Path is the path to the bbolt file.
@@ -79,3 +209,68 @@ func (b *BBolt) Validate() error {
 	}
 	return nil
 }
+
+// Postgres is the configuration for a postgres store.
+type Postgres struct {
+	// Host is the host of the postgres server.
+	Host string `mapstructure:"host,omitempty" yaml:"host,omitempty"`
+
+	// Port is the port of the postgres server.
+	Port string `mapstructure:"port,omitempty" yaml:"port,omitempty"`
+
+	// Database is the name of the database.
+	Database string `mapstructure:"database,omitempty" yaml:"database,omitempty"`
+
+	// Username is the username used to connect to postgres.
+	Username string `mapstructure:"username,omitempty" yaml:"username,omitempty"`
+
+	// Password is the password used to connect to postgres.
+	Password string `mapstructure:"password,omitempty" yaml:"password,omitempty"`
+
+	// SSLMode is the sslmode used to connect to postgres. One of: disable|require|verify-ca|verify-full
+	SSLMode string `mapstructure:"sslMode,omitempty" yaml:"sslMode,omitempty"`
+
+	// MaxConnections is the maximum number of open connections to postgres.
+	MaxConnections int `mapstructure:"maxConnections,omitempty" yaml:"maxConnections,omitempty"`
+}
+
+// Validate validates the postgres configuration.
+func (p *Postgres) Validate() error {
+	if p.Host == "" {
+		return fmt.Errorf("postgres host must be set for postgres store")
+	}
+	if p.Port == "" {
+		return fmt.Errorf("postgres port must be set for postgres store")
+	}
+	if p.Database == "" {
+		return fmt.Errorf("postgres database must be set for postgres store")
+	}
+	switch p.SSLMode {
+	case "", "disable", "require", "verify-ca", "verify-full":
+	default:
+		return fmt.Errorf("invalid postgres sslMode: %s", p.SSLMode)
+	}
+	if p.MaxConnections < 0 {
+		return fmt.Errorf("postgres maxConnections must not be negative")
+	}
+	return nil
+}
+
+// ConnectionString returns the URL used to connect to the postgres database.
+func (p *Postgres) ConnectionString() string {
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   net.JoinHostPort(p.Host, p.Port),
+		Path:   p.Database,
+	}
+	switch {
+	case p.Username != "" && p.Password != "":
+		u.User = url.UserPassword(p.Username, p.Password)
+	case p.Username != "":
+		u.User = url.User(p.Username)
+	}
+	if p.SSLMode != "" {
+		u.RawQuery = url.Values{"sslmode": []string{p.SSLMode}}.Encode()
+	}
+	return u.String()
+}
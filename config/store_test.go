@@ -16,6 +16,8 @@ package config
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -57,6 +59,147 @@ func TestStoreValidate(t *testing.T) {
 			},
 			expected: errors.New("bbolt path must be set"),
 		},
+		{
+			name: "valid postgres",
+			store: Store{
+				Type:      StoreTypePostgres,
+				MaxEvents: 100,
+				Postgres: Postgres{
+					Host:     DefaultPostgresHost,
+					Port:     DefaultPostgresPort,
+					Database: DefaultPostgresDatabase,
+					SSLMode:  DefaultPostgresSSLMode,
+				},
+			},
+		},
+		{
+			name: "missing postgres host",
+			store: Store{
+				Type:      StoreTypePostgres,
+				MaxEvents: 100,
+				Postgres: Postgres{
+					Port:     DefaultPostgresPort,
+					Database: DefaultPostgresDatabase,
+				},
+			},
+			expected: errors.New("postgres host must be set"),
+		},
+		{
+			name: "missing postgres port",
+			store: Store{
+				Type:      StoreTypePostgres,
+				MaxEvents: 100,
+				Postgres: Postgres{
+					Host:     DefaultPostgresHost,
+					Database: DefaultPostgresDatabase,
+				},
+			},
+			expected: errors.New("postgres port must be set"),
+		},
+		{
+			name: "missing postgres database",
+			store: Store{
+				Type:      StoreTypePostgres,
+				MaxEvents: 100,
+				Postgres: Postgres{
+					Host: DefaultPostgresHost,
+					Port: DefaultPostgresPort,
+				},
+			},
+			expected: errors.New("postgres database must be set"),
+		},
+		{
+			name: "invalid postgres sslMode",
+			store: Store{
+				Type:      StoreTypePostgres,
+				MaxEvents: 100,
+				Postgres: Postgres{
+					Host:     DefaultPostgresHost,
+					Port:     DefaultPostgresPort,
+					Database: DefaultPostgresDatabase,
+					SSLMode:  "invalid",
+				},
+			},
+			expected: errors.New("invalid postgres sslMode: invalid"),
+		},
+		{
+			name: "valid encryption key",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Encryption: Encryption{
+					Key:          testEncryptionKey,
+					PreviousKeys: []string{testPreviousEncryptionKey},
+				},
+			},
+		},
+		{
+			name: "invalid encryption key",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Encryption: Encryption{
+					Key: "dG9vIHNob3J0",
+				},
+			},
+			expected: errors.New("invalid encryption key: must be 32 bytes, got 9"),
+		},
+		{
+			name: "invalid previous encryption key",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Encryption: Encryption{
+					Key:          testEncryptionKey,
+					PreviousKeys: []string{"not base64!"},
+				},
+			},
+			expected: errors.New("invalid encryption previousKeys[0]: must be base64 encoded"),
+		},
+		{
+			name: "encryption key and keyFile",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Encryption: Encryption{
+					Key:     testEncryptionKey,
+					KeyFile: "/tmp/key",
+				},
+			},
+			expected: errors.New("only one of encryption key and keyFile may be set"),
+		},
+		{
+			name: "previous encryption keys without key",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Encryption: Encryption{
+					PreviousKeys: []string{testPreviousEncryptionKey},
+				},
+			},
+			expected: errors.New("encryption previousKeys requires a key or keyFile"),
+		},
+		{
+			name: "valid measurements retention",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Measurements: Measurements{
+					Retention: []string{"10s:1h", "1m:2d", "1h:90d"},
+				},
+			},
+		},
+		{
+			name: "invalid measurements retention",
+			store: Store{
+				Type:      StoreTypeMap,
+				MaxEvents: 100,
+				Measurements: Measurements{
+					Retention: []string{"10s:1h", "1m"},
+				},
+			},
+			expected: errors.New("invalid retention tier 1m, expected interval:retention"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -72,3 +215,108 @@ func TestStoreValidate(t *testing.T) {
 		})
 	}
 }
+
+const (
+	testEncryptionKey         = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	testPreviousEncryptionKey = "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+)
+
+func TestEncryptionKeys(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(testEncryptionKey+"\n"), 0600))
+
+	testCases := []struct {
+		name           string
+		encryption     Encryption
+		expectKey      []byte
+		expectPrevious [][]byte
+		expectErr      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "key",
+			encryption: Encryption{
+				Key:          testEncryptionKey,
+				PreviousKeys: []string{testPreviousEncryptionKey},
+			},
+			expectKey:      []byte("0123456789abcdef0123456789abcdef"),
+			expectPrevious: [][]byte{[]byte("fedcba9876543210fedcba9876543210")},
+		},
+		{
+			name: "key file",
+			encryption: Encryption{
+				KeyFile: keyFile,
+			},
+			expectKey: []byte("0123456789abcdef0123456789abcdef"),
+		},
+		{
+			name: "missing key file",
+			encryption: Encryption{
+				KeyFile: filepath.Join(t.TempDir(), "missing"),
+			},
+			expectErr: "failed to read encryption keyFile",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, previous, err := tc.encryption.Keys()
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectKey, key)
+			require.Equal(t, tc.expectPrevious, previous)
+		})
+	}
+}
+
+func TestPostgresConnectionString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		postgres Postgres
+		expected string
+	}{
+		{
+			name: "defaults",
+			postgres: Postgres{
+				Host:     DefaultPostgresHost,
+				Port:     DefaultPostgresPort,
+				Database: DefaultPostgresDatabase,
+				SSLMode:  DefaultPostgresSSLMode,
+			},
+			expected: "postgres://localhost:5432/bindplane?sslmode=disable",
+		},
+		{
+			name: "username only",
+			postgres: Postgres{
+				Host:     "db",
+				Port:     "5433",
+				Database: "bp",
+				Username: "user",
+			},
+			expected: "postgres://user@db:5433/bp",
+		},
+		{
+			name: "username and password are escaped",
+			postgres: Postgres{
+				Host:     "db",
+				Port:     "5432",
+				Database: "bp",
+				Username: "user",
+				Password: "p@ss/word",
+				SSLMode:  "require",
+			},
+			expected: "postgres://user:p%40ss%2Fword@db:5432/bp?sslmode=require",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.postgres.ConnectionString())
+		})
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broadcast
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/eventbus"
+)
+
+const (
+	// postgresMaxPayload is the largest payload that can be sent with NOTIFY. Postgres limits payloads to 8000 bytes.
+	// Larger payloads are written to the postgresMessagesTable and the notification only contains a reference to them.
+	postgresMaxPayload = 7999
+
+	// postgresMessagesTable contains payloads that are too large to be sent with NOTIFY
+	postgresMessagesTable = "broadcast_messages"
+
+	// postgresMessageRetention is how long large payloads are kept in the postgresMessagesTable. Listeners read them
+	// immediately after receiving the notification so this only needs to cover temporary delays.
+	postgresMessageRetention = time.Minute
+
+	postgresMinReconnectInterval = time.Second
+	postgresMaxReconnectInterval = 30 * time.Second
+	postgresPingInterval         = time.Minute
+)
+
+// postgresEnvelope is the payload of a notification. Either Data or Ref will be set. If Ref is set, the envelope is too
+// large to send with NOTIFY and must be read from the postgresMessagesTable.
+type postgresEnvelope struct {
+	Attributes MessageAttributes   `json:"attributes,omitempty"`
+	Data       jsoniter.RawMessage `json:"data,omitempty"`
+	Ref        int64               `json:"ref,omitempty"`
+}
+
+type postgresBroadcast[T any] struct {
+	// id identifies this broadcast as the origin of the messages it sends so that they are not delivered twice
+	id      string
+	channel string
+
+	db               *sql.DB
+	connectionString string
+	tableReady       bool
+	tableMtx         sync.Mutex
+
+	producerBuffer eventbus.Source[T]
+	consumerBuffer eventbus.Source[T]
+	consumer       eventbus.Source[T]
+	opts           Options[T]
+	logger         *zap.Logger
+}
+
+var _ Broadcast[any] = (*postgresBroadcast[any])(nil)
+var _ eventbus.Receiver[any] = (*postgresBroadcast[any])(nil)
+
+// NewPostgresBroadcast returns a new broadcast that uses postgres LISTEN/NOTIFY on the specified channel to send
+// messages to all nodes in the cluster. Messages are delivered to the local consumer immediately and to the consumers on
+// other nodes listening on the same channel. A parse func must be specified with WithParseFunc to receive messages from
+// other nodes. The connection to postgres is closed when the context is done.
+func NewPostgresBroadcast[T any](ctx context.Context, logger *zap.Logger, connectionString string, channel string, options ...Option[T]) Broadcast[T] {
+	opts := MakeBroadcastOptions(options)
+	logger = logger.Named("broadcast").With(zap.String("channel", channel))
+
+	// lib/pq only returns an error from Open if the driver is not registered
+	db, _ := sql.Open("postgres", connectionString)
+	db.SetMaxOpenConns(2)
+
+	b := &postgresBroadcast[T]{
+		id:               uuid.NewString(),
+		channel:          channel,
+		db:               db,
+		connectionString: connectionString,
+		logger:           logger,
+		opts:             opts,
+		consumer:         InitConsumer(opts),
+		producerBuffer:   eventbus.NewSource[T](),
+		consumerBuffer:   eventbus.NewSource[T](),
+	}
+
+	// producer => publisher
+	RelayProducer[T, T](ctx, b.producerBuffer, func(msg T) (T, bool) { return msg, true }, &postgresPublisher[T]{b}, opts)
+
+	// consumerBuffer => consumer
+	eventbus.RelayWithFilter[T, T](ctx, b.consumerBuffer, b.consumerFilter(ctx), b.consumer)
+
+	// remote => consumerBuffer
+	go b.listen(ctx)
+
+	return b
+}
+
+// Producer returns the producer which can be used to send messages to pub/sub.
+func (b *postgresBroadcast[T]) Producer() eventbus.Receiver[T] {
+	return b
+}
+
+// Consumer returns the source which can be subscribed to to receive messages from other nodes in the cluster.
+func (b *postgresBroadcast[T]) Consumer() eventbus.Source[T] {
+	return b.consumer
+}
+
+// Send sends a message to pub/sub to be received by all nodes in the cluster. It implements Receiver[*T] for use with
+// RelayWithMerge.
+func (b *postgresBroadcast[T]) Send(ctx context.Context, msg T) {
+	b.producerBuffer.Send(ctx, msg)
+}
+
+// postgresPublisher receives messages from the producer after they have been merged and publishes them
+type postgresPublisher[T any] struct {
+	broadcast *postgresBroadcast[T]
+}
+
+// Send implements eventbus.Receiver
+func (p *postgresPublisher[T]) Send(ctx context.Context, msg T) {
+	p.broadcast.publish(ctx, msg)
+}
+
+// publish delivers the message to the local consumer and sends it to the other nodes in the cluster
+func (b *postgresBroadcast[T]) publish(ctx context.Context, msg T) {
+	attrs := b.attributes(ctx, msg)
+	if b.opts.AcceptMessage(attrs) {
+		b.consumerBuffer.Send(ctx, msg)
+	}
+
+	payload, err := b.encode(msg, attrs)
+	if err != nil {
+		b.logger.Error("failed to encode message", zap.Error(err))
+		return
+	}
+
+	if len(payload) > postgresMaxPayload {
+		payload, err = b.storeLargePayload(ctx, payload)
+		if err != nil {
+			b.logger.Error("failed to store large message", zap.Error(err))
+			return
+		}
+	}
+
+	if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, payload); err != nil {
+		b.logger.Error("failed to publish message", zap.Error(err))
+	}
+}
+
+// attributes returns the attributes of a message sent by this broadcast
+func (b *postgresBroadcast[T]) attributes(ctx context.Context, msg T) MessageAttributes {
+	attrs := MessageAttributes{
+		AttributeOrigin: b.id,
+		AttributeType:   b.channel,
+	}
+	b.opts.AddAttributes(ctx, msg, attrs)
+	return attrs
+}
+
+// encode returns the envelope containing the message and its attributes
+func (b *postgresBroadcast[T]) encode(msg T, attrs MessageAttributes) (string, error) {
+	data, err := jsoniter.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal message: %w", err)
+	}
+
+	envelope, err := jsoniter.Marshal(postgresEnvelope{Attributes: attrs, Data: data})
+	if err != nil {
+		return "", fmt.Errorf("marshal envelope: %w", err)
+	}
+	return string(envelope), nil
+}
+
+// storeLargePayload writes the payload to the postgresMessagesTable and returns an envelope referencing it
+func (b *postgresBroadcast[T]) storeLargePayload(ctx context.Context, payload string) (string, error) {
+	if err := b.ensureMessagesTable(ctx); err != nil {
+		return "", err
+	}
+
+	var ref int64
+	row := b.db.QueryRowContext(ctx, fmt.Sprintf("INSERT INTO %s (channel, payload) VALUES ($1, $2) RETURNING id", postgresMessagesTable), b.channel, payload)
+	if err := row.Scan(&ref); err != nil {
+		return "", fmt.Errorf("insert message: %w", err)
+	}
+
+	envelope, err := jsoniter.Marshal(postgresEnvelope{Ref: ref})
+	if err != nil {
+		return "", fmt.Errorf("marshal envelope: %w", err)
+	}
+	return string(envelope), nil
+}
+
+// loadLargePayload reads a payload written by storeLargePayload
+func (b *postgresBroadcast[T]) loadLargePayload(ctx context.Context, ref int64) (string, error) {
+	var payload string
+	row := b.db.QueryRowContext(ctx, fmt.Sprintf("SELECT payload FROM %s WHERE id = $1", postgresMessagesTable), ref)
+	if err := row.Scan(&payload); err != nil {
+		return "", fmt.Errorf("select message %d: %w", ref, err)
+	}
+	return payload, nil
+}
+
+// ensureMessagesTable creates the postgresMessagesTable if it hasn't already been created by this broadcast
+func (b *postgresBroadcast[T]) ensureMessagesTable(ctx context.Context) error {
+	b.tableMtx.Lock()
+	defer b.tableMtx.Unlock()
+
+	if b.tableReady {
+		return nil
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id BIGSERIAL PRIMARY KEY,
+	channel TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, postgresMessagesTable)
+	if _, err := b.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("create %s: %w", postgresMessagesTable, err)
+	}
+
+	b.tableReady = true
+	return nil
+}
+
+// cleanupLargePayloads removes payloads older than the postgresMessageRetention
+func (b *postgresBroadcast[T]) cleanupLargePayloads(ctx context.Context) {
+	b.tableMtx.Lock()
+	ready := b.tableReady
+	b.tableMtx.Unlock()
+
+	// only the broadcast that created the table needs to clean it up
+	if !ready {
+		return
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE channel = $1 AND created_at < $2", postgresMessagesTable)
+	if _, err := b.db.ExecContext(ctx, query, b.channel, time.Now().Add(-postgresMessageRetention)); err != nil {
+		b.logger.Error("failed to cleanup messages", zap.Error(err))
+	}
+}
+
+// listen receives notifications from postgres and sends them to the consumer until the context is done
+func (b *postgresBroadcast[T]) listen(ctx context.Context) {
+	listener := pq.NewListener(b.connectionString, postgresMinReconnectInterval, postgresMaxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			b.logger.Error("postgres listener error", zap.Error(err))
+		}
+	})
+	defer func() {
+		_ = listener.Close()
+		_ = b.db.Close()
+	}()
+
+	if err := listener.Listen(b.channel); err != nil {
+		b.logger.Error("failed to listen", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(postgresPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				b.logger.Error("failed to ping postgres listener", zap.Error(err))
+			}
+			b.cleanupLargePayloads(ctx)
+
+		case notification := <-listener.NotificationChannel():
+			// a nil notification is sent after the listener reconnects
+			if notification == nil {
+				continue
+			}
+			b.receive(ctx, notification.Extra)
+		}
+	}
+}
+
+// receive decodes the payload of a notification and sends it to the consumer if it was sent by another node
+func (b *postgresBroadcast[T]) receive(ctx context.Context, payload string) {
+	var envelope postgresEnvelope
+	if err := jsoniter.UnmarshalFromString(payload, &envelope); err != nil {
+		b.logger.Error("failed to unmarshal envelope", zap.Error(err))
+		return
+	}
+
+	if envelope.Ref != 0 {
+		large, err := b.loadLargePayload(ctx, envelope.Ref)
+		if err != nil {
+			b.logger.Error("failed to load large message", zap.Error(err))
+			return
+		}
+		envelope = postgresEnvelope{}
+		if err := jsoniter.UnmarshalFromString(large, &envelope); err != nil {
+			b.logger.Error("failed to unmarshal envelope", zap.Error(err))
+			return
+		}
+	}
+
+	// messages sent by this broadcast were already delivered locally
+	if envelope.Attributes[AttributeOrigin] == b.id {
+		return
+	}
+
+	// if attribute filtering is configured, only process messages that pass the filter
+	if !b.opts.AcceptMessage(envelope.Attributes) {
+		return
+	}
+
+	msg, err := b.opts.ParseTo(envelope.Data)
+	if err != nil {
+		b.logger.Error("failed to parse message", zap.Error(err))
+		return
+	}
+
+	b.consumerBuffer.Send(ctx, msg)
+}
+
+// ----------------------------------------------------------------------
+// filter for processing messages
+
+func (b *postgresBroadcast[T]) consumerFilter(ctx context.Context) eventbus.SubscriptionFilter[T, T] {
+	return func(msg T) (T, bool) {
+		// build message attributes for routing
+		attrs := MessageAttributes{}
+		b.opts.AddAttributes(ctx, msg, attrs)
+
+		// if routing is configured, only process messages that have a route
+		if !b.opts.HasRoute(attrs) {
+			return msg, false
+		}
+
+		return msg, true
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+// +build integration
+
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/eventbus"
+)
+
+type largeTestMessage struct {
+	Value   int    `json:"value"`
+	Padding string `json:"padding"`
+}
+
+func postgresConnectionString(t *testing.T) string {
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:15-alpine",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "bindplane",
+				"POSTGRES_PASSWORD": "password",
+				"POSTGRES_DB":       "bindplane",
+			},
+			WaitingFor: wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	return fmt.Sprintf("postgres://bindplane:password@%s:%s/bindplane?sslmode=disable", host, port.Port())
+}
+
+func TestPostgresBroadcastAcrossNodes(t *testing.T) {
+	connectionString := postgresConnectionString(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parse := WithParseFunc(func(data []byte) (largeTestMessage, error) {
+		var msg largeTestMessage
+		err := jsoniter.Unmarshal(data, &msg)
+		return msg, err
+	})
+	node1 := NewPostgresBroadcast[largeTestMessage](ctx, zap.NewNop(), connectionString, "test", parse)
+	node2 := NewPostgresBroadcast[largeTestMessage](ctx, zap.NewNop(), connectionString, "test", parse)
+
+	ch1, unsubscribe1 := eventbus.Subscribe(ctx, node1.Consumer())
+	defer unsubscribe1()
+	ch2, unsubscribe2 := eventbus.Subscribe(ctx, node2.Consumer())
+	defer unsubscribe2()
+
+	// give the listeners time to connect
+	time.Sleep(time.Second)
+
+	small := largeTestMessage{Value: 1}
+	large := largeTestMessage{Value: 2, Padding: strings.Repeat("x", 2*postgresMaxPayload)}
+	node1.Producer().Send(ctx, small)
+	node1.Producer().Send(ctx, large)
+
+	for _, ch := range []<-chan largeTestMessage{ch1, ch2} {
+		for _, expect := range []largeTestMessage{small, large} {
+			select {
+			case msg := <-ch:
+				require.Equal(t, expect, msg)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timed out waiting for message")
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broadcast
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/eventbus"
+)
+
+// newTestPostgresBroadcast returns a broadcast that is not connected to postgres so that the encoding and decoding of
+// notifications can be tested
+func newTestPostgresBroadcast(ctx context.Context, id string, options ...Option[testMessage]) *postgresBroadcast[testMessage] {
+	options = append(options, WithParseFunc(func(data []byte) (testMessage, error) {
+		var msg testMessage
+		err := jsoniter.Unmarshal(data, &msg)
+		return msg, err
+	}))
+	opts := MakeBroadcastOptions(options)
+	b := &postgresBroadcast[testMessage]{
+		id:             id,
+		channel:        "test",
+		logger:         zap.NewNop(),
+		opts:           opts,
+		consumer:       InitConsumer(opts),
+		producerBuffer: eventbus.NewSource[testMessage](),
+		consumerBuffer: eventbus.NewSource[testMessage](),
+	}
+	eventbus.RelayWithFilter[testMessage, testMessage](ctx, b.consumerBuffer, b.consumerFilter(ctx), b.consumer)
+	return b
+}
+
+func TestPostgresBroadcastReceive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sender := newTestPostgresBroadcast(ctx, "sender")
+	receiver := newTestPostgresBroadcast(ctx, "receiver")
+
+	senderCh, unsubscribeSender := eventbus.Subscribe(ctx, sender.Consumer())
+	defer unsubscribeSender()
+	receiverCh, unsubscribeReceiver := eventbus.Subscribe(ctx, receiver.Consumer())
+	defer unsubscribeReceiver()
+
+	msg := testMessage{Value: 5}
+	payload, err := sender.encode(msg, sender.attributes(ctx, msg))
+	require.NoError(t, err)
+
+	// the sender ignores its own messages
+	sender.receive(ctx, payload)
+	// the receiver delivers messages from other nodes
+	receiver.receive(ctx, payload)
+
+	select {
+	case received := <-receiverCh:
+		require.Equal(t, msg, received)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for message")
+	}
+
+	select {
+	case received := <-senderCh:
+		require.Fail(t, "unexpected message", "%v", received)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPostgresBroadcastReceiveFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processor := WithAttributeProcessor(
+		func(m testMessage, attrs MessageAttributes) {
+			attrs["value"] = strconv.Itoa(m.Value)
+		},
+		func(attrs MessageAttributes) bool {
+			return attrs["value"] != "2"
+		},
+	)
+	sender := newTestPostgresBroadcast(ctx, "sender", processor)
+	receiver := newTestPostgresBroadcast(ctx, "receiver", processor)
+
+	ch, unsubscribe := eventbus.Subscribe(ctx, receiver.Consumer())
+	defer unsubscribe()
+
+	for _, i := range []int{1, 2, 3} {
+		msg := testMessage{Value: i}
+		payload, err := sender.encode(msg, sender.attributes(ctx, msg))
+		require.NoError(t, err)
+		receiver.receive(ctx, payload)
+	}
+
+	require.Equal(t, 4, sumMessages(ch, 2))
+}
+
+func TestPostgresBroadcastReceiveInvalid(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	receiver := newTestPostgresBroadcast(ctx, "receiver")
+	ch, unsubscribe := eventbus.Subscribe(ctx, receiver.Consumer())
+	defer unsubscribe()
+
+	receiver.receive(ctx, "not json")
+	receiver.receive(ctx, `{"attributes":{"_origin":"sender"},"data":"not a message"}`)
+
+	select {
+	case received := <-ch:
+		require.Fail(t, "unexpected message", "%v", received)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
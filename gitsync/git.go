@@ -0,0 +1,87 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// repository is a local clone of the git repository used to read resources. It is created by the first checkout and
+// updated with fetch after that.
+type repository struct {
+	url    string
+	branch string
+	dir    string
+}
+
+// checkout fetches the latest commit of the branch and checks it out, returning the commit hash
+func (r *repository) checkout(ctx context.Context) (string, error) {
+	if r.dir == "" {
+		if err := r.clone(ctx); err != nil {
+			return "", err
+		}
+	} else if _, err := git(ctx, r.dir, "fetch", "--prune", "origin"); err != nil {
+		return "", err
+	}
+
+	ref := "origin/HEAD"
+	if r.branch != "" {
+		ref = "origin/" + r.branch
+	}
+	if _, err := git(ctx, r.dir, "checkout", "--force", "--detach", ref); err != nil {
+		return "", err
+	}
+	return git(ctx, r.dir, "rev-parse", "HEAD")
+}
+
+func (r *repository) clone(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "bindplane-git-sync-")
+	if err != nil {
+		return fmt.Errorf("failed to create directory for repository: %w", err)
+	}
+	if _, err := git(ctx, dir, "clone", "--no-checkout", "--", r.url, "."); err != nil {
+		_ = os.RemoveAll(dir)
+		return err
+	}
+	r.dir = dir
+	return nil
+}
+
+// remove removes the local clone
+func (r *repository) remove() error {
+	if r.dir == "" {
+		return nil
+	}
+	err := os.RemoveAll(r.dir)
+	r.dir = ""
+	return err
+}
+
+// git runs the git command in the directory and returns its trimmed output
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 -- arguments are not passed to a shell
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,361 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitsync applies resources from a git repository to the store at an interval.
+package gitsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("gitsync")
+
+// ErrNotConfigured is returned by Sync if no repository is configured
+var ErrNotConfigured = errors.New("git sync is not configured")
+
+// pruneKinds are the kinds of resources that are pruned and reported as untracked if they are not in the repository,
+// in the order they are deleted so that resources are deleted before their dependencies. Resource types and agent
+// versions are excluded because they are seeded by the server.
+var pruneKinds = []model.Kind{
+	model.KindConfiguration,
+	model.KindAlert,
+	model.KindWebhook,
+	model.KindSource,
+	model.KindProcessor,
+	model.KindDestination,
+}
+
+// Syncer applies the resources in a git repository to the store
+type Syncer interface {
+	// Start syncs the repository immediately and then at the configured interval. It does nothing if no repository is
+	// configured.
+	Start(ctx context.Context)
+
+	// Stop stops syncing and removes the local clone of the repository
+	Stop(ctx context.Context) error
+
+	// Sync syncs the repository now and returns the resulting status. If the sync fails, the error is also included in
+	// the status.
+	Sync(ctx context.Context) (*model.GitSyncStatus, error)
+
+	// Status returns the status of the last sync
+	Status() *model.GitSyncStatus
+}
+
+type syncer struct {
+	cfg    config.GitSync
+	store  store.Store
+	logger *zap.Logger
+
+	// now is replaced in tests
+	now func() time.Time
+
+	// mtx serializes syncs and protects the fields below
+	mtx    sync.Mutex
+	repo   *repository
+	status model.GitSyncStatus
+
+	// applied contains the hash of each resource in the repository at the last sync, used to detect drift. It is empty
+	// after a restart so drift is not detected until the second sync.
+	applied map[string]string
+
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+}
+
+var _ Syncer = (*syncer)(nil)
+
+// NewSyncer returns a new Syncer for the repository in the configuration
+func NewSyncer(cfg config.GitSync, s store.Store, logger *zap.Logger) Syncer {
+	return &syncer{
+		cfg:    cfg,
+		store:  s,
+		logger: logger.Named("gitsync"),
+		now:    time.Now,
+		repo:   &repository{url: cfg.Repository, branch: cfg.Branch},
+		status: model.GitSyncStatus{
+			Enabled:    cfg.Enabled(),
+			Repository: cfg.Repository,
+			Branch:     cfg.Branch,
+			Path:       cfg.Path,
+			Prune:      cfg.Prune,
+		},
+		applied: map[string]string{},
+	}
+}
+
+// Start starts syncing the repository at the configured interval
+func (s *syncer) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancelCause(ctx)
+	if !s.cfg.Enabled() {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := s.Sync(ctx); err != nil {
+				s.logger.Error("failed to sync git repository", zap.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops syncing and removes the local clone of the repository
+func (s *syncer) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return errors.New("git syncer was not started")
+	}
+	s.cancel(errors.New("stop called"))
+
+	doneChan := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(doneChan)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-doneChan:
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.repo.remove()
+}
+
+// Status returns a copy of the status of the last sync
+func (s *syncer) Status() *model.GitSyncStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	status := s.status
+	return &status
+}
+
+// Sync syncs the repository now
+func (s *syncer) Sync(ctx context.Context) (*model.GitSyncStatus, error) {
+	ctx, span := tracer.Start(ctx, "gitsync/Sync")
+	defer span.End()
+
+	if !s.cfg.Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	status := model.GitSyncStatus{
+		Enabled:    true,
+		Repository: s.cfg.Repository,
+		Branch:     s.cfg.Branch,
+		Path:       s.cfg.Path,
+		Prune:      s.cfg.Prune,
+		LastSync:   &now,
+	}
+	err := s.sync(ctx, &status)
+	if err != nil {
+		status.Error = err.Error()
+	}
+	s.status = status
+	return &status, err
+}
+
+// sync checks out the latest commit, applies its resources, and prunes or reports the resources that are not in the
+// repository
+func (s *syncer) sync(ctx context.Context, status *model.GitSyncStatus) error {
+	commit, err := s.repo.checkout(ctx)
+	if err != nil {
+		return err
+	}
+	status.Commit = commit
+
+	resources, err := readResources(filepath.Join(s.repo.dir, s.cfg.Path))
+	if err != nil {
+		return err
+	}
+
+	// hash the resources before they are applied because the store sets their IDs and versions
+	hashes := make(map[string]string, len(resources))
+	for _, r := range resources {
+		hashes[resourceKey(r.GetKind(), r.Name())] = hashResource(r)
+	}
+
+	statuses, err := s.store.ApplyResources(ctx, resources)
+	if err != nil {
+		return fmt.Errorf("failed to apply resources: %w", err)
+	}
+	for _, rs := range statuses {
+		status.Resources = append(status.Resources, model.NewGitSyncResource(rs))
+
+		// a resource that is unchanged in the repository but changed by the sync was changed in the store
+		key := resourceKey(rs.Resource.GetKind(), rs.Resource.Name())
+		if previous, ok := s.applied[key]; !ok || previous != hashes[key] {
+			continue
+		}
+		switch rs.Status {
+		case model.StatusCreated:
+			status.Drift = append(status.Drift, model.GitSyncDrift{Kind: rs.Resource.GetKind(), Name: rs.Resource.Name(), Reason: model.GitSyncDriftMissing})
+		case model.StatusConfigured:
+			status.Drift = append(status.Drift, model.GitSyncDrift{Kind: rs.Resource.GetKind(), Name: rs.Resource.Name(), Reason: model.GitSyncDriftModified})
+		}
+	}
+	s.applied = hashes
+
+	untracked, err := s.untrackedResources(ctx, hashes)
+	if err != nil {
+		return err
+	}
+	if !s.cfg.Prune {
+		for _, r := range untracked {
+			status.Drift = append(status.Drift, model.GitSyncDrift{Kind: r.GetKind(), Name: r.Name(), Reason: model.GitSyncDriftUntracked})
+		}
+		return nil
+	}
+	if len(untracked) == 0 {
+		return nil
+	}
+
+	statuses, err = s.store.DeleteResources(ctx, untracked)
+	if err != nil {
+		return fmt.Errorf("failed to prune resources: %w", err)
+	}
+	for _, rs := range statuses {
+		status.Resources = append(status.Resources, model.NewGitSyncResource(rs))
+	}
+	return nil
+}
+
+// untrackedResources returns the resources in the store of the pruneKinds that are not in the repository
+func (s *syncer) untrackedResources(ctx context.Context, tracked map[string]string) ([]model.Resource, error) {
+	var untracked []model.Resource
+	for _, kind := range pruneKinds {
+		resources, err := storeResources(ctx, s.store, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s resources: %w", kind, err)
+		}
+		for _, r := range resources {
+			if _, ok := tracked[resourceKey(kind, r.Name())]; !ok {
+				untracked = append(untracked, r)
+			}
+		}
+	}
+	return untracked, nil
+}
+
+// storeResources returns all of the resources of the kind in the store
+func storeResources(ctx context.Context, s store.Store, kind model.Kind) ([]model.Resource, error) {
+	switch kind {
+	case model.KindConfiguration:
+		return asResources(s.Configurations(ctx))
+	case model.KindAlert:
+		return asResources(s.Alerts(ctx))
+	case model.KindWebhook:
+		return asResources(s.Webhooks(ctx))
+	case model.KindSource:
+		return asResources(s.Sources(ctx))
+	case model.KindProcessor:
+		return asResources(s.Processors(ctx))
+	case model.KindDestination:
+		return asResources(s.Destinations(ctx))
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+}
+
+func asResources[R model.Resource](items []R, err error) ([]model.Resource, error) {
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]model.Resource, 0, len(items))
+	for _, item := range items {
+		resources = append(resources, item)
+	}
+	return resources, nil
+}
+
+// readResources parses all of the .yaml and .yml files in the directory and its subdirectories
+func readResources(dir string) ([]model.Resource, error) {
+	var resources []model.Resource
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		name, _ := filepath.Rel(dir, path)
+		anyResources, err := model.ResourcesFromFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		parsed, err := model.ParseResourcesStrict(anyResources)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		resources = append(resources, parsed...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func resourceKey(kind model.Kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// hashResource returns the hash of the resource as it is defined in the repository
+func hashResource(r model.Resource) string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,280 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testConfiguration = `apiVersion: bindplane.observiq.com/v1
+kind: Configuration
+metadata:
+  name: raw
+  labels:
+    platform: linux
+spec:
+  raw: ""
+  selector:
+    matchLabels:
+      configuration: raw
+`
+
+const testAlert = `apiVersion: bindplane.observiq.com/v1
+kind: Alert
+metadata:
+  name: errors
+spec:
+  condition:
+    type: agentStatus
+    agentStatus: Error
+  channels:
+    - type: webhook
+      url: https://example.com/hook
+`
+
+// testRepository is a local bare repository with a working copy used to push commits
+type testRepository struct {
+	t    *testing.T
+	bare string
+	work string
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	r := &testRepository{
+		t:    t,
+		bare: filepath.Join(t.TempDir(), "resources.git"),
+		work: t.TempDir(),
+	}
+	r.git("", "init", "--bare", "--initial-branch=main", r.bare)
+	r.git(r.work, "init", "--initial-branch=main")
+	r.git(r.work, "remote", "add", "origin", r.bare)
+	return r
+}
+
+func (r *testRepository) git(dir string, args ...string) {
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(out))
+}
+
+// commit writes the files, removes the files with empty contents, and pushes a commit
+func (r *testRepository) commit(files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(r.work, name)
+		if contents == "" {
+			require.NoError(r.t, os.Remove(path))
+			continue
+		}
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(r.t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+	r.git(r.work, "add", "-A")
+	r.git(r.work, "commit", "-m", "update resources")
+	r.git(r.work, "push", "origin", "main")
+}
+
+func newTestSyncer(t *testing.T, cfg config.GitSync) (*syncer, store.Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	gs := NewSyncer(cfg, s, zap.NewNop()).(*syncer)
+	t.Cleanup(func() { require.NoError(t, gs.repo.remove()) })
+	return gs, s
+}
+
+func TestSyncNotConfigured(t *testing.T) {
+	gs, _ := newTestSyncer(t, config.GitSync{})
+	_, err := gs.Sync(context.Background())
+	require.ErrorIs(t, err, ErrNotConfigured)
+	require.False(t, gs.Status().Enabled)
+}
+
+func TestSyncAppliesResources(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{
+		"bindplane/configuration.yaml": testConfiguration,
+		"bindplane/alerts/errors.yml":  testAlert,
+		"README.md":                    "not a resource",
+		"other/ignored.yaml":           "not: a resource",
+	})
+
+	gs, s := newTestSyncer(t, config.GitSync{Repository: repo.bare, Path: "bindplane", Interval: time.Minute})
+	ctx := context.Background()
+
+	status, err := gs.Sync(ctx)
+	require.NoError(t, err)
+	require.True(t, status.Enabled)
+	require.Len(t, status.Commit, 40)
+	require.NotNil(t, status.LastSync)
+	require.ElementsMatch(t, []model.GitSyncResource{
+		{Kind: model.KindAlert, Name: "errors", Status: model.StatusCreated},
+		{Kind: model.KindConfiguration, Name: "raw", Status: model.StatusCreated},
+	}, status.Resources)
+	require.Empty(t, status.Drift)
+	require.Equal(t, status, gs.Status())
+
+	alert, err := s.Alert(ctx, "errors")
+	require.NoError(t, err)
+	require.Equal(t, "Error", alert.Spec.Condition.AgentStatus)
+
+	// a second sync of the same commit changes nothing
+	status, err = gs.Sync(ctx)
+	require.NoError(t, err)
+	for _, r := range status.Resources {
+		require.Equal(t, model.StatusUnchanged, r.Status, r.Name)
+	}
+	require.Empty(t, status.Drift)
+
+	// changes in the repository are applied without reporting drift
+	repo.commit(map[string]string{
+		"bindplane/alerts/errors.yml": strings.Replace(testAlert, "agentStatus: Error", "agentStatus: Disconnected", 1),
+	})
+	previousCommit := status.Commit
+	status, err = gs.Sync(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, previousCommit, status.Commit)
+	require.Contains(t, status.Resources, model.GitSyncResource{Kind: model.KindAlert, Name: "errors", Status: model.StatusConfigured})
+	require.Empty(t, status.Drift)
+
+	alert, err = s.Alert(ctx, "errors")
+	require.NoError(t, err)
+	require.Equal(t, "Disconnected", alert.Spec.Condition.AgentStatus)
+}
+
+func TestSyncDrift(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{
+		"configuration.yaml": testConfiguration,
+		"alert.yaml":         testAlert,
+	})
+
+	gs, s := newTestSyncer(t, config.GitSync{Repository: repo.bare, Branch: "main", Interval: time.Minute})
+	ctx := context.Background()
+
+	_, err := gs.Sync(ctx)
+	require.NoError(t, err)
+
+	// modify the alert, delete the configuration, and add an alert that is not in the repository
+	alert, err := s.Alert(ctx, "errors")
+	require.NoError(t, err)
+	alert.Spec.Condition.AgentStatus = "Disconnected"
+	untracked := model.NewAlert("untracked", alert.Spec)
+	_, err = s.ApplyResources(ctx, []model.Resource{alert, untracked})
+	require.NoError(t, err)
+	_, err = s.DeleteConfiguration(ctx, "raw")
+	require.NoError(t, err)
+
+	status, err := gs.Sync(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []model.GitSyncDrift{
+		{Kind: model.KindAlert, Name: "errors", Reason: model.GitSyncDriftModified},
+		{Kind: model.KindConfiguration, Name: "raw", Reason: model.GitSyncDriftMissing},
+		{Kind: model.KindAlert, Name: "untracked", Reason: model.GitSyncDriftUntracked},
+	}, status.Drift)
+
+	// the repository is restored but the untracked alert is kept without prune
+	alert, err = s.Alert(ctx, "errors")
+	require.NoError(t, err)
+	require.Equal(t, "Error", alert.Spec.Condition.AgentStatus)
+	configuration, err := s.Configuration(ctx, "raw")
+	require.NoError(t, err)
+	require.NotNil(t, configuration)
+	alert, err = s.Alert(ctx, "untracked")
+	require.NoError(t, err)
+	require.NotNil(t, alert)
+}
+
+func TestSyncPrune(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{
+		"configuration.yaml": testConfiguration,
+		"alert.yaml":         testAlert,
+	})
+
+	gs, s := newTestSyncer(t, config.GitSync{Repository: repo.bare, Interval: time.Minute, Prune: true})
+	ctx := context.Background()
+
+	_, err := gs.Sync(ctx)
+	require.NoError(t, err)
+
+	repo.commit(map[string]string{"alert.yaml": ""})
+	status, err := gs.Sync(ctx)
+	require.NoError(t, err)
+	require.Contains(t, status.Resources, model.GitSyncResource{Kind: model.KindAlert, Name: "errors", Status: model.StatusDeleted})
+	require.Empty(t, status.Drift)
+
+	alert, err := s.Alert(ctx, "errors")
+	require.NoError(t, err)
+	require.Nil(t, alert)
+	configuration, err := s.Configuration(ctx, "raw")
+	require.NoError(t, err)
+	require.NotNil(t, configuration)
+}
+
+func TestSyncErrors(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{"invalid.yaml": "apiVersion: bindplane.observiq.com/v1\nkind: Unknown\n"})
+
+	gs, _ := newTestSyncer(t, config.GitSync{Repository: repo.bare, Interval: time.Minute})
+	status, err := gs.Sync(context.Background())
+	require.Error(t, err)
+	require.Contains(t, status.Error, "failed to parse invalid.yaml")
+	require.Len(t, status.Commit, 40)
+
+	gs, _ = newTestSyncer(t, config.GitSync{Repository: filepath.Join(t.TempDir(), "missing.git"), Interval: time.Minute})
+	status, err = gs.Sync(context.Background())
+	require.Error(t, err)
+	require.Contains(t, status.Error, "git clone failed")
+	require.Equal(t, status, gs.Status())
+}
+
+func TestStartStop(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{"alert.yaml": testAlert})
+
+	gs, s := newTestSyncer(t, config.GitSync{Repository: repo.bare, Interval: time.Hour})
+	gs.Start(context.Background())
+
+	require.Eventually(t, func() bool {
+		alert, err := s.Alert(context.Background(), "errors")
+		return err == nil && alert != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, gs.Stop(context.Background()))
+	require.Empty(t, gs.repo.dir)
+}
+
+func TestStopNoStart(t *testing.T) {
+	gs, _ := newTestSyncer(t, config.GitSync{})
+	require.Error(t, gs.Stop(context.Background()))
+}
@@ -16,9 +16,12 @@
 package graphql
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
@@ -26,7 +29,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 
+	"github.com/observiq/bindplane-op/authenticator"
 	"github.com/observiq/bindplane-op/graphql/generated"
+	"github.com/observiq/bindplane-op/model"
 	exposedserver "github.com/observiq/bindplane-op/server"
 )
 
@@ -49,7 +54,9 @@ func NewHandler(bindplane exposedserver.BindPlane) *handler.Server {
 	srv := handler.New(
 		generated.NewExecutableSchema(
 			generated.Config{
-				Resolvers: NewResolver(bindplane)}))
+				Resolvers:  NewResolver(bindplane),
+				Directives: generated.DirectiveRoot{HasRole: hasRole},
+			}))
 
 	srv.AddTransport(transport.POST{})
 	srv.AddTransport(transport.Websocket{
@@ -63,3 +70,12 @@ func NewHandler(bindplane exposedserver.BindPlane) *handler.Server {
 	srv.Use(extension.Introspection{})
 	return srv
 }
+
+// hasRole implements the @hasRole directive using the role set on the request context by middleware.ResolveRole
+func hasRole(ctx context.Context, _ any, next graphql.Resolver, required model.Role) (any, error) {
+	role, _ := authenticator.RoleFromContext(ctx)
+	if !role.Allows(required) {
+		return nil, fmt.Errorf("%s role required", required)
+	}
+	return next(ctx)
+}
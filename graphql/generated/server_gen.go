@@ -15,8 +15,8 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
-	"github.com/observiq/bindplane-op/graphql/model"
-	model1 "github.com/observiq/bindplane-op/model"
+	model1 "github.com/observiq/bindplane-op/graphql/model"
+	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/model/graph"
 	"github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/otlp/record"
@@ -64,6 +64,7 @@ type ResolverRoot interface {
 }
 
 type DirectiveRoot struct {
+	HasRole func(ctx context.Context, obj interface{}, next graphql.Resolver, role model.Role) (res interface{}, err error)
 }
 
 type ComplexityRoot struct {
@@ -155,6 +156,7 @@ type ComplexityRoot struct {
 		CurrentVersion func(childComplexity int) int
 		Latest         func(childComplexity int) int
 		Pending        func(childComplexity int) int
+		Rollback       func(childComplexity int) int
 		Rollout        func(childComplexity int) int
 	}
 
@@ -235,6 +237,12 @@ type ComplexityRoot struct {
 		Timestamp  func(childComplexity int) int
 	}
 
+	MaintenanceWindow struct {
+		Cron     func(childComplexity int) int
+		Duration func(childComplexity int) int
+		Timezone func(childComplexity int) int
+	}
+
 	Metadata struct {
 		AdditionalInfo func(childComplexity int) int
 		DateModified   func(childComplexity int) int
@@ -272,10 +280,10 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		ClearAgentUpgradeError       func(childComplexity int, input model.ClearAgentUpgradeErrorInput) int
-		EditConfigurationDescription func(childComplexity int, input model.EditConfigurationDescriptionInput) int
-		RemoveAgentConfiguration     func(childComplexity int, input *model.RemoveAgentConfigurationInput) int
-		UpdateProcessors             func(childComplexity int, input model.UpdateProcessorsInput) int
+		ClearAgentUpgradeError       func(childComplexity int, input model1.ClearAgentUpgradeErrorInput) int
+		EditConfigurationDescription func(childComplexity int, input model1.EditConfigurationDescriptionInput) int
+		RemoveAgentConfiguration     func(childComplexity int, input *model1.RemoveAgentConfigurationInput) int
+		UpdateProcessors             func(childComplexity int, input model1.UpdateProcessorsInput) int
 	}
 
 	Node struct {
@@ -375,6 +383,7 @@ type ComplexityRoot struct {
 		ProcessorTypes       func(childComplexity int) int
 		ProcessorWithType    func(childComplexity int, name string) int
 		Processors           func(childComplexity int) int
+		ResourceHistory      func(childComplexity int, kind string, name string) int
 		Snapshot             func(childComplexity int, agentID string, pipelineType otel.PipelineType, position *string, resourceName *string) int
 		Source               func(childComplexity int, name string) int
 		SourceType           func(childComplexity int, name string) int
@@ -397,6 +406,7 @@ type ComplexityRoot struct {
 		Parameters  func(childComplexity int) int
 		Processors  func(childComplexity int) int
 		Type        func(childComplexity int) int
+		Version     func(childComplexity int) int
 	}
 
 	ResourceTypeSpec struct {
@@ -407,22 +417,56 @@ type ComplexityRoot struct {
 	}
 
 	Rollout struct {
-		Completed func(childComplexity int) int
-		Errors    func(childComplexity int) int
-		Options   func(childComplexity int) int
-		Pending   func(childComplexity int) int
-		Phase     func(childComplexity int) int
-		Status    func(childComplexity int) int
-		Waiting   func(childComplexity int) int
+		AwaitingApproval func(childComplexity int) int
+		Canary           func(childComplexity int) int
+		Completed        func(childComplexity int) int
+		Errors           func(childComplexity int) int
+		Health           func(childComplexity int) int
+		Options          func(childComplexity int) int
+		PausedBySchedule func(childComplexity int) int
+		Pending          func(childComplexity int) int
+		Phase            func(childComplexity int) int
+		Status           func(childComplexity int) int
+		Waiting          func(childComplexity int) int
+	}
+
+	RolloutCanary struct {
+		Agents           func(childComplexity int) int
+		Approved         func(childComplexity int) int
+		AwaitingApproval func(childComplexity int) int
+	}
+
+	RolloutHealth struct {
+		Baseline     func(childComplexity int) int
+		CheckStarted func(childComplexity int) int
+		Failure      func(childComplexity int) int
+		Passed       func(childComplexity int) int
+		Phase        func(childComplexity int) int
+		Throughput   func(childComplexity int) int
+	}
+
+	RolloutHealthCheck struct {
+		Duration             func(childComplexity int) int
+		MinThroughputPercent func(childComplexity int) int
 	}
 
 	RolloutOptions struct {
+		CanarySelector     func(childComplexity int) int
+		HealthCheck        func(childComplexity int) int
+		MaintenanceWindow  func(childComplexity int) int
 		MaxErrors          func(childComplexity int) int
 		PhaseAgentCount    func(childComplexity int) int
 		RollbackOnFailure  func(childComplexity int) int
+		StartAt            func(childComplexity int) int
 		StartAutomatically func(childComplexity int) int
 	}
 
+	RolloutRollback struct {
+		Agents  func(childComplexity int) int
+		Date    func(childComplexity int) int
+		Version func(childComplexity int) int
+	}
+
 	Snapshot struct {
 		Logs    func(childComplexity int) int
 		Metrics func(childComplexity int) int
@@ -474,105 +518,106 @@ type ComplexityRoot struct {
 }
 
 type AgentResolver interface {
-	Labels(ctx context.Context, obj *model1.Agent) (map[string]interface{}, error)
+	Labels(ctx context.Context, obj *model.Agent) (map[string]interface{}, error)
 
-	Status(ctx context.Context, obj *model1.Agent) (int, error)
+	Status(ctx context.Context, obj *model.Agent) (int, error)
 
-	Configuration(ctx context.Context, obj *model1.Agent) (*model.AgentConfiguration, error)
-	ConfigurationResource(ctx context.Context, obj *model1.Agent) (*model1.Configuration, error)
+	Configuration(ctx context.Context, obj *model.Agent) (*model1.AgentConfiguration, error)
+	ConfigurationResource(ctx context.Context, obj *model.Agent) (*model.Configuration, error)
 
-	UpgradeAvailable(ctx context.Context, obj *model1.Agent) (*string, error)
-	Features(ctx context.Context, obj *model1.Agent) (int, error)
+	UpgradeAvailable(ctx context.Context, obj *model.Agent) (*string, error)
+	Features(ctx context.Context, obj *model.Agent) (int, error)
 }
 type AgentSelectorResolver interface {
-	MatchLabels(ctx context.Context, obj *model1.AgentSelector) (map[string]interface{}, error)
+	MatchLabels(ctx context.Context, obj *model.AgentSelector) (map[string]interface{}, error)
 }
 type AgentUpgradeResolver interface {
-	Status(ctx context.Context, obj *model1.AgentUpgrade) (int, error)
+	Status(ctx context.Context, obj *model.AgentUpgrade) (int, error)
 }
 type ConfigurationResolver interface {
-	Kind(ctx context.Context, obj *model1.Configuration) (string, error)
+	Kind(ctx context.Context, obj *model.Configuration) (string, error)
 
-	AgentCount(ctx context.Context, obj *model1.Configuration) (*int, error)
-	ActiveTypes(ctx context.Context, obj *model1.Configuration) ([]string, error)
-	Graph(ctx context.Context, obj *model1.Configuration) (*graph.Graph, error)
-	Rendered(ctx context.Context, obj *model1.Configuration) (*string, error)
+	AgentCount(ctx context.Context, obj *model.Configuration) (*int, error)
+	ActiveTypes(ctx context.Context, obj *model.Configuration) ([]string, error)
+	Graph(ctx context.Context, obj *model.Configuration) (*graph.Graph, error)
+	Rendered(ctx context.Context, obj *model.Configuration) (*string, error)
 }
 type DestinationResolver interface {
-	Kind(ctx context.Context, obj *model1.Destination) (string, error)
+	Kind(ctx context.Context, obj *model.Destination) (string, error)
 }
 type DestinationTypeResolver interface {
-	Kind(ctx context.Context, obj *model1.DestinationType) (string, error)
+	Kind(ctx context.Context, obj *model.DestinationType) (string, error)
 }
 type MetadataResolver interface {
-	Labels(ctx context.Context, obj *model1.Metadata) (map[string]interface{}, error)
+	Labels(ctx context.Context, obj *model.Metadata) (map[string]interface{}, error)
 }
 type MutationResolver interface {
-	UpdateProcessors(ctx context.Context, input model.UpdateProcessorsInput) (*bool, error)
-	RemoveAgentConfiguration(ctx context.Context, input *model.RemoveAgentConfigurationInput) (*model1.Agent, error)
-	ClearAgentUpgradeError(ctx context.Context, input model.ClearAgentUpgradeErrorInput) (*bool, error)
-	EditConfigurationDescription(ctx context.Context, input model.EditConfigurationDescriptionInput) (*bool, error)
+	UpdateProcessors(ctx context.Context, input model1.UpdateProcessorsInput) (*bool, error)
+	RemoveAgentConfiguration(ctx context.Context, input *model1.RemoveAgentConfigurationInput) (*model.Agent, error)
+	ClearAgentUpgradeError(ctx context.Context, input model1.ClearAgentUpgradeErrorInput) (*bool, error)
+	EditConfigurationDescription(ctx context.Context, input model1.EditConfigurationDescriptionInput) (*bool, error)
 }
 type ParameterDefinitionResolver interface {
-	Type(ctx context.Context, obj *model1.ParameterDefinition) (model.ParameterType, error)
+	Type(ctx context.Context, obj *model.ParameterDefinition) (model1.ParameterType, error)
 }
 type ParameterOptionsResolver interface {
-	Labels(ctx context.Context, obj *model1.ParameterOptions) (map[string]interface{}, error)
+	Labels(ctx context.Context, obj *model.ParameterOptions) (map[string]interface{}, error)
 }
 type ProcessorResolver interface {
-	Kind(ctx context.Context, obj *model1.Processor) (string, error)
+	Kind(ctx context.Context, obj *model.Processor) (string, error)
 }
 type ProcessorTypeResolver interface {
-	Kind(ctx context.Context, obj *model1.ProcessorType) (string, error)
+	Kind(ctx context.Context, obj *model.ProcessorType) (string, error)
 }
 type QueryResolver interface {
-	OverviewPage(ctx context.Context, configIDs []string, destinationIDs []string, period string, telemetryType string) (*model.OverviewPage, error)
-	Agents(ctx context.Context, selector *string, query *string) (*model.Agents, error)
-	Agent(ctx context.Context, id string) (*model1.Agent, error)
-	Configurations(ctx context.Context, selector *string, query *string, onlyDeployedConfigurations *bool) (*model.Configurations, error)
-	Configuration(ctx context.Context, name string) (*model1.Configuration, error)
-	ConfigurationHistory(ctx context.Context, name string) ([]*model1.Configuration, error)
-	Sources(ctx context.Context) ([]*model1.Source, error)
-	Source(ctx context.Context, name string) (*model1.Source, error)
-	SourceTypes(ctx context.Context) ([]*model1.SourceType, error)
-	SourceType(ctx context.Context, name string) (*model1.SourceType, error)
-	SourceWithType(ctx context.Context, name string) (*model.SourceWithType, error)
-	Processors(ctx context.Context) ([]*model1.Processor, error)
-	Processor(ctx context.Context, name string) (*model1.Processor, error)
-	ProcessorWithType(ctx context.Context, name string) (*model.ProcessorWithType, error)
-	ProcessorTypes(ctx context.Context) ([]*model1.ProcessorType, error)
-	ProcessorType(ctx context.Context, name string) (*model1.ProcessorType, error)
-	Destinations(ctx context.Context, query *string, filterUnused *bool) ([]*model1.Destination, error)
-	Destination(ctx context.Context, name string) (*model1.Destination, error)
-	DestinationWithType(ctx context.Context, name string) (*model.DestinationWithType, error)
-	DestinationTypes(ctx context.Context) ([]*model1.DestinationType, error)
-	DestinationType(ctx context.Context, name string) (*model1.DestinationType, error)
-	Snapshot(ctx context.Context, agentID string, pipelineType otel.PipelineType, position *string, resourceName *string) (*model.Snapshot, error)
-	AgentMetrics(ctx context.Context, period string, ids []string) (*model.GraphMetrics, error)
-	ConfigurationMetrics(ctx context.Context, period string, name *string) (*model.GraphMetrics, error)
-	OverviewMetrics(ctx context.Context, period string, configIDs []string, destinationIDs []string) (*model.GraphMetrics, error)
+	OverviewPage(ctx context.Context, configIDs []string, destinationIDs []string, period string, telemetryType string) (*model1.OverviewPage, error)
+	Agents(ctx context.Context, selector *string, query *string) (*model1.Agents, error)
+	Agent(ctx context.Context, id string) (*model.Agent, error)
+	Configurations(ctx context.Context, selector *string, query *string, onlyDeployedConfigurations *bool) (*model1.Configurations, error)
+	Configuration(ctx context.Context, name string) (*model.Configuration, error)
+	ConfigurationHistory(ctx context.Context, name string) ([]*model.Configuration, error)
+	ResourceHistory(ctx context.Context, kind string, name string) ([]model.Resource, error)
+	Sources(ctx context.Context) ([]*model.Source, error)
+	Source(ctx context.Context, name string) (*model.Source, error)
+	SourceTypes(ctx context.Context) ([]*model.SourceType, error)
+	SourceType(ctx context.Context, name string) (*model.SourceType, error)
+	SourceWithType(ctx context.Context, name string) (*model1.SourceWithType, error)
+	Processors(ctx context.Context) ([]*model.Processor, error)
+	Processor(ctx context.Context, name string) (*model.Processor, error)
+	ProcessorWithType(ctx context.Context, name string) (*model1.ProcessorWithType, error)
+	ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error)
+	ProcessorType(ctx context.Context, name string) (*model.ProcessorType, error)
+	Destinations(ctx context.Context, query *string, filterUnused *bool) ([]*model.Destination, error)
+	Destination(ctx context.Context, name string) (*model.Destination, error)
+	DestinationWithType(ctx context.Context, name string) (*model1.DestinationWithType, error)
+	DestinationTypes(ctx context.Context) ([]*model.DestinationType, error)
+	DestinationType(ctx context.Context, name string) (*model.DestinationType, error)
+	Snapshot(ctx context.Context, agentID string, pipelineType otel.PipelineType, position *string, resourceName *string) (*model1.Snapshot, error)
+	AgentMetrics(ctx context.Context, period string, ids []string) (*model1.GraphMetrics, error)
+	ConfigurationMetrics(ctx context.Context, period string, name *string) (*model1.GraphMetrics, error)
+	OverviewMetrics(ctx context.Context, period string, configIDs []string, destinationIDs []string) (*model1.GraphMetrics, error)
 }
 type RelevantIfConditionResolver interface {
-	Operator(ctx context.Context, obj *model1.RelevantIfCondition) (model.RelevantIfOperatorType, error)
+	Operator(ctx context.Context, obj *model.RelevantIfCondition) (model1.RelevantIfOperatorType, error)
 }
 type RolloutResolver interface {
-	Completed(ctx context.Context, obj *model1.Rollout) (int, error)
-	Errors(ctx context.Context, obj *model1.Rollout) (int, error)
-	Pending(ctx context.Context, obj *model1.Rollout) (int, error)
-	Waiting(ctx context.Context, obj *model1.Rollout) (int, error)
+	Completed(ctx context.Context, obj *model.Rollout) (int, error)
+	Errors(ctx context.Context, obj *model.Rollout) (int, error)
+	Pending(ctx context.Context, obj *model.Rollout) (int, error)
+	Waiting(ctx context.Context, obj *model.Rollout) (int, error)
 }
 type SourceResolver interface {
-	Kind(ctx context.Context, obj *model1.Source) (string, error)
+	Kind(ctx context.Context, obj *model.Source) (string, error)
 }
 type SourceTypeResolver interface {
-	Kind(ctx context.Context, obj *model1.SourceType) (string, error)
+	Kind(ctx context.Context, obj *model.SourceType) (string, error)
 }
 type SubscriptionResolver interface {
-	AgentChanges(ctx context.Context, selector *string, query *string) (<-chan []*model.AgentChange, error)
-	ConfigurationChanges(ctx context.Context, selector *string, query *string) (<-chan []*model.ConfigurationChange, error)
-	AgentMetrics(ctx context.Context, period string, ids []string) (<-chan *model.GraphMetrics, error)
-	ConfigurationMetrics(ctx context.Context, period string, name *string, agent *string) (<-chan *model.GraphMetrics, error)
-	OverviewMetrics(ctx context.Context, period string, configIDs []string, destinationIDs []string) (<-chan *model.GraphMetrics, error)
+	AgentChanges(ctx context.Context, selector *string, query *string) (<-chan []*model1.AgentChange, error)
+	ConfigurationChanges(ctx context.Context, selector *string, query *string) (<-chan []*model1.ConfigurationChange, error)
+	AgentMetrics(ctx context.Context, period string, ids []string) (<-chan *model1.GraphMetrics, error)
+	ConfigurationMetrics(ctx context.Context, period string, name *string, agent *string) (<-chan *model1.GraphMetrics, error)
+	OverviewMetrics(ctx context.Context, period string, configIDs []string, destinationIDs []string) (<-chan *model1.GraphMetrics, error)
 }
 
 type executableSchema struct {
@@ -989,6 +1034,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConfigurationStatus.Pending(childComplexity), true
 
+	case "ConfigurationStatus.rollback":
+		if e.complexity.ConfigurationStatus.Rollback == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationStatus.Rollback(childComplexity), true
+
 	case "ConfigurationStatus.rollout":
 		if e.complexity.ConfigurationStatus.Rollout == nil {
 			break
@@ -1304,6 +1356,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Log.Timestamp(childComplexity), true
 
+	case "MaintenanceWindow.cron":
+		if e.complexity.MaintenanceWindow.Cron == nil {
+			break
+		}
+
+		return e.complexity.MaintenanceWindow.Cron(childComplexity), true
+
+	case "MaintenanceWindow.duration":
+		if e.complexity.MaintenanceWindow.Duration == nil {
+			break
+		}
+
+		return e.complexity.MaintenanceWindow.Duration(childComplexity), true
+
+	case "MaintenanceWindow.timezone":
+		if e.complexity.MaintenanceWindow.Timezone == nil {
+			break
+		}
+
+		return e.complexity.MaintenanceWindow.Timezone(childComplexity), true
+
 	case "Metadata.additionalInfo":
 		if e.complexity.Metadata.AdditionalInfo == nil {
 			break
@@ -1482,7 +1555,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.ClearAgentUpgradeError(childComplexity, args["input"].(model.ClearAgentUpgradeErrorInput)), true
+		return e.complexity.Mutation.ClearAgentUpgradeError(childComplexity, args["input"].(model1.ClearAgentUpgradeErrorInput)), true
 
 	case "Mutation.editConfigurationDescription":
 		if e.complexity.Mutation.EditConfigurationDescription == nil {
@@ -1494,7 +1567,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.EditConfigurationDescription(childComplexity, args["input"].(model.EditConfigurationDescriptionInput)), true
+		return e.complexity.Mutation.EditConfigurationDescription(childComplexity, args["input"].(model1.EditConfigurationDescriptionInput)), true
 
 	case "Mutation.removeAgentConfiguration":
 		if e.complexity.Mutation.RemoveAgentConfiguration == nil {
@@ -1506,7 +1579,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.RemoveAgentConfiguration(childComplexity, args["input"].(*model.RemoveAgentConfigurationInput)), true
+		return e.complexity.Mutation.RemoveAgentConfiguration(childComplexity, args["input"].(*model1.RemoveAgentConfigurationInput)), true
 
 	case "Mutation.updateProcessors":
 		if e.complexity.Mutation.UpdateProcessors == nil {
@@ -1518,7 +1591,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateProcessors(childComplexity, args["input"].(model.UpdateProcessorsInput)), true
+		return e.complexity.Mutation.UpdateProcessors(childComplexity, args["input"].(model1.UpdateProcessorsInput)), true
 
 	case "Node.attributes":
 		if e.complexity.Node.Attributes == nil {
@@ -2062,6 +2135,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Processors(childComplexity), true
 
+	case "Query.resourceHistory":
+		if e.complexity.Query.ResourceHistory == nil {
+			break
+		}
+
+		args, err := ec.field_Query_resourceHistory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ResourceHistory(childComplexity, args["kind"].(string), args["name"].(string)), true
+
 	case "Query.snapshot":
 		if e.complexity.Query.Snapshot == nil {
 			break
@@ -2194,6 +2279,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ResourceConfiguration.Type(childComplexity), true
 
+	case "ResourceConfiguration.version":
+		if e.complexity.ResourceConfiguration.Version == nil {
+			break
+		}
+
+		return e.complexity.ResourceConfiguration.Version(childComplexity), true
+
 	case "ResourceTypeSpec.parameters":
 		if e.complexity.ResourceTypeSpec.Parameters == nil {
 			break
@@ -2222,6 +2314,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ResourceTypeSpec.Version(childComplexity), true
 
+	case "Rollout.awaitingApproval":
+		if e.complexity.Rollout.AwaitingApproval == nil {
+			break
+		}
+
+		return e.complexity.Rollout.AwaitingApproval(childComplexity), true
+
+	case "Rollout.canary":
+		if e.complexity.Rollout.Canary == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Canary(childComplexity), true
+
 	case "Rollout.completed":
 		if e.complexity.Rollout.Completed == nil {
 			break
@@ -2236,6 +2342,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Rollout.Errors(childComplexity), true
 
+	case "Rollout.health":
+		if e.complexity.Rollout.Health == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Health(childComplexity), true
+
 	case "Rollout.options":
 		if e.complexity.Rollout.Options == nil {
 			break
@@ -2243,6 +2356,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Rollout.Options(childComplexity), true
 
+	case "Rollout.pausedBySchedule":
+		if e.complexity.Rollout.PausedBySchedule == nil {
+			break
+		}
+
+		return e.complexity.Rollout.PausedBySchedule(childComplexity), true
+
 	case "Rollout.pending":
 		if e.complexity.Rollout.Pending == nil {
 			break
@@ -2271,6 +2391,104 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Rollout.Waiting(childComplexity), true
 
+	case "RolloutCanary.agents":
+		if e.complexity.RolloutCanary.Agents == nil {
+			break
+		}
+
+		return e.complexity.RolloutCanary.Agents(childComplexity), true
+
+	case "RolloutCanary.approved":
+		if e.complexity.RolloutCanary.Approved == nil {
+			break
+		}
+
+		return e.complexity.RolloutCanary.Approved(childComplexity), true
+
+	case "RolloutCanary.awaitingApproval":
+		if e.complexity.RolloutCanary.AwaitingApproval == nil {
+			break
+		}
+
+		return e.complexity.RolloutCanary.AwaitingApproval(childComplexity), true
+
+	case "RolloutHealth.baseline":
+		if e.complexity.RolloutHealth.Baseline == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.Baseline(childComplexity), true
+
+	case "RolloutHealth.checkStarted":
+		if e.complexity.RolloutHealth.CheckStarted == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.CheckStarted(childComplexity), true
+
+	case "RolloutHealth.failure":
+		if e.complexity.RolloutHealth.Failure == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.Failure(childComplexity), true
+
+	case "RolloutHealth.passed":
+		if e.complexity.RolloutHealth.Passed == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.Passed(childComplexity), true
+
+	case "RolloutHealth.phase":
+		if e.complexity.RolloutHealth.Phase == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.Phase(childComplexity), true
+
+	case "RolloutHealth.throughput":
+		if e.complexity.RolloutHealth.Throughput == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealth.Throughput(childComplexity), true
+
+	case "RolloutHealthCheck.duration":
+		if e.complexity.RolloutHealthCheck.Duration == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealthCheck.Duration(childComplexity), true
+
+	case "RolloutHealthCheck.minThroughputPercent":
+		if e.complexity.RolloutHealthCheck.MinThroughputPercent == nil {
+			break
+		}
+
+		return e.complexity.RolloutHealthCheck.MinThroughputPercent(childComplexity), true
+
+	case "RolloutOptions.canarySelector":
+		if e.complexity.RolloutOptions.CanarySelector == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.CanarySelector(childComplexity), true
+
+	case "RolloutOptions.healthCheck":
+		if e.complexity.RolloutOptions.HealthCheck == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.HealthCheck(childComplexity), true
+
+	case "RolloutOptions.maintenanceWindow":
+		if e.complexity.RolloutOptions.MaintenanceWindow == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.MaintenanceWindow(childComplexity), true
+
 	case "RolloutOptions.maxErrors":
 		if e.complexity.RolloutOptions.MaxErrors == nil {
 			break
@@ -2292,6 +2510,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RolloutOptions.RollbackOnFailure(childComplexity), true
 
+	case "RolloutOptions.startAt":
+		if e.complexity.RolloutOptions.StartAt == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.StartAt(childComplexity), true
+
 	case "RolloutOptions.startAutomatically":
 		if e.complexity.RolloutOptions.StartAutomatically == nil {
 			break
@@ -2299,6 +2524,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RolloutOptions.StartAutomatically(childComplexity), true
 
+	case "RolloutRollback.agents":
+		if e.complexity.RolloutRollback.Agents == nil {
+			break
+		}
+
+		return e.complexity.RolloutRollback.Agents(childComplexity), true
+
+	case "RolloutRollback.date":
+		if e.complexity.RolloutRollback.Date == nil {
+			break
+		}
+
+		return e.complexity.RolloutRollback.Date(childComplexity), true
+
+	case "RolloutRollback.version":
+		if e.complexity.RolloutRollback.Version == nil {
+			break
+		}
+
+		return e.complexity.RolloutRollback.Version(childComplexity), true
+
 	case "Snapshot.logs":
 		if e.complexity.Snapshot.Logs == nil {
 			break
@@ -2725,6 +2971,7 @@ type Configuration {
 type ConfigurationStatus {
   rollout: Rollout!
   currentVersion: Version!
+  rollback: RolloutRollback
 
   current: Boolean!
   pending: Boolean!
@@ -2739,6 +2986,10 @@ type Rollout {
   errors: Int!
   pending: Int!
   waiting: Int!
+  pausedBySchedule: Boolean!
+  health: RolloutHealth
+  canary: RolloutCanary
+  awaitingApproval: Boolean!
 }
 
 scalar RolloutStatus
@@ -2748,6 +2999,42 @@ type RolloutOptions {
   rollbackOnFailure: Boolean!
   maxErrors: Int!
   phaseAgentCount: PhaseAgentCount
+  startAt: Time
+  maintenanceWindow: MaintenanceWindow
+  healthCheck: RolloutHealthCheck
+  canarySelector: AgentSelector
+}
+
+type MaintenanceWindow {
+  cron: String!
+  duration: String!
+  timezone: String!
+}
+
+type RolloutHealthCheck {
+  minThroughputPercent: Float!
+  duration: String!
+}
+
+type RolloutHealth {
+  baseline: Float
+  throughput: Float
+  phase: Int!
+  checkStarted: Time
+  passed: Boolean!
+  failure: String!
+}
+
+type RolloutCanary {
+  agents: Int!
+  awaitingApproval: Boolean!
+  approved: Boolean!
+}
+
+type RolloutRollback {
+  version: Version!
+  agents: Int!
+  date: Time!
 }
 
 type PhaseAgentCount {
@@ -2769,6 +3056,7 @@ type ResourceConfiguration {
   id: String
   name: String
   displayName: String
+  version: Version
   type: String
   parameters: [Parameter!]
   processors: [ResourceConfiguration!]
@@ -3014,6 +3302,8 @@ type DestinationWithType {
   destinationType: DestinationType
 }
 
+union VersionedResource = Source | Processor | Destination
+
 type ParameterizedSpec {
   type: String!
   parameters: [Parameter!]
@@ -3107,55 +3397,58 @@ type Query {
     destinationIDs: [ID!]
     period: String!
     telemetryType: String!
-  ): OverviewPage!
+  ): OverviewPage! @hasRole(role: viewer)
 
-  agents(selector: String, query: String): Agents!
-  agent(id: ID!): Agent
+  agents(selector: String, query: String): Agents! @hasRole(role: viewer)
+  agent(id: ID!): Agent @hasRole(role: viewer)
 
   configurations(
     selector: String
     query: String
     onlyDeployedConfigurations: Boolean
-  ): Configurations!
-  configuration(name: String!): Configuration
+  ): Configurations! @hasRole(role: viewer)
+  configuration(name: String!): Configuration @hasRole(role: viewer)
 
-  configurationHistory(name: String!): [Configuration!]!
+  configurationHistory(name: String!): [Configuration!]! @hasRole(role: viewer)
 
-  sources: [Source!]!
-  source(name: String!): Source
+  # all versions of a Source, Processor, or Destination, newest version first
+  resourceHistory(kind: String!, name: String!): [VersionedResource!]! @hasRole(role: viewer)
 
-  sourceTypes: [SourceType!]!
-  sourceType(name: String!): SourceType
-  sourceWithType(name: String!): SourceWithType!
+  sources: [Source!]! @hasRole(role: viewer)
+  source(name: String!): Source @hasRole(role: viewer)
 
-  processors: [Processor!]!
-  processor(name: String!): Processor
-  processorWithType(name: String!): ProcessorWithType!
+  sourceTypes: [SourceType!]! @hasRole(role: viewer)
+  sourceType(name: String!): SourceType @hasRole(role: viewer)
+  sourceWithType(name: String!): SourceWithType! @hasRole(role: viewer)
 
-  processorTypes: [ProcessorType!]!
-  processorType(name: String!): ProcessorType
+  processors: [Processor!]! @hasRole(role: viewer)
+  processor(name: String!): Processor @hasRole(role: viewer)
+  processorWithType(name: String!): ProcessorWithType! @hasRole(role: viewer)
 
-  destinations(query: String, filterUnused: Boolean): [Destination!]!
-  destination(name: String!): Destination
-  destinationWithType(name: String!): DestinationWithType!
+  processorTypes: [ProcessorType!]! @hasRole(role: viewer)
+  processorType(name: String!): ProcessorType @hasRole(role: viewer)
 
-  destinationTypes: [DestinationType!]!
-  destinationType(name: String!): DestinationType
+  destinations(query: String, filterUnused: Boolean): [Destination!]! @hasRole(role: viewer)
+  destination(name: String!): Destination @hasRole(role: viewer)
+  destinationWithType(name: String!): DestinationWithType! @hasRole(role: viewer)
+
+  destinationTypes: [DestinationType!]! @hasRole(role: viewer)
+  destinationType(name: String!): DestinationType @hasRole(role: viewer)
 
   snapshot(
     agentID: String!
     pipelineType: PipelineType!
     position: String
     resourceName: String
-  ): Snapshot!
+  ): Snapshot! @hasRole(role: viewer)
 
-  agentMetrics(period: String!, ids: [ID!]): GraphMetrics!
-  configurationMetrics(period: String!, name: String): GraphMetrics!
+  agentMetrics(period: String!, ids: [ID!]): GraphMetrics! @hasRole(role: viewer)
+  configurationMetrics(period: String!, name: String): GraphMetrics! @hasRole(role: viewer)
   overviewMetrics(
     period: String!
     configIDs: [ID!]
     destinationIDs: [ID!]
-  ): GraphMetrics!
+  ): GraphMetrics! @hasRole(role: viewer)
 }
 
 # ----------------------------------------------------------------------
@@ -3167,24 +3460,27 @@ enum Role {
   viewer
 }
 
+# hasRole requires the authenticated user to have at least the specified role
+directive @hasRole(role: Role!) on FIELD_DEFINITION
+
 # ----------------------------------------------------------------------
 # subscriptions
 
 type Subscription {
-  agentChanges(selector: String, query: String): [AgentChange!]!
-  configurationChanges(selector: String, query: String): [ConfigurationChange!]!
+  agentChanges(selector: String, query: String): [AgentChange!]! @hasRole(role: viewer)
+  configurationChanges(selector: String, query: String): [ConfigurationChange!]! @hasRole(role: viewer)
 
-  agentMetrics(period: String!, ids: [ID!]): GraphMetrics!
+  agentMetrics(period: String!, ids: [ID!]): GraphMetrics! @hasRole(role: viewer)
   configurationMetrics(
     period: String!
     name: String
     agent: String
-  ): GraphMetrics!
+  ): GraphMetrics! @hasRole(role: viewer)
   overviewMetrics(
     period: String!
     configIDs: [ID!]
     destinationIDs: [ID!]
-  ): GraphMetrics!
+  ): GraphMetrics! @hasRole(role: viewer)
 }
 
 # ----------------------------------------------------------------------
@@ -3204,6 +3500,7 @@ input ProcessorInput {
   id: String
   name: String
   displayName: String
+  version: Version
   type: String
   parameters: [ParameterInput!]
   disabled: Boolean
@@ -3230,13 +3527,15 @@ input EditConfigurationDescriptionInput {
 }
 
 type Mutation {
-  updateProcessors(input: UpdateProcessorsInput!): Boolean
+  updateProcessors(input: UpdateProcessorsInput!): Boolean @hasRole(role: user)
   removeAgentConfiguration(input: RemoveAgentConfigurationInput): Agent
+    @hasRole(role: user)
   clearAgentUpgradeError(input: ClearAgentUpgradeErrorInput!): Boolean
+    @hasRole(role: user)
 
   editConfigurationDescription(
     input: EditConfigurationDescriptionInput!
-  ): Boolean
+  ): Boolean @hasRole(role: user)
 }
 `, BuiltIn: false},
 }
@@ -3246,10 +3545,25 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
+func (ec *executionContext) dir_hasRole_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.Role
+	if tmp, ok := rawArgs["role"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("role"))
+		arg0, err = ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["role"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_clearAgentUpgradeError_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.ClearAgentUpgradeErrorInput
+	var arg0 model1.ClearAgentUpgradeErrorInput
 	if tmp, ok := rawArgs["input"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 		arg0, err = ec.unmarshalNClearAgentUpgradeErrorInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐClearAgentUpgradeErrorInput(ctx, tmp)
@@ -3264,7 +3578,7 @@ func (ec *executionContext) field_Mutation_clearAgentUpgradeError_args(ctx conte
 func (ec *executionContext) field_Mutation_editConfigurationDescription_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.EditConfigurationDescriptionInput
+	var arg0 model1.EditConfigurationDescriptionInput
 	if tmp, ok := rawArgs["input"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 		arg0, err = ec.unmarshalNEditConfigurationDescriptionInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEditConfigurationDescriptionInput(ctx, tmp)
@@ -3279,7 +3593,7 @@ func (ec *executionContext) field_Mutation_editConfigurationDescription_args(ctx
 func (ec *executionContext) field_Mutation_removeAgentConfiguration_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 *model.RemoveAgentConfigurationInput
+	var arg0 *model1.RemoveAgentConfigurationInput
 	if tmp, ok := rawArgs["input"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 		arg0, err = ec.unmarshalORemoveAgentConfigurationInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRemoveAgentConfigurationInput(ctx, tmp)
@@ -3294,7 +3608,7 @@ func (ec *executionContext) field_Mutation_removeAgentConfiguration_args(ctx con
 func (ec *executionContext) field_Mutation_updateProcessors_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.UpdateProcessorsInput
+	var arg0 model1.UpdateProcessorsInput
 	if tmp, ok := rawArgs["input"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
 		arg0, err = ec.unmarshalNUpdateProcessorsInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐUpdateProcessorsInput(ctx, tmp)
@@ -3660,6 +3974,30 @@ func (ec *executionContext) field_Query_processor_args(ctx context.Context, rawA
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_resourceHistory_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["kind"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("kind"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["kind"] = arg0
+	var arg1 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg1, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg1
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_snapshot_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -3923,7 +4261,7 @@ func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArg
 
 // region    **************************** field.gotpl *****************************
 
-func (ec *executionContext) _AdditionalInfo_message(ctx context.Context, field graphql.CollectedField, obj *model1.AdditionalInfo) (ret graphql.Marshaler) {
+func (ec *executionContext) _AdditionalInfo_message(ctx context.Context, field graphql.CollectedField, obj *model.AdditionalInfo) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AdditionalInfo_message(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -3967,7 +4305,7 @@ func (ec *executionContext) fieldContext_AdditionalInfo_message(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _AdditionalInfo_documentation(ctx context.Context, field graphql.CollectedField, obj *model1.AdditionalInfo) (ret graphql.Marshaler) {
+func (ec *executionContext) _AdditionalInfo_documentation(ctx context.Context, field graphql.CollectedField, obj *model.AdditionalInfo) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AdditionalInfo_documentation(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -3993,7 +4331,7 @@ func (ec *executionContext) _AdditionalInfo_documentation(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.DocumentationLink)
+	res := resTmp.([]model.DocumentationLink)
 	fc.Result = res
 	return ec.marshalNDocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx, field.Selections, res)
 }
@@ -4017,7 +4355,7 @@ func (ec *executionContext) fieldContext_AdditionalInfo_documentation(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_id(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_id(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_id(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4061,7 +4399,7 @@ func (ec *executionContext) fieldContext_Agent_id(ctx context.Context, field gra
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_architecture(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_architecture(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_architecture(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4102,7 +4440,7 @@ func (ec *executionContext) fieldContext_Agent_architecture(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_hostName(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_hostName(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_hostName(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4143,7 +4481,7 @@ func (ec *executionContext) fieldContext_Agent_hostName(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_labels(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_labels(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4184,7 +4522,7 @@ func (ec *executionContext) fieldContext_Agent_labels(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_platform(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4225,7 +4563,7 @@ func (ec *executionContext) fieldContext_Agent_platform(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_operatingSystem(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4266,7 +4604,7 @@ func (ec *executionContext) fieldContext_Agent_operatingSystem(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_version(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4307,7 +4645,7 @@ func (ec *executionContext) fieldContext_Agent_version(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_name(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_name(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4351,7 +4689,7 @@ func (ec *executionContext) fieldContext_Agent_name(ctx context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_home(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4392,7 +4730,7 @@ func (ec *executionContext) fieldContext_Agent_home(ctx context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_macAddress(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4433,7 +4771,7 @@ func (ec *executionContext) fieldContext_Agent_macAddress(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_remoteAddress(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4474,7 +4812,7 @@ func (ec *executionContext) fieldContext_Agent_remoteAddress(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_type(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4515,7 +4853,7 @@ func (ec *executionContext) fieldContext_Agent_type(ctx context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_status(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_status(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_status(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4559,7 +4897,7 @@ func (ec *executionContext) fieldContext_Agent_status(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_errorMessage(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4600,7 +4938,7 @@ func (ec *executionContext) fieldContext_Agent_errorMessage(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_connectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_connectedAt(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_connectedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4641,7 +4979,7 @@ func (ec *executionContext) fieldContext_Agent_connectedAt(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_disconnectedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4682,7 +5020,7 @@ func (ec *executionContext) fieldContext_Agent_disconnectedAt(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_configuration(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_configuration(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_configuration(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4705,7 +5043,7 @@ func (ec *executionContext) _Agent_configuration(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.AgentConfiguration)
+	res := resTmp.(*model1.AgentConfiguration)
 	fc.Result = res
 	return ec.marshalOAgentConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentConfiguration(ctx, field.Selections, res)
 }
@@ -4731,7 +5069,7 @@ func (ec *executionContext) fieldContext_Agent_configuration(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_configurationResource(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_configurationResource(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_configurationResource(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4754,7 +5092,7 @@ func (ec *executionContext) _Agent_configurationResource(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(*model.Configuration)
 	fc.Result = res
 	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
 }
@@ -4792,7 +5130,7 @@ func (ec *executionContext) fieldContext_Agent_configurationResource(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_upgrade(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4815,7 +5153,7 @@ func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.AgentUpgrade)
+	res := resTmp.(*model.AgentUpgrade)
 	fc.Result = res
 	return ec.marshalOAgentUpgrade2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentUpgrade(ctx, field.Selections, res)
 }
@@ -4841,7 +5179,7 @@ func (ec *executionContext) fieldContext_Agent_upgrade(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_upgradeAvailable(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4882,7 +5220,7 @@ func (ec *executionContext) fieldContext_Agent_upgradeAvailable(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.CollectedField, obj *model.Agent) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agent_features(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4926,7 +5264,7 @@ func (ec *executionContext) fieldContext_Agent_features(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphql.CollectedField, obj *model1.AgentChange) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentChange_agent(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -4952,7 +5290,7 @@ func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Agent)
+	res := resTmp.(*model.Agent)
 	fc.Result = res
 	return ec.marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
 }
@@ -5014,7 +5352,7 @@ func (ec *executionContext) fieldContext_AgentChange_agent(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field graphql.CollectedField, obj *model1.AgentChange) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentChange_changeType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5040,7 +5378,7 @@ func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.AgentChangeType)
+	res := resTmp.(model1.AgentChangeType)
 	fc.Result = res
 	return ec.marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeType(ctx, field.Selections, res)
 }
@@ -5058,7 +5396,7 @@ func (ec *executionContext) fieldContext_AgentChange_changeType(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField, obj *model1.AgentConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentConfiguration_Collector(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5099,7 +5437,7 @@ func (ec *executionContext) fieldContext_AgentConfiguration_Collector(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField, obj *model1.AgentConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentConfiguration_Logging(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5140,7 +5478,7 @@ func (ec *executionContext) fieldContext_AgentConfiguration_Logging(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField, obj *model1.AgentConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentConfiguration_Manager(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5181,7 +5519,7 @@ func (ec *executionContext) fieldContext_AgentConfiguration_Manager(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField, obj *model1.AgentSelector) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField, obj *model.AgentSelector) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentSelector_matchLabels(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5222,7 +5560,7 @@ func (ec *executionContext) fieldContext_AgentSelector_matchLabels(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field graphql.CollectedField, obj *model.AgentUpgrade) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentUpgrade_status(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5266,7 +5604,7 @@ func (ec *executionContext) fieldContext_AgentUpgrade_status(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field graphql.CollectedField, obj *model.AgentUpgrade) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentUpgrade_version(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5310,7 +5648,7 @@ func (ec *executionContext) fieldContext_AgentUpgrade_version(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_error(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+func (ec *executionContext) _AgentUpgrade_error(ctx context.Context, field graphql.CollectedField, obj *model.AgentUpgrade) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_AgentUpgrade_error(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5351,7 +5689,7 @@ func (ec *executionContext) fieldContext_AgentUpgrade_error(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_query(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agents_query(ctx context.Context, field graphql.CollectedField, obj *model1.Agents) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agents_query(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5392,7 +5730,7 @@ func (ec *executionContext) fieldContext_Agents_query(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.CollectedField, obj *model1.Agents) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agents_agents(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5418,7 +5756,7 @@ func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Agent)
+	res := resTmp.([]*model.Agent)
 	fc.Result = res
 	return ec.marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx, field.Selections, res)
 }
@@ -5480,7 +5818,7 @@ func (ec *executionContext) fieldContext_Agents_agents(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agents_suggestions(ctx context.Context, field graphql.CollectedField, obj *model1.Agents) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agents_suggestions(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5527,7 +5865,7 @@ func (ec *executionContext) fieldContext_Agents_suggestions(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Agents) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Agents_latestVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5571,7 +5909,7 @@ func (ec *executionContext) fieldContext_Agents_latestVersion(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5615,7 +5953,7 @@ func (ec *executionContext) fieldContext_Configuration_apiVersion(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_kind(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5659,7 +5997,7 @@ func (ec *executionContext) fieldContext_Configuration_kind(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_metadata(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5685,7 +6023,7 @@ func (ec *executionContext) _Configuration_metadata(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -5725,7 +6063,7 @@ func (ec *executionContext) fieldContext_Configuration_metadata(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_spec(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5751,7 +6089,7 @@ func (ec *executionContext) _Configuration_spec(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ConfigurationSpec)
+	res := resTmp.(model.ConfigurationSpec)
 	fc.Result = res
 	return ec.marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx, field.Selections, res)
 }
@@ -5783,7 +6121,7 @@ func (ec *executionContext) fieldContext_Configuration_spec(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_agentCount(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_agentCount(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_agentCount(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5824,7 +6162,7 @@ func (ec *executionContext) fieldContext_Configuration_agentCount(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_activeTypes(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_activeTypes(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_activeTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5865,7 +6203,7 @@ func (ec *executionContext) fieldContext_Configuration_activeTypes(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_graph(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_graph(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_graph(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5918,7 +6256,7 @@ func (ec *executionContext) fieldContext_Configuration_graph(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_rendered(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_rendered(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_rendered(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5959,7 +6297,7 @@ func (ec *executionContext) fieldContext_Configuration_rendered(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_status(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configuration_status(ctx context.Context, field graphql.CollectedField, obj *model.Configuration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configuration_status(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -5985,7 +6323,7 @@ func (ec *executionContext) _Configuration_status(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ConfigurationStatus)
+	res := resTmp.(model.ConfigurationStatus)
 	fc.Result = res
 	return ec.marshalNConfigurationStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationStatus(ctx, field.Selections, res)
 }
@@ -6002,6 +6340,8 @@ func (ec *executionContext) fieldContext_Configuration_status(ctx context.Contex
 				return ec.fieldContext_ConfigurationStatus_rollout(ctx, field)
 			case "currentVersion":
 				return ec.fieldContext_ConfigurationStatus_currentVersion(ctx, field)
+			case "rollback":
+				return ec.fieldContext_ConfigurationStatus_rollback(ctx, field)
 			case "current":
 				return ec.fieldContext_ConfigurationStatus_current(ctx, field)
 			case "pending":
@@ -6015,7 +6355,7 @@ func (ec *executionContext) fieldContext_Configuration_status(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationChange) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationChange_configuration(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6041,7 +6381,7 @@ func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Conte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(*model.Configuration)
 	fc.Result = res
 	return ec.marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
 }
@@ -6079,7 +6419,7 @@ func (ec *executionContext) fieldContext_ConfigurationChange_configuration(ctx c
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationChange) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationChange_eventType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6105,7 +6445,7 @@ func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.EventType)
+	res := resTmp.(model1.EventType)
 	fc.Result = res
 	return ec.marshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEventType(ctx, field.Selections, res)
 }
@@ -6123,7 +6463,7 @@ func (ec *executionContext) fieldContext_ConfigurationChange_eventType(ctx conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_measurementInterval(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_measurementInterval(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_measurementInterval(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6164,7 +6504,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_measurementInterval(c
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_contentType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6205,7 +6545,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_contentType(ctx conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_raw(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6246,7 +6586,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_raw(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_sources(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6269,7 +6609,7 @@ func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.([]model.ResourceConfiguration)
 	fc.Result = res
 	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -6288,6 +6628,8 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_sources(ctx context.C
 				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
 			case "displayName":
 				return ec.fieldContext_ResourceConfiguration_displayName(ctx, field)
+			case "version":
+				return ec.fieldContext_ResourceConfiguration_version(ctx, field)
 			case "type":
 				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
 			case "parameters":
@@ -6303,7 +6645,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_sources(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_destinations(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6326,7 +6668,7 @@ func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.([]model.ResourceConfiguration)
 	fc.Result = res
 	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -6345,6 +6687,8 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_destinations(ctx cont
 				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
 			case "displayName":
 				return ec.fieldContext_ResourceConfiguration_displayName(ctx, field)
+			case "version":
+				return ec.fieldContext_ResourceConfiguration_version(ctx, field)
 			case "type":
 				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
 			case "parameters":
@@ -6360,7 +6704,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_destinations(ctx cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationSpec_selector(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6383,7 +6727,7 @@ func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(model1.AgentSelector)
+	res := resTmp.(model.AgentSelector)
 	fc.Result = res
 	return ec.marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx, field.Selections, res)
 }
@@ -6405,7 +6749,7 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_selector(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationStatus_rollout(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationStatus_rollout(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationStatus_rollout(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6431,7 +6775,7 @@ func (ec *executionContext) _ConfigurationStatus_rollout(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Rollout)
+	res := resTmp.(model.Rollout)
 	fc.Result = res
 	return ec.marshalNRollout2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
 }
@@ -6458,6 +6802,14 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_rollout(ctx context
 				return ec.fieldContext_Rollout_pending(ctx, field)
 			case "waiting":
 				return ec.fieldContext_Rollout_waiting(ctx, field)
+			case "pausedBySchedule":
+				return ec.fieldContext_Rollout_pausedBySchedule(ctx, field)
+			case "health":
+				return ec.fieldContext_Rollout_health(ctx, field)
+			case "canary":
+				return ec.fieldContext_Rollout_canary(ctx, field)
+			case "awaitingApproval":
+				return ec.fieldContext_Rollout_awaitingApproval(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
@@ -6465,7 +6817,7 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_rollout(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationStatus_currentVersion(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationStatus_currentVersion(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationStatus_currentVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6491,7 +6843,7 @@ func (ec *executionContext) _ConfigurationStatus_currentVersion(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Version)
+	res := resTmp.(model.Version)
 	fc.Result = res
 	return ec.marshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx, field.Selections, res)
 }
@@ -6509,7 +6861,56 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_currentVersion(ctx
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationStatus_current(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationStatus_rollback(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationStatus_rollback(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Rollback, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.RolloutRollback)
+	fc.Result = res
+	return ec.marshalORolloutRollback2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutRollback(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ConfigurationStatus_rollback(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ConfigurationStatus",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "version":
+				return ec.fieldContext_RolloutRollback_version(ctx, field)
+			case "agents":
+				return ec.fieldContext_RolloutRollback_agents(ctx, field)
+			case "date":
+				return ec.fieldContext_RolloutRollback_date(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RolloutRollback", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ConfigurationStatus_current(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationStatus_current(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6553,7 +6954,7 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_current(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationStatus_pending(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationStatus_pending(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationStatus_pending(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6597,7 +6998,7 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_pending(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationStatus_latest(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _ConfigurationStatus_latest(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationStatus) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ConfigurationStatus_latest(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6641,7 +7042,7 @@ func (ec *executionContext) fieldContext_ConfigurationStatus_latest(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_query(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configurations_query(ctx context.Context, field graphql.CollectedField, obj *model1.Configurations) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configurations_query(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6682,7 +7083,7 @@ func (ec *executionContext) fieldContext_Configurations_query(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_configurations(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configurations_configurations(ctx context.Context, field graphql.CollectedField, obj *model1.Configurations) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configurations_configurations(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6708,7 +7109,7 @@ func (ec *executionContext) _Configurations_configurations(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Configuration)
+	res := resTmp.([]*model.Configuration)
 	fc.Result = res
 	return ec.marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -6746,7 +7147,7 @@ func (ec *executionContext) fieldContext_Configurations_configurations(ctx conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Configurations_suggestions(ctx context.Context, field graphql.CollectedField, obj *model1.Configurations) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Configurations_suggestions(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6793,7 +7194,7 @@ func (ec *executionContext) fieldContext_Configurations_suggestions(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.Destination) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Destination_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6837,7 +7238,7 @@ func (ec *executionContext) fieldContext_Destination_apiVersion(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql.CollectedField, obj *model.Destination) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Destination_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6881,7 +7282,7 @@ func (ec *executionContext) fieldContext_Destination_kind(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+func (ec *executionContext) _Destination_metadata(ctx context.Context, field graphql.CollectedField, obj *model.Destination) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Destination_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6907,7 +7308,7 @@ func (ec *executionContext) _Destination_metadata(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -6947,7 +7348,7 @@ func (ec *executionContext) fieldContext_Destination_metadata(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql.CollectedField, obj *model.Destination) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Destination_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -6973,7 +7374,7 @@ func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterizedSpec)
+	res := resTmp.(model.ParameterizedSpec)
 	fc.Result = res
 	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
 }
@@ -7001,7 +7402,7 @@ func (ec *executionContext) fieldContext_Destination_spec(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.DestinationType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7045,7 +7446,7 @@ func (ec *executionContext) fieldContext_DestinationType_apiVersion(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field graphql.CollectedField, obj *model.DestinationType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7071,7 +7472,7 @@ func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -7111,7 +7512,7 @@ func (ec *executionContext) fieldContext_DestinationType_metadata(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationType_kind(ctx context.Context, field graphql.CollectedField, obj *model.DestinationType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7155,7 +7556,7 @@ func (ec *executionContext) fieldContext_DestinationType_kind(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationType_spec(ctx context.Context, field graphql.CollectedField, obj *model.DestinationType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationType_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7181,7 +7582,7 @@ func (ec *executionContext) _DestinationType_spec(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ResourceTypeSpec)
+	res := resTmp.(model.ResourceTypeSpec)
 	fc.Result = res
 	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
 }
@@ -7209,7 +7610,7 @@ func (ec *executionContext) fieldContext_DestinationType_spec(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationWithType_destination(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationWithType_destination(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationWithType_destination(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7232,7 +7633,7 @@ func (ec *executionContext) _DestinationWithType_destination(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Destination)
+	res := resTmp.(*model.Destination)
 	fc.Result = res
 	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
 }
@@ -7260,7 +7661,7 @@ func (ec *executionContext) fieldContext_DestinationWithType_destination(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DestinationWithType_destinationType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7283,7 +7684,7 @@ func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Con
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.DestinationType)
+	res := resTmp.(*model.DestinationType)
 	fc.Result = res
 	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
 }
@@ -7311,7 +7712,7 @@ func (ec *executionContext) fieldContext_DestinationWithType_destinationType(ctx
 	return fc, nil
 }
 
-func (ec *executionContext) _DocumentationLink_text(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
+func (ec *executionContext) _DocumentationLink_text(ctx context.Context, field graphql.CollectedField, obj *model.DocumentationLink) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DocumentationLink_text(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7355,7 +7756,7 @@ func (ec *executionContext) fieldContext_DocumentationLink_text(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _DocumentationLink_url(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
+func (ec *executionContext) _DocumentationLink_url(ctx context.Context, field graphql.CollectedField, obj *model.DocumentationLink) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_DocumentationLink_url(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7531,7 +7932,7 @@ func (ec *executionContext) fieldContext_Edge_target(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _EdgeMetric_name(ctx context.Context, field graphql.CollectedField, obj *model.EdgeMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeMetric_name(ctx context.Context, field graphql.CollectedField, obj *model1.EdgeMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_EdgeMetric_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7575,7 +7976,7 @@ func (ec *executionContext) fieldContext_EdgeMetric_name(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _EdgeMetric_edgeID(ctx context.Context, field graphql.CollectedField, obj *model.EdgeMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeMetric_edgeID(ctx context.Context, field graphql.CollectedField, obj *model1.EdgeMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_EdgeMetric_edgeID(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7619,7 +8020,7 @@ func (ec *executionContext) fieldContext_EdgeMetric_edgeID(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _EdgeMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model.EdgeMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model1.EdgeMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_EdgeMetric_pipelineType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7663,7 +8064,7 @@ func (ec *executionContext) fieldContext_EdgeMetric_pipelineType(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _EdgeMetric_value(ctx context.Context, field graphql.CollectedField, obj *model.EdgeMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeMetric_value(ctx context.Context, field graphql.CollectedField, obj *model1.EdgeMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_EdgeMetric_value(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -7707,7 +8108,7 @@ func (ec *executionContext) fieldContext_EdgeMetric_value(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _EdgeMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model.EdgeMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _EdgeMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model1.EdgeMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_EdgeMetric_unit(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8009,7 +8410,7 @@ func (ec *executionContext) fieldContext_Graph_attributes(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_name(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_name(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8053,7 +8454,7 @@ func (ec *executionContext) fieldContext_GraphMetric_name(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_nodeID(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8097,7 +8498,7 @@ func (ec *executionContext) fieldContext_GraphMetric_nodeID(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_pipelineType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8141,7 +8542,7 @@ func (ec *executionContext) fieldContext_GraphMetric_pipelineType(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_value(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8185,7 +8586,7 @@ func (ec *executionContext) fieldContext_GraphMetric_value(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_unit(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8229,7 +8630,7 @@ func (ec *executionContext) fieldContext_GraphMetric_unit(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_agentID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetric_agentID(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetric) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetric_agentID(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8270,7 +8671,7 @@ func (ec *executionContext) fieldContext_GraphMetric_agentID(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetrics) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetrics_metrics(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8296,7 +8697,7 @@ func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.GraphMetric)
+	res := resTmp.([]*model1.GraphMetric)
 	fc.Result = res
 	return ec.marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetricᚄ(ctx, field.Selections, res)
 }
@@ -8328,7 +8729,7 @@ func (ec *executionContext) fieldContext_GraphMetrics_metrics(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_maxMetricValue(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetrics_maxMetricValue(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetrics) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetrics_maxMetricValue(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8372,7 +8773,7 @@ func (ec *executionContext) fieldContext_GraphMetrics_maxMetricValue(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_maxLogValue(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetrics_maxLogValue(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetrics) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetrics_maxLogValue(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8416,7 +8817,7 @@ func (ec *executionContext) fieldContext_GraphMetrics_maxLogValue(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_maxTraceValue(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetrics_maxTraceValue(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetrics) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetrics_maxTraceValue(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8460,7 +8861,7 @@ func (ec *executionContext) fieldContext_GraphMetrics_maxTraceValue(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_edgeMetrics(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+func (ec *executionContext) _GraphMetrics_edgeMetrics(ctx context.Context, field graphql.CollectedField, obj *model1.GraphMetrics) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_GraphMetrics_edgeMetrics(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8486,7 +8887,7 @@ func (ec *executionContext) _GraphMetrics_edgeMetrics(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.EdgeMetric)
+	res := resTmp.([]*model1.EdgeMetric)
 	fc.Result = res
 	return ec.marshalNEdgeMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEdgeMetricᚄ(ctx, field.Selections, res)
 }
@@ -8721,8 +9122,8 @@ func (ec *executionContext) fieldContext_Log_resource(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_id(ctx, field)
+func (ec *executionContext) _MaintenanceWindow_cron(ctx context.Context, field graphql.CollectedField, obj *model.MaintenanceWindow) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MaintenanceWindow_cron(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8735,7 +9136,7 @@ func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Cron, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8749,24 +9150,24 @@ func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.Coll
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MaintenanceWindow_cron(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "MaintenanceWindow",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_name(ctx, field)
+func (ec *executionContext) _MaintenanceWindow_duration(ctx context.Context, field graphql.CollectedField, obj *model.MaintenanceWindow) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MaintenanceWindow_duration(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8779,7 +9180,7 @@ func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Duration, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8796,9 +9197,9 @@ func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.Co
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MaintenanceWindow_duration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "MaintenanceWindow",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8809,8 +9210,8 @@ func (ec *executionContext) fieldContext_Metadata_name(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_displayName(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_displayName(ctx, field)
+func (ec *executionContext) _MaintenanceWindow_timezone(ctx context.Context, field graphql.CollectedField, obj *model.MaintenanceWindow) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MaintenanceWindow_timezone(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8823,23 +9224,26 @@ func (ec *executionContext) _Metadata_displayName(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DisplayName, nil
+		return obj.Timezone, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MaintenanceWindow_timezone(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "MaintenanceWindow",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8850,8 +9254,8 @@ func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_description(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_description(ctx, field)
+func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8864,35 +9268,38 @@ func (ec *executionContext) _Metadata_description(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Metadata",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_icon(ctx, field)
+func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8905,21 +9312,24 @@ func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Icon, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Metadata",
 		Field:      field,
@@ -8932,8 +9342,131 @@ func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_labels(ctx, field)
+func (ec *executionContext) _Metadata_displayName(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_displayName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DisplayName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Metadata",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Metadata_description(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_description(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Metadata_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Metadata",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_icon(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Icon, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Metadata",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Metadata_labels(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_labels(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8973,7 +9506,7 @@ func (ec *executionContext) fieldContext_Metadata_labels(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_version(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+func (ec *executionContext) _Metadata_version(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Metadata_version(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -8999,7 +9532,7 @@ func (ec *executionContext) _Metadata_version(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Version)
+	res := resTmp.(model.Version)
 	fc.Result = res
 	return ec.marshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx, field.Selections, res)
 }
@@ -9017,7 +9550,7 @@ func (ec *executionContext) fieldContext_Metadata_version(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_dateModified(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+func (ec *executionContext) _Metadata_dateModified(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Metadata_dateModified(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9058,7 +9591,7 @@ func (ec *executionContext) fieldContext_Metadata_dateModified(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_additionalInfo(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+func (ec *executionContext) _Metadata_additionalInfo(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Metadata_additionalInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9081,7 +9614,7 @@ func (ec *executionContext) _Metadata_additionalInfo(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.AdditionalInfo)
+	res := resTmp.(*model.AdditionalInfo)
 	fc.Result = res
 	return ec.marshalOAdditionalInfo2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAdditionalInfo(ctx, field.Selections, res)
 }
@@ -9105,7 +9638,7 @@ func (ec *executionContext) fieldContext_Metadata_additionalInfo(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_deprecated(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+func (ec *executionContext) _Metadata_deprecated(ctx context.Context, field graphql.CollectedField, obj *model.Metadata) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Metadata_deprecated(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9433,7 +9966,7 @@ func (ec *executionContext) fieldContext_Metric_resource(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_label(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricCategory_label(ctx context.Context, field graphql.CollectedField, obj *model.MetricCategory) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricCategory_label(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9477,7 +10010,7 @@ func (ec *executionContext) fieldContext_MetricCategory_label(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_column(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricCategory_column(ctx context.Context, field graphql.CollectedField, obj *model.MetricCategory) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricCategory_column(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9521,7 +10054,7 @@ func (ec *executionContext) fieldContext_MetricCategory_column(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field graphql.CollectedField, obj *model.MetricCategory) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricCategory_metrics(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9547,7 +10080,7 @@ func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.MetricOption)
+	res := resTmp.([]model.MetricOption)
 	fc.Result = res
 	return ec.marshalNMetricOption2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOptionᚄ(ctx, field.Selections, res)
 }
@@ -9575,7 +10108,7 @@ func (ec *executionContext) fieldContext_MetricCategory_metrics(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_name(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricOption_name(ctx context.Context, field graphql.CollectedField, obj *model.MetricOption) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricOption_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9619,7 +10152,7 @@ func (ec *executionContext) fieldContext_MetricOption_name(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_description(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricOption_description(ctx context.Context, field graphql.CollectedField, obj *model.MetricOption) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricOption_description(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9660,7 +10193,7 @@ func (ec *executionContext) fieldContext_MetricOption_description(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql.CollectedField, obj *model.MetricOption) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricOption_kpi(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9701,7 +10234,7 @@ func (ec *executionContext) fieldContext_MetricOption_kpi(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_defaultDisabled(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+func (ec *executionContext) _MetricOption_defaultDisabled(ctx context.Context, field graphql.CollectedField, obj *model.MetricOption) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_MetricOption_defaultDisabled(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -9755,8 +10288,32 @@ func (ec *executionContext) _Mutation_updateProcessors(ctx context.Context, fiel
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().UpdateProcessors(rctx, fc.Args["input"].(model.UpdateProcessorsInput))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().UpdateProcessors(rctx, fc.Args["input"].(model1.UpdateProcessorsInput))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "user")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*bool); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *bool`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9807,8 +10364,32 @@ func (ec *executionContext) _Mutation_removeAgentConfiguration(ctx context.Conte
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RemoveAgentConfiguration(rctx, fc.Args["input"].(*model.RemoveAgentConfigurationInput))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().RemoveAgentConfiguration(rctx, fc.Args["input"].(*model1.RemoveAgentConfigurationInput))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "user")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Agent); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Agent`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9817,7 +10398,7 @@ func (ec *executionContext) _Mutation_removeAgentConfiguration(ctx context.Conte
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Agent)
+	res := resTmp.(*model.Agent)
 	fc.Result = res
 	return ec.marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
 }
@@ -9903,8 +10484,32 @@ func (ec *executionContext) _Mutation_clearAgentUpgradeError(ctx context.Context
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ClearAgentUpgradeError(rctx, fc.Args["input"].(model.ClearAgentUpgradeErrorInput))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ClearAgentUpgradeError(rctx, fc.Args["input"].(model1.ClearAgentUpgradeErrorInput))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "user")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*bool); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *bool`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9955,8 +10560,32 @@ func (ec *executionContext) _Mutation_editConfigurationDescription(ctx context.C
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().EditConfigurationDescription(rctx, fc.Args["input"].(model.EditConfigurationDescriptionInput))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().EditConfigurationDescription(rctx, fc.Args["input"].(model1.EditConfigurationDescriptionInput))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "user")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*bool); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *bool`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10170,7 +10799,7 @@ func (ec *executionContext) fieldContext_Node_attributes(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graphql.CollectedField, obj *model.OverviewPage) (ret graphql.Marshaler) {
+func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graphql.CollectedField, obj *model1.OverviewPage) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_OverviewPage_graph(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10226,7 +10855,7 @@ func (ec *executionContext) fieldContext_OverviewPage_graph(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
+func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.CollectedField, obj *model.Parameter) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Parameter_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10270,7 +10899,7 @@ func (ec *executionContext) fieldContext_Parameter_name(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
+func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.CollectedField, obj *model.Parameter) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Parameter_value(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10314,7 +10943,7 @@ func (ec *executionContext) fieldContext_Parameter_value(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Parameter_sensitive(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
+func (ec *executionContext) _Parameter_sensitive(ctx context.Context, field graphql.CollectedField, obj *model.Parameter) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Parameter_sensitive(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10355,7 +10984,7 @@ func (ec *executionContext) fieldContext_Parameter_sensitive(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10399,7 +11028,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_name(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_label(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10443,7 +11072,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_label(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_description(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_description(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_description(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10487,7 +11116,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_description(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_required(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10531,7 +11160,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_required(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_type(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10557,7 +11186,7 @@ func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.ParameterType)
+	res := resTmp.(model1.ParameterType)
 	fc.Result = res
 	return ec.marshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐParameterType(ctx, field.Selections, res)
 }
@@ -10575,7 +11204,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_type(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_validValues(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10616,7 +11245,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_validValues(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_default(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10657,7 +11286,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_default(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_relevantIf(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10680,7 +11309,7 @@ func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.RelevantIfCondition)
+	res := resTmp.([]model.RelevantIfCondition)
 	fc.Result = res
 	return ec.marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfConditionᚄ(ctx, field.Selections, res)
 }
@@ -10706,7 +11335,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_relevantIf(ctx cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_advancedConfig(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10747,7 +11376,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_advancedConfig(ctx
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_options(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10773,7 +11402,7 @@ func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterOptions)
+	res := resTmp.(model.ParameterOptions)
 	fc.Result = res
 	return ec.marshalNParameterOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterOptions(ctx, field.Selections, res)
 }
@@ -10815,7 +11444,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_options(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField, obj *model.ParameterDefinition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterDefinition_documentation(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10838,7 +11467,7 @@ func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Conte
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.DocumentationLink)
+	res := resTmp.([]model.DocumentationLink)
 	fc.Result = res
 	return ec.marshalODocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx, field.Selections, res)
 }
@@ -10862,7 +11491,7 @@ func (ec *executionContext) fieldContext_ParameterDefinition_documentation(ctx c
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_creatable(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10903,7 +11532,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_creatable(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_trackUnchecked(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10944,7 +11573,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_trackUnchecked(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_gridColumns(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -10985,7 +11614,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_gridColumns(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_sectionHeader(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11026,7 +11655,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_sectionHeader(ctx cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_metricCategories(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11049,7 +11678,7 @@ func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Conte
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.MetricCategory)
+	res := resTmp.([]model.MetricCategory)
 	fc.Result = res
 	return ec.marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategoryᚄ(ctx, field.Selections, res)
 }
@@ -11075,7 +11704,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_metricCategories(ctx c
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_multiline(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11116,7 +11745,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_multiline(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_labels(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_labels(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_labels(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11157,7 +11786,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_labels(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_password(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_password(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_password(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11198,7 +11827,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_password(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_sensitive(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_sensitive(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_sensitive(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11239,7 +11868,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_sensitive(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_subHeader(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_subHeader(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_subHeader(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11280,7 +11909,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_subHeader(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_horizontalDivider(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterOptions_horizontalDivider(ctx context.Context, field graphql.CollectedField, obj *model.ParameterOptions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterOptions_horizontalDivider(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11321,7 +11950,7 @@ func (ec *executionContext) fieldContext_ParameterOptions_horizontalDivider(ctx
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField, obj *model.ParameterizedSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterizedSpec_type(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11365,7 +11994,7 @@ func (ec *executionContext) fieldContext_ParameterizedSpec_type(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model.ParameterizedSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11388,7 +12017,7 @@ func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.Parameter)
+	res := resTmp.([]model.Parameter)
 	fc.Result = res
 	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
 }
@@ -11414,7 +12043,7 @@ func (ec *executionContext) fieldContext_ParameterizedSpec_parameters(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField, obj *model.ParameterizedSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterizedSpec_processors(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11437,7 +12066,7 @@ func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.([]model.ResourceConfiguration)
 	fc.Result = res
 	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -11456,6 +12085,8 @@ func (ec *executionContext) fieldContext_ParameterizedSpec_processors(ctx contex
 				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
 			case "displayName":
 				return ec.fieldContext_ResourceConfiguration_displayName(ctx, field)
+			case "version":
+				return ec.fieldContext_ResourceConfiguration_version(ctx, field)
 			case "type":
 				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
 			case "parameters":
@@ -11471,7 +12102,7 @@ func (ec *executionContext) fieldContext_ParameterizedSpec_processors(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField, obj *model.ParameterizedSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11515,7 +12146,7 @@ func (ec *executionContext) fieldContext_ParameterizedSpec_disabled(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _PhaseAgentCount_initial(ctx context.Context, field graphql.CollectedField, obj *model1.PhaseAgentCount) (ret graphql.Marshaler) {
+func (ec *executionContext) _PhaseAgentCount_initial(ctx context.Context, field graphql.CollectedField, obj *model.PhaseAgentCount) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_PhaseAgentCount_initial(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11559,7 +12190,7 @@ func (ec *executionContext) fieldContext_PhaseAgentCount_initial(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _PhaseAgentCount_multiplier(ctx context.Context, field graphql.CollectedField, obj *model1.PhaseAgentCount) (ret graphql.Marshaler) {
+func (ec *executionContext) _PhaseAgentCount_multiplier(ctx context.Context, field graphql.CollectedField, obj *model.PhaseAgentCount) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_PhaseAgentCount_multiplier(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11603,7 +12234,7 @@ func (ec *executionContext) fieldContext_PhaseAgentCount_multiplier(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _PhaseAgentCount_maximum(ctx context.Context, field graphql.CollectedField, obj *model1.PhaseAgentCount) (ret graphql.Marshaler) {
+func (ec *executionContext) _PhaseAgentCount_maximum(ctx context.Context, field graphql.CollectedField, obj *model.PhaseAgentCount) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_PhaseAgentCount_maximum(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11647,7 +12278,7 @@ func (ec *executionContext) fieldContext_PhaseAgentCount_maximum(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.Processor) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Processor_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11691,7 +12322,7 @@ func (ec *executionContext) fieldContext_Processor_apiVersion(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.CollectedField, obj *model.Processor) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Processor_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11735,7 +12366,7 @@ func (ec *executionContext) fieldContext_Processor_kind(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+func (ec *executionContext) _Processor_metadata(ctx context.Context, field graphql.CollectedField, obj *model.Processor) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Processor_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11761,7 +12392,7 @@ func (ec *executionContext) _Processor_metadata(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -11801,7 +12432,7 @@ func (ec *executionContext) fieldContext_Processor_metadata(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.CollectedField, obj *model.Processor) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Processor_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11827,7 +12458,7 @@ func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterizedSpec)
+	res := resTmp.(model.ParameterizedSpec)
 	fc.Result = res
 	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
 }
@@ -11855,7 +12486,7 @@ func (ec *executionContext) fieldContext_Processor_spec(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11899,7 +12530,7 @@ func (ec *executionContext) fieldContext_ProcessorType_apiVersion(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -11925,7 +12556,7 @@ func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -11965,7 +12596,7 @@ func (ec *executionContext) fieldContext_ProcessorType_metadata(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -12009,7 +12640,7 @@ func (ec *executionContext) fieldContext_ProcessorType_kind(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorType_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -12035,7 +12666,7 @@ func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ResourceTypeSpec)
+	res := resTmp.(model.ResourceTypeSpec)
 	fc.Result = res
 	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
 }
@@ -12063,7 +12694,7 @@ func (ec *executionContext) fieldContext_ProcessorType_spec(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorWithType_processor(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorWithType_processor(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorWithType_processor(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -12086,7 +12717,7 @@ func (ec *executionContext) _ProcessorWithType_processor(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Processor)
+	res := resTmp.(*model.Processor)
 	fc.Result = res
 	return ec.marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx, field.Selections, res)
 }
@@ -12114,7 +12745,7 @@ func (ec *executionContext) fieldContext_ProcessorWithType_processor(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorWithType_processorType(ctx context.Context, field graphql.CollectedField, obj *model.ProcessorWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ProcessorWithType_processorType(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ProcessorWithType_processorType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -12137,7 +12768,7 @@ func (ec *executionContext) _ProcessorWithType_processorType(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.ProcessorType)
+	res := resTmp.(*model.ProcessorType)
 	fc.Result = res
 	return ec.marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx, field.Selections, res)
 }
@@ -12178,8 +12809,32 @@ func (ec *executionContext) _Query_overviewPage(ctx context.Context, field graph
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OverviewPage(rctx, fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string), fc.Args["period"].(string), fc.Args["telemetryType"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().OverviewPage(rctx, fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string), fc.Args["period"].(string), fc.Args["telemetryType"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.OverviewPage); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.OverviewPage`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12191,7 +12846,7 @@ func (ec *executionContext) _Query_overviewPage(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.OverviewPage)
+	res := resTmp.(*model1.OverviewPage)
 	fc.Result = res
 	return ec.marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐOverviewPage(ctx, field.Selections, res)
 }
@@ -12237,8 +12892,32 @@ func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.Col
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Agents(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Agents(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.Agents); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.Agents`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12250,7 +12929,7 @@ func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Agents)
+	res := resTmp.(*model1.Agents)
 	fc.Result = res
 	return ec.marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgents(ctx, field.Selections, res)
 }
@@ -12302,8 +12981,32 @@ func (ec *executionContext) _Query_agent(ctx context.Context, field graphql.Coll
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Agent(rctx, fc.Args["id"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Agent(rctx, fc.Args["id"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Agent); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Agent`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12312,7 +13015,7 @@ func (ec *executionContext) _Query_agent(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Agent)
+	res := resTmp.(*model.Agent)
 	fc.Result = res
 	return ec.marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
 }
@@ -12398,8 +13101,32 @@ func (ec *executionContext) _Query_configurations(ctx context.Context, field gra
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Configurations(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["onlyDeployedConfigurations"].(*bool))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Configurations(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["onlyDeployedConfigurations"].(*bool))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.Configurations); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.Configurations`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12411,7 +13138,7 @@ func (ec *executionContext) _Query_configurations(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Configurations)
+	res := resTmp.(*model1.Configurations)
 	fc.Result = res
 	return ec.marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurations(ctx, field.Selections, res)
 }
@@ -12461,8 +13188,32 @@ func (ec *executionContext) _Query_configuration(ctx context.Context, field grap
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Configuration(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Configuration(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Configuration); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Configuration`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12471,7 +13222,7 @@ func (ec *executionContext) _Query_configuration(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(*model.Configuration)
 	fc.Result = res
 	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
 }
@@ -12533,8 +13284,32 @@ func (ec *executionContext) _Query_configurationHistory(ctx context.Context, fie
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ConfigurationHistory(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ConfigurationHistory(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Configuration); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.Configuration`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12546,7 +13321,7 @@ func (ec *executionContext) _Query_configurationHistory(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Configuration)
+	res := resTmp.([]*model.Configuration)
 	fc.Result = res
 	return ec.marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -12595,8 +13370,8 @@ func (ec *executionContext) fieldContext_Query_configurationHistory(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_sources(ctx, field)
+func (ec *executionContext) _Query_resourceHistory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_resourceHistory(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -12608,8 +13383,32 @@ func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.Co
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Sources(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ResourceHistory(rctx, fc.Args["kind"].(string), fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]model.Resource); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []github.com/observiq/bindplane-op/model.Resource`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12621,28 +13420,107 @@ func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Source)
+	res := resTmp.([]model.Resource)
 	fc.Result = res
-	return ec.marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx, field.Selections, res)
+	return ec.marshalNVersionedResource2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_resourceHistory(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Source_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Source_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Source_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Source_spec(ctx, field)
-			}
+			return nil, errors.New("field of type VersionedResource does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_resourceHistory_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_sources(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Sources(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Source); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.Source`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Source)
+	fc.Result = res
+	return ec.marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Source_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Source_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Source_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Source_spec(ctx, field)
+			}
 			return nil, fmt.Errorf("no field named %q was found under type Source", field.Name)
 		},
 	}
@@ -12662,8 +13540,32 @@ func (ec *executionContext) _Query_source(ctx context.Context, field graphql.Col
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Source(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Source(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Source); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Source`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12672,7 +13574,7 @@ func (ec *executionContext) _Query_source(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Source)
+	res := resTmp.(*model.Source)
 	fc.Result = res
 	return ec.marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx, field.Selections, res)
 }
@@ -12724,8 +13626,32 @@ func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphq
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SourceTypes(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().SourceTypes(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.SourceType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.SourceType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12737,7 +13663,7 @@ func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.SourceType)
+	res := resTmp.([]*model.SourceType)
 	fc.Result = res
 	return ec.marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceTypeᚄ(ctx, field.Selections, res)
 }
@@ -12778,8 +13704,32 @@ func (ec *executionContext) _Query_sourceType(ctx context.Context, field graphql
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SourceType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().SourceType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.SourceType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.SourceType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12788,7 +13738,7 @@ func (ec *executionContext) _Query_sourceType(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.SourceType)
+	res := resTmp.(*model.SourceType)
 	fc.Result = res
 	return ec.marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx, field.Selections, res)
 }
@@ -12840,8 +13790,32 @@ func (ec *executionContext) _Query_sourceWithType(ctx context.Context, field gra
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SourceWithType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().SourceWithType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.SourceWithType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.SourceWithType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12853,7 +13827,7 @@ func (ec *executionContext) _Query_sourceWithType(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.SourceWithType)
+	res := resTmp.(*model1.SourceWithType)
 	fc.Result = res
 	return ec.marshalNSourceWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSourceWithType(ctx, field.Selections, res)
 }
@@ -12901,8 +13875,32 @@ func (ec *executionContext) _Query_processors(ctx context.Context, field graphql
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Processors(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Processors(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Processor); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.Processor`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12914,7 +13912,7 @@ func (ec *executionContext) _Query_processors(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Processor)
+	res := resTmp.([]*model.Processor)
 	fc.Result = res
 	return ec.marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorᚄ(ctx, field.Selections, res)
 }
@@ -12955,8 +13953,32 @@ func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Processor(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Processor(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Processor); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Processor`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12965,7 +13987,7 @@ func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Processor)
+	res := resTmp.(*model.Processor)
 	fc.Result = res
 	return ec.marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx, field.Selections, res)
 }
@@ -13017,8 +14039,32 @@ func (ec *executionContext) _Query_processorWithType(ctx context.Context, field
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ProcessorWithType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ProcessorWithType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.ProcessorWithType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.ProcessorWithType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13030,7 +14076,7 @@ func (ec *executionContext) _Query_processorWithType(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ProcessorWithType)
+	res := resTmp.(*model1.ProcessorWithType)
 	fc.Result = res
 	return ec.marshalNProcessorWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐProcessorWithType(ctx, field.Selections, res)
 }
@@ -13078,8 +14124,32 @@ func (ec *executionContext) _Query_processorTypes(ctx context.Context, field gra
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ProcessorTypes(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ProcessorTypes(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.ProcessorType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.ProcessorType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13091,7 +14161,7 @@ func (ec *executionContext) _Query_processorTypes(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.ProcessorType)
+	res := resTmp.([]*model.ProcessorType)
 	fc.Result = res
 	return ec.marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorTypeᚄ(ctx, field.Selections, res)
 }
@@ -13132,8 +14202,32 @@ func (ec *executionContext) _Query_processorType(ctx context.Context, field grap
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ProcessorType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ProcessorType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ProcessorType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.ProcessorType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13142,7 +14236,7 @@ func (ec *executionContext) _Query_processorType(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.ProcessorType)
+	res := resTmp.(*model.ProcessorType)
 	fc.Result = res
 	return ec.marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx, field.Selections, res)
 }
@@ -13194,8 +14288,32 @@ func (ec *executionContext) _Query_destinations(ctx context.Context, field graph
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Destinations(rctx, fc.Args["query"].(*string), fc.Args["filterUnused"].(*bool))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Destinations(rctx, fc.Args["query"].(*string), fc.Args["filterUnused"].(*bool))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Destination); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.Destination`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13207,7 +14325,7 @@ func (ec *executionContext) _Query_destinations(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Destination)
+	res := resTmp.([]*model.Destination)
 	fc.Result = res
 	return ec.marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx, field.Selections, res)
 }
@@ -13259,8 +14377,32 @@ func (ec *executionContext) _Query_destination(ctx context.Context, field graphq
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Destination(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Destination(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Destination); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.Destination`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13269,7 +14411,7 @@ func (ec *executionContext) _Query_destination(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Destination)
+	res := resTmp.(*model.Destination)
 	fc.Result = res
 	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
 }
@@ -13321,8 +14463,32 @@ func (ec *executionContext) _Query_destinationWithType(ctx context.Context, fiel
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationWithType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().DestinationWithType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.DestinationWithType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.DestinationWithType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13334,7 +14500,7 @@ func (ec *executionContext) _Query_destinationWithType(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.DestinationWithType)
+	res := resTmp.(*model1.DestinationWithType)
 	fc.Result = res
 	return ec.marshalNDestinationWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐDestinationWithType(ctx, field.Selections, res)
 }
@@ -13382,8 +14548,32 @@ func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field g
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationTypes(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().DestinationTypes(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.DestinationType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/observiq/bindplane-op/model.DestinationType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13395,7 +14585,7 @@ func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.DestinationType)
+	res := resTmp.([]*model.DestinationType)
 	fc.Result = res
 	return ec.marshalNDestinationType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationTypeᚄ(ctx, field.Selections, res)
 }
@@ -13436,8 +14626,32 @@ func (ec *executionContext) _Query_destinationType(ctx context.Context, field gr
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationType(rctx, fc.Args["name"].(string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().DestinationType(rctx, fc.Args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.DestinationType); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/model.DestinationType`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13446,7 +14660,7 @@ func (ec *executionContext) _Query_destinationType(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.DestinationType)
+	res := resTmp.(*model.DestinationType)
 	fc.Result = res
 	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
 }
@@ -13498,8 +14712,32 @@ func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.C
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Snapshot(rctx, fc.Args["agentID"].(string), fc.Args["pipelineType"].(otel.PipelineType), fc.Args["position"].(*string), fc.Args["resourceName"].(*string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Snapshot(rctx, fc.Args["agentID"].(string), fc.Args["pipelineType"].(otel.PipelineType), fc.Args["position"].(*string), fc.Args["resourceName"].(*string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.Snapshot); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.Snapshot`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13511,7 +14749,7 @@ func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Snapshot)
+	res := resTmp.(*model1.Snapshot)
 	fc.Result = res
 	return ec.marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSnapshot(ctx, field.Selections, res)
 }
@@ -13561,8 +14799,32 @@ func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graph
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13574,7 +14836,7 @@ func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(*model1.GraphMetrics)
 	fc.Result = res
 	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
@@ -13628,20 +14890,44 @@ func (ec *executionContext) _Query_configurationMetrics(ctx context.Context, fie
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string))
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string))
 		}
-		return graphql.Null
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(*model1.GraphMetrics)
 	fc.Result = res
 	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
@@ -13695,8 +14981,32 @@ func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field gr
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13708,7 +15018,7 @@ func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(*model1.GraphMetrics)
 	fc.Result = res
 	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
@@ -13878,7 +15188,7 @@ func (ec *executionContext) fieldContext_Query___schema(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField, obj *model.RelevantIfCondition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_RelevantIfCondition_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -13922,7 +15232,7 @@ func (ec *executionContext) fieldContext_RelevantIfCondition_name(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField, obj *model.RelevantIfCondition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_RelevantIfCondition_operator(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -13948,7 +15258,7 @@ func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.RelevantIfOperatorType)
+	res := resTmp.(model1.RelevantIfOperatorType)
 	fc.Result = res
 	return ec.marshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx, field.Selections, res)
 }
@@ -13966,7 +15276,7 @@ func (ec *executionContext) fieldContext_RelevantIfCondition_operator(ctx contex
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField, obj *model.RelevantIfCondition) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_RelevantIfCondition_value(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14010,7 +15320,7 @@ func (ec *executionContext) fieldContext_RelevantIfCondition_value(ctx context.C
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_id(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_id(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_id(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14051,7 +15361,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_id(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_name(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14092,7 +15402,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_name(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_displayName(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_displayName(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_displayName(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14133,7 +15443,48 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_displayName(ctx c
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_version(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_version(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Version, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(model.Version)
+	fc.Result = res
+	return ec.marshalOVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ResourceConfiguration_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ResourceConfiguration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Version does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_type(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14174,7 +15525,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_type(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14197,7 +15548,7 @@ func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Contex
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.Parameter)
+	res := resTmp.([]model.Parameter)
 	fc.Result = res
 	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
 }
@@ -14223,7 +15574,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_parameters(ctx co
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_processors(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14246,7 +15597,7 @@ func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Contex
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.([]model.ResourceConfiguration)
 	fc.Result = res
 	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
@@ -14265,6 +15616,8 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_processors(ctx co
 				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
 			case "displayName":
 				return ec.fieldContext_ResourceConfiguration_displayName(ctx, field)
+			case "version":
+				return ec.fieldContext_ResourceConfiguration_version(ctx, field)
 			case "type":
 				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
 			case "parameters":
@@ -14280,7 +15633,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_processors(ctx co
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField, obj *model.ResourceConfiguration) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14324,7 +15677,7 @@ func (ec *executionContext) fieldContext_ResourceConfiguration_disabled(ctx cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField, obj *model.ResourceTypeSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceTypeSpec_version(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14368,7 +15721,7 @@ func (ec *executionContext) fieldContext_ResourceTypeSpec_version(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model.ResourceTypeSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14394,7 +15747,7 @@ func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ParameterDefinition)
+	res := resTmp.([]model.ParameterDefinition)
 	fc.Result = res
 	return ec.marshalNParameterDefinition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinitionᚄ(ctx, field.Selections, res)
 }
@@ -14436,7 +15789,7 @@ func (ec *executionContext) fieldContext_ResourceTypeSpec_parameters(ctx context
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField, obj *model.ResourceTypeSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14480,7 +15833,7 @@ func (ec *executionContext) fieldContext_ResourceTypeSpec_supportedPlatforms(ctx
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField, obj *model.ResourceTypeSpec) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14524,7 +15877,7 @@ func (ec *executionContext) fieldContext_ResourceTypeSpec_telemetryTypes(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_options(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rollout_options(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Rollout_options(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -14547,7 +15900,7 @@ func (ec *executionContext) _Rollout_options(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(model1.RolloutOptions)
+	res := resTmp.(model.RolloutOptions)
 	fc.Result = res
 	return ec.marshalORolloutOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutOptions(ctx, field.Selections, res)
 }
@@ -14568,6 +15921,14 @@ func (ec *executionContext) fieldContext_Rollout_options(ctx context.Context, fi
 				return ec.fieldContext_RolloutOptions_maxErrors(ctx, field)
 			case "phaseAgentCount":
 				return ec.fieldContext_RolloutOptions_phaseAgentCount(ctx, field)
+			case "startAt":
+				return ec.fieldContext_RolloutOptions_startAt(ctx, field)
+			case "maintenanceWindow":
+				return ec.fieldContext_RolloutOptions_maintenanceWindow(ctx, field)
+			case "healthCheck":
+				return ec.fieldContext_RolloutOptions_healthCheck(ctx, field)
+			case "canarySelector":
+				return ec.fieldContext_RolloutOptions_canarySelector(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type RolloutOptions", field.Name)
 		},
@@ -14575,8 +15936,983 @@ func (ec *executionContext) fieldContext_Rollout_options(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_status(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_status(ctx, field)
+func (ec *executionContext) _Rollout_status(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model.RolloutStatus)
+	fc.Result = res
+	return ec.marshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type RolloutStatus does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_phase(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_phase(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Phase, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_phase(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_completed(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_completed(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Rollout().Completed(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_completed(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_errors(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_errors(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Rollout().Errors(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_errors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_pending(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_pending(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Rollout().Pending(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_pending(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_waiting(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_waiting(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Rollout().Waiting(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_waiting(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_pausedBySchedule(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_pausedBySchedule(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PausedBySchedule, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_pausedBySchedule(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_health(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_health(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Health, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.RolloutHealth)
+	fc.Result = res
+	return ec.marshalORolloutHealth2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutHealth(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_health(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "baseline":
+				return ec.fieldContext_RolloutHealth_baseline(ctx, field)
+			case "throughput":
+				return ec.fieldContext_RolloutHealth_throughput(ctx, field)
+			case "phase":
+				return ec.fieldContext_RolloutHealth_phase(ctx, field)
+			case "checkStarted":
+				return ec.fieldContext_RolloutHealth_checkStarted(ctx, field)
+			case "passed":
+				return ec.fieldContext_RolloutHealth_passed(ctx, field)
+			case "failure":
+				return ec.fieldContext_RolloutHealth_failure(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RolloutHealth", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_canary(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_canary(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Canary, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.RolloutCanary)
+	fc.Result = res
+	return ec.marshalORolloutCanary2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutCanary(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_canary(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "agents":
+				return ec.fieldContext_RolloutCanary_agents(ctx, field)
+			case "awaitingApproval":
+				return ec.fieldContext_RolloutCanary_awaitingApproval(ctx, field)
+			case "approved":
+				return ec.fieldContext_RolloutCanary_approved(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RolloutCanary", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Rollout_awaitingApproval(ctx context.Context, field graphql.CollectedField, obj *model.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_awaitingApproval(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AwaitingApproval(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Rollout_awaitingApproval(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Rollout",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutCanary_agents(ctx context.Context, field graphql.CollectedField, obj *model.RolloutCanary) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutCanary_agents(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Agents, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutCanary_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutCanary",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutCanary_awaitingApproval(ctx context.Context, field graphql.CollectedField, obj *model.RolloutCanary) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutCanary_awaitingApproval(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AwaitingApproval, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutCanary_awaitingApproval(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutCanary",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutCanary_approved(ctx context.Context, field graphql.CollectedField, obj *model.RolloutCanary) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutCanary_approved(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Approved, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutCanary_approved(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutCanary",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_baseline(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_baseline(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Baseline, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*float64)
+	fc.Result = res
+	return ec.marshalOFloat2ᚖfloat64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_baseline(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_throughput(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_throughput(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Throughput, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*float64)
+	fc.Result = res
+	return ec.marshalOFloat2ᚖfloat64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_throughput(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_phase(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_phase(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Phase, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_phase(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_checkStarted(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_checkStarted(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CheckStarted, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_checkStarted(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_passed(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_passed(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Passed, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_passed(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealth_failure(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealth) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealth_failure(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Failure, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealth_failure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealthCheck_minThroughputPercent(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealthCheck) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealthCheck_minThroughputPercent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MinThroughputPercent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealthCheck_minThroughputPercent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealthCheck",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutHealthCheck_duration(ctx context.Context, field graphql.CollectedField, obj *model.RolloutHealthCheck) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutHealthCheck_duration(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Duration, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutHealthCheck_duration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutHealthCheck",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutOptions_startAutomatically(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_startAutomatically(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartAutomatically, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_RolloutOptions_startAutomatically(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RolloutOptions",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _RolloutOptions_rollbackOnFailure(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_rollbackOnFailure(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14589,7 +16925,7 @@ func (ec *executionContext) _Rollout_status(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.RollbackOnFailure, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14601,26 +16937,26 @@ func (ec *executionContext) _Rollout_status(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.RolloutStatus)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_rollbackOnFailure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type RolloutStatus does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_phase(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_phase(ctx, field)
+func (ec *executionContext) _RolloutOptions_maxErrors(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_maxErrors(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14633,7 +16969,7 @@ func (ec *executionContext) _Rollout_phase(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Phase, nil
+		return obj.MaxErrors, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14650,9 +16986,9 @@ func (ec *executionContext) _Rollout_phase(ctx context.Context, field graphql.Co
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_phase(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_maxErrors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -14663,8 +16999,8 @@ func (ec *executionContext) fieldContext_Rollout_phase(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_completed(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_completed(ctx, field)
+func (ec *executionContext) _RolloutOptions_phaseAgentCount(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_phaseAgentCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14677,38 +17013,43 @@ func (ec *executionContext) _Rollout_completed(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Rollout().Completed(rctx, obj)
+		return obj.PhaseAgentCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(model.PhaseAgentCount)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOPhaseAgentCount2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐPhaseAgentCount(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_completed(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_phaseAgentCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "initial":
+				return ec.fieldContext_PhaseAgentCount_initial(ctx, field)
+			case "multiplier":
+				return ec.fieldContext_PhaseAgentCount_multiplier(ctx, field)
+			case "maximum":
+				return ec.fieldContext_PhaseAgentCount_maximum(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PhaseAgentCount", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_errors(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_errors(ctx, field)
+func (ec *executionContext) _RolloutOptions_startAt(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_startAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14721,38 +17062,35 @@ func (ec *executionContext) _Rollout_errors(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Rollout().Errors(rctx, obj)
+		return obj.StartAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_errors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_startAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_pending(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_pending(ctx, field)
+func (ec *executionContext) _RolloutOptions_maintenanceWindow(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_maintenanceWindow(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14765,38 +17103,43 @@ func (ec *executionContext) _Rollout_pending(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Rollout().Pending(rctx, obj)
+		return obj.MaintenanceWindow, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.MaintenanceWindow)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOMaintenanceWindow2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMaintenanceWindow(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_pending(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_maintenanceWindow(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "cron":
+				return ec.fieldContext_MaintenanceWindow_cron(ctx, field)
+			case "duration":
+				return ec.fieldContext_MaintenanceWindow_duration(ctx, field)
+			case "timezone":
+				return ec.fieldContext_MaintenanceWindow_timezone(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MaintenanceWindow", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Rollout_waiting(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Rollout_waiting(ctx, field)
+func (ec *executionContext) _RolloutOptions_healthCheck(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_healthCheck(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14809,38 +17152,41 @@ func (ec *executionContext) _Rollout_waiting(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Rollout().Waiting(rctx, obj)
+		return obj.HealthCheck, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.RolloutHealthCheck)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalORolloutHealthCheck2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutHealthCheck(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Rollout_waiting(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_healthCheck(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Rollout",
+		Object:     "RolloutOptions",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "minThroughputPercent":
+				return ec.fieldContext_RolloutHealthCheck_minThroughputPercent(ctx, field)
+			case "duration":
+				return ec.fieldContext_RolloutHealthCheck_duration(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RolloutHealthCheck", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RolloutOptions_startAutomatically(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RolloutOptions_startAutomatically(ctx, field)
+func (ec *executionContext) _RolloutOptions_canarySelector(ctx context.Context, field graphql.CollectedField, obj *model.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_canarySelector(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14853,38 +17199,39 @@ func (ec *executionContext) _RolloutOptions_startAutomatically(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartAutomatically, nil
+		return obj.CanarySelector, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.AgentSelector)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOAgentSelector2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RolloutOptions_startAutomatically(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_canarySelector(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "matchLabels":
+				return ec.fieldContext_AgentSelector_matchLabels(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentSelector", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RolloutOptions_rollbackOnFailure(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RolloutOptions_rollbackOnFailure(ctx, field)
+func (ec *executionContext) _RolloutRollback_version(ctx context.Context, field graphql.CollectedField, obj *model.RolloutRollback) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutRollback_version(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14897,7 +17244,7 @@ func (ec *executionContext) _RolloutOptions_rollbackOnFailure(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RollbackOnFailure, nil
+		return obj.Version, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14909,26 +17256,26 @@ func (ec *executionContext) _RolloutOptions_rollbackOnFailure(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(model.Version)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RolloutOptions_rollbackOnFailure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutRollback_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RolloutOptions",
+		Object:     "RolloutRollback",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Version does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RolloutOptions_maxErrors(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RolloutOptions_maxErrors(ctx, field)
+func (ec *executionContext) _RolloutRollback_agents(ctx context.Context, field graphql.CollectedField, obj *model.RolloutRollback) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutRollback_agents(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14941,7 +17288,7 @@ func (ec *executionContext) _RolloutOptions_maxErrors(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MaxErrors, nil
+		return obj.Agents, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14958,9 +17305,9 @@ func (ec *executionContext) _RolloutOptions_maxErrors(ctx context.Context, field
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RolloutOptions_maxErrors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutRollback_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RolloutOptions",
+		Object:     "RolloutRollback",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -14971,8 +17318,8 @@ func (ec *executionContext) fieldContext_RolloutOptions_maxErrors(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _RolloutOptions_phaseAgentCount(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RolloutOptions_phaseAgentCount(ctx, field)
+func (ec *executionContext) _RolloutRollback_date(ctx context.Context, field graphql.CollectedField, obj *model.RolloutRollback) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutRollback_date(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -14985,42 +17332,37 @@ func (ec *executionContext) _RolloutOptions_phaseAgentCount(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PhaseAgentCount, nil
+		return obj.Date, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.PhaseAgentCount)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOPhaseAgentCount2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐPhaseAgentCount(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RolloutOptions_phaseAgentCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutRollback_date(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RolloutOptions",
+		Object:     "RolloutRollback",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "initial":
-				return ec.fieldContext_PhaseAgentCount_initial(ctx, field)
-			case "multiplier":
-				return ec.fieldContext_PhaseAgentCount_multiplier(ctx, field)
-			case "maximum":
-				return ec.fieldContext_PhaseAgentCount_maximum(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PhaseAgentCount", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Snapshot_logs(ctx context.Context, field graphql.CollectedField, obj *model.Snapshot) (ret graphql.Marshaler) {
+func (ec *executionContext) _Snapshot_logs(ctx context.Context, field graphql.CollectedField, obj *model1.Snapshot) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Snapshot_logs(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15076,7 +17418,7 @@ func (ec *executionContext) fieldContext_Snapshot_logs(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Snapshot_metrics(ctx context.Context, field graphql.CollectedField, obj *model.Snapshot) (ret graphql.Marshaler) {
+func (ec *executionContext) _Snapshot_metrics(ctx context.Context, field graphql.CollectedField, obj *model1.Snapshot) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Snapshot_metrics(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15136,7 +17478,7 @@ func (ec *executionContext) fieldContext_Snapshot_metrics(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Snapshot_traces(ctx context.Context, field graphql.CollectedField, obj *model.Snapshot) (ret graphql.Marshaler) {
+func (ec *executionContext) _Snapshot_traces(ctx context.Context, field graphql.CollectedField, obj *model1.Snapshot) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Snapshot_traces(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15198,7 +17540,7 @@ func (ec *executionContext) fieldContext_Snapshot_traces(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Source_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Source) (ret graphql.Marshaler) {
+func (ec *executionContext) _Source_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.Source) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Source_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15242,7 +17584,7 @@ func (ec *executionContext) fieldContext_Source_apiVersion(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Source_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Source) (ret graphql.Marshaler) {
+func (ec *executionContext) _Source_kind(ctx context.Context, field graphql.CollectedField, obj *model.Source) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Source_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15286,7 +17628,7 @@ func (ec *executionContext) fieldContext_Source_kind(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Source_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Source) (ret graphql.Marshaler) {
+func (ec *executionContext) _Source_metadata(ctx context.Context, field graphql.CollectedField, obj *model.Source) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Source_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15312,7 +17654,7 @@ func (ec *executionContext) _Source_metadata(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -15352,7 +17694,7 @@ func (ec *executionContext) fieldContext_Source_metadata(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Source_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Source) (ret graphql.Marshaler) {
+func (ec *executionContext) _Source_spec(ctx context.Context, field graphql.CollectedField, obj *model.Source) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Source_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15378,7 +17720,7 @@ func (ec *executionContext) _Source_spec(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterizedSpec)
+	res := resTmp.(model.ParameterizedSpec)
 	fc.Result = res
 	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
 }
@@ -15406,7 +17748,7 @@ func (ec *executionContext) fieldContext_Source_spec(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.SourceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model.SourceType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15450,7 +17792,7 @@ func (ec *executionContext) fieldContext_SourceType_apiVersion(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.SourceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceType_metadata(ctx context.Context, field graphql.CollectedField, obj *model.SourceType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15476,7 +17818,7 @@ func (ec *executionContext) _SourceType_metadata(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(model.Metadata)
 	fc.Result = res
 	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
@@ -15516,7 +17858,7 @@ func (ec *executionContext) fieldContext_SourceType_metadata(ctx context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.SourceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceType_kind(ctx context.Context, field graphql.CollectedField, obj *model.SourceType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15560,7 +17902,7 @@ func (ec *executionContext) fieldContext_SourceType_kind(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.SourceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceType_spec(ctx context.Context, field graphql.CollectedField, obj *model.SourceType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceType_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15586,7 +17928,7 @@ func (ec *executionContext) _SourceType_spec(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ResourceTypeSpec)
+	res := resTmp.(model.ResourceTypeSpec)
 	fc.Result = res
 	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
 }
@@ -15614,7 +17956,7 @@ func (ec *executionContext) fieldContext_SourceType_spec(ctx context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceWithType_source(ctx context.Context, field graphql.CollectedField, obj *model.SourceWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceWithType_source(ctx context.Context, field graphql.CollectedField, obj *model1.SourceWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceWithType_source(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15637,7 +17979,7 @@ func (ec *executionContext) _SourceWithType_source(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Source)
+	res := resTmp.(*model.Source)
 	fc.Result = res
 	return ec.marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx, field.Selections, res)
 }
@@ -15665,7 +18007,7 @@ func (ec *executionContext) fieldContext_SourceWithType_source(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _SourceWithType_sourceType(ctx context.Context, field graphql.CollectedField, obj *model.SourceWithType) (ret graphql.Marshaler) {
+func (ec *executionContext) _SourceWithType_sourceType(ctx context.Context, field graphql.CollectedField, obj *model1.SourceWithType) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_SourceWithType_sourceType(ctx, field)
 	if err != nil {
 		return graphql.Null
@@ -15688,7 +18030,7 @@ func (ec *executionContext) _SourceWithType_sourceType(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.SourceType)
+	res := resTmp.(*model.SourceType)
 	fc.Result = res
 	return ec.marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx, field.Selections, res)
 }
@@ -15729,8 +18071,32 @@ func (ec *executionContext) _Subscription_agentChanges(ctx context.Context, fiel
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().AgentChanges(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().AgentChanges(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan []*model1.AgentChange); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan []*github.com/observiq/bindplane-op/graphql/model.AgentChange`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15744,7 +18110,7 @@ func (ec *executionContext) _Subscription_agentChanges(ctx context.Context, fiel
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan []*model.AgentChange):
+		case res, ok := <-resTmp.(<-chan []*model1.AgentChange):
 			if !ok {
 				return nil
 			}
@@ -15804,8 +18170,32 @@ func (ec *executionContext) _Subscription_configurationChanges(ctx context.Conte
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().ConfigurationChanges(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().ConfigurationChanges(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan []*model1.ConfigurationChange); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan []*github.com/observiq/bindplane-op/graphql/model.ConfigurationChange`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15819,7 +18209,7 @@ func (ec *executionContext) _Subscription_configurationChanges(ctx context.Conte
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan []*model.ConfigurationChange):
+		case res, ok := <-resTmp.(<-chan []*model1.ConfigurationChange):
 			if !ok {
 				return nil
 			}
@@ -15879,8 +18269,32 @@ func (ec *executionContext) _Subscription_agentMetrics(ctx context.Context, fiel
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan *model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15894,7 +18308,7 @@ func (ec *executionContext) _Subscription_agentMetrics(ctx context.Context, fiel
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan *model.GraphMetrics):
+		case res, ok := <-resTmp.(<-chan *model1.GraphMetrics):
 			if !ok {
 				return nil
 			}
@@ -15960,8 +18374,32 @@ func (ec *executionContext) _Subscription_configurationMetrics(ctx context.Conte
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string), fc.Args["agent"].(*string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string), fc.Args["agent"].(*string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan *model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15975,7 +18413,7 @@ func (ec *executionContext) _Subscription_configurationMetrics(ctx context.Conte
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan *model.GraphMetrics):
+		case res, ok := <-resTmp.(<-chan *model1.GraphMetrics):
 			if !ok {
 				return nil
 			}
@@ -16041,8 +18479,32 @@ func (ec *executionContext) _Subscription_overviewMetrics(ctx context.Context, f
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			role, err := ec.unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx, "viewer")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasRole == nil {
+				return nil, errors.New("directive hasRole is not implemented")
+			}
+			return ec.directives.HasRole(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan *model1.GraphMetrics); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan *github.com/observiq/bindplane-op/graphql/model.GraphMetrics`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16056,7 +18518,7 @@ func (ec *executionContext) _Subscription_overviewMetrics(ctx context.Context, f
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan *model.GraphMetrics):
+		case res, ok := <-resTmp.(<-chan *model1.GraphMetrics):
 			if !ok {
 				return nil
 			}
@@ -18298,8 +20760,8 @@ func (ec *executionContext) fieldContext___Type_specifiedByURL(ctx context.Conte
 
 // region    **************************** input.gotpl *****************************
 
-func (ec *executionContext) unmarshalInputClearAgentUpgradeErrorInput(ctx context.Context, obj interface{}) (model.ClearAgentUpgradeErrorInput, error) {
-	var it model.ClearAgentUpgradeErrorInput
+func (ec *executionContext) unmarshalInputClearAgentUpgradeErrorInput(ctx context.Context, obj interface{}) (model1.ClearAgentUpgradeErrorInput, error) {
+	var it model1.ClearAgentUpgradeErrorInput
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -18326,8 +20788,8 @@ func (ec *executionContext) unmarshalInputClearAgentUpgradeErrorInput(ctx contex
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputEditConfigurationDescriptionInput(ctx context.Context, obj interface{}) (model.EditConfigurationDescriptionInput, error) {
-	var it model.EditConfigurationDescriptionInput
+func (ec *executionContext) unmarshalInputEditConfigurationDescriptionInput(ctx context.Context, obj interface{}) (model1.EditConfigurationDescriptionInput, error) {
+	var it model1.EditConfigurationDescriptionInput
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -18362,8 +20824,8 @@ func (ec *executionContext) unmarshalInputEditConfigurationDescriptionInput(ctx
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputParameterInput(ctx context.Context, obj interface{}) (model1.Parameter, error) {
-	var it model1.Parameter
+func (ec *executionContext) unmarshalInputParameterInput(ctx context.Context, obj interface{}) (model.Parameter, error) {
+	var it model.Parameter
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -18398,14 +20860,14 @@ func (ec *executionContext) unmarshalInputParameterInput(ctx context.Context, ob
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputProcessorInput(ctx context.Context, obj interface{}) (model1.ResourceConfiguration, error) {
-	var it model1.ResourceConfiguration
+func (ec *executionContext) unmarshalInputProcessorInput(ctx context.Context, obj interface{}) (model.ResourceConfiguration, error) {
+	var it model.ResourceConfiguration
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"id", "name", "displayName", "type", "parameters", "disabled"}
+	fieldsInOrder := [...]string{"id", "name", "displayName", "version", "type", "parameters", "disabled"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -18436,6 +20898,14 @@ func (ec *executionContext) unmarshalInputProcessorInput(ctx context.Context, ob
 			if err != nil {
 				return it, err
 			}
+		case "version":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("version"))
+			it.Version, err = ec.unmarshalOVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		case "type":
 			var err error
 
@@ -18466,8 +20936,8 @@ func (ec *executionContext) unmarshalInputProcessorInput(ctx context.Context, ob
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputRemoveAgentConfigurationInput(ctx context.Context, obj interface{}) (model.RemoveAgentConfigurationInput, error) {
-	var it model.RemoveAgentConfigurationInput
+func (ec *executionContext) unmarshalInputRemoveAgentConfigurationInput(ctx context.Context, obj interface{}) (model1.RemoveAgentConfigurationInput, error) {
+	var it model1.RemoveAgentConfigurationInput
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -18494,8 +20964,8 @@ func (ec *executionContext) unmarshalInputRemoveAgentConfigurationInput(ctx cont
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputUpdateProcessorsInput(ctx context.Context, obj interface{}) (model.UpdateProcessorsInput, error) {
-	var it model.UpdateProcessorsInput
+func (ec *executionContext) unmarshalInputUpdateProcessorsInput(ctx context.Context, obj interface{}) (model1.UpdateProcessorsInput, error) {
+	var it model1.UpdateProcessorsInput
 	asMap := map[string]interface{}{}
 	for k, v := range obj.(map[string]interface{}) {
 		asMap[k] = v
@@ -18550,13 +21020,37 @@ func (ec *executionContext) unmarshalInputUpdateProcessorsInput(ctx context.Cont
 
 // region    ************************** interface.gotpl ***************************
 
+func (ec *executionContext) _VersionedResource(ctx context.Context, sel ast.SelectionSet, obj model.Resource) graphql.Marshaler {
+	switch obj := (obj).(type) {
+	case nil:
+		return graphql.Null
+	case *model.Source:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Source(ctx, sel, obj)
+	case *model.Processor:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Processor(ctx, sel, obj)
+	case *model.Destination:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Destination(ctx, sel, obj)
+	default:
+		panic(fmt.Errorf("unexpected type %T", obj))
+	}
+}
+
 // endregion ************************** interface.gotpl ***************************
 
 // region    **************************** object.gotpl ****************************
 
 var additionalInfoImplementors = []string{"AdditionalInfo"}
 
-func (ec *executionContext) _AdditionalInfo(ctx context.Context, sel ast.SelectionSet, obj *model1.AdditionalInfo) graphql.Marshaler {
+func (ec *executionContext) _AdditionalInfo(ctx context.Context, sel ast.SelectionSet, obj *model.AdditionalInfo) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, additionalInfoImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18591,7 +21085,7 @@ func (ec *executionContext) _AdditionalInfo(ctx context.Context, sel ast.Selecti
 
 var agentImplementors = []string{"Agent"}
 
-func (ec *executionContext) _Agent(ctx context.Context, sel ast.SelectionSet, obj *model1.Agent) graphql.Marshaler {
+func (ec *executionContext) _Agent(ctx context.Context, sel ast.SelectionSet, obj *model.Agent) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18786,7 +21280,7 @@ func (ec *executionContext) _Agent(ctx context.Context, sel ast.SelectionSet, ob
 
 var agentChangeImplementors = []string{"AgentChange"}
 
-func (ec *executionContext) _AgentChange(ctx context.Context, sel ast.SelectionSet, obj *model.AgentChange) graphql.Marshaler {
+func (ec *executionContext) _AgentChange(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentChange) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentChangeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18821,7 +21315,7 @@ func (ec *executionContext) _AgentChange(ctx context.Context, sel ast.SelectionS
 
 var agentConfigurationImplementors = []string{"AgentConfiguration"}
 
-func (ec *executionContext) _AgentConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model.AgentConfiguration) graphql.Marshaler {
+func (ec *executionContext) _AgentConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentConfiguration) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentConfigurationImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18854,7 +21348,7 @@ func (ec *executionContext) _AgentConfiguration(ctx context.Context, sel ast.Sel
 
 var agentSelectorImplementors = []string{"AgentSelector"}
 
-func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentSelector) graphql.Marshaler {
+func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.SelectionSet, obj *model.AgentSelector) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentSelectorImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18892,7 +21386,7 @@ func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.Selectio
 
 var agentUpgradeImplementors = []string{"AgentUpgrade"}
 
-func (ec *executionContext) _AgentUpgrade(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentUpgrade) graphql.Marshaler {
+func (ec *executionContext) _AgentUpgrade(ctx context.Context, sel ast.SelectionSet, obj *model.AgentUpgrade) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentUpgradeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18944,7 +21438,7 @@ func (ec *executionContext) _AgentUpgrade(ctx context.Context, sel ast.Selection
 
 var agentsImplementors = []string{"Agents"}
 
-func (ec *executionContext) _Agents(ctx context.Context, sel ast.SelectionSet, obj *model.Agents) graphql.Marshaler {
+func (ec *executionContext) _Agents(ctx context.Context, sel ast.SelectionSet, obj *model1.Agents) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, agentsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -18987,7 +21481,7 @@ func (ec *executionContext) _Agents(ctx context.Context, sel ast.SelectionSet, o
 
 var configurationImplementors = []string{"Configuration"}
 
-func (ec *executionContext) _Configuration(ctx context.Context, sel ast.SelectionSet, obj *model1.Configuration) graphql.Marshaler {
+func (ec *executionContext) _Configuration(ctx context.Context, sel ast.SelectionSet, obj *model.Configuration) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, configurationImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19124,7 +21618,7 @@ func (ec *executionContext) _Configuration(ctx context.Context, sel ast.Selectio
 
 var configurationChangeImplementors = []string{"ConfigurationChange"}
 
-func (ec *executionContext) _ConfigurationChange(ctx context.Context, sel ast.SelectionSet, obj *model.ConfigurationChange) graphql.Marshaler {
+func (ec *executionContext) _ConfigurationChange(ctx context.Context, sel ast.SelectionSet, obj *model1.ConfigurationChange) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, configurationChangeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19159,7 +21653,7 @@ func (ec *executionContext) _ConfigurationChange(ctx context.Context, sel ast.Se
 
 var configurationSpecImplementors = []string{"ConfigurationSpec"}
 
-func (ec *executionContext) _ConfigurationSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ConfigurationSpec) graphql.Marshaler {
+func (ec *executionContext) _ConfigurationSpec(ctx context.Context, sel ast.SelectionSet, obj *model.ConfigurationSpec) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, configurationSpecImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19204,7 +21698,7 @@ func (ec *executionContext) _ConfigurationSpec(ctx context.Context, sel ast.Sele
 
 var configurationStatusImplementors = []string{"ConfigurationStatus"}
 
-func (ec *executionContext) _ConfigurationStatus(ctx context.Context, sel ast.SelectionSet, obj *model1.ConfigurationStatus) graphql.Marshaler {
+func (ec *executionContext) _ConfigurationStatus(ctx context.Context, sel ast.SelectionSet, obj *model.ConfigurationStatus) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, configurationStatusImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19226,6 +21720,10 @@ func (ec *executionContext) _ConfigurationStatus(ctx context.Context, sel ast.Se
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "rollback":
+
+			out.Values[i] = ec._ConfigurationStatus_rollback(ctx, field, obj)
+
 		case "current":
 
 			out.Values[i] = ec._ConfigurationStatus_current(ctx, field, obj)
@@ -19260,7 +21758,7 @@ func (ec *executionContext) _ConfigurationStatus(ctx context.Context, sel ast.Se
 
 var configurationsImplementors = []string{"Configurations"}
 
-func (ec *executionContext) _Configurations(ctx context.Context, sel ast.SelectionSet, obj *model.Configurations) graphql.Marshaler {
+func (ec *executionContext) _Configurations(ctx context.Context, sel ast.SelectionSet, obj *model1.Configurations) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, configurationsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19294,9 +21792,9 @@ func (ec *executionContext) _Configurations(ctx context.Context, sel ast.Selecti
 	return out
 }
 
-var destinationImplementors = []string{"Destination"}
+var destinationImplementors = []string{"Destination", "VersionedResource"}
 
-func (ec *executionContext) _Destination(ctx context.Context, sel ast.SelectionSet, obj *model1.Destination) graphql.Marshaler {
+func (ec *executionContext) _Destination(ctx context.Context, sel ast.SelectionSet, obj *model.Destination) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, destinationImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19358,7 +21856,7 @@ func (ec *executionContext) _Destination(ctx context.Context, sel ast.SelectionS
 
 var destinationTypeImplementors = []string{"DestinationType"}
 
-func (ec *executionContext) _DestinationType(ctx context.Context, sel ast.SelectionSet, obj *model1.DestinationType) graphql.Marshaler {
+func (ec *executionContext) _DestinationType(ctx context.Context, sel ast.SelectionSet, obj *model.DestinationType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, destinationTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19420,7 +21918,7 @@ func (ec *executionContext) _DestinationType(ctx context.Context, sel ast.Select
 
 var destinationWithTypeImplementors = []string{"DestinationWithType"}
 
-func (ec *executionContext) _DestinationWithType(ctx context.Context, sel ast.SelectionSet, obj *model.DestinationWithType) graphql.Marshaler {
+func (ec *executionContext) _DestinationWithType(ctx context.Context, sel ast.SelectionSet, obj *model1.DestinationWithType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, destinationWithTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19449,7 +21947,7 @@ func (ec *executionContext) _DestinationWithType(ctx context.Context, sel ast.Se
 
 var documentationLinkImplementors = []string{"DocumentationLink"}
 
-func (ec *executionContext) _DocumentationLink(ctx context.Context, sel ast.SelectionSet, obj *model1.DocumentationLink) graphql.Marshaler {
+func (ec *executionContext) _DocumentationLink(ctx context.Context, sel ast.SelectionSet, obj *model.DocumentationLink) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, documentationLinkImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19526,7 +22024,7 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 
 var edgeMetricImplementors = []string{"EdgeMetric"}
 
-func (ec *executionContext) _EdgeMetric(ctx context.Context, sel ast.SelectionSet, obj *model.EdgeMetric) graphql.Marshaler {
+func (ec *executionContext) _EdgeMetric(ctx context.Context, sel ast.SelectionSet, obj *model1.EdgeMetric) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, edgeMetricImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19638,7 +22136,7 @@ func (ec *executionContext) _Graph(ctx context.Context, sel ast.SelectionSet, ob
 
 var graphMetricImplementors = []string{"GraphMetric"}
 
-func (ec *executionContext) _GraphMetric(ctx context.Context, sel ast.SelectionSet, obj *model.GraphMetric) graphql.Marshaler {
+func (ec *executionContext) _GraphMetric(ctx context.Context, sel ast.SelectionSet, obj *model1.GraphMetric) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, graphMetricImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19698,7 +22196,7 @@ func (ec *executionContext) _GraphMetric(ctx context.Context, sel ast.SelectionS
 
 var graphMetricsImplementors = []string{"GraphMetrics"}
 
-func (ec *executionContext) _GraphMetrics(ctx context.Context, sel ast.SelectionSet, obj *model.GraphMetrics) graphql.Marshaler {
+func (ec *executionContext) _GraphMetrics(ctx context.Context, sel ast.SelectionSet, obj *model1.GraphMetrics) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, graphMetricsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19793,9 +22291,51 @@ func (ec *executionContext) _Log(ctx context.Context, sel ast.SelectionSet, obj
 	return out
 }
 
+var maintenanceWindowImplementors = []string{"MaintenanceWindow"}
+
+func (ec *executionContext) _MaintenanceWindow(ctx context.Context, sel ast.SelectionSet, obj *model.MaintenanceWindow) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, maintenanceWindowImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("MaintenanceWindow")
+		case "cron":
+
+			out.Values[i] = ec._MaintenanceWindow_cron(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "duration":
+
+			out.Values[i] = ec._MaintenanceWindow_duration(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "timezone":
+
+			out.Values[i] = ec._MaintenanceWindow_timezone(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var metadataImplementors = []string{"Metadata"}
 
-func (ec *executionContext) _Metadata(ctx context.Context, sel ast.SelectionSet, obj *model1.Metadata) graphql.Marshaler {
+func (ec *executionContext) _Metadata(ctx context.Context, sel ast.SelectionSet, obj *model.Metadata) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, metadataImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19927,7 +22467,7 @@ func (ec *executionContext) _Metric(ctx context.Context, sel ast.SelectionSet, o
 
 var metricCategoryImplementors = []string{"MetricCategory"}
 
-func (ec *executionContext) _MetricCategory(ctx context.Context, sel ast.SelectionSet, obj *model1.MetricCategory) graphql.Marshaler {
+func (ec *executionContext) _MetricCategory(ctx context.Context, sel ast.SelectionSet, obj *model.MetricCategory) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, metricCategoryImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -19969,7 +22509,7 @@ func (ec *executionContext) _MetricCategory(ctx context.Context, sel ast.Selecti
 
 var metricOptionImplementors = []string{"MetricOption"}
 
-func (ec *executionContext) _MetricOption(ctx context.Context, sel ast.SelectionSet, obj *model1.MetricOption) graphql.Marshaler {
+func (ec *executionContext) _MetricOption(ctx context.Context, sel ast.SelectionSet, obj *model.MetricOption) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, metricOptionImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20112,7 +22652,7 @@ func (ec *executionContext) _Node(ctx context.Context, sel ast.SelectionSet, obj
 
 var overviewPageImplementors = []string{"OverviewPage"}
 
-func (ec *executionContext) _OverviewPage(ctx context.Context, sel ast.SelectionSet, obj *model.OverviewPage) graphql.Marshaler {
+func (ec *executionContext) _OverviewPage(ctx context.Context, sel ast.SelectionSet, obj *model1.OverviewPage) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, overviewPageImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20140,7 +22680,7 @@ func (ec *executionContext) _OverviewPage(ctx context.Context, sel ast.Selection
 
 var parameterImplementors = []string{"Parameter"}
 
-func (ec *executionContext) _Parameter(ctx context.Context, sel ast.SelectionSet, obj *model1.Parameter) graphql.Marshaler {
+func (ec *executionContext) _Parameter(ctx context.Context, sel ast.SelectionSet, obj *model.Parameter) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, parameterImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20179,7 +22719,7 @@ func (ec *executionContext) _Parameter(ctx context.Context, sel ast.SelectionSet
 
 var parameterDefinitionImplementors = []string{"ParameterDefinition"}
 
-func (ec *executionContext) _ParameterDefinition(ctx context.Context, sel ast.SelectionSet, obj *model1.ParameterDefinition) graphql.Marshaler {
+func (ec *executionContext) _ParameterDefinition(ctx context.Context, sel ast.SelectionSet, obj *model.ParameterDefinition) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, parameterDefinitionImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20275,7 +22815,7 @@ func (ec *executionContext) _ParameterDefinition(ctx context.Context, sel ast.Se
 
 var parameterOptionsImplementors = []string{"ParameterOptions"}
 
-func (ec *executionContext) _ParameterOptions(ctx context.Context, sel ast.SelectionSet, obj *model1.ParameterOptions) graphql.Marshaler {
+func (ec *executionContext) _ParameterOptions(ctx context.Context, sel ast.SelectionSet, obj *model.ParameterOptions) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, parameterOptionsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20353,7 +22893,7 @@ func (ec *executionContext) _ParameterOptions(ctx context.Context, sel ast.Selec
 
 var parameterizedSpecImplementors = []string{"ParameterizedSpec"}
 
-func (ec *executionContext) _ParameterizedSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ParameterizedSpec) graphql.Marshaler {
+func (ec *executionContext) _ParameterizedSpec(ctx context.Context, sel ast.SelectionSet, obj *model.ParameterizedSpec) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, parameterizedSpecImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20396,7 +22936,7 @@ func (ec *executionContext) _ParameterizedSpec(ctx context.Context, sel ast.Sele
 
 var phaseAgentCountImplementors = []string{"PhaseAgentCount"}
 
-func (ec *executionContext) _PhaseAgentCount(ctx context.Context, sel ast.SelectionSet, obj *model1.PhaseAgentCount) graphql.Marshaler {
+func (ec *executionContext) _PhaseAgentCount(ctx context.Context, sel ast.SelectionSet, obj *model.PhaseAgentCount) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, phaseAgentCountImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20436,9 +22976,9 @@ func (ec *executionContext) _PhaseAgentCount(ctx context.Context, sel ast.Select
 	return out
 }
 
-var processorImplementors = []string{"Processor"}
+var processorImplementors = []string{"Processor", "VersionedResource"}
 
-func (ec *executionContext) _Processor(ctx context.Context, sel ast.SelectionSet, obj *model1.Processor) graphql.Marshaler {
+func (ec *executionContext) _Processor(ctx context.Context, sel ast.SelectionSet, obj *model.Processor) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, processorImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20500,7 +23040,7 @@ func (ec *executionContext) _Processor(ctx context.Context, sel ast.SelectionSet
 
 var processorTypeImplementors = []string{"ProcessorType"}
 
-func (ec *executionContext) _ProcessorType(ctx context.Context, sel ast.SelectionSet, obj *model1.ProcessorType) graphql.Marshaler {
+func (ec *executionContext) _ProcessorType(ctx context.Context, sel ast.SelectionSet, obj *model.ProcessorType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, processorTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20562,7 +23102,7 @@ func (ec *executionContext) _ProcessorType(ctx context.Context, sel ast.Selectio
 
 var processorWithTypeImplementors = []string{"ProcessorWithType"}
 
-func (ec *executionContext) _ProcessorWithType(ctx context.Context, sel ast.SelectionSet, obj *model.ProcessorWithType) graphql.Marshaler {
+func (ec *executionContext) _ProcessorWithType(ctx context.Context, sel ast.SelectionSet, obj *model1.ProcessorWithType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, processorWithTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -20737,6 +23277,29 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "resourceHistory":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_resourceHistory(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
@@ -21184,7 +23747,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 
 var relevantIfConditionImplementors = []string{"RelevantIfCondition"}
 
-func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.SelectionSet, obj *model1.RelevantIfCondition) graphql.Marshaler {
+func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.SelectionSet, obj *model.RelevantIfCondition) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, relevantIfConditionImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21239,7 +23802,7 @@ func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.Se
 
 var resourceConfigurationImplementors = []string{"ResourceConfiguration"}
 
-func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceConfiguration) graphql.Marshaler {
+func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model.ResourceConfiguration) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, resourceConfigurationImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21259,6 +23822,10 @@ func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.
 
 			out.Values[i] = ec._ResourceConfiguration_displayName(ctx, field, obj)
 
+		case "version":
+
+			out.Values[i] = ec._ResourceConfiguration_version(ctx, field, obj)
+
 		case "type":
 
 			out.Values[i] = ec._ResourceConfiguration_type(ctx, field, obj)
@@ -21291,7 +23858,7 @@ func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.
 
 var resourceTypeSpecImplementors = []string{"ResourceTypeSpec"}
 
-func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceTypeSpec) graphql.Marshaler {
+func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, obj *model.ResourceTypeSpec) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, resourceTypeSpecImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21308,21 +23875,293 @@ func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.Selec
 			}
 		case "parameters":
 
-			out.Values[i] = ec._ResourceTypeSpec_parameters(ctx, field, obj)
+			out.Values[i] = ec._ResourceTypeSpec_parameters(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "supportedPlatforms":
+
+			out.Values[i] = ec._ResourceTypeSpec_supportedPlatforms(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "telemetryTypes":
+
+			out.Values[i] = ec._ResourceTypeSpec_telemetryTypes(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var rolloutImplementors = []string{"Rollout"}
+
+func (ec *executionContext) _Rollout(ctx context.Context, sel ast.SelectionSet, obj *model.Rollout) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Rollout")
+		case "options":
+
+			out.Values[i] = ec._Rollout_options(ctx, field, obj)
+
+		case "status":
+
+			out.Values[i] = ec._Rollout_status(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "phase":
+
+			out.Values[i] = ec._Rollout_phase(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "completed":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Rollout_completed(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "errors":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Rollout_errors(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "pending":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Rollout_pending(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "waiting":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Rollout_waiting(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "pausedBySchedule":
+
+			out.Values[i] = ec._Rollout_pausedBySchedule(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "health":
+
+			out.Values[i] = ec._Rollout_health(ctx, field, obj)
+
+		case "canary":
+
+			out.Values[i] = ec._Rollout_canary(ctx, field, obj)
+
+		case "awaitingApproval":
+
+			out.Values[i] = ec._Rollout_awaitingApproval(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var rolloutCanaryImplementors = []string{"RolloutCanary"}
+
+func (ec *executionContext) _RolloutCanary(ctx context.Context, sel ast.SelectionSet, obj *model.RolloutCanary) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutCanaryImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RolloutCanary")
+		case "agents":
+
+			out.Values[i] = ec._RolloutCanary_agents(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "awaitingApproval":
+
+			out.Values[i] = ec._RolloutCanary_awaitingApproval(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "approved":
+
+			out.Values[i] = ec._RolloutCanary_approved(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var rolloutHealthImplementors = []string{"RolloutHealth"}
+
+func (ec *executionContext) _RolloutHealth(ctx context.Context, sel ast.SelectionSet, obj *model.RolloutHealth) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutHealthImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RolloutHealth")
+		case "baseline":
+
+			out.Values[i] = ec._RolloutHealth_baseline(ctx, field, obj)
+
+		case "throughput":
+
+			out.Values[i] = ec._RolloutHealth_throughput(ctx, field, obj)
+
+		case "phase":
+
+			out.Values[i] = ec._RolloutHealth_phase(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "checkStarted":
+
+			out.Values[i] = ec._RolloutHealth_checkStarted(ctx, field, obj)
+
+		case "passed":
+
+			out.Values[i] = ec._RolloutHealth_passed(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "failure":
+
+			out.Values[i] = ec._RolloutHealth_failure(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var rolloutHealthCheckImplementors = []string{"RolloutHealthCheck"}
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "supportedPlatforms":
+func (ec *executionContext) _RolloutHealthCheck(ctx context.Context, sel ast.SelectionSet, obj *model.RolloutHealthCheck) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutHealthCheckImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RolloutHealthCheck")
+		case "minThroughputPercent":
 
-			out.Values[i] = ec._ResourceTypeSpec_supportedPlatforms(ctx, field, obj)
+			out.Values[i] = ec._RolloutHealthCheck_minThroughputPercent(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "telemetryTypes":
+		case "duration":
 
-			out.Values[i] = ec._ResourceTypeSpec_telemetryTypes(ctx, field, obj)
+			out.Values[i] = ec._RolloutHealthCheck_duration(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
 				invalids++
@@ -21338,114 +24177,57 @@ func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.Selec
 	return out
 }
 
-var rolloutImplementors = []string{"Rollout"}
+var rolloutOptionsImplementors = []string{"RolloutOptions"}
 
-func (ec *executionContext) _Rollout(ctx context.Context, sel ast.SelectionSet, obj *model1.Rollout) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutImplementors)
+func (ec *executionContext) _RolloutOptions(ctx context.Context, sel ast.SelectionSet, obj *model.RolloutOptions) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutOptionsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Rollout")
-		case "options":
-
-			out.Values[i] = ec._Rollout_options(ctx, field, obj)
-
-		case "status":
+			out.Values[i] = graphql.MarshalString("RolloutOptions")
+		case "startAutomatically":
 
-			out.Values[i] = ec._Rollout_status(ctx, field, obj)
+			out.Values[i] = ec._RolloutOptions_startAutomatically(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
-				atomic.AddUint32(&invalids, 1)
+				invalids++
 			}
-		case "phase":
+		case "rollbackOnFailure":
 
-			out.Values[i] = ec._Rollout_phase(ctx, field, obj)
+			out.Values[i] = ec._RolloutOptions_rollbackOnFailure(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
-				atomic.AddUint32(&invalids, 1)
+				invalids++
 			}
-		case "completed":
-			field := field
+		case "maxErrors":
 
-			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				defer func() {
-					if r := recover(); r != nil {
-						ec.Error(ctx, ec.Recover(ctx, r))
-					}
-				}()
-				res = ec._Rollout_completed(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
-				return res
-			}
+			out.Values[i] = ec._RolloutOptions_maxErrors(ctx, field, obj)
 
-			out.Concurrently(i, func() graphql.Marshaler {
-				return innerFunc(ctx)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "phaseAgentCount":
 
-			})
-		case "errors":
-			field := field
+			out.Values[i] = ec._RolloutOptions_phaseAgentCount(ctx, field, obj)
 
-			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				defer func() {
-					if r := recover(); r != nil {
-						ec.Error(ctx, ec.Recover(ctx, r))
-					}
-				}()
-				res = ec._Rollout_errors(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
-				return res
-			}
+		case "startAt":
 
-			out.Concurrently(i, func() graphql.Marshaler {
-				return innerFunc(ctx)
+			out.Values[i] = ec._RolloutOptions_startAt(ctx, field, obj)
 
-			})
-		case "pending":
-			field := field
+		case "maintenanceWindow":
 
-			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				defer func() {
-					if r := recover(); r != nil {
-						ec.Error(ctx, ec.Recover(ctx, r))
-					}
-				}()
-				res = ec._Rollout_pending(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
-				return res
-			}
+			out.Values[i] = ec._RolloutOptions_maintenanceWindow(ctx, field, obj)
 
-			out.Concurrently(i, func() graphql.Marshaler {
-				return innerFunc(ctx)
+		case "healthCheck":
 
-			})
-		case "waiting":
-			field := field
+			out.Values[i] = ec._RolloutOptions_healthCheck(ctx, field, obj)
 
-			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				defer func() {
-					if r := recover(); r != nil {
-						ec.Error(ctx, ec.Recover(ctx, r))
-					}
-				}()
-				res = ec._Rollout_waiting(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
-				return res
-			}
+		case "canarySelector":
 
-			out.Concurrently(i, func() graphql.Marshaler {
-				return innerFunc(ctx)
+			out.Values[i] = ec._RolloutOptions_canarySelector(ctx, field, obj)
 
-			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -21457,41 +24239,37 @@ func (ec *executionContext) _Rollout(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var rolloutOptionsImplementors = []string{"RolloutOptions"}
+var rolloutRollbackImplementors = []string{"RolloutRollback"}
 
-func (ec *executionContext) _RolloutOptions(ctx context.Context, sel ast.SelectionSet, obj *model1.RolloutOptions) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutOptionsImplementors)
+func (ec *executionContext) _RolloutRollback(ctx context.Context, sel ast.SelectionSet, obj *model.RolloutRollback) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutRollbackImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("RolloutOptions")
-		case "startAutomatically":
+			out.Values[i] = graphql.MarshalString("RolloutRollback")
+		case "version":
 
-			out.Values[i] = ec._RolloutOptions_startAutomatically(ctx, field, obj)
+			out.Values[i] = ec._RolloutRollback_version(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "rollbackOnFailure":
+		case "agents":
 
-			out.Values[i] = ec._RolloutOptions_rollbackOnFailure(ctx, field, obj)
+			out.Values[i] = ec._RolloutRollback_agents(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "maxErrors":
+		case "date":
 
-			out.Values[i] = ec._RolloutOptions_maxErrors(ctx, field, obj)
+			out.Values[i] = ec._RolloutRollback_date(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "phaseAgentCount":
-
-			out.Values[i] = ec._RolloutOptions_phaseAgentCount(ctx, field, obj)
-
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -21505,7 +24283,7 @@ func (ec *executionContext) _RolloutOptions(ctx context.Context, sel ast.Selecti
 
 var snapshotImplementors = []string{"Snapshot"}
 
-func (ec *executionContext) _Snapshot(ctx context.Context, sel ast.SelectionSet, obj *model.Snapshot) graphql.Marshaler {
+func (ec *executionContext) _Snapshot(ctx context.Context, sel ast.SelectionSet, obj *model1.Snapshot) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, snapshotImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21545,9 +24323,9 @@ func (ec *executionContext) _Snapshot(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var sourceImplementors = []string{"Source"}
+var sourceImplementors = []string{"Source", "VersionedResource"}
 
-func (ec *executionContext) _Source(ctx context.Context, sel ast.SelectionSet, obj *model1.Source) graphql.Marshaler {
+func (ec *executionContext) _Source(ctx context.Context, sel ast.SelectionSet, obj *model.Source) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, sourceImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21609,7 +24387,7 @@ func (ec *executionContext) _Source(ctx context.Context, sel ast.SelectionSet, o
 
 var sourceTypeImplementors = []string{"SourceType"}
 
-func (ec *executionContext) _SourceType(ctx context.Context, sel ast.SelectionSet, obj *model1.SourceType) graphql.Marshaler {
+func (ec *executionContext) _SourceType(ctx context.Context, sel ast.SelectionSet, obj *model.SourceType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, sourceTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -21671,7 +24449,7 @@ func (ec *executionContext) _SourceType(ctx context.Context, sel ast.SelectionSe
 
 var sourceWithTypeImplementors = []string{"SourceWithType"}
 
-func (ec *executionContext) _SourceWithType(ctx context.Context, sel ast.SelectionSet, obj *model.SourceWithType) graphql.Marshaler {
+func (ec *executionContext) _SourceWithType(ctx context.Context, sel ast.SelectionSet, obj *model1.SourceWithType) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, sourceWithTypeImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
@@ -22132,7 +24910,7 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 
 // region    ***************************** type.gotpl *****************************
 
-func (ec *executionContext) marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Agent) graphql.Marshaler {
+func (ec *executionContext) marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Agent) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22176,7 +24954,7 @@ func (ec *executionContext) marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindpl
 	return ret
 }
 
-func (ec *executionContext) marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx context.Context, sel ast.SelectionSet, v *model1.Agent) graphql.Marshaler {
+func (ec *executionContext) marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx context.Context, sel ast.SelectionSet, v *model.Agent) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22186,7 +24964,7 @@ func (ec *executionContext) marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplane
 	return ec._Agent(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNAgentChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.AgentChange) graphql.Marshaler {
+func (ec *executionContext) marshalNAgentChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.AgentChange) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22230,7 +25008,7 @@ func (ec *executionContext) marshalNAgentChange2ᚕᚖgithubᚗcomᚋobserviqᚋ
 	return ret
 }
 
-func (ec *executionContext) marshalNAgentChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChange(ctx context.Context, sel ast.SelectionSet, v *model.AgentChange) graphql.Marshaler {
+func (ec *executionContext) marshalNAgentChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChange(ctx context.Context, sel ast.SelectionSet, v *model1.AgentChange) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22240,21 +25018,21 @@ func (ec *executionContext) marshalNAgentChange2ᚖgithubᚗcomᚋobserviqᚋbin
 	return ec._AgentChange(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeType(ctx context.Context, v interface{}) (model.AgentChangeType, error) {
-	var res model.AgentChangeType
+func (ec *executionContext) unmarshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeType(ctx context.Context, v interface{}) (model1.AgentChangeType, error) {
+	var res model1.AgentChangeType
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeType(ctx context.Context, sel ast.SelectionSet, v model.AgentChangeType) graphql.Marshaler {
+func (ec *executionContext) marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentChangeType(ctx context.Context, sel ast.SelectionSet, v model1.AgentChangeType) graphql.Marshaler {
 	return v
 }
 
-func (ec *executionContext) marshalNAgents2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgents(ctx context.Context, sel ast.SelectionSet, v model.Agents) graphql.Marshaler {
+func (ec *executionContext) marshalNAgents2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgents(ctx context.Context, sel ast.SelectionSet, v model1.Agents) graphql.Marshaler {
 	return ec._Agents(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgents(ctx context.Context, sel ast.SelectionSet, v *model.Agents) graphql.Marshaler {
+func (ec *executionContext) marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgents(ctx context.Context, sel ast.SelectionSet, v *model1.Agents) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22300,12 +25078,12 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 	return res
 }
 
-func (ec *executionContext) unmarshalNClearAgentUpgradeErrorInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐClearAgentUpgradeErrorInput(ctx context.Context, v interface{}) (model.ClearAgentUpgradeErrorInput, error) {
+func (ec *executionContext) unmarshalNClearAgentUpgradeErrorInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐClearAgentUpgradeErrorInput(ctx context.Context, v interface{}) (model1.ClearAgentUpgradeErrorInput, error) {
 	res, err := ec.unmarshalInputClearAgentUpgradeErrorInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Configuration) graphql.Marshaler {
+func (ec *executionContext) marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Configuration) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22349,7 +25127,7 @@ func (ec *executionContext) marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviq
 	return ret
 }
 
-func (ec *executionContext) marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx context.Context, sel ast.SelectionSet, v *model1.Configuration) graphql.Marshaler {
+func (ec *executionContext) marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx context.Context, sel ast.SelectionSet, v *model.Configuration) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22359,7 +25137,7 @@ func (ec *executionContext) marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋb
 	return ec._Configuration(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNConfigurationChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurationChangeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.ConfigurationChange) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurationChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurationChangeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.ConfigurationChange) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22403,7 +25181,7 @@ func (ec *executionContext) marshalNConfigurationChange2ᚕᚖgithubᚗcomᚋobs
 	return ret
 }
 
-func (ec *executionContext) marshalNConfigurationChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurationChange(ctx context.Context, sel ast.SelectionSet, v *model.ConfigurationChange) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurationChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurationChange(ctx context.Context, sel ast.SelectionSet, v *model1.ConfigurationChange) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22413,19 +25191,19 @@ func (ec *executionContext) marshalNConfigurationChange2ᚖgithubᚗcomᚋobserv
 	return ec._ConfigurationChange(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx context.Context, sel ast.SelectionSet, v model1.ConfigurationSpec) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx context.Context, sel ast.SelectionSet, v model.ConfigurationSpec) graphql.Marshaler {
 	return ec._ConfigurationSpec(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNConfigurationStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationStatus(ctx context.Context, sel ast.SelectionSet, v model1.ConfigurationStatus) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurationStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationStatus(ctx context.Context, sel ast.SelectionSet, v model.ConfigurationStatus) graphql.Marshaler {
 	return ec._ConfigurationStatus(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNConfigurations2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurations(ctx context.Context, sel ast.SelectionSet, v model.Configurations) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurations2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurations(ctx context.Context, sel ast.SelectionSet, v model1.Configurations) graphql.Marshaler {
 	return ec._Configurations(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurations(ctx context.Context, sel ast.SelectionSet, v *model.Configurations) graphql.Marshaler {
+func (ec *executionContext) marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐConfigurations(ctx context.Context, sel ast.SelectionSet, v *model1.Configurations) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22435,7 +25213,7 @@ func (ec *executionContext) marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋ
 	return ec._Configurations(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Destination) graphql.Marshaler {
+func (ec *executionContext) marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Destination) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22479,7 +25257,7 @@ func (ec *executionContext) marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋ
 	return ret
 }
 
-func (ec *executionContext) marshalNDestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx context.Context, sel ast.SelectionSet, v *model1.Destination) graphql.Marshaler {
+func (ec *executionContext) marshalNDestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx context.Context, sel ast.SelectionSet, v *model.Destination) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22489,7 +25267,7 @@ func (ec *executionContext) marshalNDestination2ᚖgithubᚗcomᚋobserviqᚋbin
 	return ec._Destination(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNDestinationType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.DestinationType) graphql.Marshaler {
+func (ec *executionContext) marshalNDestinationType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.DestinationType) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22533,7 +25311,7 @@ func (ec *executionContext) marshalNDestinationType2ᚕᚖgithubᚗcomᚋobservi
 	return ret
 }
 
-func (ec *executionContext) marshalNDestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx context.Context, sel ast.SelectionSet, v *model1.DestinationType) graphql.Marshaler {
+func (ec *executionContext) marshalNDestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx context.Context, sel ast.SelectionSet, v *model.DestinationType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22543,11 +25321,11 @@ func (ec *executionContext) marshalNDestinationType2ᚖgithubᚗcomᚋobserviq
 	return ec._DestinationType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNDestinationWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐDestinationWithType(ctx context.Context, sel ast.SelectionSet, v model.DestinationWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNDestinationWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐDestinationWithType(ctx context.Context, sel ast.SelectionSet, v model1.DestinationWithType) graphql.Marshaler {
 	return ec._DestinationWithType(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNDestinationWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐDestinationWithType(ctx context.Context, sel ast.SelectionSet, v *model.DestinationWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNDestinationWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐDestinationWithType(ctx context.Context, sel ast.SelectionSet, v *model1.DestinationWithType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22557,11 +25335,11 @@ func (ec *executionContext) marshalNDestinationWithType2ᚖgithubᚗcomᚋobserv
 	return ec._DestinationWithType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNDocumentationLink2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLink(ctx context.Context, sel ast.SelectionSet, v model1.DocumentationLink) graphql.Marshaler {
+func (ec *executionContext) marshalNDocumentationLink2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLink(ctx context.Context, sel ast.SelectionSet, v model.DocumentationLink) graphql.Marshaler {
 	return ec._DocumentationLink(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNDocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.DocumentationLink) graphql.Marshaler {
+func (ec *executionContext) marshalNDocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx context.Context, sel ast.SelectionSet, v []model.DocumentationLink) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22659,7 +25437,7 @@ func (ec *executionContext) marshalNEdge2ᚖgithubᚗcomᚋobserviqᚋbindplane
 	return ec._Edge(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNEdgeMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEdgeMetricᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.EdgeMetric) graphql.Marshaler {
+func (ec *executionContext) marshalNEdgeMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEdgeMetricᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.EdgeMetric) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22703,7 +25481,7 @@ func (ec *executionContext) marshalNEdgeMetric2ᚕᚖgithubᚗcomᚋobserviqᚋb
 	return ret
 }
 
-func (ec *executionContext) marshalNEdgeMetric2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEdgeMetric(ctx context.Context, sel ast.SelectionSet, v *model.EdgeMetric) graphql.Marshaler {
+func (ec *executionContext) marshalNEdgeMetric2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEdgeMetric(ctx context.Context, sel ast.SelectionSet, v *model1.EdgeMetric) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22713,18 +25491,18 @@ func (ec *executionContext) marshalNEdgeMetric2ᚖgithubᚗcomᚋobserviqᚋbind
 	return ec._EdgeMetric(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNEditConfigurationDescriptionInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEditConfigurationDescriptionInput(ctx context.Context, v interface{}) (model.EditConfigurationDescriptionInput, error) {
+func (ec *executionContext) unmarshalNEditConfigurationDescriptionInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEditConfigurationDescriptionInput(ctx context.Context, v interface{}) (model1.EditConfigurationDescriptionInput, error) {
 	res, err := ec.unmarshalInputEditConfigurationDescriptionInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEventType(ctx context.Context, v interface{}) (model.EventType, error) {
-	var res model.EventType
+func (ec *executionContext) unmarshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEventType(ctx context.Context, v interface{}) (model1.EventType, error) {
+	var res model1.EventType
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEventType(ctx context.Context, sel ast.SelectionSet, v model.EventType) graphql.Marshaler {
+func (ec *executionContext) marshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐEventType(ctx context.Context, sel ast.SelectionSet, v model1.EventType) graphql.Marshaler {
 	return v
 }
 
@@ -22753,7 +25531,7 @@ func (ec *executionContext) marshalNGraph2ᚖgithubᚗcomᚋobserviqᚋbindplane
 	return ec._Graph(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetricᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.GraphMetric) graphql.Marshaler {
+func (ec *executionContext) marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetricᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.GraphMetric) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -22797,7 +25575,7 @@ func (ec *executionContext) marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋ
 	return ret
 }
 
-func (ec *executionContext) marshalNGraphMetric2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetric(ctx context.Context, sel ast.SelectionSet, v *model.GraphMetric) graphql.Marshaler {
+func (ec *executionContext) marshalNGraphMetric2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetric(ctx context.Context, sel ast.SelectionSet, v *model1.GraphMetric) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22807,11 +25585,11 @@ func (ec *executionContext) marshalNGraphMetric2ᚖgithubᚗcomᚋobserviqᚋbin
 	return ec._GraphMetric(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNGraphMetrics2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx context.Context, sel ast.SelectionSet, v model.GraphMetrics) graphql.Marshaler {
+func (ec *executionContext) marshalNGraphMetrics2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx context.Context, sel ast.SelectionSet, v model1.GraphMetrics) graphql.Marshaler {
 	return ec._GraphMetrics(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx context.Context, sel ast.SelectionSet, v *model.GraphMetrics) graphql.Marshaler {
+func (ec *executionContext) marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐGraphMetrics(ctx context.Context, sel ast.SelectionSet, v *model1.GraphMetrics) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -22926,7 +25704,7 @@ func (ec *executionContext) marshalNMap2map(ctx context.Context, sel ast.Selecti
 	return res
 }
 
-func (ec *executionContext) marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx context.Context, sel ast.SelectionSet, v model1.Metadata) graphql.Marshaler {
+func (ec *executionContext) marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx context.Context, sel ast.SelectionSet, v model.Metadata) graphql.Marshaler {
 	return ec._Metadata(ctx, sel, &v)
 }
 
@@ -22984,15 +25762,15 @@ func (ec *executionContext) marshalNMetric2ᚖgithubᚗcomᚋobserviqᚋbindplan
 	return ec._Metric(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNMetricCategory2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategory(ctx context.Context, sel ast.SelectionSet, v model1.MetricCategory) graphql.Marshaler {
+func (ec *executionContext) marshalNMetricCategory2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategory(ctx context.Context, sel ast.SelectionSet, v model.MetricCategory) graphql.Marshaler {
 	return ec._MetricCategory(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNMetricOption2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOption(ctx context.Context, sel ast.SelectionSet, v model1.MetricOption) graphql.Marshaler {
+func (ec *executionContext) marshalNMetricOption2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOption(ctx context.Context, sel ast.SelectionSet, v model.MetricOption) graphql.Marshaler {
 	return ec._MetricOption(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNMetricOption2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOptionᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.MetricOption) graphql.Marshaler {
+func (ec *executionContext) marshalNMetricOption2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOptionᚄ(ctx context.Context, sel ast.SelectionSet, v []model.MetricOption) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23090,11 +25868,11 @@ func (ec *executionContext) marshalNNode2ᚖgithubᚗcomᚋobserviqᚋbindplane
 	return ec._Node(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNOverviewPage2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐOverviewPage(ctx context.Context, sel ast.SelectionSet, v model.OverviewPage) graphql.Marshaler {
+func (ec *executionContext) marshalNOverviewPage2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐOverviewPage(ctx context.Context, sel ast.SelectionSet, v model1.OverviewPage) graphql.Marshaler {
 	return ec._OverviewPage(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐOverviewPage(ctx context.Context, sel ast.SelectionSet, v *model.OverviewPage) graphql.Marshaler {
+func (ec *executionContext) marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐOverviewPage(ctx context.Context, sel ast.SelectionSet, v *model1.OverviewPage) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23104,15 +25882,15 @@ func (ec *executionContext) marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbi
 	return ec._OverviewPage(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNParameter2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameter(ctx context.Context, sel ast.SelectionSet, v model1.Parameter) graphql.Marshaler {
+func (ec *executionContext) marshalNParameter2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameter(ctx context.Context, sel ast.SelectionSet, v model.Parameter) graphql.Marshaler {
 	return ec._Parameter(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNParameterDefinition2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinition(ctx context.Context, sel ast.SelectionSet, v model1.ParameterDefinition) graphql.Marshaler {
+func (ec *executionContext) marshalNParameterDefinition2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinition(ctx context.Context, sel ast.SelectionSet, v model.ParameterDefinition) graphql.Marshaler {
 	return ec._ParameterDefinition(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNParameterDefinition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinitionᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.ParameterDefinition) graphql.Marshaler {
+func (ec *executionContext) marshalNParameterDefinition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinitionᚄ(ctx context.Context, sel ast.SelectionSet, v []model.ParameterDefinition) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23156,26 +25934,26 @@ func (ec *executionContext) marshalNParameterDefinition2ᚕgithubᚗcomᚋobserv
 	return ret
 }
 
-func (ec *executionContext) unmarshalNParameterInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameter(ctx context.Context, v interface{}) (model1.Parameter, error) {
+func (ec *executionContext) unmarshalNParameterInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameter(ctx context.Context, v interface{}) (model.Parameter, error) {
 	res, err := ec.unmarshalInputParameterInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNParameterOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterOptions(ctx context.Context, sel ast.SelectionSet, v model1.ParameterOptions) graphql.Marshaler {
+func (ec *executionContext) marshalNParameterOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterOptions(ctx context.Context, sel ast.SelectionSet, v model.ParameterOptions) graphql.Marshaler {
 	return ec._ParameterOptions(ctx, sel, &v)
 }
 
-func (ec *executionContext) unmarshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐParameterType(ctx context.Context, v interface{}) (model.ParameterType, error) {
-	var res model.ParameterType
+func (ec *executionContext) unmarshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐParameterType(ctx context.Context, v interface{}) (model1.ParameterType, error) {
+	var res model1.ParameterType
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐParameterType(ctx context.Context, sel ast.SelectionSet, v model.ParameterType) graphql.Marshaler {
+func (ec *executionContext) marshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐParameterType(ctx context.Context, sel ast.SelectionSet, v model1.ParameterType) graphql.Marshaler {
 	return v
 }
 
-func (ec *executionContext) marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx context.Context, sel ast.SelectionSet, v model1.ParameterizedSpec) graphql.Marshaler {
+func (ec *executionContext) marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx context.Context, sel ast.SelectionSet, v model.ParameterizedSpec) graphql.Marshaler {
 	return ec._ParameterizedSpec(ctx, sel, &v)
 }
 
@@ -23256,7 +26034,7 @@ func (ec *executionContext) marshalNPipelineType2ᚕgithubᚗcomᚋobserviqᚋbi
 	return ret
 }
 
-func (ec *executionContext) marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Processor) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Processor) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23300,7 +26078,7 @@ func (ec *executionContext) marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbi
 	return ret
 }
 
-func (ec *executionContext) marshalNProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx context.Context, sel ast.SelectionSet, v *model1.Processor) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx context.Context, sel ast.SelectionSet, v *model.Processor) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23310,13 +26088,13 @@ func (ec *executionContext) marshalNProcessor2ᚖgithubᚗcomᚋobserviqᚋbindp
 	return ec._Processor(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNProcessorInput2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx context.Context, v interface{}) ([]*model1.ResourceConfiguration, error) {
+func (ec *executionContext) unmarshalNProcessorInput2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx context.Context, v interface{}) ([]*model.ResourceConfiguration, error) {
 	var vSlice []interface{}
 	if v != nil {
 		vSlice = graphql.CoerceList(v)
 	}
 	var err error
-	res := make([]*model1.ResourceConfiguration, len(vSlice))
+	res := make([]*model.ResourceConfiguration, len(vSlice))
 	for i := range vSlice {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
 		res[i], err = ec.unmarshalNProcessorInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfiguration(ctx, vSlice[i])
@@ -23327,12 +26105,12 @@ func (ec *executionContext) unmarshalNProcessorInput2ᚕᚖgithubᚗcomᚋobserv
 	return res, nil
 }
 
-func (ec *executionContext) unmarshalNProcessorInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfiguration(ctx context.Context, v interface{}) (*model1.ResourceConfiguration, error) {
+func (ec *executionContext) unmarshalNProcessorInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfiguration(ctx context.Context, v interface{}) (*model.ResourceConfiguration, error) {
 	res, err := ec.unmarshalInputProcessorInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.ProcessorType) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.ProcessorType) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23376,7 +26154,7 @@ func (ec *executionContext) marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviq
 	return ret
 }
 
-func (ec *executionContext) marshalNProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx context.Context, sel ast.SelectionSet, v *model1.ProcessorType) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx context.Context, sel ast.SelectionSet, v *model.ProcessorType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23386,11 +26164,11 @@ func (ec *executionContext) marshalNProcessorType2ᚖgithubᚗcomᚋobserviqᚋb
 	return ec._ProcessorType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNProcessorWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐProcessorWithType(ctx context.Context, sel ast.SelectionSet, v model.ProcessorWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessorWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐProcessorWithType(ctx context.Context, sel ast.SelectionSet, v model1.ProcessorWithType) graphql.Marshaler {
 	return ec._ProcessorWithType(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNProcessorWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐProcessorWithType(ctx context.Context, sel ast.SelectionSet, v *model.ProcessorWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNProcessorWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐProcessorWithType(ctx context.Context, sel ast.SelectionSet, v *model1.ProcessorWithType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23400,57 +26178,73 @@ func (ec *executionContext) marshalNProcessorWithType2ᚖgithubᚗcomᚋobserviq
 	return ec._ProcessorWithType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNRelevantIfCondition2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfCondition(ctx context.Context, sel ast.SelectionSet, v model1.RelevantIfCondition) graphql.Marshaler {
+func (ec *executionContext) marshalNRelevantIfCondition2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfCondition(ctx context.Context, sel ast.SelectionSet, v model.RelevantIfCondition) graphql.Marshaler {
 	return ec._RelevantIfCondition(ctx, sel, &v)
 }
 
-func (ec *executionContext) unmarshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx context.Context, v interface{}) (model.RelevantIfOperatorType, error) {
-	var res model.RelevantIfOperatorType
+func (ec *executionContext) unmarshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx context.Context, v interface{}) (model1.RelevantIfOperatorType, error) {
+	var res model1.RelevantIfOperatorType
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx context.Context, sel ast.SelectionSet, v model.RelevantIfOperatorType) graphql.Marshaler {
+func (ec *executionContext) marshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx context.Context, sel ast.SelectionSet, v model1.RelevantIfOperatorType) graphql.Marshaler {
 	return v
 }
 
-func (ec *executionContext) marshalNResourceConfiguration2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfiguration(ctx context.Context, sel ast.SelectionSet, v model1.ResourceConfiguration) graphql.Marshaler {
+func (ec *executionContext) marshalNResourceConfiguration2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfiguration(ctx context.Context, sel ast.SelectionSet, v model.ResourceConfiguration) graphql.Marshaler {
 	return ec._ResourceConfiguration(ctx, sel, &v)
 }
 
-func (ec *executionContext) unmarshalNResourceTypeKind2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐResourceTypeKind(ctx context.Context, v interface{}) (model.ResourceTypeKind, error) {
-	var res model.ResourceTypeKind
+func (ec *executionContext) unmarshalNResourceTypeKind2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐResourceTypeKind(ctx context.Context, v interface{}) (model1.ResourceTypeKind, error) {
+	var res model1.ResourceTypeKind
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNResourceTypeKind2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐResourceTypeKind(ctx context.Context, sel ast.SelectionSet, v model.ResourceTypeKind) graphql.Marshaler {
+func (ec *executionContext) marshalNResourceTypeKind2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐResourceTypeKind(ctx context.Context, sel ast.SelectionSet, v model1.ResourceTypeKind) graphql.Marshaler {
 	return v
 }
 
-func (ec *executionContext) marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, v model1.ResourceTypeSpec) graphql.Marshaler {
+func (ec *executionContext) marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, v model.ResourceTypeSpec) graphql.Marshaler {
 	return ec._ResourceTypeSpec(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNRollout2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx context.Context, sel ast.SelectionSet, v model1.Rollout) graphql.Marshaler {
+func (ec *executionContext) unmarshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx context.Context, v interface{}) (model.Role, error) {
+	tmp, err := graphql.UnmarshalString(v)
+	res := model.Role(tmp)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNRole2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRole(ctx context.Context, sel ast.SelectionSet, v model.Role) graphql.Marshaler {
+	res := graphql.MarshalString(string(v))
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNRollout2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx context.Context, sel ast.SelectionSet, v model.Rollout) graphql.Marshaler {
 	return ec._Rollout(ctx, sel, &v)
 }
 
-func (ec *executionContext) unmarshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx context.Context, v interface{}) (model1.RolloutStatus, error) {
-	var res model1.RolloutStatus
+func (ec *executionContext) unmarshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx context.Context, v interface{}) (model.RolloutStatus, error) {
+	var res model.RolloutStatus
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx context.Context, sel ast.SelectionSet, v model1.RolloutStatus) graphql.Marshaler {
+func (ec *executionContext) marshalNRolloutStatus2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutStatus(ctx context.Context, sel ast.SelectionSet, v model.RolloutStatus) graphql.Marshaler {
 	return v
 }
 
-func (ec *executionContext) marshalNSnapshot2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSnapshot(ctx context.Context, sel ast.SelectionSet, v model.Snapshot) graphql.Marshaler {
+func (ec *executionContext) marshalNSnapshot2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSnapshot(ctx context.Context, sel ast.SelectionSet, v model1.Snapshot) graphql.Marshaler {
 	return ec._Snapshot(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSnapshot(ctx context.Context, sel ast.SelectionSet, v *model.Snapshot) graphql.Marshaler {
+func (ec *executionContext) marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSnapshot(ctx context.Context, sel ast.SelectionSet, v *model1.Snapshot) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23460,7 +26254,7 @@ func (ec *executionContext) marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindpl
 	return ec._Snapshot(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Source) graphql.Marshaler {
+func (ec *executionContext) marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Source) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23504,7 +26298,7 @@ func (ec *executionContext) marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindp
 	return ret
 }
 
-func (ec *executionContext) marshalNSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx context.Context, sel ast.SelectionSet, v *model1.Source) graphql.Marshaler {
+func (ec *executionContext) marshalNSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx context.Context, sel ast.SelectionSet, v *model.Source) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23514,7 +26308,7 @@ func (ec *executionContext) marshalNSource2ᚖgithubᚗcomᚋobserviqᚋbindplan
 	return ec._Source(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.SourceType) graphql.Marshaler {
+func (ec *executionContext) marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.SourceType) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -23558,7 +26352,7 @@ func (ec *executionContext) marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋb
 	return ret
 }
 
-func (ec *executionContext) marshalNSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx context.Context, sel ast.SelectionSet, v *model1.SourceType) graphql.Marshaler {
+func (ec *executionContext) marshalNSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx context.Context, sel ast.SelectionSet, v *model.SourceType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23568,11 +26362,11 @@ func (ec *executionContext) marshalNSourceType2ᚖgithubᚗcomᚋobserviqᚋbind
 	return ec._SourceType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalNSourceWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSourceWithType(ctx context.Context, sel ast.SelectionSet, v model.SourceWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNSourceWithType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSourceWithType(ctx context.Context, sel ast.SelectionSet, v model1.SourceWithType) graphql.Marshaler {
 	return ec._SourceWithType(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNSourceWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSourceWithType(ctx context.Context, sel ast.SelectionSet, v *model.SourceWithType) graphql.Marshaler {
+func (ec *executionContext) marshalNSourceWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐSourceWithType(ctx context.Context, sel ast.SelectionSet, v *model1.SourceWithType) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -23639,6 +26433,21 @@ func (ec *executionContext) marshalNSuggestion2ᚖgithubᚗcomᚋobserviqᚋbind
 	return ec._Suggestion(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNTime2timeᚐTime(ctx context.Context, v interface{}) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNTrace2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋotlpᚋrecordᚐTraceᚄ(ctx context.Context, sel ast.SelectionSet, v []*record.Trace) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -23693,21 +26502,75 @@ func (ec *executionContext) marshalNTrace2ᚖgithubᚗcomᚋobserviqᚋbindplane
 	return ec._Trace(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNUpdateProcessorsInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐUpdateProcessorsInput(ctx context.Context, v interface{}) (model.UpdateProcessorsInput, error) {
+func (ec *executionContext) unmarshalNUpdateProcessorsInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐUpdateProcessorsInput(ctx context.Context, v interface{}) (model1.UpdateProcessorsInput, error) {
 	res, err := ec.unmarshalInputUpdateProcessorsInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, v interface{}) (model1.Version, error) {
-	var res model1.Version
+func (ec *executionContext) unmarshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, v interface{}) (model.Version, error) {
+	var res model.Version
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, sel ast.SelectionSet, v model1.Version) graphql.Marshaler {
+func (ec *executionContext) marshalNVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, sel ast.SelectionSet, v model.Version) graphql.Marshaler {
 	return v
 }
 
+func (ec *executionContext) marshalNVersionedResource2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResource(ctx context.Context, sel ast.SelectionSet, v model.Resource) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._VersionedResource(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNVersionedResource2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceᚄ(ctx context.Context, sel ast.SelectionSet, v []model.Resource) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNVersionedResource2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResource(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
 	return ec.___Directive(ctx, sel, &v)
 }
@@ -23961,32 +26824,39 @@ func (ec *executionContext) marshalN__TypeKind2string(ctx context.Context, sel a
 	return res
 }
 
-func (ec *executionContext) marshalOAdditionalInfo2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAdditionalInfo(ctx context.Context, sel ast.SelectionSet, v *model1.AdditionalInfo) graphql.Marshaler {
+func (ec *executionContext) marshalOAdditionalInfo2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAdditionalInfo(ctx context.Context, sel ast.SelectionSet, v *model.AdditionalInfo) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._AdditionalInfo(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx context.Context, sel ast.SelectionSet, v *model1.Agent) graphql.Marshaler {
+func (ec *executionContext) marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx context.Context, sel ast.SelectionSet, v *model.Agent) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._Agent(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalOAgentConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentConfiguration(ctx context.Context, sel ast.SelectionSet, v *model.AgentConfiguration) graphql.Marshaler {
+func (ec *executionContext) marshalOAgentConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐAgentConfiguration(ctx context.Context, sel ast.SelectionSet, v *model1.AgentConfiguration) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._AgentConfiguration(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx context.Context, sel ast.SelectionSet, v model1.AgentSelector) graphql.Marshaler {
+func (ec *executionContext) marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx context.Context, sel ast.SelectionSet, v model.AgentSelector) graphql.Marshaler {
 	return ec._AgentSelector(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalOAgentUpgrade2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentUpgrade(ctx context.Context, sel ast.SelectionSet, v *model1.AgentUpgrade) graphql.Marshaler {
+func (ec *executionContext) marshalOAgentSelector2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx context.Context, sel ast.SelectionSet, v *model.AgentSelector) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._AgentSelector(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOAgentUpgrade2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentUpgrade(ctx context.Context, sel ast.SelectionSet, v *model.AgentUpgrade) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24035,28 +26905,28 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
-func (ec *executionContext) marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx context.Context, sel ast.SelectionSet, v *model1.Configuration) graphql.Marshaler {
+func (ec *executionContext) marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx context.Context, sel ast.SelectionSet, v *model.Configuration) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._Configuration(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx context.Context, sel ast.SelectionSet, v *model1.Destination) graphql.Marshaler {
+func (ec *executionContext) marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx context.Context, sel ast.SelectionSet, v *model.Destination) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._Destination(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx context.Context, sel ast.SelectionSet, v *model1.DestinationType) graphql.Marshaler {
+func (ec *executionContext) marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx context.Context, sel ast.SelectionSet, v *model.DestinationType) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._DestinationType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalODocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.DocumentationLink) graphql.Marshaler {
+func (ec *executionContext) marshalODocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx context.Context, sel ast.SelectionSet, v []model.DocumentationLink) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24103,6 +26973,22 @@ func (ec *executionContext) marshalODocumentationLink2ᚕgithubᚗcomᚋobserviq
 	return ret
 }
 
+func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v interface{}) (*float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalFloatContext(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOFloat2ᚖfloat64(ctx context.Context, sel ast.SelectionSet, v *float64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalFloatContext(*v)
+	return graphql.WrapContextMarshaler(ctx, res)
+}
+
 func (ec *executionContext) marshalOGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx context.Context, sel ast.SelectionSet, v *graph.Graph) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -24180,6 +27066,13 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return res
 }
 
+func (ec *executionContext) marshalOMaintenanceWindow2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMaintenanceWindow(ctx context.Context, sel ast.SelectionSet, v *model.MaintenanceWindow) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._MaintenanceWindow(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
 	if v == nil {
 		return nil, nil
@@ -24196,7 +27089,7 @@ func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.Selecti
 	return res
 }
 
-func (ec *executionContext) marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategoryᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.MetricCategory) graphql.Marshaler {
+func (ec *executionContext) marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategoryᚄ(ctx context.Context, sel ast.SelectionSet, v []model.MetricCategory) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24243,7 +27136,7 @@ func (ec *executionContext) marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋ
 	return ret
 }
 
-func (ec *executionContext) marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.Parameter) graphql.Marshaler {
+func (ec *executionContext) marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx context.Context, sel ast.SelectionSet, v []model.Parameter) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24290,7 +27183,7 @@ func (ec *executionContext) marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindp
 	return ret
 }
 
-func (ec *executionContext) unmarshalOParameterInput2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx context.Context, v interface{}) ([]model1.Parameter, error) {
+func (ec *executionContext) unmarshalOParameterInput2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx context.Context, v interface{}) ([]model.Parameter, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -24299,7 +27192,7 @@ func (ec *executionContext) unmarshalOParameterInput2ᚕgithubᚗcomᚋobserviq
 		vSlice = graphql.CoerceList(v)
 	}
 	var err error
-	res := make([]model1.Parameter, len(vSlice))
+	res := make([]model.Parameter, len(vSlice))
 	for i := range vSlice {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
 		res[i], err = ec.unmarshalNParameterInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameter(ctx, vSlice[i])
@@ -24310,25 +27203,25 @@ func (ec *executionContext) unmarshalOParameterInput2ᚕgithubᚗcomᚋobserviq
 	return res, nil
 }
 
-func (ec *executionContext) marshalOPhaseAgentCount2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐPhaseAgentCount(ctx context.Context, sel ast.SelectionSet, v model1.PhaseAgentCount) graphql.Marshaler {
+func (ec *executionContext) marshalOPhaseAgentCount2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐPhaseAgentCount(ctx context.Context, sel ast.SelectionSet, v model.PhaseAgentCount) graphql.Marshaler {
 	return ec._PhaseAgentCount(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx context.Context, sel ast.SelectionSet, v *model1.Processor) graphql.Marshaler {
+func (ec *executionContext) marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx context.Context, sel ast.SelectionSet, v *model.Processor) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._Processor(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx context.Context, sel ast.SelectionSet, v *model1.ProcessorType) graphql.Marshaler {
+func (ec *executionContext) marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx context.Context, sel ast.SelectionSet, v *model.ProcessorType) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._ProcessorType(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfConditionᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.RelevantIfCondition) graphql.Marshaler {
+func (ec *executionContext) marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfConditionᚄ(ctx context.Context, sel ast.SelectionSet, v []model.RelevantIfCondition) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24375,7 +27268,7 @@ func (ec *executionContext) marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserv
 	return ret
 }
 
-func (ec *executionContext) unmarshalORemoveAgentConfigurationInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRemoveAgentConfigurationInput(ctx context.Context, v interface{}) (*model.RemoveAgentConfigurationInput, error) {
+func (ec *executionContext) unmarshalORemoveAgentConfigurationInput2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋgraphqlᚋmodelᚐRemoveAgentConfigurationInput(ctx context.Context, v interface{}) (*model1.RemoveAgentConfigurationInput, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -24383,7 +27276,7 @@ func (ec *executionContext) unmarshalORemoveAgentConfigurationInput2ᚖgithubᚗ
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.ResourceConfiguration) graphql.Marshaler {
+func (ec *executionContext) marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx context.Context, sel ast.SelectionSet, v []model.ResourceConfiguration) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24430,18 +27323,46 @@ func (ec *executionContext) marshalOResourceConfiguration2ᚕgithubᚗcomᚋobse
 	return ret
 }
 
-func (ec *executionContext) marshalORolloutOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutOptions(ctx context.Context, sel ast.SelectionSet, v model1.RolloutOptions) graphql.Marshaler {
+func (ec *executionContext) marshalORolloutCanary2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutCanary(ctx context.Context, sel ast.SelectionSet, v *model.RolloutCanary) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RolloutCanary(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalORolloutHealth2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutHealth(ctx context.Context, sel ast.SelectionSet, v *model.RolloutHealth) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RolloutHealth(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalORolloutHealthCheck2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutHealthCheck(ctx context.Context, sel ast.SelectionSet, v *model.RolloutHealthCheck) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RolloutHealthCheck(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalORolloutOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutOptions(ctx context.Context, sel ast.SelectionSet, v model.RolloutOptions) graphql.Marshaler {
 	return ec._RolloutOptions(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx context.Context, sel ast.SelectionSet, v *model1.Source) graphql.Marshaler {
+func (ec *executionContext) marshalORolloutRollback2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutRollback(ctx context.Context, sel ast.SelectionSet, v *model.RolloutRollback) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._RolloutRollback(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx context.Context, sel ast.SelectionSet, v *model.Source) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return ec._Source(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx context.Context, sel ast.SelectionSet, v *model1.SourceType) graphql.Marshaler {
+func (ec *executionContext) marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx context.Context, sel ast.SelectionSet, v *model.SourceType) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -24585,6 +27506,16 @@ func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel
 	return res
 }
 
+func (ec *executionContext) unmarshalOVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, v interface{}) (model.Version, error) {
+	var res model.Version
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOVersion2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐVersion(ctx context.Context, sel ast.SelectionSet, v model.Version) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -115,3 +115,11 @@ func ClearCurrentAgentUpgradeError(cur *model.Agent) {
 	}
 	cur.Upgrade.Error = ""
 }
+
+// Snapshot contains the recent telemetry of an agent. It is bound in gqlgen.yml so that it isn't confused with the
+// saved model.Snapshot.
+type Snapshot struct {
+	Logs    []*record.Log    `json:"logs"`
+	Metrics []*record.Metric `json:"metrics"`
+	Traces  []*record.Trace  `json:"traces"`
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/model/graph"
-	"github.com/observiq/bindplane-op/otlp/record"
 	"github.com/observiq/bindplane-op/store/search"
 )
 
@@ -94,12 +93,6 @@ type RemoveAgentConfigurationInput struct {
 	AgentID string `json:"agentId"`
 }
 
-type Snapshot struct {
-	Logs    []*record.Log    `json:"logs"`
-	Metrics []*record.Metric `json:"metrics"`
-	Traces  []*record.Trace  `json:"traces"`
-}
-
 type SourceWithType struct {
 	Source     *model.Source     `json:"source"`
 	SourceType *model.SourceType `json:"sourceType"`
@@ -344,46 +337,3 @@ func (e *ResourceTypeKind) UnmarshalGQL(v interface{}) error {
 func (e ResourceTypeKind) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
-
-type Role string
-
-const (
-	RoleAdmin  Role = "admin"
-	RoleUser   Role = "user"
-	RoleViewer Role = "viewer"
-)
-
-var AllRole = []Role{
-	RoleAdmin,
-	RoleUser,
-	RoleViewer,
-}
-
-func (e Role) IsValid() bool {
-	switch e {
-	case RoleAdmin, RoleUser, RoleViewer:
-		return true
-	}
-	return false
-}
-
-func (e Role) String() string {
-	return string(e)
-}
-
-func (e *Role) UnmarshalGQL(v interface{}) error {
-	str, ok := v.(string)
-	if !ok {
-		return fmt.Errorf("enums must be strings")
-	}
-
-	*e = Role(str)
-	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid Role", str)
-	}
-	return nil
-}
-
-func (e Role) MarshalGQL(w io.Writer) {
-	fmt.Fprint(w, strconv.Quote(e.String()))
-}
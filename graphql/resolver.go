@@ -18,10 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 	"time"
 
+	"github.com/observiq/bindplane-op/audit"
 	"github.com/observiq/bindplane-op/eventbus"
 	model1 "github.com/observiq/bindplane-op/graphql/model"
 	"github.com/observiq/bindplane-op/internal/server"
@@ -31,7 +31,6 @@ import (
 	bpotel "github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/otlp/record"
 	exposedserver "github.com/observiq/bindplane-op/server"
-	"github.com/observiq/bindplane-op/server/protocol"
 	"github.com/observiq/bindplane-op/store"
 	"github.com/observiq/bindplane-op/store/search"
 	"github.com/observiq/bindplane-op/store/stats"
@@ -276,6 +275,7 @@ func (r *Resolver) UpdateProcessors(ctx context.Context, input model1.UpdateProc
 	if statuses[0].Status == model.StatusError || statuses[0].Status == model.StatusInvalid {
 		return nil, errors.New(statuses[0].Reason)
 	}
+	r.Bindplane.Audit().Record(ctx, audit.StatusEvents(ctx, statuses)...)
 
 	return nil, nil
 }
@@ -302,6 +302,10 @@ func (r *Resolver) RemoveAgentConfiguration(ctx context.Context, input *model1.R
 		return nil, err
 	}
 
+	event := audit.NewEvent(ctx, model.AuditActionAgentLabels, model.KindAgent, agent.ID, 0)
+	event.Details = newAgent.Labels.String()
+	r.Bindplane.Audit().Record(ctx, event)
+
 	return newAgent, nil
 }
 
@@ -527,100 +531,31 @@ func (r *Resolver) Snapshot(ctx context.Context, agentID string, pipelineType ot
 	ctx, span := tracer.Start(ctx, "resolver/Snapshot")
 	defer span.End()
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
 	signals := &model1.Snapshot{}
-	store := r.Bindplane.Store()
 
-	// get the agent
-	agent, err := store.Agent(ctx, agentID)
-	if err != nil {
-		return signals, err
+	var snapshotPosition, snapshotResourceName string
+	if position != nil && resourceName != nil {
+		snapshotPosition, snapshotResourceName = *position, *resourceName
 	}
 
-	// construct a reporting config for this agent
-	config, err := store.AgentConfiguration(ctx, agent)
-	if err != nil {
+	snapshot, err := server.CaptureSnapshot(ctx, r.Bindplane, agentID, pipelineType, model.MeasurementPosition(snapshotPosition), snapshotResourceName)
+	switch {
+	case errors.Is(err, server.ErrSnapshotTimeout):
+		// the agent may not have any telemetry to report
+		return signals, nil
+	case err != nil:
 		return signals, err
 	}
-	if config == nil {
-		return signals, fmt.Errorf("no configuration available for agent %s", agentID)
-	}
-
-	snapshotProcessorName := otel.SnapshotProcessorName
-	if position != nil && resourceName != nil {
-		snapshotProcessorName = model.SnapshotProcessor(model.MeasurementPosition(*position), *resourceName)
-	}
-
-	reportRequest := func(id string) protocol.Report {
-		rc := protocol.Report{
-			Snapshot: protocol.Snapshot{
-				Processor:    string(snapshotProcessorName),
-				PipelineType: pipelineType,
-				Endpoint: protocol.ReportEndpoint{
-					URL: fmt.Sprintf("%s/v1/otlphttp/v1/%s", r.Bindplane.BindPlaneURL(), pipelineType),
-					Header: http.Header{
-						server.HeaderSessionID: []string{id},
-					},
-				},
-			},
-		}
-		r.Bindplane.Logger().Info("Requesting report", zap.Any("config", rc))
-		return rc
-	}
-
-	// all three cases follow the same pattern:
-	//
-	// 1) receive a channel to await results from relayer
-	//
-	// 2) send a message to the agent with the specified session id
-	//
-	// 3) wait for results or timeout
 
 	switch pipelineType {
 	case otel.Logs:
-		id, result, cancel := r.Bindplane.Relayers().Logs().AwaitResult()
-		defer cancel()
-
-		if err := r.Bindplane.Manager().RequestReport(ctx, agentID, reportRequest(id)); err != nil {
-			return signals, err
-		}
-
-		select {
-		case <-ctx.Done():
-		case logs := <-result:
-			signals.Logs = record.ConvertLogs(logs.OTLP())
-		}
+		signals.Logs, err = snapshot.Logs()
 	case otel.Metrics:
-		id, result, cancel := r.Bindplane.Relayers().Metrics().AwaitResult()
-		defer cancel()
-
-		if err := r.Bindplane.Manager().RequestReport(ctx, agentID, reportRequest(id)); err != nil {
-			return signals, err
-		}
-
-		select {
-		case <-ctx.Done():
-		case metrics := <-result:
-			signals.Metrics = record.ConvertMetrics(ctx, metrics.OTLP())
-		}
+		signals.Metrics, err = snapshot.Metrics(ctx)
 	case otel.Traces:
-		id, result, cancel := r.Bindplane.Relayers().Traces().AwaitResult()
-		defer cancel()
-
-		if err := r.Bindplane.Manager().RequestReport(ctx, agentID, reportRequest(id)); err != nil {
-			return signals, err
-		}
-
-		select {
-		case <-ctx.Done():
-		case traces := <-result:
-			signals.Traces = record.ConvertTraces(traces.OTLP())
-		}
+		signals.Traces, err = snapshot.Traces()
 	}
-
-	return signals, nil
+	return signals, err
 }
 
 // AgentChanges returns a channel of agent changes
@@ -890,7 +825,7 @@ func OverviewMetrics(ctx context.Context, bindplane exposedserver.BindPlane, per
 	if period == "" {
 		period = "1m"
 	}
-	d, err := time.ParseDuration(period)
+	d, err := stats.ParsePeriod(period)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse period %s", period)
 	}
@@ -1030,7 +965,7 @@ func ConfigurationMetrics(ctx context.Context, bindplane exposedserver.BindPlane
 	if name != nil {
 		configurationName = *name
 	}
-	d, err := time.ParseDuration(period)
+	d, err := stats.ParsePeriod(period)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse period %s with duration %s", period, d)
 	}
@@ -1051,7 +986,7 @@ func AgentMetrics(ctx context.Context, bindplane exposedserver.BindPlane, period
 		period = "1m"
 	}
 
-	d, err := time.ParseDuration(period)
+	d, err := stats.ParsePeriod(period)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse period %s with duration %s", period, d)
 	}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/observiq/bindplane-op/audit"
 	"github.com/observiq/bindplane-op/graphql/generated"
 	model1 "github.com/observiq/bindplane-op/graphql/model"
 	"github.com/observiq/bindplane-op/model"
@@ -161,17 +162,28 @@ func (r *mutationResolver) RemoveAgentConfiguration(ctx context.Context, input *
 
 // ClearAgentUpgradeError is the resolver for the clearAgentUpgradeError field.
 func (r *mutationResolver) ClearAgentUpgradeError(ctx context.Context, input model1.ClearAgentUpgradeErrorInput) (*bool, error) {
-	_, err := r.Bindplane.Store().UpdateAgent(ctx, input.AgentID, model1.ClearCurrentAgentUpgradeError)
-	return nil, err
+	agent, err := r.Bindplane.Store().UpdateAgent(ctx, input.AgentID, model1.ClearCurrentAgentUpgradeError)
+	if err != nil || agent == nil {
+		return nil, err
+	}
+
+	event := audit.NewEvent(ctx, model.AuditActionAgentUpgrade, model.KindAgent, agent.ID, 0)
+	event.Details = "cleared upgrade error"
+	r.Bindplane.Audit().Record(ctx, event)
+	return nil, nil
 }
 
 // EditConfigurationDescription is the resolver for the editConfigurationDescription field.
 func (r *mutationResolver) EditConfigurationDescription(ctx context.Context, input model1.EditConfigurationDescriptionInput) (*bool, error) {
-	_, _, err := r.Bindplane.Store().UpdateConfiguration(ctx, input.Name, func(current *model.Configuration) {
+	config, status, err := r.Bindplane.Store().UpdateConfiguration(ctx, input.Name, func(current *model.Configuration) {
 		current.Metadata.Description = input.Description
 	})
+	if err != nil || config == nil || status != model.StatusConfigured {
+		return nil, err
+	}
 
-	return nil, err
+	r.Bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionUpdate, config))
+	return nil, nil
 }
 
 // Type is the resolver for the type field.
@@ -243,6 +255,33 @@ func (r *queryResolver) ConfigurationHistory(ctx context.Context, name string) (
 	return configurationHistory, nil
 }
 
+// ResourceHistory is the resolver for the resourceHistory field.
+func (r *queryResolver) ResourceHistory(ctx context.Context, kind string, name string) ([]model.Resource, error) {
+	resourceKind := model.ParseKind(kind)
+	switch resourceKind {
+	case model.KindSource, model.KindProcessor, model.KindDestination:
+	default:
+		return nil, fmt.Errorf("resourceHistory resolver, unsupported kind: %s", kind)
+	}
+
+	archive, ok := r.Bindplane.Store().(store.ArchiveStore)
+	if !ok {
+		return nil, errors.New("cannot get resource history from non-archive store")
+	}
+
+	history, err := archive.ResourceHistory(ctx, resourceKind, name)
+	if err != nil {
+		return nil, fmt.Errorf("resourceHistory resolver, archive: %w", err)
+	}
+
+	resourceHistory, err := model.ParseResources(history)
+	if err != nil {
+		return nil, fmt.Errorf("resourceHistory resolver, parsing history: %w", err)
+	}
+
+	return resourceHistory, nil
+}
+
 // Sources is the resolver for the sources field.
 func (r *queryResolver) Sources(ctx context.Context) ([]*model.Source, error) {
 	return r.Bindplane.Store().Sources(ctx)
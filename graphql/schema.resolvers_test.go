@@ -22,6 +22,7 @@ import (
 	"github.com/99designs/gqlgen/client"
 	"github.com/observiq/bindplane-op/agent"
 	agentMocks "github.com/observiq/bindplane-op/agent/mocks"
+	"github.com/observiq/bindplane-op/authenticator"
 	"github.com/observiq/bindplane-op/config"
 	sourceMocks "github.com/observiq/bindplane-op/eventbus/mocks"
 	model1 "github.com/observiq/bindplane-op/graphql/model"
@@ -121,7 +122,7 @@ func TestQueryResolvers(t *testing.T) {
 	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), mapstore, mockVersions(), mockBatcher)
 
 	srv := NewHandler(bindplane)
-	c := client.New(srv)
+	c := client.New(srv, withRole(model.RoleViewer))
 
 	s := bindplane.Store()
 
@@ -169,6 +170,52 @@ func TestQueryResolvers(t *testing.T) {
 	})
 }
 
+func TestMutationRoles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mapstore := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), mapstore, mockVersions(), mockBatcher)
+
+	_, err := mapstore.ApplyResources(ctx, []model.Resource{model.NewConfiguration("config")})
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(bindplane))
+	mutation := `mutation TestMutation { editConfigurationDescription(input: { name: "config", description: "new" }) }`
+
+	var resp map[string]any
+	err = c.Post(mutation, &resp)
+	require.ErrorContains(t, err, "user role required")
+
+	err = c.Post(mutation, &resp, withRole(model.RoleViewer))
+	require.ErrorContains(t, err, "user role required")
+
+	err = c.Post(mutation, &resp, withRole(model.RoleUser))
+	require.NoError(t, err)
+
+	err = c.Post(mutation, &resp, withRole(model.RoleAdmin))
+	require.NoError(t, err)
+
+	// queries and subscriptions require the viewer role like the GET routes of the REST API
+	query := `query TestQuery { agents(selector: "") { agents { id } } }`
+	err = c.Post(query, &resp)
+	require.ErrorContains(t, err, "viewer role required")
+
+	err = c.Post(query, &resp, withRole(model.RoleViewer))
+	require.NoError(t, err)
+}
+
+// withRole sets the role of the request like middleware.ResolveRole
+func withRole(role model.Role) client.Option {
+	return func(r *client.Request) {
+		r.HTTP = r.HTTP.WithContext(authenticator.WithRole(r.HTTP.Context(), role))
+	}
+}
+
 func TestConfigForAgent(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -181,7 +228,7 @@ func TestConfigForAgent(t *testing.T) {
 	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), mapstore, mockVersions(), mockBatcher)
 
 	srv := NewHandler(bindplane)
-	c := client.New(srv)
+	c := client.New(srv, withRole(model.RoleViewer))
 
 	store := bindplane.Store()
 
@@ -217,6 +264,10 @@ func TestConfigForAgent(t *testing.T) {
 	_, err := bindplane.Store().ApplyResources(ctx, []model.Resource{config})
 	require.NoError(t, err)
 
+	// agent 1 receives the configuration when it is rolled out
+	_, err = bindplane.Store().StartRollout(ctx, "config", nil)
+	require.NoError(t, err)
+
 	resp := &struct {
 		Agents struct {
 			Agents []struct {
@@ -411,6 +462,146 @@ func Test_queryResolver_ConfigurationHistory(t *testing.T) {
 	}
 }
 
+func Test_queryResolver_ResourceHistory(t *testing.T) {
+	updates := sourceMocks.NewMockSource[store.BasicEventUpdates](t)
+	updates.On("Subscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	tests := []struct {
+		name           string
+		kind           string
+		store          func(t *testing.T) store.Store
+		want           []model.Resource
+		wantErr        bool
+		wantErrMessage string
+	}{
+		{
+			"unsupported kind",
+			"Configuration",
+			func(t *testing.T) store.Store {
+				s := mocks.NewMockStore(t)
+				s.On("Updates", mock.Anything).Return(updates)
+				return s
+			},
+			nil,
+			true,
+			"resourceHistory resolver, unsupported kind: Configuration",
+		},
+		{
+			"ResourceHistory fails",
+			"Destination",
+			func(t *testing.T) store.Store {
+				s := mocks.NewMockStore(t)
+				s.On("Updates", mock.Anything).Return(updates)
+
+				s.On("ResourceHistory", mock.Anything, model.KindDestination, "name").Return(nil, errors.New("error"))
+				return s
+			},
+			nil,
+			true,
+			"resourceHistory resolver, archive: error",
+		},
+		{
+			"error parsing",
+			"Source",
+			func(t *testing.T) store.Store {
+				s := mocks.NewMockStore(t)
+				s.On("Updates", mock.Anything).Return(updates)
+
+				s.On("ResourceHistory", mock.Anything, model.KindSource, "name").Return(
+					[]*model.AnyResource{
+						{
+							ResourceMeta: model.ResourceMeta{
+								Kind:     model.KindUnknown,
+								Metadata: model.Metadata{},
+							},
+						},
+					}, nil)
+				return s
+			},
+			nil,
+			true,
+			"resourceHistory resolver, parsing history: unknown resource kind: Unknown",
+		},
+		{
+			"returns processors",
+			"processors",
+			func(t *testing.T) store.Store {
+				s := mocks.NewMockStore(t)
+				s.On("Updates", mock.Anything).Return(updates)
+
+				s.On("ResourceHistory", mock.Anything, model.KindProcessor, "name").Return(
+					[]*model.AnyResource{
+						{
+							ResourceMeta: model.ResourceMeta{
+								Kind: model.KindProcessor,
+								Metadata: model.Metadata{
+									Version: 1,
+								},
+							},
+						},
+						{
+							ResourceMeta: model.ResourceMeta{
+								Kind: model.KindProcessor,
+								Metadata: model.Metadata{
+									Version: 2,
+								},
+							},
+						},
+					}, nil)
+				return s
+			},
+			[]model.Resource{
+				&model.Processor{
+					ResourceMeta: model.ResourceMeta{
+						Kind: model.KindProcessor,
+						Metadata: model.Metadata{
+							Version: 1,
+						},
+					},
+				},
+				&model.Processor{
+					ResourceMeta: model.ResourceMeta{
+						Kind: model.KindProcessor,
+						Metadata: model.Metadata{
+							Version: 2,
+						},
+					},
+				},
+			},
+			false,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+			bindplane := server.NewBindPlane(
+				&config.Config{},
+				zap.NewNop(),
+				tt.store(t),
+				mockVersions(),
+				mockBatcher,
+			)
+
+			resolver := NewResolver(bindplane)
+			r := &queryResolver{
+				Resolver: resolver,
+			}
+			got, err := r.ResourceHistory(context.Background(), tt.kind, "name")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMessage, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_mutationResolver_ClearAgentUpgradeError(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -434,7 +625,8 @@ func Test_mutationResolver_ClearAgentUpgradeError(t *testing.T) {
 			"update succeeds",
 			func(t *testing.T) store.Store {
 				s := mocks.NewMockStore(t)
-				s.On("UpdateAgent", mock.Anything, "1", mock.AnythingOfType("store.AgentUpdater")).Return(&model.Agent{}, nil)
+				s.On("UpdateAgent", mock.Anything, "1", mock.AnythingOfType("store.AgentUpdater")).Return(&model.Agent{ID: "1"}, nil)
+				s.On("AddAuditEvents", mock.Anything, mock.Anything).Return(nil)
 				return s
 			},
 			&model1.ClearAgentUpgradeErrorInput{
@@ -489,6 +681,7 @@ func Test_mutationResolver_EditConfigurationDescription(t *testing.T) {
 				s := mocks.NewMockStore(t)
 				s.On("Updates", mock.Anything).Return(updates)
 				s.On("UpdateConfiguration", mock.Anything, configName, mock.Anything).Return(&model.Configuration{}, model.StatusConfigured, nil)
+				s.On("AddAuditEvents", mock.Anything, mock.Anything).Return(nil)
 
 				return s
 			},
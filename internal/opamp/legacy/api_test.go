@@ -595,6 +595,11 @@ service:
 				statuses, err := testMapStore.ApplyResources(context.Background(), []model.Resource{raw})
 				require.Equal(t, model.StatusCreated, statuses[0].Status)
 				require.NoError(t, err)
+				// mark the configuration as rolled out so that it is sent to matching agents
+				_, _, err = testMapStore.UpdateConfiguration(context.Background(), raw.Name(), func(current *model.Configuration) {
+					current.Status.CurrentVersion = current.Version()
+				})
+				require.NoError(t, err)
 			},
 		},
 		{
@@ -678,7 +683,7 @@ service:
 			verify: func(t *testing.T, server *legacyOpampServer, result *protobufs.ServerToAgent) {
 				agent, err := server.manager.Agent(context.Background(), agentID)
 				require.NoError(t, err)
-				require.Equal(t, "api-test:0", agent.ConfigurationStatus.Current)
+				require.Equal(t, "api-test:1", agent.ConfigurationStatus.Current)
 			},
 		},
 		{
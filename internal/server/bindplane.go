@@ -19,8 +19,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/observiq/bindplane-op/agent"
+	"github.com/observiq/bindplane-op/audit"
 	"github.com/observiq/bindplane-op/authenticator"
 	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/gitsync"
 	bpserver "github.com/observiq/bindplane-op/server"
 	"github.com/observiq/bindplane-op/store"
 	"github.com/observiq/bindplane-op/store/stats"
@@ -36,13 +38,25 @@ func NewBindPlane(cfg *config.Config, logger *zap.Logger, s store.Store, version
 			manager:            bpserver.NewManager(cfg, s, versions, logger),
 			relayers:           NewRelayers(logger),
 			versions:           versions,
-			authenticator:      authenticator.NewBasicAuthenticator(cfg.Auth.Username, cfg.Auth.Password),
+			authenticator:      newAuthenticator(cfg, s, logger),
+			audit:              audit.NewRecorder(s, cfg.Audit.FilePath, cfg.Audit.MaxFileSizeBytes(), logger),
+			gitSync:            gitsync.NewSyncer(cfg.GitSync, s, logger),
 			measurementBatcher: batcher,
 		},
 	}
 }
 
 // ----------------------------------------------------------------------
+// newAuthenticator returns the authenticator for the server profile user, stored users, API keys, and single sign-on if
+// it is configured
+func newAuthenticator(cfg *config.Config, s store.Store, logger *zap.Logger) authenticator.Authenticator {
+	auth := authenticator.NewBasicAuthenticator(cfg.Auth.Username, cfg.Auth.Password, s)
+	if cfg.Auth.OIDC.Enabled() {
+		auth = authenticator.NewOIDCAuthenticator(cfg.Auth.OIDC, cfg.Auth.Username, auth, s, logger)
+	}
+	return authenticator.NewAPIKeyAuthenticator(auth, s)
+}
+
 type bindplane struct {
 	config             *config.Config
 	manager            bpserver.Manager
@@ -50,6 +64,8 @@ type bindplane struct {
 	versions           agent.Versions
 	relayers           *Relayers
 	authenticator      authenticator.Authenticator
+	audit              audit.Recorder
+	gitSync            gitsync.Syncer
 	measurementBatcher stats.MeasurementBatcher
 }
 
@@ -76,6 +92,16 @@ func (s *storeBindPlane) Authenticator() authenticator.Authenticator {
 	return s.authenticator
 }
 
+// Audit returns the recorder for changes made through the REST and GraphQL APIs
+func (s *storeBindPlane) Audit() audit.Recorder {
+	return s.audit
+}
+
+// GitSync returns the syncer that applies resources from a git repository
+func (s *storeBindPlane) GitSync() gitsync.Syncer {
+	return s.gitSync
+}
+
 // ----------------------------------------------------------------------
 
 type storeBindPlane struct {
@@ -112,6 +138,10 @@ func (s *storeBindPlane) SecretKey() string {
 	return s.config.Auth.SecretKey
 }
 
+func (s *storeBindPlane) ServerUsername() string {
+	return s.config.Auth.Username
+}
+
 func (s *storeBindPlane) BindPlaneInsecureSkipVerify() bool {
 	return s.config.BindPlaneInsecureSkipVerify()
 }
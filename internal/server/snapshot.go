@@ -0,0 +1,154 @@
+// Copyright  observIQ, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/model/otel"
+	exposedserver "github.com/observiq/bindplane-op/server"
+	"github.com/observiq/bindplane-op/server/protocol"
+)
+
+// SnapshotTimeout is the maximum time to wait for an agent to report a snapshot
+var SnapshotTimeout = 30 * time.Second
+
+// ErrSnapshotTimeout is returned by CaptureSnapshot if the agent does not report the snapshot before the
+// SnapshotTimeout
+var ErrSnapshotTimeout = errors.New("timed out waiting for the agent to report the snapshot")
+
+// ErrSnapshotAgentNotFound is returned by CaptureSnapshot if the agent does not exist
+var ErrSnapshotAgentNotFound = errors.New("agent not found")
+
+// CaptureSnapshot requests a snapshot from a snapshot processor of the agent and waits for the agent to report it. The
+// position and resourceName identify the snapshot processor, e.g. s0 of a source is before the source processors. If
+// resourceName is empty, the snapshot processor at the end of each pipeline is used. The snapshot is not saved.
+func CaptureSnapshot(ctx context.Context, bindplane exposedserver.BindPlane, agentID string, pipelineType otel.PipelineType, position model.MeasurementPosition, resourceName string) (*model.Snapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, SnapshotTimeout)
+	defer cancel()
+
+	store := bindplane.Store()
+
+	agent, err := store.Agent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotAgentNotFound, agentID)
+	}
+
+	// the agent must have a configuration with snapshot processors
+	config, err := store.AgentConfiguration(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("no configuration available for agent %s", agentID)
+	}
+
+	if resourceName == "" {
+		position = ""
+	}
+	processor := model.SnapshotProcessor(position, resourceName)
+
+	reportRequest := func(id string) protocol.Report {
+		rc := protocol.Report{
+			Snapshot: protocol.Snapshot{
+				Processor:    string(processor),
+				PipelineType: pipelineType,
+				Endpoint: protocol.ReportEndpoint{
+					URL: fmt.Sprintf("%s/v1/otlphttp/v1/%s", bindplane.BindPlaneURL(), pipelineType),
+					Header: http.Header{
+						HeaderSessionID: []string{id},
+					},
+				},
+			},
+		}
+		bindplane.Logger().Info("Requesting report", zap.Any("config", rc))
+		return rc
+	}
+
+	var (
+		count int
+		data  []byte
+	)
+	switch pipelineType {
+	case otel.Logs:
+		logs, err := awaitReport(ctx, bindplane, agentID, bindplane.Relayers().Logs(), reportRequest)
+		if err != nil {
+			return nil, err
+		}
+		count = logs.OTLP().LogRecordCount()
+		data, err = json.Marshal(logs)
+		if err != nil {
+			return nil, err
+		}
+	case otel.Metrics:
+		metrics, err := awaitReport(ctx, bindplane, agentID, bindplane.Relayers().Metrics(), reportRequest)
+		if err != nil {
+			return nil, err
+		}
+		count = metrics.OTLP().DataPointCount()
+		data, err = json.Marshal(metrics)
+		if err != nil {
+			return nil, err
+		}
+	case otel.Traces:
+		traces, err := awaitReport(ctx, bindplane, agentID, bindplane.Relayers().Traces(), reportRequest)
+		if err != nil {
+			return nil, err
+		}
+		count = traces.OTLP().SpanCount()
+		data, err = json.Marshal(traces)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown pipeline type: %s", pipelineType)
+	}
+
+	return model.NewSnapshot(agent, pipelineType, position, resourceName, count, data), nil
+}
+
+// awaitReport receives a channel to await the result from the relayer, sends the report request to the agent with the
+// session id of the result, and waits for the result or the timeout.
+func awaitReport[T any](ctx context.Context, bindplane exposedserver.BindPlane, agentID string, relayer exposedserver.Relayer[T], reportRequest func(id string) protocol.Report) (T, error) {
+	var empty T
+
+	id, result, cancel := relayer.AwaitResult()
+	defer cancel()
+
+	if err := bindplane.Manager().RequestReport(ctx, agentID, reportRequest(id)); err != nil {
+		return empty, err
+	}
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return empty, ErrSnapshotTimeout
+		}
+		return empty, ctx.Err()
+	case report := <-result:
+		return report, nil
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Prometheus is a metrics provider that exposes metrics to be scraped by Prometheus. Metrics are collected when they
+// are scraped.
+type Prometheus struct {
+	registry *prometheus.Registry
+	handler  http.Handler
+	mp       *metric.MeterProvider
+	resource *resource.Resource
+}
+
+var _ Handler = (*Prometheus)(nil)
+
+// NewPrometheus returns a new Prometheus metrics provider
+func NewPrometheus(resource *resource.Resource) *Prometheus {
+	registry := prometheus.NewRegistry()
+	return &Prometheus{
+		registry: registry,
+		handler:  promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+		resource: resource,
+	}
+}
+
+// Start sets the provider as the OTel global meter provider. Metric names are not changed by adding units or scope
+// labels so that they are the same as the names of the instruments.
+func (p *Prometheus) Start(_ context.Context) error {
+	exporter, err := otelprometheus.New(
+		otelprometheus.WithRegisterer(p.registry),
+		otelprometheus.WithoutUnits(),
+		otelprometheus.WithoutScopeInfo(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create prometheus exporter: %w", err)
+	}
+
+	p.mp = metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithResource(p.resource),
+	)
+
+	otel.SetMeterProvider(p.mp)
+	return nil
+}
+
+// Shutdown shuts down the provider
+func (p *Prometheus) Shutdown(ctx context.Context) error {
+	if p.mp == nil {
+		return nil
+	}
+	return p.mp.Shutdown(ctx)
+}
+
+// ServeHTTP collects the current metrics and writes them in the Prometheus exposition format
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.mp == nil {
+		http.Error(w, "metrics provider is not started", http.StatusServiceUnavailable)
+		return
+	}
+	p.handler.ServeHTTP(w, r)
+}
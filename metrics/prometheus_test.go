@@ -0,0 +1,115 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestPrometheusServeHTTP(t *testing.T) {
+	ctx := context.Background()
+	p := NewPrometheus(nil)
+	require.NoError(t, p.Start(ctx))
+	defer p.Shutdown(ctx)
+
+	meter := p.mp.Meter("test")
+
+	counter, err := meter.Int64Counter("agent_messages", metric.WithDescription("Number of agent messages received"))
+	require.NoError(t, err)
+	counter.Add(ctx, 3)
+
+	_, err = meter.Int64ObservableGauge("agents",
+		metric.WithDescription("Number of agents by status"),
+		metric.WithInt64Callback(func(_ context.Context, io metric.Int64Observer) error {
+			io.Observe(2, metric.WithAttributes(attribute.String("status", "Connected")))
+			io.Observe(1, metric.WithAttributes(attribute.String("status", "Error")))
+			return nil
+		}))
+	require.NoError(t, err)
+
+	histogram, err := meter.Float64Histogram("store.operation_duration")
+	require.NoError(t, err)
+	histogram.Record(ctx, 5, metric.WithAttributes(attribute.String("operation", "UpdateAgents")))
+	histogram.Record(ctx, 50, metric.WithAttributes(attribute.String("operation", "UpdateAgents")))
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, PrometheusContentType, w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	require.Contains(t, body, "# HELP agent_messages_total Number of agent messages received\n# TYPE agent_messages_total counter\nagent_messages_total 3\n")
+	require.Contains(t, body, "# TYPE agents gauge\n")
+	require.Contains(t, body, "agents{status=\"Connected\"} 2\n")
+	require.Contains(t, body, "agents{status=\"Error\"} 1\n")
+	require.Contains(t, body, "# TYPE store_operation_duration histogram\n")
+	require.Contains(t, body, "store_operation_duration_bucket{operation=\"UpdateAgents\",le=\"5\"} 1\n")
+	require.Contains(t, body, "store_operation_duration_bucket{operation=\"UpdateAgents\",le=\"50\"} 2\n")
+	require.Contains(t, body, "store_operation_duration_bucket{operation=\"UpdateAgents\",le=\"+Inf\"} 2\n")
+	require.Contains(t, body, "store_operation_duration_sum{operation=\"UpdateAgents\"} 55\n")
+	require.Contains(t, body, "store_operation_duration_count{operation=\"UpdateAgents\"} 2\n")
+}
+
+func TestPrometheusServeHTTPNotStarted(t *testing.T) {
+	p := NewPrometheus(nil)
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+// TestPrometheusExposition compares the exposition of each kind of instrument with testdata/prometheus.txt, including
+// label values that must be escaped and the +Inf bucket of histograms
+func TestPrometheusExposition(t *testing.T) {
+	ctx := context.Background()
+	p := NewPrometheus(resource.NewSchemaless(attribute.String("service.name", "bindplane")))
+	require.NoError(t, p.Start(ctx))
+	defer p.Shutdown(ctx)
+
+	meter := p.mp.Meter("test")
+
+	counter, err := meter.Int64Counter("agent_messages", metric.WithDescription("Number of agent messages\nreceived"))
+	require.NoError(t, err)
+	counter.Add(ctx, 3, metric.WithAttributes(attribute.String("agent", `quote " backslash \ newline
+end`)))
+
+	sum, err := meter.Float64UpDownCounter("queue.depth")
+	require.NoError(t, err)
+	sum.Add(ctx, 1.5, metric.WithAttributes(attribute.String("queue-name", "metrics")))
+
+	histogram, err := meter.Float64Histogram("store.operation_duration", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	histogram.Record(ctx, 5, metric.WithAttributes(attribute.String("operation", "UpdateAgents")))
+	histogram.Record(ctx, 20000, metric.WithAttributes(attribute.String("operation", "UpdateAgents")))
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "prometheus.txt"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), w.Body.String())
+}
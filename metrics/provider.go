@@ -17,6 +17,7 @@ package metrics
 
 import (
 	"context"
+	"net/http"
 )
 
 // Provider is a metrics provider
@@ -28,3 +29,9 @@ type Provider interface {
 	// Shutdown shuts down the provider
 	Shutdown(context.Context) error
 }
+
+// Handler is a Provider that serves metrics over HTTP, e.g. to be scraped by Prometheus
+type Handler interface {
+	Provider
+	http.Handler
+}
@@ -0,0 +1,30 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/observiq/bindplane-op/audit"
+	"github.com/observiq/bindplane-op/authenticator"
+)
+
+// AuditActor should follow RequireLogin in the middleware chain. It sets the user or API key identified by
+// authenticator.LoginKey and the client address on the request context so that changes are attributed to them in the
+// audit log.
+func AuditActor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), c.GetString(authenticator.LoginKey), c.ClientIP()))
+	}
+}
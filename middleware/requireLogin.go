@@ -40,6 +40,8 @@ func Chain(server exposedserver.BindPlane) (handlers []gin.HandlerFunc) {
 		server.Authenticator().Middleware(),
 		CheckSession(server),
 		RequireLogin(),
+		ResolveRole(server),
+		AuditActor(),
 	}...)
 
 	return handlers
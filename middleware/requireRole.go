@@ -0,0 +1,50 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observiq/bindplane-op/authenticator"
+	"github.com/observiq/bindplane-op/model"
+	exposedserver "github.com/observiq/bindplane-op/server"
+)
+
+// ResolveRole should follow RequireLogin in the middleware chain. It looks up the role of the user identified by
+// authenticator.LoginKey and sets it on the gin context with authenticator.RoleKey and on the request context so that
+// it is available to GraphQL resolvers.
+func ResolveRole(server exposedserver.BindPlane) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, err := server.Authenticator().Role(c.Request.Context(), c.GetString(authenticator.LoginKey))
+		if err != nil {
+			_ = authenticator.AbortWithError(c, err)
+			return
+		}
+		c.Set(authenticator.RoleKey, role)
+		c.Request = c.Request.WithContext(authenticator.WithRole(c.Request.Context(), role))
+	}
+}
+
+// RequireRole aborts the request with http.StatusForbidden unless the role set by ResolveRole allows the required role.
+func RequireRole(required model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := authenticator.RoleFromContext(c.Request.Context())
+		if !role.Allows(required) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
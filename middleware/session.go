@@ -39,6 +39,10 @@ func CheckSession(server exposedserver.BindPlane) gin.HandlerFunc {
 			return
 		}
 
+		// Basic auth credentials on the request take precedence over the session
+		if _, ok := c.Get(authenticator.LoginKey); !ok {
+			c.Set(authenticator.LoginKey, session.Values[authenticator.LoginKey])
+		}
 		c.Set(authenticator.AuthenticatedKey, true)
 		// Extend the cookies life by 60 minutes since the user is active and making requests.
 		session.Options.MaxAge = 60 * 60
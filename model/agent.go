@@ -87,6 +87,17 @@ func (a AgentStatus) DisplayText() string {
 	}
 }
 
+// ParseAgentStatus returns the AgentStatus with the specified DisplayText. It returns false if there is no matching
+// status.
+func ParseAgentStatus(displayText string) (AgentStatus, bool) {
+	for _, status := range []AgentStatus{Disconnected, Connected, Error, ComponentFailed, Deleted, Configuring, Upgrading} {
+		if status.DisplayText() == displayText {
+			return status, true
+		}
+	}
+	return Disconnected, false
+}
+
 // AgentUpgradeStatus is the status of the AgentUpgrade
 type AgentUpgradeStatus uint8
 
@@ -399,6 +410,29 @@ func (a *Agent) SetFutureConfiguration(configuration *Configuration) {
 	a.ConfigurationStatus.Future = nameAndVersion
 }
 
+// RollbackConfiguration restores the previous configuration to an agent affected by the failed rollout of the
+// configuration name and version failed. If the agent received the failed version, previous becomes Pending so that it
+// is sent to the agent again, even if it is already Current. If the agent is waiting for the failed version, Future is
+// cleared. If previous is nil, the agent keeps its Current configuration.
+func (a *Agent) RollbackConfiguration(failed string, previous *Configuration) {
+	if a.ConfigurationStatus.Future == failed {
+		a.ConfigurationStatus.Future = ""
+	}
+	if a.ConfigurationStatus.Current != failed && a.ConfigurationStatus.Pending != failed {
+		return
+	}
+	if previous == nil {
+		if a.ConfigurationStatus.Pending == failed {
+			a.ConfigurationStatus.Pending = ""
+		}
+		return
+	}
+	a.ConfigurationStatus.Pending = previous.NameAndVersion()
+	if a.Status == Error {
+		a.Status = Configuring
+	}
+}
+
 // clear sets all of the configuration versions to empty strings.
 func (cv *ConfigurationVersions) clear() {
 	cv.Current = ""
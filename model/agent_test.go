@@ -261,6 +261,60 @@ func TestAgentConfigurationSetting(t *testing.T) {
 			require.Empty(t, agent.ConfigurationStatus.Future)
 		})
 	})
+
+	t.Run("RollbackConfiguration", func(t *testing.T) {
+		failed := "config1:2"
+
+		t.Run("when failed version is current", func(t *testing.T) {
+			agent := &Agent{
+				ConfigurationStatus: ConfigurationVersions{
+					Current: failed,
+				},
+			}
+			agent.RollbackConfiguration(failed, config1)
+			require.Equal(t, failed, agent.ConfigurationStatus.Current)
+			require.Equal(t, config1.NameAndVersion(), agent.ConfigurationStatus.Pending)
+		})
+
+		t.Run("when failed version is pending", func(t *testing.T) {
+			agent := &Agent{
+				Status: Error,
+				ConfigurationStatus: ConfigurationVersions{
+					Current: config1.NameAndVersion(),
+					Pending: failed,
+				},
+			}
+			agent.RollbackConfiguration(failed, config1)
+			require.Equal(t, config1.NameAndVersion(), agent.ConfigurationStatus.Current)
+			require.Equal(t, config1.NameAndVersion(), agent.ConfigurationStatus.Pending)
+			require.Equal(t, Configuring, agent.Status)
+		})
+
+		t.Run("when failed version is future", func(t *testing.T) {
+			agent := &Agent{
+				ConfigurationStatus: ConfigurationVersions{
+					Current: config1.NameAndVersion(),
+					Future:  failed,
+				},
+			}
+			agent.RollbackConfiguration(failed, config1)
+			require.Equal(t, config1.NameAndVersion(), agent.ConfigurationStatus.Current)
+			require.Empty(t, agent.ConfigurationStatus.Pending)
+			require.Empty(t, agent.ConfigurationStatus.Future)
+		})
+
+		t.Run("when there is no previous version", func(t *testing.T) {
+			agent := &Agent{
+				ConfigurationStatus: ConfigurationVersions{
+					Current: config2.NameAndVersion(),
+					Pending: failed,
+				},
+			}
+			agent.RollbackConfiguration(failed, nil)
+			require.Equal(t, config2.NameAndVersion(), agent.ConfigurationStatus.Current)
+			require.Empty(t, agent.ConfigurationStatus.Pending)
+		})
+	})
 }
 
 func TestAgentStatusDisplayText(t *testing.T) {
@@ -0,0 +1,404 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/model/validation"
+	"github.com/observiq/bindplane-op/model/version"
+)
+
+type alertKind struct{}
+
+func (k *alertKind) NewEmptyResource() *Alert { return &Alert{} }
+
+var _ HasSensitiveParameters = (*Alert)(nil)
+
+// Alert is a resource that defines a condition over agents, rollouts, or throughput that is evaluated periodically.
+// Notifications are sent to the channels of the alert when it starts firing and when it is resolved.
+type Alert struct {
+	ResourceMeta            `yaml:",inline" mapstructure:",squash"`
+	Spec                    AlertSpec `json:"spec" yaml:"spec" mapstructure:"spec"`
+	StatusType[AlertStatus] `yaml:",inline" mapstructure:",squash"`
+}
+
+// AlertSpec is the spec for an Alert
+type AlertSpec struct {
+	// Condition determines when the alert fires
+	Condition AlertCondition `json:"condition" yaml:"condition" mapstructure:"condition"`
+
+	// Channels are notified when the alert fires and when it is resolved
+	Channels []AlertChannel `json:"channels" yaml:"channels" mapstructure:"channels"`
+}
+
+// AlertConditionType is the type of condition evaluated by an Alert
+type AlertConditionType string
+
+const (
+	// AlertConditionAgentStatus fires when any agent has the AgentStatus of the condition
+	AlertConditionAgentStatus AlertConditionType = "agentStatus"
+
+	// AlertConditionRolloutStatus fires when the rollout of a configuration has the RolloutStatus of the condition
+	AlertConditionRolloutStatus AlertConditionType = "rolloutStatus"
+
+	// AlertConditionThroughput fires when the throughput of a configuration is at or below the Threshold of the
+	// condition
+	AlertConditionThroughput AlertConditionType = "throughput"
+)
+
+// DefaultAlertPeriod is the period of the throughput measurements used if the condition doesn't specify a Period
+const DefaultAlertPeriod = 5 * time.Minute
+
+// alertPeriods are the periods supported by the measurements store
+var alertPeriods = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+// AlertCondition is the condition of an Alert. The fields used depend on the Type of the condition.
+type AlertCondition struct {
+	// Type is the type of condition: agentStatus, rolloutStatus, or throughput
+	Type AlertConditionType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// AgentStatus is the status of agents that fire an agentStatus alert, e.g. Error or Disconnected
+	AgentStatus string `json:"agentStatus,omitempty" yaml:"agentStatus,omitempty" mapstructure:"agentStatus"`
+
+	// Selector limits an agentStatus alert to agents matching the labels of the selector. All agents are included if
+	// it is not specified.
+	Selector *AgentSelector `json:"selector,omitempty" yaml:"selector,omitempty" mapstructure:"selector"`
+
+	// RolloutStatus is the status of rollouts that fire a rolloutStatus alert, e.g. Error
+	RolloutStatus string `json:"rolloutStatus,omitempty" yaml:"rolloutStatus,omitempty" mapstructure:"rolloutStatus"`
+
+	// Configuration is the name of the configuration of a throughput alert. It is optional for a rolloutStatus alert
+	// and all configurations are included if it is not specified.
+	Configuration string `json:"configuration,omitempty" yaml:"configuration,omitempty" mapstructure:"configuration"`
+
+	// Destination limits a throughput alert to a single destination of the configuration. The throughput of all
+	// destinations is included if it is not specified.
+	Destination string `json:"destination,omitempty" yaml:"destination,omitempty" mapstructure:"destination"`
+
+	// Threshold is the throughput in bytes per second at or below which a throughput alert fires. The default of 0 fires
+	// the alert when the throughput drops to zero.
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty" mapstructure:"threshold"`
+
+	// Period is the period of the throughput measurements of a throughput alert: 1m, 5m, 1h, or 24h. The default is
+	// 5m.
+	Period string `json:"period,omitempty" yaml:"period,omitempty" mapstructure:"period"`
+}
+
+// AlertChannelType is the type of notification channel of an Alert
+type AlertChannelType string
+
+const (
+	// AlertChannelWebhook posts the AlertNotification as JSON to the URL of the channel
+	AlertChannelWebhook AlertChannelType = "webhook"
+
+	// AlertChannelSlack posts a message to the URL of a Slack-compatible incoming webhook
+	AlertChannelSlack AlertChannelType = "slack"
+
+	// AlertChannelEmail sends an email to the recipients of the channel using the SMTP server of the BindPlane server
+	AlertChannelEmail AlertChannelType = "email"
+)
+
+// AlertChannel is a notification channel of an Alert
+type AlertChannel struct {
+	// Type is the type of channel: webhook, slack, or email
+	Type AlertChannelType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// URL is the URL of a webhook or slack channel. The URL of a slack channel contains the token of the incoming webhook
+	// and is sensitive: it is encrypted by stores with an encryption key and it is masked when the alert is read.
+	URL string `json:"url,omitempty" yaml:"url,omitempty" mapstructure:"url"`
+
+	// To are the recipients of an email channel
+	To []string `json:"to,omitempty" yaml:"to,omitempty" mapstructure:"to"`
+}
+
+// AlertState is the state of an Alert
+type AlertState string
+
+const (
+	// AlertStateFiring is the state of an alert when its condition is met
+	AlertStateFiring AlertState = "firing"
+
+	// AlertStateResolved is the state of an alert when its condition is no longer met after it was firing
+	AlertStateResolved AlertState = "resolved"
+)
+
+// AlertStatus is the status of an Alert. It is managed by the server and only changes when the state of the alert
+// changes.
+type AlertStatus struct {
+	// State is the state of the alert. It is empty if the alert has never fired.
+	State AlertState `json:"state,omitempty" yaml:"state,omitempty" mapstructure:"state"`
+
+	// Message describes why the alert is firing or that it was resolved
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message"`
+
+	// Since is the time that the alert changed to its current state
+	Since *time.Time `json:"since,omitempty" yaml:"since,omitempty" mapstructure:"since"`
+
+	// NotificationError is the error from sending notifications for the last change of state, if any
+	NotificationError string `json:"notificationError,omitempty" yaml:"notificationError,omitempty" mapstructure:"notificationError"`
+}
+
+// AlertNotification is sent to the channels of an Alert when its state changes
+type AlertNotification struct {
+	Alert   string     `json:"alert"`
+	State   AlertState `json:"state"`
+	Message string     `json:"message"`
+	Time    time.Time  `json:"time"`
+}
+
+// NewAlert creates a new Alert with the specified name and spec
+func NewAlert(name string, spec AlertSpec) *Alert {
+	return &Alert{
+		ResourceMeta: ResourceMeta{
+			APIVersion: version.V1,
+			Kind:       KindAlert,
+			Metadata: Metadata{
+				Name: name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// GetKind returns "Alert"
+func (a *Alert) GetKind() Kind {
+	return KindAlert
+}
+
+// GetSpec returns the spec for this resource.
+func (a *Alert) GetSpec() any {
+	return a.Spec
+}
+
+// Firing returns true if the alert is firing
+func (a *Alert) Firing() bool {
+	return a.Status.State == AlertStateFiring
+}
+
+// PeriodDuration returns the period of the throughput measurements of the condition
+func (c *AlertCondition) PeriodDuration() time.Duration {
+	if period, ok := alertPeriods[c.Period]; ok {
+		return period
+	}
+	return DefaultAlertPeriod
+}
+
+// Transition updates the status with the result of evaluating the condition of the alert. It returns the
+// notification to send if the state of the alert changed or nil if it did not change.
+func (a *Alert) Transition(firing bool, message string, now time.Time) *AlertNotification {
+	var state AlertState
+	switch {
+	case firing && !a.Firing():
+		state = AlertStateFiring
+	case !firing && a.Firing():
+		state = AlertStateResolved
+		if message == "" {
+			message = fmt.Sprintf("%s is resolved", a.Name())
+		}
+	default:
+		return nil
+	}
+	a.Status = AlertStatus{
+		State:   state,
+		Message: message,
+		Since:   &now,
+	}
+	return &AlertNotification{
+		Alert:   a.Name(),
+		State:   state,
+		Message: message,
+		Time:    now,
+	}
+}
+
+// ----------------------------------------------------------------------
+// validation
+
+// ValidateWithStore validates the alert. Alerts don't refer to other resources so the store is not used.
+func (a *Alert) ValidateWithStore(_ context.Context, _ ResourceStore) (warnings string, errors error) {
+	return a.Validate()
+}
+
+// Validate returns an error if the condition or any of the channels are invalid
+func (a *Alert) Validate() (warnings string, errors error) {
+	errs := validation.NewErrors()
+	a.ResourceMeta.validate(errs)
+	a.Spec.Condition.validate(errs)
+	if len(a.Spec.Channels) == 0 {
+		errs.Warn(fmt.Errorf("alert %s has no channels and will not send notifications", a.Name()))
+	}
+	for i, channel := range a.Spec.Channels {
+		if err := channel.validate(); err != nil {
+			errs.Add(fmt.Errorf("channel %d is invalid: %w", i+1, err))
+		}
+	}
+	return errs.Warnings(), errs.Result()
+}
+
+func (c *AlertCondition) validate(errs validation.Errors) {
+	switch c.Type {
+	case AlertConditionAgentStatus:
+		if _, ok := ParseAgentStatus(c.AgentStatus); !ok {
+			errs.Add(fmt.Errorf("agentStatus condition has an invalid agentStatus: %q", c.AgentStatus))
+		}
+		if c.Selector != nil {
+			c.Selector.validate(errs)
+		}
+	case AlertConditionRolloutStatus:
+		if _, ok := ParseRolloutStatus(c.RolloutStatus); !ok {
+			errs.Add(fmt.Errorf("rolloutStatus condition has an invalid rolloutStatus: %q", c.RolloutStatus))
+		}
+	case AlertConditionThroughput:
+		if c.Configuration == "" {
+			errs.Add(errors.New("throughput condition must specify a configuration"))
+		}
+		if c.Threshold < 0 {
+			errs.Add(fmt.Errorf("throughput condition threshold must not be negative: %v", c.Threshold))
+		}
+		if _, ok := alertPeriods[c.Period]; c.Period != "" && !ok {
+			errs.Add(fmt.Errorf("throughput condition has an invalid period %q: must be one of 1m, 5m, 1h, or 24h", c.Period))
+		}
+	default:
+		errs.Add(fmt.Errorf("condition has an invalid type %q: must be one of agentStatus, rolloutStatus, or throughput", c.Type))
+	}
+}
+
+func (c *AlertChannel) validate() error {
+	switch c.Type {
+	case AlertChannelWebhook, AlertChannelSlack:
+		if c.URL == "" {
+			return fmt.Errorf("%s channel must specify a url", c.Type)
+		}
+		if c.sensitive() && c.URL == SensitiveParameterPlaceholder {
+			// the masked url is replaced with the url of the existing alert when it is stored
+			return nil
+		}
+		return common.ValidateURL(c.URL, []string{"http", "https"})
+	case AlertChannelEmail:
+		if len(c.To) == 0 {
+			return errors.New("email channel must specify at least one recipient")
+		}
+		for _, to := range c.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return fmt.Errorf("invalid recipient %q: %w", to, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid type %q: must be one of webhook, slack, or email", c.Type)
+	}
+}
+
+// ----------------------------------------------------------------------
+// sensitive values
+
+// sensitive returns true if the url of the channel is sensitive
+func (c *AlertChannel) sensitive() bool {
+	return c.Type == AlertChannelSlack && c.URL != ""
+}
+
+// MaskSensitiveParameters replaces the urls of slack channels with SensitiveParameterPlaceholder
+func (a *Alert) MaskSensitiveParameters(ctx context.Context) {
+	if IsWithoutSensitiveParameterMasking(ctx) {
+		return
+	}
+	for i, channel := range a.Spec.Channels {
+		if channel.sensitive() {
+			a.Spec.Channels[i].URL = SensitiveParameterPlaceholder
+		}
+	}
+}
+
+// PreserveSensitiveParameters replaces slack channel urls with the SensitiveParameterPlaceholder value with the url of
+// the slack channel at the same position in the existing alert. This does nothing if existing is nil because there is
+// no existing alert.
+func (a *Alert) PreserveSensitiveParameters(_ context.Context, existing *AnyResource) error {
+	if existing == nil {
+		return nil
+	}
+	parsed, err := ParseResource(existing)
+	if err != nil {
+		return fmt.Errorf("unable to parse existing resource: %v %w", existing, err)
+	}
+	existingAlert, ok := parsed.(*Alert)
+	if !ok {
+		return nil
+	}
+	for i, channel := range a.Spec.Channels {
+		if channel.URL != SensitiveParameterPlaceholder || i >= len(existingAlert.Spec.Channels) {
+			continue
+		}
+		if existingChannel := existingAlert.Spec.Channels[i]; existingChannel.Type == channel.Type {
+			a.Spec.Channels[i].URL = existingChannel.URL
+		}
+	}
+	return nil
+}
+
+// TransformSensitiveParameters replaces the urls of slack channels with the values returned by the transform
+func (a *Alert) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	for i, channel := range a.Spec.Channels {
+		if !channel.sensitive() {
+			continue
+		}
+		url, err := transformSensitiveString(channel.URL, transform)
+		if err != nil {
+			return fmt.Errorf("channel %d url: %w", i+1, err)
+		}
+		a.Spec.Channels[i].URL = url
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (a *Alert) PrintableFieldTitles() []string {
+	return []string{"Name", "Condition", "State", "Since", "Message"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (a *Alert) PrintableFieldValue(title string) string {
+	switch title {
+	case "Condition":
+		return string(a.Spec.Condition.Type)
+	case "State":
+		if a.Status.State == "" {
+			return "-"
+		}
+		return string(a.Status.State)
+	case "Since":
+		if a.Status.Since == nil {
+			return "-"
+		}
+		return a.Status.Since.Format(time.RFC3339)
+	case "Message":
+		return a.Status.Message
+	default:
+		return a.ResourceMeta.PrintableFieldValue(title)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertValidate(t *testing.T) {
+	webhook := AlertChannel{Type: AlertChannelWebhook, URL: "https://example.com/hook"}
+	tests := []struct {
+		name       string
+		spec       AlertSpec
+		expectErr  string
+		expectWarn string
+	}{
+		{
+			name: "agent status",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionAgentStatus, AgentStatus: "Error", Selector: &AgentSelector{MatchLabels: MatchLabels{"env": "prod"}}},
+				Channels:  []AlertChannel{webhook},
+			},
+		},
+		{
+			name: "invalid agent status",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionAgentStatus, AgentStatus: "Broken"},
+				Channels:  []AlertChannel{webhook},
+			},
+			expectErr: `invalid agentStatus: "Broken"`,
+		},
+		{
+			name: "rollout status",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionRolloutStatus, RolloutStatus: "Error"},
+				Channels:  []AlertChannel{{Type: AlertChannelSlack, URL: "https://hooks.slack.com/services/T/B/X"}},
+			},
+		},
+		{
+			name: "invalid rollout status",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionRolloutStatus, RolloutStatus: "Failed"},
+				Channels:  []AlertChannel{webhook},
+			},
+			expectErr: `invalid rolloutStatus: "Failed"`,
+		},
+		{
+			name: "throughput",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionThroughput, Configuration: "config", Destination: "dest", Period: "1h"},
+				Channels:  []AlertChannel{{Type: AlertChannelEmail, To: []string{"ops@example.com"}}},
+			},
+		},
+		{
+			name: "throughput without configuration",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionThroughput, Period: "2m"},
+				Channels:  []AlertChannel{webhook},
+			},
+			expectErr: "must specify a configuration",
+		},
+		{
+			name: "throughput with invalid period",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionThroughput, Configuration: "config", Period: "2m"},
+				Channels:  []AlertChannel{webhook},
+			},
+			expectErr: `invalid period "2m"`,
+		},
+		{
+			name: "invalid condition type",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: "cpu"},
+				Channels:  []AlertChannel{webhook},
+			},
+			expectErr: `invalid type "cpu"`,
+		},
+		{
+			name: "invalid channels",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionAgentStatus, AgentStatus: "Error"},
+				Channels: []AlertChannel{
+					{Type: AlertChannelWebhook},
+					{Type: AlertChannelSlack, URL: "ftp://example.com"},
+					{Type: AlertChannelEmail, To: []string{"not an address"}},
+					{Type: "pager"},
+				},
+			},
+			expectErr: "4 errors occurred",
+		},
+		{
+			name: "no channels",
+			spec: AlertSpec{
+				Condition: AlertCondition{Type: AlertConditionAgentStatus, AgentStatus: "Error"},
+			},
+			expectWarn: "has no channels",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alert := NewAlert("alert", test.spec)
+			warnings, err := alert.Validate()
+			storeWarnings, storeErr := alert.ValidateWithStore(context.Background(), nil)
+			require.Equal(t, warnings, storeWarnings)
+			require.Equal(t, err, storeErr)
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			if test.expectWarn != "" {
+				require.Contains(t, warnings, test.expectWarn)
+			}
+		})
+	}
+}
+
+func TestAlertTransition(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	alert := NewAlert("errors", AlertSpec{})
+
+	require.Nil(t, alert.Transition(false, "", now))
+	require.Equal(t, "-", alert.PrintableFieldValue("State"))
+
+	notification := alert.Transition(true, "1 agent has status Error", now)
+	require.Equal(t, &AlertNotification{Alert: "errors", State: AlertStateFiring, Message: "1 agent has status Error", Time: now}, notification)
+	require.True(t, alert.Firing())
+	require.Equal(t, &now, alert.Status.Since)
+
+	// still firing does not notify again
+	require.Nil(t, alert.Transition(true, "2 agents have status Error", now.Add(time.Minute)))
+	require.Equal(t, "1 agent has status Error", alert.Status.Message)
+
+	resolved := now.Add(2 * time.Minute)
+	notification = alert.Transition(false, "", resolved)
+	require.Equal(t, AlertStateResolved, notification.State)
+	require.Equal(t, "errors is resolved", notification.Message)
+	require.False(t, alert.Firing())
+	require.Equal(t, "resolved", alert.PrintableFieldValue("State"))
+	require.Equal(t, "2023-05-01T12:02:00Z", alert.PrintableFieldValue("Since"))
+}
+
+func TestAlertParse(t *testing.T) {
+	alert, err := ParseOne[*Alert](&AnyResource{
+		ResourceMeta: ResourceMeta{APIVersion: "bindplane.observiq.com/v1", Kind: KindAlert, Metadata: Metadata{Name: "zero-throughput"}},
+		Spec: map[string]any{
+			"condition": map[string]any{"type": "throughput", "configuration": "config", "threshold": 10},
+			"channels":  []any{map[string]any{"type": "slack", "url": "https://hooks.slack.com/services/T/B/X"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AlertConditionThroughput, alert.Spec.Condition.Type)
+	require.Equal(t, 10.0, alert.Spec.Condition.Threshold)
+	require.Equal(t, DefaultAlertPeriod, alert.Spec.Condition.PeriodDuration())
+	require.Equal(t, KindAlert, ParseKind("alerts"))
+}
+
+func TestAlertSensitiveSlackURL(t *testing.T) {
+	ctx := context.Background()
+	spec := func(slackURL string) AlertSpec {
+		return AlertSpec{
+			Condition: AlertCondition{Type: AlertConditionAgentStatus, AgentStatus: "Error"},
+			Channels: []AlertChannel{
+				{Type: AlertChannelWebhook, URL: "https://example.com/hook"},
+				{Type: AlertChannelSlack, URL: slackURL},
+			},
+		}
+	}
+	existingAny, err := AsAny(NewAlert("alert", spec("https://hooks.slack.com/services/T/B/X")))
+	require.NoError(t, err)
+
+	t.Run("masked", func(t *testing.T) {
+		alert := NewAlert("alert", spec("https://hooks.slack.com/services/T/B/X"))
+		alert.MaskSensitiveParameters(ctx)
+		require.Equal(t, "https://example.com/hook", alert.Spec.Channels[0].URL)
+		require.Equal(t, SensitiveParameterPlaceholder, alert.Spec.Channels[1].URL)
+	})
+
+	t.Run("masked url is valid", func(t *testing.T) {
+		_, err := NewAlert("alert", spec(SensitiveParameterPlaceholder)).Validate()
+		require.NoError(t, err)
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		alert := NewAlert("alert", spec(SensitiveParameterPlaceholder))
+		require.NoError(t, alert.PreserveSensitiveParameters(ctx, existingAny))
+		require.Equal(t, "https://hooks.slack.com/services/T/B/X", alert.Spec.Channels[1].URL)
+	})
+
+	t.Run("transformed", func(t *testing.T) {
+		alert := NewAlert("alert", spec("https://hooks.slack.com/services/T/B/X"))
+		require.NoError(t, alert.TransformSensitiveParameters(func(value any) (any, error) {
+			return "encrypted", nil
+		}))
+		require.Equal(t, "https://example.com/hook", alert.Spec.Channels[0].URL)
+		require.Equal(t, "encrypted", alert.Spec.Channels[1].URL)
+	})
+}
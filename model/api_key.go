@@ -0,0 +1,157 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefix identifies BindPlane API keys, e.g. bp_<id>_<secret>
+const apiKeyPrefix = "bp"
+
+// APIKeyLoginPrefix is prepended to the ID of an API key to form the loginID of requests authenticated with the key.
+// User names can't begin with it.
+const APIKeyLoginPrefix = "apikey:"
+
+// ErrInvalidAPIKey is returned by ParseAPIKey when the key is not in the format bp_<id>_<secret>
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKey is a named key that automation clients can use instead of a username and password. Only the hash of the
+// secret is stored and the key itself is only returned when it is created. Revoking a key deletes it.
+type APIKey struct {
+	ID        string    `json:"id" yaml:"id"`
+	Name      string    `json:"name" yaml:"name"`
+	Role      Role      `json:"role" yaml:"role"`
+	CreatedBy string    `json:"createdBy,omitempty" yaml:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	// SecretHash is the hex encoded sha256 hash of the secret part of the key. It should be removed with Redacted
+	// before a key is returned to a client.
+	SecretHash string `json:"secretHash,omitempty" yaml:"secretHash,omitempty"`
+}
+
+// NewAPIKey returns a new APIKey with the specified name and role and the key that clients use to authenticate. The
+// key cannot be recovered from the APIKey.
+func NewAPIKey(name string, role Role, createdBy string) (*APIKey, string, error) {
+	apiKey := &APIKey{
+		ID:        strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Name:      name,
+		Role:      role,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := apiKey.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.SecretHash = hashAPIKeySecret(encoded)
+
+	return apiKey, strings.Join([]string{apiKeyPrefix, apiKey.ID, encoded}, "_"), nil
+}
+
+// ParseAPIKey returns the ID and secret of a key returned by NewAPIKey
+func ParseAPIKey(key string) (id, secret string, err error) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", ErrInvalidAPIKey
+	}
+	return parts[1], parts[2], nil
+}
+
+// Validate returns an error if the key does not have a name or has an invalid role
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return errors.New("api key name must be specified")
+	}
+	if !k.Role.Valid() {
+		return fmt.Errorf("invalid role %q, must be one of: admin, user, viewer", k.Role)
+	}
+	return nil
+}
+
+// CheckSecret returns true if the secret matches the SecretHash
+func (k *APIKey) CheckSecret(secret string) bool {
+	if k.SecretHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(k.SecretHash), []byte(hashAPIKeySecret(secret))) == 1
+}
+
+// Redacted returns a copy of the key without the SecretHash
+func (k *APIKey) Redacted() *APIKey {
+	clone := *k
+	clone.SecretHash = ""
+	return &clone
+}
+
+// UniqueKey returns the ID of the key
+func (k *APIKey) UniqueKey() string {
+	return k.ID
+}
+
+// API keys have enough entropy that a fast hash is sufficient and avoids the cost of bcrypt on every request
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Configuration"
+func (k *APIKey) PrintableKindSingular() string {
+	return "APIKey"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Configurations"
+func (k *APIKey) PrintableKindPlural() string {
+	return "APIKeys"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (k *APIKey) PrintableFieldTitles() []string {
+	return []string{"ID", "Name", "Role", "Created"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (k *APIKey) PrintableFieldValue(title string) string {
+	switch title {
+	case "ID":
+		return k.ID
+	case "Name":
+		return k.Name
+	case "Role":
+		return k.Role.String()
+	case "Created":
+		return k.CreatedAt.Format(time.RFC3339)
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	apiKey, key, err := NewAPIKey("ci", RoleUser, "admin")
+	require.NoError(t, err)
+	require.Equal(t, "ci", apiKey.Name)
+	require.Equal(t, RoleUser, apiKey.Role)
+	require.Equal(t, "admin", apiKey.CreatedBy)
+	require.NotEmpty(t, apiKey.SecretHash)
+
+	id, secret, err := ParseAPIKey(key)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, id)
+	require.True(t, apiKey.CheckSecret(secret))
+	require.False(t, apiKey.CheckSecret(secret+"x"))
+	require.False(t, apiKey.Redacted().CheckSecret(secret))
+
+	// a second key has a different ID and secret
+	other, otherKey, err := NewAPIKey("ci", RoleUser, "admin")
+	require.NoError(t, err)
+	require.NotEqual(t, apiKey.ID, other.ID)
+	require.NotEqual(t, key, otherKey)
+
+	_, _, err = NewAPIKey("", RoleUser, "admin")
+	require.Error(t, err)
+	_, _, err = NewAPIKey("ci", Role("owner"), "admin")
+	require.Error(t, err)
+}
+
+func TestParseAPIKey(t *testing.T) {
+	tests := []struct {
+		key          string
+		expectID     string
+		expectSecret string
+		expectErr    bool
+	}{
+		{key: "bp_id_secret", expectID: "id", expectSecret: "secret"},
+		{key: "bp_id_secret_with_underscores", expectID: "id", expectSecret: "secret_with_underscores"},
+		{key: "xx_id_secret", expectErr: true},
+		{key: "bp_id", expectErr: true},
+		{key: "bp__secret", expectErr: true},
+		{key: "", expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			id, secret, err := ParseAPIKey(test.key)
+			if test.expectErr {
+				require.ErrorIs(t, err, ErrInvalidAPIKey)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectID, id)
+			require.Equal(t, test.expectSecret, secret)
+		})
+	}
+}
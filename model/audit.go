@@ -0,0 +1,202 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditAction is the type of change recorded by an AuditEvent
+type AuditAction string
+
+const (
+	// AuditActionCreate is recorded when a resource, user, or API key is created
+	AuditActionCreate AuditAction = "create"
+	// AuditActionUpdate is recorded when a resource or user is modified
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionDelete is recorded when a resource, agent, user, or API key is deleted
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionRolloutStart is recorded when a rollout of a configuration is started
+	AuditActionRolloutStart AuditAction = "rollout.start"
+	// AuditActionRolloutPause is recorded when a rollout of a configuration is paused
+	AuditActionRolloutPause AuditAction = "rollout.pause"
+	// AuditActionRolloutResume is recorded when a rollout of a configuration is resumed
+	AuditActionRolloutResume AuditAction = "rollout.resume"
+	// AuditActionRolloutUpdate is recorded when a rollout of a configuration is advanced
+	AuditActionRolloutUpdate AuditAction = "rollout.update"
+	// AuditActionAgentLabels is recorded when the labels of an agent are changed
+	AuditActionAgentLabels AuditAction = "agent.labels"
+	// AuditActionAgentUpgrade is recorded when an agent upgrade is requested
+	AuditActionAgentUpgrade AuditAction = "agent.upgrade"
+	// AuditActionBackup is recorded when a backup of the store is made
+	AuditActionBackup AuditAction = "backup"
+	// AuditActionRestore is recorded when a backup is restored into the store
+	AuditActionRestore AuditAction = "restore"
+	// AuditActionRotateEncryptionKey is recorded when sensitive parameter values are re-encrypted with the current key
+	AuditActionRotateEncryptionKey AuditAction = "encryption.rotate"
+)
+
+const (
+	// AuditKindUser is the kind recorded for changes to users, which are not resources
+	AuditKindUser Kind = "User"
+	// AuditKindAPIKey is the kind recorded for changes to API keys, which are not resources
+	AuditKindAPIKey Kind = "APIKey"
+	// AuditKindBackup is the kind recorded for backups and restores of the store, which are not resources
+	AuditKindBackup Kind = "Backup"
+	// AuditKindEncryptionKey is the kind recorded for rotations of the store encryption key, which is not a resource
+	AuditKindEncryptionKey Kind = "EncryptionKey"
+	// AuditKindSnapshot is the kind recorded for saved snapshots of agent telemetry, which are not resources
+	AuditKindSnapshot Kind = "Snapshot"
+)
+
+// AuditEvent is a record of a change made by a user or API key. Events are only recorded for changes that succeed.
+type AuditEvent struct {
+	// ID orders events by the time they were recorded
+	ID        string    `json:"id" yaml:"id"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Actor is the login ID of the user or API key that made the change
+	Actor string `json:"actor" yaml:"actor"`
+
+	// Origin is the address of the client that made the request
+	Origin string `json:"origin,omitempty" yaml:"origin,omitempty"`
+
+	Action          AuditAction `json:"action" yaml:"action"`
+	ResourceKind    Kind        `json:"resourceKind,omitempty" yaml:"resourceKind,omitempty"`
+	ResourceName    string      `json:"resourceName,omitempty" yaml:"resourceName,omitempty"`
+	ResourceVersion Version     `json:"resourceVersion,omitempty" yaml:"resourceVersion,omitempty"`
+
+	// Details describes the change if the action and resource are not sufficient, e.g. the version of an agent upgrade
+	Details string `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// NewAuditEvent returns a new AuditEvent recorded now for the actor and origin
+func NewAuditEvent(actor, origin string, action AuditAction, kind Kind, name string, version Version) *AuditEvent {
+	timestamp := time.Now().UTC()
+	return &AuditEvent{
+		ID:              newOrderedID(timestamp),
+		Timestamp:       timestamp,
+		Actor:           actor,
+		Origin:          origin,
+		Action:          action,
+		ResourceKind:    kind,
+		ResourceName:    name,
+		ResourceVersion: version,
+	}
+}
+
+// newOrderedID returns an ID that sorts by timestamp with a random suffix to make it unique, e.g. for audit events
+// and snapshots
+func newOrderedID(timestamp time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%s", OrderedIDPrefix(timestamp), hex.EncodeToString(suffix))
+}
+
+// OrderedIDPrefix returns the prefix of the IDs of audit events and snapshots recorded at the time. IDs recorded before
+// the time sort before the prefix, so stores can select a time range as a range of IDs.
+func OrderedIDPrefix(timestamp time.Time) string {
+	return fmt.Sprintf("%019d", timestamp.UnixNano())
+}
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Configuration"
+func (e *AuditEvent) PrintableKindSingular() string {
+	return "AuditEvent"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Configurations"
+func (e *AuditEvent) PrintableKindPlural() string {
+	return "AuditEvents"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (e *AuditEvent) PrintableFieldTitles() []string {
+	return []string{"Timestamp", "Actor", "Action", "Kind", "Name", "Version", "Origin"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (e *AuditEvent) PrintableFieldValue(title string) string {
+	switch title {
+	case "Timestamp":
+		return e.Timestamp.Format(time.RFC3339)
+	case "Actor":
+		return e.Actor
+	case "Action":
+		return string(e.Action)
+	case "Kind":
+		return valueOrDash(string(e.ResourceKind))
+	case "Name":
+		return valueOrDash(e.ResourceName)
+	case "Version":
+		if e.ResourceVersion == 0 {
+			return "-"
+		}
+		return strconv.Itoa(int(e.ResourceVersion))
+	case "Origin":
+		return valueOrDash(e.Origin)
+	default:
+		return "-"
+	}
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// ----------------------------------------------------------------------
+
+// AuditEventFilter selects the audit events returned by a query. Empty fields match all events.
+type AuditEventFilter struct {
+	Actor        string
+	Action       AuditAction
+	ResourceKind Kind
+	ResourceName string
+
+	// Since and Until select events recorded in the range [Since, Until)
+	Since time.Time
+	Until time.Time
+
+	// Limit is the maximum number of events to return, starting with the most recent. If it is 0, all matching events
+	// are returned.
+	Limit int
+}
+
+// Matches returns true if the event matches the filter. Kinds are compared without case so that kinds can be
+// specified as they are on the command line.
+func (f AuditEventFilter) Matches(event *AuditEvent) bool {
+	switch {
+	case f.Actor != "" && event.Actor != f.Actor:
+		return false
+	case f.Action != "" && event.Action != f.Action:
+		return false
+	case f.ResourceKind != "" && !strings.EqualFold(string(event.ResourceKind), string(f.ResourceKind)):
+		return false
+	case f.ResourceName != "" && event.ResourceName != f.ResourceName:
+		return false
+	case !f.Since.IsZero() && event.Timestamp.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !event.Timestamp.Before(f.Until):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditEventIDsSortByTime(t *testing.T) {
+	first := NewAuditEvent("admin", "", AuditActionCreate, KindSource, "host", 1)
+	second := NewAuditEvent("admin", "", AuditActionUpdate, KindSource, "host", 2)
+	require.NotEqual(t, first.ID, second.ID)
+	require.Less(t, first.ID, second.ID)
+
+	// a later timestamp with fewer digits in an unpadded ID would sort first
+	require.Less(t, newOrderedID(time.Unix(9, 0)), newOrderedID(time.Unix(10, 0)))
+}
+
+func TestAuditEventPrintableFieldValue(t *testing.T) {
+	event := NewAuditEvent("admin", "", AuditActionAgentUpgrade, KindAgent, "agent-1", 0)
+	event.Timestamp = time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	expected := map[string]string{
+		"Timestamp": "2023-05-01T12:30:00Z",
+		"Actor":     "admin",
+		"Action":    "agent.upgrade",
+		"Kind":      "Agent",
+		"Name":      "agent-1",
+		"Version":   "-",
+		"Origin":    "-",
+	}
+	for _, title := range event.PrintableFieldTitles() {
+		require.Equal(t, expected[title], event.PrintableFieldValue(title), title)
+	}
+}
+
+func TestAuditEventFilterMatches(t *testing.T) {
+	event := NewAuditEvent("admin", "127.0.0.1", AuditActionCreate, KindConfiguration, "linux", 1)
+	event.Timestamp = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter AuditEventFilter
+		expect bool
+	}{
+		{"empty filter", AuditEventFilter{}, true},
+		{"actor", AuditEventFilter{Actor: "admin"}, true},
+		{"other actor", AuditEventFilter{Actor: "ci"}, false},
+		{"action", AuditEventFilter{Action: AuditActionCreate}, true},
+		{"other action", AuditEventFilter{Action: AuditActionDelete}, false},
+		{"kind ignores case", AuditEventFilter{ResourceKind: "configuration"}, true},
+		{"other kind", AuditEventFilter{ResourceKind: KindSource}, false},
+		{"name", AuditEventFilter{ResourceName: "linux"}, true},
+		{"other name", AuditEventFilter{ResourceName: "windows"}, false},
+		{"since includes the timestamp", AuditEventFilter{Since: event.Timestamp}, true},
+		{"since after", AuditEventFilter{Since: event.Timestamp.Add(time.Second)}, false},
+		{"until excludes the timestamp", AuditEventFilter{Until: event.Timestamp}, false},
+		{"until after", AuditEventFilter{Until: event.Timestamp.Add(time.Second)}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, test.filter.Matches(event))
+		})
+	}
+}
@@ -133,6 +133,10 @@ type ConfigurationStatus struct {
 	// completes.
 	PendingVersion Version `json:"pendingVersion,omitempty" yaml:"pendingVersion,omitempty" mapstructure:"pendingVersion"`
 
+	// Rollback is set if the rollout of this version failed and the agents that received it were restored to the
+	// previous version
+	Rollback *RolloutRollback `json:"rollback,omitempty" yaml:"rollback,omitempty" mapstructure:"rollback"`
+
 	// ----------------------------------------------------------------------
 	// transient values set when the configuration is read from the store
 
@@ -172,7 +176,7 @@ const (
 	// RolloutStatusStarted is in progress
 	RolloutStatusStarted RolloutStatus = 1
 
-	// RolloutStatusPaused is paused by the user
+	// RolloutStatusPaused is paused by the user or because the rollout is outside of its schedule
 	RolloutStatusPaused RolloutStatus = 2
 
 	// ----------------------------------------------------------------------
@@ -205,6 +209,17 @@ var rolloutStatusMap = map[RolloutStatus]string{
 	RolloutStatusReplaced: "Replaced",
 }
 
+// ParseRolloutStatus returns the RolloutStatus with the specified name, e.g. Error. It returns false if there is no
+// matching status.
+func ParseRolloutStatus(name string) (RolloutStatus, bool) {
+	for status, str := range rolloutStatusMap {
+		if str == name {
+			return status, true
+		}
+	}
+	return RolloutStatusPending, false
+}
+
 // RolloutOptions are stored with a configuration and determine how rollouts for that configuration are managed.
 type RolloutOptions struct {
 	// StartAutomatically determines if this rollout transitions immediately from RolloutStatusPending to
@@ -220,6 +235,22 @@ type RolloutOptions struct {
 
 	// MaxErrors is the maximum number of failed agents before the rollout will be considered an error
 	MaxErrors int `json:"maxErrors" yaml:"maxErrors" mapstructure:"maxErrors"`
+
+	// StartAt is the earliest time that the first phase of the rollout will start. A rollout started before this time
+	// is paused until StartAt.
+	StartAt *time.Time `json:"startAt,omitempty" yaml:"startAt,omitempty" mapstructure:"startAt"`
+
+	// MaintenanceWindow restricts the phases of the rollout to a recurring window. The rollout is paused while the window
+	// is closed and resumed when it opens.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty" yaml:"maintenanceWindow,omitempty" mapstructure:"maintenanceWindow"`
+
+	// HealthCheck must pass for the agents of each phase before the rollout moves to the next phase. If the check fails,
+	// the rollout is stopped with RolloutStatusError.
+	HealthCheck *RolloutHealthCheck `json:"healthCheck,omitempty" yaml:"healthCheck,omitempty" mapstructure:"healthCheck"`
+
+	// CanarySelector selects the agents that receive the new version in the first phase of the rollout. The rollout is
+	// paused for approval after the canary phase and the remaining agents are updated using PhaseAgentCount.
+	CanarySelector *AgentSelector `json:"canarySelector,omitempty" yaml:"canarySelector,omitempty" mapstructure:"canarySelector"`
 }
 
 // PhaseAgentCount is the number of agents that will be updated in each phase of a rollout.
@@ -304,6 +335,29 @@ type Rollout struct {
 
 	// Progress is the current progress of the rollout
 	Progress RolloutProgress `json:"progress" yaml:"progress" mapstructure:"progress"`
+
+	// PausedBySchedule is true if the rollout was paused because it is outside of the StartAt or MaintenanceWindow of
+	// its options. It will be resumed automatically when the schedule opens.
+	PausedBySchedule bool `json:"pausedBySchedule,omitempty" yaml:"pausedBySchedule,omitempty" mapstructure:"pausedBySchedule"`
+
+	// Health is the status of the HealthCheck of the rollout options
+	Health *RolloutHealth `json:"health,omitempty" yaml:"health,omitempty" mapstructure:"health"`
+
+	// Canary is the status of the canary phase if the rollout options have a CanarySelector
+	Canary *RolloutCanary `json:"canary,omitempty" yaml:"canary,omitempty" mapstructure:"canary"`
+}
+
+// RolloutRollback records the rollback of a failed rollout when RollbackOnFailure is set
+type RolloutRollback struct {
+	// Version is the previous version restored to the agents. It is 0 if there was no previous version and the agents
+	// kept their current configuration.
+	Version Version `json:"version" yaml:"version" mapstructure:"version"`
+
+	// Agents is the number of agents that had received the failed version
+	Agents int `json:"agents" yaml:"agents" mapstructure:"agents"`
+
+	// Date is the time of the rollback
+	Date time.Time `json:"date" yaml:"date" mapstructure:"date"`
 }
 
 // RolloutProgress is the current progress of the rollout
@@ -321,8 +375,17 @@ type RolloutProgress struct {
 	Waiting int `json:"waiting" yaml:"waiting" mapstructure:"waiting"`
 }
 
-// AgentsPerPhase returns the number of agents that will be updated in the current phase.
+// AgentsPerPhase returns the number of agents that will be updated in the current phase. If the rollout has a canary
+// phase, the first phase updates the canary agents and the following phases start at the initial count.
 func (r *Rollout) AgentsPerPhase() int {
+	phase := r.Phase
+	if r.hasCanary() {
+		if phase == 0 {
+			return r.Canary.Agents
+		}
+		phase--
+	}
+
 	multiplier := r.Options.PhaseAgentCount.Multiplier
 	// If the multiplier is 1 or less, the number of agents won't change over phases.
 	if multiplier <= 1 {
@@ -330,7 +393,7 @@ func (r *Rollout) AgentsPerPhase() int {
 	}
 
 	numAgents := float64(r.Options.PhaseAgentCount.Initial)
-	for i := 0; i < r.Phase; i++ {
+	for i := 0; i < phase; i++ {
 		numAgents *= multiplier
 
 		// Check for potential overflow or if the number exceeds the max.
@@ -349,9 +412,10 @@ func (r *Rollout) UpdateStatus(progress RolloutProgress) (newAgentsPending int)
 
 	if p.Errors > r.Options.MaxErrors {
 		r.Status = RolloutStatusError
-	} else if r.Status == RolloutStatusStarted && p.Waiting == 0 && p.Pending == 0 {
+	} else if r.Status == RolloutStatusStarted && p.Waiting == 0 && p.Pending == 0 && r.phaseHealthy() {
 		r.Status = RolloutStatusStable
 	}
+	r.pauseForApproval()
 
 	newAgentsPending = r.AgentsNextPhase()
 
@@ -369,8 +433,12 @@ func (r *Rollout) UpdateStatus(progress RolloutProgress) (newAgentsPending int)
 }
 
 // AgentsNextPhase returns the number of agents that will be updated in the next phase. If the rollout is not in the
-// started state or additional agents are pending, zero is returned.
+// started state, additional agents are pending, or the health check of the current phase has not passed, zero is
+// returned.
 func (r *Rollout) AgentsNextPhase() int {
+	if r.Status == RolloutStatusStarted && !r.phaseHealthy() {
+		return 0
+	}
 	if (r.Status == RolloutStatusStarted || r.Status == RolloutStatusStable) && r.Progress.Pending == 0 {
 		return r.AgentsPerPhase()
 	}
@@ -388,6 +456,10 @@ type ResourceConfiguration struct {
 	// DisplayName is a friendly name of the resource that will be displayed in the UI
 	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty" mapstructure:"displayName"`
 
+	// Version pins a reference to another resource by name to a specific version of that resource. If it is not
+	// specified, the latest version is used and the reference is updated when the resource is modified.
+	Version Version `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version"`
+
 	// ParameterizedSpec contains the definition of an embedded resource if this is not a reference to another resource
 	ParameterizedSpec `yaml:",inline" mapstructure:",squash"`
 }
@@ -400,6 +472,7 @@ func (rc *ResourceConfiguration) ShallowEqual(other *ResourceConfiguration) bool
 	if rc.Disabled != other.Disabled ||
 		rc.DisplayName != other.DisplayName ||
 		rc.Name != other.Name ||
+		rc.Version != other.Version ||
 		rc.ID != other.ID ||
 		rc.Type != other.Type {
 		return false
@@ -444,6 +517,20 @@ func (rc *ResourceConfiguration) maskSensitiveParameters(ctx context.Context) {
 	}
 }
 
+func (rc *ResourceConfiguration) transformSensitiveParameters(transform SensitiveParameterTransform) error {
+	if err := transformSensitiveParameters(rc, transform); err != nil {
+		return err
+	}
+	for i, p := range rc.Processors {
+		p := p
+		if err := transformSensitiveParameters(&p, transform); err != nil {
+			return err
+		}
+		rc.Processors[i] = p
+	}
+	return nil
+}
+
 // PreserveSensitiveParameters will replace parameters with the SensitiveParameterPlaceholder value with the value of
 // the parameter from the existing resource. This does nothing if existing is nil because there is no existing
 // resource.
@@ -1002,6 +1089,19 @@ func (rc *ResourceConfiguration) validateParameters(ctx context.Context, resourc
 	rc.Name = TrimVersion(rc.Name)
 	rc.Type = TrimVersion(rc.Type)
 
+	// find the pinned version of the resource instead of the latest version
+	if rc.Version != VersionLatest {
+		switch {
+		case rc.Name == "":
+			errors.Add(fmt.Errorf("%s version %d can only be specified with the name of a %s", resourceKind, rc.Version, resourceKind))
+			return
+		case rc.Version < 0:
+			errors.Add(fmt.Errorf("%s %s has an invalid version %d", resourceKind, rc.Name, rc.Version))
+			return
+		}
+		rc.Name = JoinVersion(rc.Name, rc.Version)
+	}
+
 	resource, resourceType, err := findResourceAndType(ctx, resourceKind, rc, string(resourceKind), store)
 
 	if err != nil {
@@ -1103,7 +1203,7 @@ func (c *Configuration) Rollout() *Rollout {
 
 // PrintableFieldTitles returns the list of field titles, used for printing a table of resources
 func (r *Rollout) PrintableFieldTitles() []string {
-	return []string{"Name", "Status", "Phase", "Completed", "Errors", "Pending", "Waiting"}
+	return []string{"Name", "Status", "Phase", "Completed", "Errors", "Pending", "Waiting", "Start", "Window", "Health", "Canary"}
 }
 
 // PrintableFieldValue returns the field value for a title, used for printing a table of resources
@@ -1112,6 +1212,12 @@ func (r *Rollout) PrintableFieldValue(title string) string {
 	case "Name":
 		return r.Name
 	case "Status":
+		if r.Status == RolloutStatusPaused && r.PausedBySchedule {
+			return r.Status.String() + " (scheduled)"
+		}
+		if r.AwaitingApproval() {
+			return r.Status.String() + " (approval)"
+		}
 		return r.Status.String()
 	case "Phase":
 		return fmt.Sprintf("%d", r.Phase)
@@ -1123,6 +1229,26 @@ func (r *Rollout) PrintableFieldValue(title string) string {
 		return fmt.Sprintf("%d", r.Progress.Pending)
 	case "Waiting":
 		return fmt.Sprintf("%d", r.Progress.Waiting)
+	case "Start":
+		if r.Options.StartAt == nil {
+			return "-"
+		}
+		return r.Options.StartAt.Format(time.RFC3339)
+	case "Window":
+		if r.Options.MaintenanceWindow == nil {
+			return "-"
+		}
+		return r.Options.MaintenanceWindow.String()
+	case "Health":
+		if r.Options.HealthCheck == nil {
+			return "-"
+		}
+		return r.Health.String()
+	case "Canary":
+		if r.Options.CanarySelector == nil {
+			return "-"
+		}
+		return r.Canary.String()
 	default:
 		return "-"
 	}
@@ -1468,6 +1594,23 @@ func (c *Configuration) PreserveSensitiveParameters(ctx context.Context, existin
 	return nil
 }
 
+// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the transform
+func (c *Configuration) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	for i, source := range c.Spec.Sources {
+		if err := source.transformSensitiveParameters(transform); err != nil {
+			return fmt.Errorf("source %s: %w", source.ID, err)
+		}
+		c.Spec.Sources[i] = source
+	}
+	for i, destination := range c.Spec.Destinations {
+		if err := destination.transformSensitiveParameters(transform); err != nil {
+			return fmt.Errorf("destination %s: %w", destination.ID, err)
+		}
+		c.Spec.Destinations[i] = destination
+	}
+	return nil
+}
+
 // findResourceConfiguration looks through all of the resources for a resource matching the specified resourceID.
 func findResourceConfiguration(resourceID string, resources []ResourceConfiguration) *ResourceConfiguration {
 	for _, resource := range resources {
@@ -2808,6 +2808,13 @@ func TestResourceConfigurationShallowEqual(t *testing.T) {
 
 		require.False(t, rc1.ShallowEqual(rc2))
 	})
+	t.Run("Version Not equal", func(t *testing.T) {
+		rc1 := newTestResourceConfiguration(t)
+		rc2 := newTestResourceConfiguration(t)
+		rc1.Version = 2
+
+		require.False(t, rc1.ShallowEqual(rc2))
+	})
 	t.Run("DisplayName Not equal", func(t *testing.T) {
 		rc1 := newTestResourceConfiguration(t)
 		rc2 := newTestResourceConfiguration(t)
@@ -3120,6 +3127,10 @@ func TestRolloutPrintableFieldValue(t *testing.T) {
 		"Errors":    "4",
 		"Pending":   "5",
 		"Waiting":   "6",
+		"Start":     "-",
+		"Window":    "-",
+		"Health":    "-",
+		"Canary":    "-",
 	}
 
 	titles := r.PrintableFieldTitles()
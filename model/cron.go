@@ -0,0 +1,239 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed standard 5 field cron expression: minute, hour, day of month, month, and day of week. Each
+// field is stored as a bitset of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny are true if the day of month or day of week field is *. As with cron, if both fields are
+	// restricted, a day matches if either field matches.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week allows 7 for Sunday and it is folded into 0 after parsing
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronSearchYears limits the search for the next time matching a schedule, e.g. for Feb 30 which never matches
+const cronSearchYears = 5
+
+// parseCron parses a standard 5 field cron expression. Each field can be *, a value, a range (1-5), a list (1,3,5), and
+// can include a step (*/15 or 0-30/10). Months and days of the week can also be specified with 3 letter names.
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+
+	schedule := &cronSchedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if schedule.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = cronDom.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = cronDow.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// parse parses a single field of a cron expression and returns the bitset of matching values
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			first, last, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = f.value(first); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(last); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangePart); err != nil {
+				return 0, err
+			}
+			end = start
+			if hasStep {
+				// 5/15 is the same as 5-max/15
+				end = f.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of a field, which is either a number or a name
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, must be %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// next returns the first time after t that matches the schedule in the location of t. If there is no match within
+// cronSearchYears, the zero time is returned.
+//
+// Like cron, times skipped when clocks are set forward for daylight saving time never match, and times repeated when
+// clocks are set back only match once unless the schedule runs every hour.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// start at the next whole minute. time.Date isn't used because it may return the first of two repeated times.
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !cronMatch(c.month, int(t.Month())) {
+		t = cronDate(t.Year(), t.Month()+1, 1, 0, loc)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !c.dayMatches(t) {
+		t = cronDate(t.Year(), t.Month(), t.Day()+1, 0, loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for !cronMatch(c.hour, t.Hour()) {
+		day := t.Day()
+		t = cronDate(t.Year(), t.Month(), t.Day(), t.Hour()+1, loc)
+		// the next hour is on the next day, which may start after midnight if midnight is skipped
+		if t.Day() != day {
+			goto wrap
+		}
+	}
+	for !cronMatch(c.minute, t.Minute()) {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	if c.hour != cronAllHours && repeatedTime(t) {
+		t = t.Add(time.Minute)
+		goto wrap
+	}
+	return t
+}
+
+// cronDate returns the start of the hour in loc like time.Date, except that if the hour was skipped because clocks were
+// set forward, it returns the time when clocks were set forward instead of an earlier time.
+func cronDate(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, 0, 0, 0, loc)
+	requested := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	return t.Add(requested.Sub(wall))
+}
+
+// cronAllHours is the hour field of a schedule that runs every hour
+const cronAllHours = 1<<24 - 1
+
+// repeatedTime returns true if the wall clock time of t already occurred earlier because clocks were set back, e.g.
+// 01:30 EST on the day daylight saving time ends in America/New_York.
+func repeatedTime(t time.Time) bool {
+	_, offset := t.Zone()
+	// clocks are never set back by more than a few hours
+	_, before := t.Add(-3 * time.Hour).Zone()
+	if before <= offset {
+		return false
+	}
+	// the same wall clock time with the offset from before clocks were set back
+	_, earlier := t.Add(-time.Duration(before-offset) * time.Second).Zone()
+	return earlier == before
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := cronMatch(c.dom, t.Day())
+	dowMatch := cronMatch(c.dow, int(t.Weekday()))
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func cronMatch(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -154,3 +154,8 @@ func (d *Destination) MaskSensitiveParameters(ctx context.Context) {
 func (d *Destination) PreserveSensitiveParameters(ctx context.Context, existing *AnyResource) error {
 	return PreserveSensitiveParameters(ctx, d, existing)
 }
+
+// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the transform
+func (d *Destination) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	return d.Spec.transformSensitiveParameters(transform)
+}
@@ -0,0 +1,190 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceDiff is the result of a dry run of applying a resource. It contains the status that applying the resource
+// would have and the current and proposed resource in YAML.
+type ResourceDiff struct {
+	Kind   Kind         `json:"kind"`
+	Name   string       `json:"name"`
+	Status UpdateStatus `json:"status"`
+	Reason string       `json:"reason,omitempty"`
+
+	// CurrentVersion is the version of the current resource or 0 if the resource does not exist or is not versioned
+	CurrentVersion Version `json:"currentVersion,omitempty"`
+
+	// Current is the metadata and spec of the current resource in YAML. It is empty if the resource does not exist.
+	Current string `json:"current,omitempty"`
+
+	// Proposed is the metadata and spec of the resource as it would be applied in YAML
+	Proposed string `json:"proposed"`
+}
+
+// Unified returns a unified diff of the current and proposed resource. It returns an empty string if they are the
+// same.
+func (d *ResourceDiff) Unified() string {
+	from := "/dev/null"
+	if d.Current != "" {
+		from = fmt.Sprintf("%s/%s", d.Kind, JoinVersion(d.Name, d.CurrentVersion))
+	}
+	return UnifiedDiff(from, fmt.Sprintf("%s/%s", d.Kind, d.Name), d.Current, d.Proposed)
+}
+
+// diffDocument contains the fields of a resource that are compared by a ResourceDiff
+type diffDocument struct {
+	Metadata diffMetadata `yaml:"metadata"`
+	Spec     any          `yaml:"spec,omitempty"`
+}
+
+type diffMetadata struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+}
+
+// DiffDocument returns the user managed metadata and spec of the resource in YAML with sorted keys so that two
+// versions of a resource can be compared line by line
+func DiffDocument(r Resource) (string, error) {
+	// round trip the spec through json to use the json field names and sort the keys
+	data, err := jsoniter.Marshal(r.GetSpec())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	var spec any
+	if err := jsoniter.Unmarshal(data, &spec); err != nil {
+		return "", fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+
+	labels := r.GetLabels()
+	doc := diffDocument{
+		Metadata: diffMetadata{
+			Name:        r.Name(),
+			Description: r.Description(),
+			Labels:      labels.AsMap(),
+		},
+		Spec: spec,
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	return string(out), nil
+}
+
+// ConfigurationDiff is the difference between the agent configurations rendered for two versions of a Configuration.
+// Because configurations refer to specific versions of sources, processors, destinations, and their types, changes to
+// those resources between the two versions are included.
+type ConfigurationDiff struct {
+	Name string  `json:"name"`
+	From Version `json:"from"`
+	To   Version `json:"to"`
+
+	// Agent is the ID of the agent that both versions were rendered for. It is empty if they were rendered without an
+	// agent.
+	Agent string `json:"agent,omitempty"`
+
+	// FromConfig and ToConfig are the rendered agent configurations in YAML with sorted keys and without comments
+	FromConfig string `json:"fromConfig"`
+	ToConfig   string `json:"toConfig"`
+
+	// Diff is a unified diff of FromConfig and ToConfig. It is empty if they are the same.
+	Diff string `json:"diff,omitempty"`
+}
+
+// NewConfigurationDiff renders both versions of the configuration for the agent and compares them. The agent can be nil
+// to render the configurations without a specific agent.
+func NewConfigurationDiff(ctx context.Context, from, to *Configuration, agent *Agent, bindPlaneURL string, bindPlaneInsecureSkipVerify bool, store ResourceStore, headers map[string]string) (*ConfigurationDiff, error) {
+	diff := &ConfigurationDiff{
+		Name: to.Name(),
+		From: from.Version(),
+		To:   to.Version(),
+	}
+	if agent != nil {
+		diff.Agent = agent.ID
+	}
+
+	var err error
+	if diff.FromConfig, err = renderDiffConfig(ctx, from, agent, bindPlaneURL, bindPlaneInsecureSkipVerify, store, headers); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", from.NameAndVersion(), err)
+	}
+	if diff.ToConfig, err = renderDiffConfig(ctx, to, agent, bindPlaneURL, bindPlaneInsecureSkipVerify, store, headers); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", to.NameAndVersion(), err)
+	}
+	diff.Diff = UnifiedDiff(from.NameAndVersion(), to.NameAndVersion(), diff.FromConfig, diff.ToConfig)
+	return diff, nil
+}
+
+// renderDiffConfig renders the configuration and normalizes the YAML so that differences in key order and comments,
+// which include the configuration version, are ignored
+func renderDiffConfig(ctx context.Context, c *Configuration, agent *Agent, bindPlaneURL string, bindPlaneInsecureSkipVerify bool, store ResourceStore, headers map[string]string) (string, error) {
+	rendered, err := c.Render(ctx, agent, bindPlaneURL, bindPlaneInsecureSkipVerify, store, headers)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeYAML(rendered), nil
+}
+
+// NormalizeYAML returns the YAML with sorted keys and without comments. Text that is not valid YAML is returned
+// unchanged.
+func NormalizeYAML(text string) string {
+	var doc any
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil || doc == nil {
+		return text
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return text
+	}
+	return string(out)
+}
+
+// UnifiedDiff returns a unified diff between two texts with the specified names. It returns an empty string if they
+// are the same.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitLines splits the text into lines that include their line endings. Unlike difflib.SplitLines, it doesn't add an
+// empty line to the end of text that ends with a newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
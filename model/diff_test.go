@@ -0,0 +1,117 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDocument(t *testing.T) {
+	webhook := NewWebhook("changes", WebhookSpec{
+		URL:   "https://example.com/hook",
+		Kinds: []Kind{KindConfiguration},
+	})
+	webhook.Metadata.Description = "configuration changes"
+	webhook.Metadata.Labels = Labels{Set: map[string]string{"team": "ops", "env": "prod"}}
+	webhook.Status.Deliveries = []WebhookDelivery{{ID: "ignored"}}
+
+	doc, err := DiffDocument(webhook)
+	require.NoError(t, err)
+	require.Equal(t, `metadata:
+    name: changes
+    description: configuration changes
+    labels:
+        env: prod
+        team: ops
+spec:
+    kinds:
+        - Configuration
+    url: https://example.com/hook
+`, doc)
+}
+
+func TestResourceDiffUnified(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		diff := &ResourceDiff{Kind: KindWebhook, Name: "changes", Status: StatusCreated, Proposed: "metadata:\n    name: changes\n"}
+		require.Equal(t, `--- /dev/null
++++ Webhook/changes
+@@ -0,0 +1,2 @@
++metadata:
++    name: changes
+`, diff.Unified())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		diff := &ResourceDiff{
+			Kind:           KindWebhook,
+			Name:           "changes",
+			Status:         StatusConfigured,
+			CurrentVersion: 2,
+			Current:        "spec:\n    url: https://example.com/a\n",
+			Proposed:       "spec:\n    url: https://example.com/b\n",
+		}
+		require.Equal(t, `--- Webhook/changes:2
++++ Webhook/changes
+@@ -1,2 +1,2 @@
+ spec:
+-    url: https://example.com/a
++    url: https://example.com/b
+`, diff.Unified())
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		diff := &ResourceDiff{Kind: KindWebhook, Name: "changes", Status: StatusUnchanged, Current: "a\n", Proposed: "a\n"}
+		require.Empty(t, diff.Unified())
+	})
+}
+
+func TestNewConfigurationDiff(t *testing.T) {
+	from := SetVersion(NewRawConfiguration("config", "# version 3\nreceivers:\n  otlp: {}\nexporters:\n  logging: {}\n"), 3)
+	to := SetVersion(NewRawConfiguration("config", "# version 5\nexporters:\n  logging:\n    verbosity: detailed\nreceivers:\n  otlp: {}\n"), 5)
+
+	diff, err := NewConfigurationDiff(context.Background(), from, to, &Agent{ID: "agent-1"}, "", false, newTestResourceStore(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "config", diff.Name)
+	require.Equal(t, Version(3), diff.From)
+	require.Equal(t, Version(5), diff.To)
+	require.Equal(t, "agent-1", diff.Agent)
+	require.Equal(t, "exporters:\n    logging: {}\nreceivers:\n    otlp: {}\n", diff.FromConfig)
+
+	// comments and key order are ignored
+	require.Equal(t, `--- config:3
++++ config:5
+@@ -1,4 +1,5 @@
+ exporters:
+-    logging: {}
++    logging:
++        verbosity: detailed
+ receivers:
+     otlp: {}
+`, diff.Diff)
+
+	diff, err = NewConfigurationDiff(context.Background(), from, from, nil, "", false, newTestResourceStore(), nil)
+	require.NoError(t, err)
+	require.Empty(t, diff.Agent)
+	require.Empty(t, diff.Diff)
+}
+
+func TestNormalizeYAML(t *testing.T) {
+	require.Equal(t, "a: 1\nb: 2\n", NormalizeYAML("# comment\nb: 2\na: 1\n"))
+	require.Equal(t, "", NormalizeYAML(""))
+	require.Equal(t, "not: [valid", NormalizeYAML("not: [valid"))
+}
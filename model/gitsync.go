@@ -0,0 +1,85 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// GitSyncDriftReason describes how a resource in the store differs from the git repository
+type GitSyncDriftReason string
+
+const (
+	// GitSyncDriftModified indicates that the resource was modified in the store since the last sync
+	GitSyncDriftModified GitSyncDriftReason = "modified"
+
+	// GitSyncDriftMissing indicates that the resource was deleted from the store since the last sync
+	GitSyncDriftMissing GitSyncDriftReason = "missing"
+
+	// GitSyncDriftUntracked indicates that the resource is in the store but not in the repository. It is only reported
+	// if prune is disabled.
+	GitSyncDriftUntracked GitSyncDriftReason = "untracked"
+)
+
+// GitSyncStatus is the status of syncing resources from a git repository
+type GitSyncStatus struct {
+	// Enabled is true if a repository is configured
+	Enabled bool `json:"enabled"`
+
+	Repository string `json:"repository,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Prune      bool   `json:"prune"`
+
+	// Commit is the commit of the repository at the last sync
+	Commit string `json:"commit,omitempty"`
+
+	// LastSync is the time of the last sync
+	LastSync *time.Time `json:"lastSync,omitempty"`
+
+	// Error is the error of the last sync if it failed
+	Error string `json:"error,omitempty"`
+
+	// Resources are the results of applying and pruning resources at the last sync
+	Resources []GitSyncResource `json:"resources,omitempty"`
+
+	// Drift are the differences between the store and the repository found at the last sync
+	Drift []GitSyncDrift `json:"drift,omitempty"`
+}
+
+// GitSyncResource is the result of applying or pruning a resource during a sync
+type GitSyncResource struct {
+	Kind   Kind         `json:"kind"`
+	Name   string       `json:"name"`
+	Status UpdateStatus `json:"status"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// GitSyncDrift is a resource in the store that differed from the repository during a sync
+type GitSyncDrift struct {
+	Kind   Kind               `json:"kind"`
+	Name   string             `json:"name"`
+	Reason GitSyncDriftReason `json:"reason"`
+}
+
+// NewGitSyncResource returns the GitSyncResource for the status of a resource
+func NewGitSyncResource(status ResourceStatus) GitSyncResource {
+	return GitSyncResource{
+		Kind:   status.Resource.GetKind(),
+		Name:   status.Resource.Name(),
+		Status: status.Status,
+		Reason: status.Reason,
+	}
+}
@@ -21,6 +21,7 @@ import (
 func init() {
 	initRegistry()
 	RegisterDefault[*AgentVersion](version.V1, KindAgentVersion, &agentVersionKind{})
+	RegisterDefault[*Alert](version.V1, KindAlert, &alertKind{})
 	RegisterDefault[*Configuration](version.V1, KindConfiguration, &configurationKind{})
 	RegisterDefault[*Destination](version.V1, KindDestination, &destinationKind{})
 	RegisterDefault[*DestinationType](version.V1, KindDestinationType, &destinationTypeKind{})
@@ -29,5 +30,6 @@ func init() {
 	RegisterDefault[*ProcessorType](version.V1, KindProcessorType, &processorTypeKind{})
 	RegisterDefault[*Source](version.V1, KindSource, &sourceKind{})
 	RegisterDefault[*SourceType](version.V1, KindSourceType, &sourceTypeKind{})
+	RegisterDefault[*Webhook](version.V1, KindWebhook, &webhookKind{})
 	RegisterKind(KindAgent)
 }
@@ -38,8 +38,15 @@ type HasSensitiveParameters interface {
 	// the parameter from the existing resource. This does nothing if existing is nil because there is no existing
 	// resource.
 	PreserveSensitiveParameters(ctx context.Context, existing *AnyResource) error
+
+	// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the
+	// transform. It is used by the store to encrypt sensitive parameter values.
+	TransformSensitiveParameters(transform SensitiveParameterTransform) error
 }
 
+// SensitiveParameterTransform returns the replacement for the value of a sensitive parameter
+type SensitiveParameterTransform func(value any) (any, error)
+
 // ParameterizedSpec is the spec for a ParameterizedResource
 type ParameterizedSpec struct {
 	Type       string      `yaml:"type,omitempty" json:"type,omitempty" mapstructure:"type"`
@@ -137,6 +144,51 @@ func maskSensitiveParameters(ctx context.Context, resource HasResourceParameters
 	}
 }
 
+func (s *ParameterizedSpec) transformSensitiveParameters(transform SensitiveParameterTransform) error {
+	if err := transformSensitiveParameters(s, transform); err != nil {
+		return err
+	}
+	for i, p := range s.Processors {
+		if err := p.transformSensitiveParameters(transform); err != nil {
+			return err
+		}
+		s.Processors[i] = p
+	}
+	return nil
+}
+
+// transformSensitiveParameters replaces the values of parameters marked Sensitive with the values returned by the
+// transform
+func transformSensitiveParameters(resource HasResourceParameters, transform SensitiveParameterTransform) error {
+	params := resource.ResourceParameters()
+	for i, param := range params {
+		if !param.Sensitive {
+			continue
+		}
+		value, err := transform(param.Value)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", param.Name, err)
+		}
+		param.Value = value
+		params[i] = param
+	}
+	return nil
+}
+
+// transformSensitiveString applies the transform to a sensitive value that must remain a string, like the secret of a
+// Webhook
+func transformSensitiveString(value string, transform SensitiveParameterTransform) (string, error) {
+	transformed, err := transform(value)
+	if err != nil {
+		return "", err
+	}
+	s, ok := transformed.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string, got %T", transformed)
+	}
+	return s, nil
+}
+
 // ParameterValue returns the value of the first Parameter with the specified name. If multiple Parameters exist with the
 // specified name, only the first one will be returned.
 func ParameterValue(parameters []Parameter, name string) any {
@@ -151,3 +151,8 @@ func (s *Processor) MaskSensitiveParameters(ctx context.Context) {
 func (s *Processor) PreserveSensitiveParameters(ctx context.Context, existing *AnyResource) error {
 	return PreserveSensitiveParameters(ctx, s, existing)
 }
+
+// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the transform
+func (s *Processor) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	return s.Spec.transformSensitiveParameters(transform)
+}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/mitchellh/mapstructure"
@@ -246,6 +247,8 @@ func parseResource[T Resource](r *AnyResource, instance T, errorUnused bool) (T,
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		ErrorUnused: errorUnused,
 		Result:      instance,
+		// times like RolloutOptions.StartAt are strings in JSON and YAML
+		DecodeHook: mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
 	})
 	if err != nil {
 		return instance, fmt.Errorf("failed to create decoder: %w", err)
@@ -92,6 +92,8 @@ const (
 	KindDestinationType Kind = "DestinationType"
 	KindUnknown         Kind = "Unknown"
 	KindRollout         Kind = "Rollout"
+	KindAlert           Kind = "Alert"
+	KindWebhook         Kind = "Webhook"
 )
 
 // Resource is implemented by all resources, e.g. SourceType, DestinationType, Configuration, etc.
@@ -289,6 +291,30 @@ func (r *AnyResource) PreserveSensitiveParameters(ctx context.Context, existing
 	return nil
 }
 
+// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the transform
+func (r *AnyResource) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	// get the underlying resource, transform, and then make it an AnyResource again
+	parsed, err := ParseResource(r)
+	if err != nil {
+		// if we can't parse the resource, we can't transform it
+		return nil
+	}
+	resourceWithSensitiveParameters, ok := parsed.(HasSensitiveParameters)
+	if !ok {
+		// the underlying resource doesn't have sensitive parameters, so there's nothing to transform
+		return nil
+	}
+	if err := resourceWithSensitiveParameters.TransformSensitiveParameters(transform); err != nil {
+		return err
+	}
+	anyResource, err := AsAny(parsed)
+	if err != nil {
+		return err
+	}
+	r.Spec = anyResource.Spec
+	return nil
+}
+
 // ResourceMeta TODO(doc)
 type ResourceMeta struct {
 	APIVersion string   `yaml:"apiVersion,omitempty" json:"apiVersion"`
@@ -676,6 +702,38 @@ func JoinVersion(resourceKey string, version Version) string {
 	}
 }
 
+// ParseVersion parses a version number, optionally prefixed with "v", or one of latest, current, stable, or pending.
+// Unlike SplitVersion, it returns an error if the version cannot be parsed.
+func ParseVersion(value string) (Version, error) {
+	switch value {
+	case "latest":
+		return VersionLatest, nil
+	case "stable", "current":
+		return VersionCurrent, nil
+	case "pending":
+		return VersionPending, nil
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(value, "v"))
+	if err != nil || version < 1 {
+		return VersionLatest, fmt.Errorf("invalid version %q: must be a version number, latest, current, or pending", value)
+	}
+	return Version(version), nil
+}
+
+// FormatVersion returns the version as a string that can be parsed by ParseVersion
+func FormatVersion(version Version) string {
+	switch version {
+	case VersionLatest:
+		return "latest"
+	case VersionCurrent:
+		return "current"
+	case VersionPending:
+		return "pending"
+	default:
+		return strconv.Itoa(int(version))
+	}
+}
+
 // indexFields returns a map of field name to field value to be stored in the index
 func (m *Metadata) indexFields(index modelSearch.Indexer) {
 	index("id", m.ID)
@@ -16,6 +16,7 @@ package model
 
 import (
 	"errors"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -54,7 +55,7 @@ func (t *StatusType[T]) SetStatus(status any) error {
 		// not a matching type, try to map it into the right format. this allows
 		// Configuration.SetStatus(AnyResource.GetStatus()) to work.
 		var empty T
-		if err := mapstructure.Decode(s, &empty); err != nil {
+		if err := decodeStatus(s, &empty); err != nil {
 			return err
 		}
 		t.Status = empty
@@ -78,7 +79,7 @@ func ParseResourceStatus[T any](resource Resource) (*T, bool) {
 	}
 	if s, ok := status.(map[string]any); ok {
 		var empty T
-		if err := mapstructure.Decode(s, &empty); err != nil {
+		if err := decodeStatus(s, &empty); err != nil {
 			return nil, false
 		}
 		return &empty, true
@@ -86,6 +87,19 @@ func ParseResourceStatus[T any](resource Resource) (*T, bool) {
 	return nil, false
 }
 
+// decodeStatus decodes a status parsed from JSON into the specified result. Times like Rollout.Health.CheckStarted are
+// strings in JSON.
+func decodeStatus(status map[string]any, result any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     result,
+		DecodeHook: mapstructure.StringToTimeHookFunc(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(status)
+}
+
 // ----------------------------------------------------------------------
 // accessors
 
@@ -16,6 +16,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -99,3 +100,19 @@ func TestResourceSetCurrent(t *testing.T) {
 		require.True(t, anyResource.IsCurrent())
 	})
 }
+
+func TestResourceSetStatusFromJSON(t *testing.T) {
+	date := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	config := &Configuration{}
+	config.Status.Rollback = &RolloutRollback{Version: 2, Agents: 3, Date: date}
+	config.Status.Rollout.Options.StartAt = &date
+	config.Status.Rollout.Health = &RolloutHealth{CheckStarted: &date}
+
+	// the status of an existing resource is parsed from JSON as a map
+	anyResource, err := AsAny(config)
+	require.NoError(t, err)
+
+	parsed := &Configuration{}
+	require.NoError(t, parsed.SetStatus(anyResource.GetStatus()))
+	require.Equal(t, config.Status, parsed.Status)
+}
@@ -556,6 +556,41 @@ func TestJoinVersion(t *testing.T) {
 	}
 }
 
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		value     string
+		expect    Version
+		expectErr bool
+	}{
+		{value: "3", expect: 3},
+		{value: "v5", expect: 5},
+		{value: "latest", expect: VersionLatest},
+		{value: "current", expect: VersionCurrent},
+		{value: "stable", expect: VersionCurrent},
+		{value: "pending", expect: VersionPending},
+		{value: "", expectErr: true},
+		{value: "0", expectErr: true},
+		{value: "v", expectErr: true},
+		{value: "three", expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseVersion(test.value)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expect, got)
+
+			// formatting and parsing again returns the same version
+			got, err = ParseVersion(FormatVersion(got))
+			require.NoError(t, err)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
 func TestTrimVersion(t *testing.T) {
 	tests := []struct {
 		resourceKey string
@@ -800,6 +835,76 @@ func TestUpdateDependencies(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keep pinned source version in configuration",
+			setup: func(t *testing.T, store *MockResourceStore) {
+				sourceV1 := sourceWithType("source-type:1")
+				sourceV1.Metadata.Version = 1
+				store.EXPECT().Source(ctx, "source1:1").Return(sourceV1, nil)
+				store.EXPECT().SourceType(ctx, "source-type:1").Return(&SourceType{
+					ResourceType: ResourceType{
+						ResourceMeta: ResourceMeta{
+							Metadata: Metadata{
+								Name:    "source-type",
+								Version: 1,
+							},
+						},
+					},
+				}, nil)
+				store.EXPECT().Destination(ctx, "destination1").Return(&Destination{
+					ResourceMeta: ResourceMeta{
+						Metadata: Metadata{
+							Name:    "destination1",
+							Version: 2,
+						},
+					},
+					Spec: ParameterizedSpec{
+						Type: "destination-type:2",
+					},
+				}, nil)
+				store.EXPECT().DestinationType(ctx, "destination-type:2").Return(destinationTypeV2, nil)
+			},
+			resource: &Configuration{
+				ResourceMeta: ResourceMeta{
+					Metadata: Metadata{
+						Name: "config1",
+					},
+				},
+				Spec: ConfigurationSpec{
+					Sources: []ResourceConfiguration{
+						{
+							Name:    "source1:1",
+							Version: 1,
+						},
+					},
+					Destinations: []ResourceConfiguration{
+						{
+							Name: "destination1:1",
+						},
+					},
+				},
+			},
+			expect: &Configuration{
+				ResourceMeta: ResourceMeta{
+					Metadata: Metadata{
+						Name: "config1",
+					},
+				},
+				Spec: ConfigurationSpec{
+					Sources: []ResourceConfiguration{
+						{
+							Name:    "source1:1",
+							Version: 1,
+						},
+					},
+					Destinations: []ResourceConfiguration{
+						{
+							Name: "destination1:2",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
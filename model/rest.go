@@ -14,6 +14,13 @@
 
 package model
 
+import (
+	"fmt"
+
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/observiq/bindplane-op/store/stats"
+)
+
 // AgentResponse is the REST API response to GET /v1/agent/:name
 type AgentResponse struct {
 	Agent *Agent `json:"agent"`
@@ -76,6 +83,11 @@ type ConfigurationResponse struct {
 	Raw           string         `json:"raw"`
 }
 
+// ConfigurationDiffResponse is the REST API response to GET /v1/configurations/:name/diff
+type ConfigurationDiffResponse struct {
+	Diff *ConfigurationDiff `json:"diff"`
+}
+
 // SourcesResponse is the REST API response to GET /v1/sources
 type SourcesResponse struct {
 	Sources []*Source `json:"sources"`
@@ -136,16 +148,45 @@ type DestinationTypeResponse struct {
 	DestinationType *DestinationType `json:"destinationType"`
 }
 
+// AlertsResponse is the REST API response to GET /v1/alerts
+type AlertsResponse struct {
+	Alerts []*Alert `json:"alerts"`
+}
+
+// AlertResponse is the REST API response to GET /v1/alerts/:name
+type AlertResponse struct {
+	Alert *Alert `json:"alert"`
+}
+
+// GitSyncResponse is the REST API response to GET and POST /v1/sync/git
+type GitSyncResponse struct {
+	Status *GitSyncStatus `json:"status"`
+}
+
+// WebhooksResponse is the REST API response to GET /v1/webhooks
+type WebhooksResponse struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookResponse is the REST API response to GET /v1/webhooks/:name
+type WebhookResponse struct {
+	Webhook *Webhook `json:"webhook"`
+}
+
 // ApplyResponse is the REST API response to POST /v1/apply.  This is used on
 // the server side to return updates consisting of generic ResourceStatuses.
 type ApplyResponse struct {
 	Updates []ResourceStatus `json:"updates"`
+
+	// Diffs compare each resource with the current resource if dryRun was specified
+	Diffs []*ResourceDiff `json:"diffs,omitempty"`
 }
 
 // ApplyResponseClientSide is the REST API Response.  This is used on the client
 // side where updates consists of AnyResourceStatuses.
 type ApplyResponseClientSide struct {
 	Updates []*AnyResourceStatus `json:"updates"`
+	Diffs   []*ResourceDiff      `json:"diffs,omitempty"`
 }
 
 // ApplyPayload is the REST API body for POST /v1/apply
@@ -209,7 +250,137 @@ type StartRolloutPayload struct {
 	Options *RolloutOptions `json:"options"`
 }
 
+// RevertConfigurationPayload is the REST API body to POST /v1/configurations/:name/revert
+type RevertConfigurationPayload struct {
+	// Version is the version of the configuration with the spec to apply as a new version
+	Version Version `json:"version"`
+
+	// StartRollout starts a rollout of the configuration after it is reverted
+	StartRollout bool `json:"startRollout,omitempty"`
+
+	// Options are used for the rollout if StartRollout is true. The rollout options of the configuration are used if
+	// they are not specified.
+	Options *RolloutOptions `json:"options,omitempty"`
+}
+
+// RevertConfigurationResponse is the REST API response to POST /v1/configurations/:name/revert
+type RevertConfigurationResponse struct {
+	// Status is the result of applying the reverted configuration. It is unchanged if the spec of the latest version is
+	// already the same as the spec of the version.
+	Status        UpdateStatus   `json:"status"`
+	Configuration *Configuration `json:"configuration"`
+}
+
 // HistoryResponse is the REST API response to GET /v1/:kind/:name/history
 type HistoryResponse struct {
 	Versions []*AnyResource `json:"versions"`
 }
+
+// Users
+
+// UsersResponse is the REST API response to GET /v1/users
+type UsersResponse struct {
+	Users []*User `json:"users"`
+}
+
+// UserResponse is the REST API response to GET /v1/users/:name
+type UserResponse struct {
+	User *User `json:"user"`
+}
+
+// PostUserRequest is the REST API body for POST /v1/users
+type PostUserRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+// PutUserRoleRequest is the REST API body for PUT /v1/users/:name/role
+type PutUserRoleRequest struct {
+	Role Role `json:"role"`
+}
+
+// API Keys
+
+// APIKeysResponse is the REST API response to GET /v1/api-keys
+type APIKeysResponse struct {
+	APIKeys []*APIKey `json:"apiKeys"`
+}
+
+// PostAPIKeyRequest is the REST API body for POST /v1/api-keys
+type PostAPIKeyRequest struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// PostAPIKeyResponse is the REST API response to POST /v1/api-keys. Key is only returned when the key is created.
+type PostAPIKeyResponse struct {
+	APIKey *APIKey `json:"apiKey"`
+	Key    string  `json:"key"`
+}
+
+// Audit
+
+// AuditEventsResponse is the REST API response to GET /v1/audit
+type AuditEventsResponse struct {
+	Events []*AuditEvent `json:"events"`
+}
+
+// Backup
+
+// BackupSummary is the number of resource versions, agents, and measurements in a backup
+type BackupSummary struct {
+	Resources    int `json:"resources"`
+	Agents       int `json:"agents"`
+	Measurements int `json:"measurements"`
+}
+
+// String returns the summary as a sentence fragment, e.g. "12 resources, 2 agents, and 0 measurements"
+func (s BackupSummary) String() string {
+	return fmt.Sprintf("%d resources, %d agents, and %d measurements", s.Resources, s.Agents, s.Measurements)
+}
+
+// RestoreResponse is the REST API response to POST /v1/restore
+type RestoreResponse struct {
+	Restored BackupSummary `json:"restored"`
+
+	// Error describes the resources, agents, and measurements that could not be restored
+	Error string `json:"error,omitempty"`
+}
+
+// RotateEncryptionKeyResponse is the REST API response to POST /v1/encryption/rotate
+type RotateEncryptionKeyResponse struct {
+	// Resources is the number of resources and archived versions that were re-encrypted
+	Resources int `json:"resources"`
+}
+
+// Measurements
+
+// MeasurementsResponse is the REST API response to GET /v1/measurements
+type MeasurementsResponse struct {
+	Series []*stats.TimeSeries `json:"series"`
+}
+
+// SnapshotsResponse is the REST API response to GET /v1/snapshots. The snapshots do not include their data.
+type SnapshotsResponse struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+// SnapshotResponse is the REST API response to GET /v1/snapshots/:id and POST /v1/snapshots
+type SnapshotResponse struct {
+	Snapshot *Snapshot `json:"snapshot"`
+}
+
+// PostSnapshotRequest is the REST API body for POST /v1/snapshots. If ResourceName is empty, the snapshot is captured
+// at the end of each pipeline after all processors.
+type PostSnapshotRequest struct {
+	AgentID      string              `json:"agentId"`
+	PipelineType otel.PipelineType   `json:"pipelineType"`
+	Position     MeasurementPosition `json:"position,omitempty"`
+	ResourceName string              `json:"resourceName,omitempty"`
+}
+
+// SnapshotDiffResponse is the REST API response to GET /v1/snapshots/:id/diff
+type SnapshotDiffResponse struct {
+	Diff *SnapshotDiff `json:"diff"`
+}
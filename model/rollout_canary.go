@@ -0,0 +1,105 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RolloutCanary is the status of the canary phase of a rollout with a CanarySelector
+type RolloutCanary struct {
+	// Agents is the number of agents matching the CanarySelector that receive the new version in the canary phase. It is
+	// counted when the rollout starts. If no agents match, the rollout continues without a canary phase.
+	Agents int `json:"agents" yaml:"agents" mapstructure:"agents"`
+
+	// AwaitingApproval is true if the canary phase completed and the rollout is paused until it is resumed
+	AwaitingApproval bool `json:"awaitingApproval,omitempty" yaml:"awaitingApproval,omitempty" mapstructure:"awaitingApproval"`
+
+	// Approved is true if the rollout was resumed after the canary phase
+	Approved bool `json:"approved,omitempty" yaml:"approved,omitempty" mapstructure:"approved"`
+}
+
+// String returns a short description of the canary phase for printing
+func (c *RolloutCanary) String() string {
+	switch {
+	case c == nil:
+		return "Pending"
+	case c.Agents == 0:
+		return "No agents"
+	case c.Approved:
+		return fmt.Sprintf("Approved (%d agents)", c.Agents)
+	case c.AwaitingApproval:
+		return fmt.Sprintf("Awaiting approval (%d agents)", c.Agents)
+	default:
+		return fmt.Sprintf("%d agents", c.Agents)
+	}
+}
+
+// validateCanarySelector returns an error if the selector doesn't have any labels or the labels are invalid. An empty
+// selector would match every agent.
+func validateCanarySelector(s *AgentSelector) error {
+	if len(s.MatchLabels) == 0 {
+		return errors.New("invalid canary selector: at least one label is required")
+	}
+	if _, err := SelectorFromMap(s.MatchLabels); err != nil {
+		return fmt.Errorf("invalid canary selector: %w", err)
+	}
+	return nil
+}
+
+// SetCanaryAgents records the number of agents matching the CanarySelector before the canary phase starts. It does
+// nothing if the rollout doesn't have a CanarySelector or the canary phase has already started.
+func (r *Rollout) SetCanaryAgents(count int) {
+	if r.Options.CanarySelector == nil || r.Phase > 0 {
+		return
+	}
+	r.Canary = &RolloutCanary{Agents: count}
+}
+
+// ApproveCanary resumes a rollout that is awaiting approval after its canary phase. It returns false if the rollout is
+// not awaiting approval.
+func (r *Rollout) ApproveCanary() bool {
+	if !r.AwaitingApproval() {
+		return false
+	}
+	r.Canary.AwaitingApproval = false
+	r.Canary.Approved = true
+	r.Status = RolloutStatusStarted
+	return true
+}
+
+// AwaitingApproval returns true if the rollout is paused after its canary phase until it is resumed
+func (r *Rollout) AwaitingApproval() bool {
+	return r.Status == RolloutStatusPaused && r.Canary != nil && r.Canary.AwaitingApproval
+}
+
+// hasCanary returns true if the first phase of the rollout is a canary phase
+func (r *Rollout) hasCanary() bool {
+	return r.Options.CanarySelector != nil && r.Canary != nil && r.Canary.Agents > 0
+}
+
+// pauseForApproval pauses the rollout once the agents of the canary phase have completed the new version and there are
+// agents waiting for the remaining phases
+func (r *Rollout) pauseForApproval() {
+	if !r.hasCanary() || r.Canary.Approved || r.Canary.AwaitingApproval || r.Phase != 1 {
+		return
+	}
+	if r.Status != RolloutStatusStarted || r.Progress.Pending > 0 || r.Progress.Waiting == 0 || !r.phaseHealthy() {
+		return
+	}
+	r.Status = RolloutStatusPaused
+	r.Canary.AwaitingApproval = true
+}
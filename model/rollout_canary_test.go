@@ -0,0 +1,112 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCanarySelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  AgentSelector
+		expectErr string
+	}{
+		{name: "valid", selector: AgentSelector{MatchLabels: MatchLabels{"env": "staging"}}},
+		{name: "empty", selector: AgentSelector{}, expectErr: "at least one label is required"},
+		{name: "invalid label", selector: AgentSelector{MatchLabels: MatchLabels{"env": "not valid"}}, expectErr: "invalid canary selector"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := RolloutOptions{CanarySelector: &test.selector}
+			err := options.Validate()
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRolloutCanary(t *testing.T) {
+	newRollout := func() *Rollout {
+		return &Rollout{
+			Status: RolloutStatusStarted,
+			Options: RolloutOptions{
+				PhaseAgentCount: PhaseAgentCount{Initial: 2, Multiplier: 2, Maximum: 10},
+				CanarySelector:  &AgentSelector{MatchLabels: MatchLabels{"canary": "true"}},
+			},
+		}
+	}
+
+	t.Run("canary phase pauses for approval", func(t *testing.T) {
+		r := newRollout()
+		r.SetCanaryAgents(3)
+		require.Equal(t, 3, r.UpdateStatus(RolloutProgress{Waiting: 10}))
+		require.Equal(t, 1, r.Phase)
+		require.Equal(t, "3 agents", r.PrintableFieldValue("Canary"))
+
+		// the canary agents are not counted again once the canary phase started
+		r.SetCanaryAgents(5)
+		require.Equal(t, 3, r.Canary.Agents)
+
+		// still applying the configuration
+		require.Equal(t, 0, r.UpdateStatus(RolloutProgress{Pending: 3, Waiting: 7}))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+
+		require.Equal(t, 0, r.UpdateStatus(RolloutProgress{Completed: 3, Waiting: 7}))
+		require.Equal(t, RolloutStatusPaused, r.Status)
+		require.True(t, r.AwaitingApproval())
+		require.Equal(t, "Paused (approval)", r.PrintableFieldValue("Status"))
+
+		// the remaining phases start at the initial count
+		require.True(t, r.ApproveCanary())
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.Equal(t, 2, r.UpdateStatus(RolloutProgress{Completed: 3, Waiting: 7}))
+		require.Equal(t, 0, r.UpdateStatus(RolloutProgress{Completed: 3, Pending: 2, Waiting: 5}))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.Equal(t, 4, r.UpdateStatus(RolloutProgress{Completed: 5, Waiting: 5}))
+		require.Equal(t, "Approved (3 agents)", r.PrintableFieldValue("Canary"))
+	})
+
+	t.Run("approve does nothing unless awaiting approval", func(t *testing.T) {
+		r := newRollout()
+		r.SetCanaryAgents(3)
+		r.UpdateStatus(RolloutProgress{Waiting: 10})
+		r.Status = RolloutStatusPaused
+		require.False(t, r.ApproveCanary())
+		require.Equal(t, RolloutStatusPaused, r.Status)
+	})
+
+	t.Run("rollout to only canary agents becomes stable", func(t *testing.T) {
+		r := newRollout()
+		r.SetCanaryAgents(3)
+		require.Equal(t, 3, r.UpdateStatus(RolloutProgress{Waiting: 3}))
+		r.UpdateStatus(RolloutProgress{Completed: 3})
+		require.Equal(t, RolloutStatusStable, r.Status)
+	})
+
+	t.Run("no matching agents skips the canary phase", func(t *testing.T) {
+		r := newRollout()
+		r.SetCanaryAgents(0)
+		require.Equal(t, 2, r.UpdateStatus(RolloutProgress{Waiting: 10}))
+		r.UpdateStatus(RolloutProgress{Completed: 2, Waiting: 8})
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.Equal(t, "No agents", r.PrintableFieldValue("Canary"))
+	})
+}
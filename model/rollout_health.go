@@ -0,0 +1,180 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// RolloutHealthCheck gates each phase of a rollout on the throughput of the agents that received the new version.
+// Throughput is measured at the destinations after processors and compared to the baseline throughput of agents with
+// the previous version, measured when the rollout starts.
+type RolloutHealthCheck struct {
+	// MinThroughputPercent is the minimum throughput per agent with the new version as a percentage of the baseline
+	MinThroughputPercent float64 `json:"minThroughputPercent" yaml:"minThroughputPercent" mapstructure:"minThroughputPercent"`
+
+	// Duration is how long the throughput must stay above the minimum before the rollout moves to the next phase, e.g.
+	// 10m. If agents with the new version don't report any measurements for this long, the check fails.
+	Duration string `json:"duration" yaml:"duration" mapstructure:"duration"`
+}
+
+// Validate returns an error if the percentage or duration of the health check is invalid
+func (c *RolloutHealthCheck) Validate() error {
+	if c.MinThroughputPercent < 0 || c.MinThroughputPercent > 100 {
+		return fmt.Errorf("invalid health check: minThroughputPercent %v must be between 0 and 100", c.MinThroughputPercent)
+	}
+	if _, err := c.duration(); err != nil {
+		return fmt.Errorf("invalid health check: %w", err)
+	}
+	return nil
+}
+
+func (c *RolloutHealthCheck) duration() (time.Duration, error) {
+	duration, err := time.ParseDuration(c.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", c.Duration, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %q must be greater than zero", c.Duration)
+	}
+	return duration, nil
+}
+
+// RolloutThroughput is the average throughput in bytes per second per agent measured for a RolloutHealthCheck. A nil
+// value means that the agents did not report any measurements or were not measured.
+type RolloutThroughput struct {
+	// Previous is the throughput of agents that have not received the new version
+	Previous *float64
+
+	// Current is the throughput of agents that have completed the new version
+	Current *float64
+}
+
+// RolloutHealth is the status of the RolloutHealthCheck of a rollout
+type RolloutHealth struct {
+	// Baseline is the throughput per agent of agents with the previous version, measured when the rollout started
+	Baseline *float64 `json:"baseline,omitempty" yaml:"baseline,omitempty" mapstructure:"baseline"`
+
+	// Throughput is the most recent throughput per agent of agents with the new version
+	Throughput *float64 `json:"throughput,omitempty" yaml:"throughput,omitempty" mapstructure:"throughput"`
+
+	// Phase is the phase of the rollout being checked
+	Phase int `json:"phase" yaml:"phase" mapstructure:"phase"`
+
+	// CheckStarted is the time when all of the agents of the phase completed the new version and the check started
+	CheckStarted *time.Time `json:"checkStarted,omitempty" yaml:"checkStarted,omitempty" mapstructure:"checkStarted"`
+
+	// Passed is true if the agents of the phase stayed healthy for the duration of the check
+	Passed bool `json:"passed" yaml:"passed" mapstructure:"passed"`
+
+	// Failure describes why the check failed and the rollout was stopped
+	Failure string `json:"failure,omitempty" yaml:"failure,omitempty" mapstructure:"failure"`
+}
+
+// String returns a short description of the health check for printing
+func (h *RolloutHealth) String() string {
+	switch {
+	case h == nil:
+		return "Pending"
+	case h.Failure != "":
+		return "Failed: " + h.Failure
+	case h.Passed:
+		return "Passed"
+	case h.CheckStarted != nil:
+		return "Checking"
+	default:
+		return "Pending"
+	}
+}
+
+// ApplyHealthCheck evaluates the health check of a started rollout once all of the agents of the current phase have
+// completed the new version. If the throughput of those agents falls below the minimum, the rollout is stopped with
+// RolloutStatusError. The next phase can start once the check has passed.
+func (r *Rollout) ApplyHealthCheck(now time.Time, progress RolloutProgress, throughput RolloutThroughput) {
+	check := r.Options.HealthCheck
+	if check == nil {
+		return
+	}
+	if r.Health == nil {
+		r.Health = &RolloutHealth{}
+	}
+	h := r.Health
+	if h.Baseline == nil {
+		h.Baseline = throughput.Previous
+	}
+	if throughput.Current != nil {
+		h.Throughput = throughput.Current
+	}
+	if h.Phase != r.Phase {
+		// start over for the next phase
+		*h = RolloutHealth{Baseline: h.Baseline, Throughput: h.Throughput, Phase: r.Phase}
+	}
+
+	if r.Status != RolloutStatusStarted || r.Phase == 0 || h.Passed || progress.Pending > 0 {
+		return
+	}
+	if h.CheckStarted == nil {
+		h.CheckStarted = &now
+	}
+
+	duration, err := check.duration()
+	if err != nil {
+		r.failHealthCheck(err.Error())
+		return
+	}
+	elapsed := now.Sub(*h.CheckStarted)
+
+	if throughput.Current == nil {
+		if elapsed >= duration {
+			r.failHealthCheck(fmt.Sprintf("no throughput was measured for agents with the new version after %s", check.Duration))
+		}
+		return
+	}
+
+	minimum := 0.0
+	if h.Baseline != nil {
+		minimum = *h.Baseline * check.MinThroughputPercent / 100
+	}
+	if *throughput.Current < minimum {
+		r.failHealthCheck(fmt.Sprintf("throughput of %.2f B/s per agent is below %v%% of the baseline of %.2f B/s", *throughput.Current, check.MinThroughputPercent, *h.Baseline))
+		return
+	}
+	if elapsed >= duration {
+		h.Passed = true
+	}
+}
+
+// OverrideHealthCheck clears a health check failure and accepts the current phase. It is used when a rollout stopped by
+// its health check is resumed.
+func (r *Rollout) OverrideHealthCheck() {
+	if r.Health != nil && r.Health.Failure != "" {
+		r.Health.Failure = ""
+		r.Health.Passed = true
+	}
+}
+
+func (r *Rollout) failHealthCheck(reason string) {
+	r.Status = RolloutStatusError
+	r.Health.Failure = reason
+}
+
+// phaseHealthy returns true if the rollout doesn't have a health check or the check passed for the current phase
+func (r *Rollout) phaseHealthy() bool {
+	if r.Options.HealthCheck == nil || r.Phase == 0 {
+		return true
+	}
+	return r.Health != nil && r.Health.Phase == r.Phase && r.Health.Passed
+}
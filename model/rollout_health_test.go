@@ -0,0 +1,168 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutHealthCheckValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		check     RolloutHealthCheck
+		expectErr string
+	}{
+		{name: "valid", check: RolloutHealthCheck{MinThroughputPercent: 80, Duration: "10m"}},
+		{name: "zero percent only requires measurements", check: RolloutHealthCheck{Duration: "10m"}},
+		{name: "percent too high", check: RolloutHealthCheck{MinThroughputPercent: 120, Duration: "10m"}, expectErr: "must be between 0 and 100"},
+		{name: "negative percent", check: RolloutHealthCheck{MinThroughputPercent: -1, Duration: "10m"}, expectErr: "must be between 0 and 100"},
+		{name: "missing duration", check: RolloutHealthCheck{MinThroughputPercent: 80}, expectErr: "invalid duration"},
+		{name: "zero duration", check: RolloutHealthCheck{MinThroughputPercent: 80, Duration: "0s"}, expectErr: "must be greater than zero"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check.Validate()
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRolloutOptionsValidate(t *testing.T) {
+	options := RolloutOptions{
+		MaintenanceWindow: &MaintenanceWindow{Cron: "0 2 * *", Duration: "2h"},
+		HealthCheck:       &RolloutHealthCheck{MinThroughputPercent: 200, Duration: "10m"},
+	}
+	err := options.Validate()
+	require.ErrorContains(t, err, "must have 5 fields")
+	require.ErrorContains(t, err, "must be between 0 and 100")
+
+	require.NoError(t, (&RolloutOptions{}).Validate())
+}
+
+func TestRolloutApplyHealthCheck(t *testing.T) {
+	start := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	throughput := func(previous, current *float64) RolloutThroughput {
+		return RolloutThroughput{Previous: previous, Current: current}
+	}
+	value := func(v float64) *float64 { return &v }
+
+	newRollout := func() *Rollout {
+		return &Rollout{
+			Status: RolloutStatusStarted,
+			Options: RolloutOptions{
+				PhaseAgentCount: PhaseAgentCount{Initial: 1, Multiplier: 1, Maximum: 1},
+				HealthCheck:     &RolloutHealthCheck{MinThroughputPercent: 80, Duration: "10m"},
+			},
+		}
+	}
+
+	t.Run("baseline is recorded when the rollout starts and the first phase is not gated", func(t *testing.T) {
+		r := newRollout()
+		progress := RolloutProgress{Waiting: 3}
+		r.ApplyHealthCheck(start, progress, throughput(value(100), nil))
+		require.Equal(t, value(100), r.Health.Baseline)
+		require.Nil(t, r.Health.CheckStarted)
+		require.Equal(t, 1, r.UpdateStatus(progress))
+		require.Equal(t, 1, r.Phase)
+	})
+
+	t.Run("phase advances after staying healthy for the duration", func(t *testing.T) {
+		r := newRollout()
+		r.ApplyHealthCheck(start, RolloutProgress{Waiting: 3}, throughput(value(100), nil))
+		r.UpdateStatus(RolloutProgress{Waiting: 3})
+
+		// agents of the first phase are still applying the configuration
+		progress := RolloutProgress{Pending: 1, Waiting: 2}
+		r.ApplyHealthCheck(start.Add(time.Minute), progress, throughput(nil, nil))
+		require.Nil(t, r.Health.CheckStarted)
+
+		progress = RolloutProgress{Completed: 1, Waiting: 2}
+		r.ApplyHealthCheck(start.Add(2*time.Minute), progress, throughput(nil, value(90)))
+		require.Equal(t, "Checking", r.Health.String())
+		require.Equal(t, 0, r.UpdateStatus(progress))
+
+		r.ApplyHealthCheck(start.Add(12*time.Minute), progress, throughput(nil, value(85)))
+		require.Equal(t, "Passed", r.Health.String())
+		require.Equal(t, value(85), r.Health.Throughput)
+		require.Equal(t, 1, r.UpdateStatus(progress))
+		require.Equal(t, 2, r.Phase)
+
+		// the next phase is checked again
+		progress = RolloutProgress{Completed: 2, Waiting: 1}
+		r.ApplyHealthCheck(start.Add(13*time.Minute), progress, throughput(nil, value(85)))
+		require.Equal(t, 2, r.Health.Phase)
+		require.False(t, r.Health.Passed)
+		require.Equal(t, 0, r.UpdateStatus(progress))
+	})
+
+	t.Run("rollout is not stable until the last phase passes", func(t *testing.T) {
+		r := newRollout()
+		r.Phase = 1
+		progress := RolloutProgress{Completed: 1}
+		r.ApplyHealthCheck(start, progress, throughput(value(100), value(100)))
+		r.UpdateStatus(progress)
+		require.Equal(t, RolloutStatusStarted, r.Status)
+
+		r.ApplyHealthCheck(start.Add(10*time.Minute), progress, throughput(nil, value(100)))
+		r.UpdateStatus(progress)
+		require.Equal(t, RolloutStatusStable, r.Status)
+	})
+
+	t.Run("low throughput fails the rollout", func(t *testing.T) {
+		r := newRollout()
+		r.Phase = 1
+		progress := RolloutProgress{Completed: 1, Waiting: 2}
+		r.ApplyHealthCheck(start, progress, throughput(value(100), value(10)))
+		require.Equal(t, RolloutStatusError, r.Status)
+		require.Equal(t, "Failed: throughput of 10.00 B/s per agent is below 80% of the baseline of 100.00 B/s", r.PrintableFieldValue("Health"))
+		require.Equal(t, 0, r.UpdateStatus(progress))
+
+		// resuming the rollout accepts the phase
+		r.OverrideHealthCheck()
+		r.Status = RolloutStatusStarted
+		r.ApplyHealthCheck(start.Add(time.Minute), progress, throughput(nil, value(10)))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.Equal(t, 1, r.UpdateStatus(progress))
+	})
+
+	t.Run("missing measurements fail the rollout after the duration", func(t *testing.T) {
+		r := newRollout()
+		r.Phase = 1
+		progress := RolloutProgress{Completed: 1, Waiting: 2}
+		r.ApplyHealthCheck(start, progress, throughput(nil, nil))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+
+		r.ApplyHealthCheck(start.Add(10*time.Minute), progress, throughput(nil, nil))
+		require.Equal(t, RolloutStatusError, r.Status)
+		require.Contains(t, r.Health.Failure, "no throughput was measured")
+	})
+
+	t.Run("without a baseline only measurements are required", func(t *testing.T) {
+		r := newRollout()
+		r.Phase = 1
+		progress := RolloutProgress{Completed: 1, Waiting: 2}
+		r.ApplyHealthCheck(start, progress, throughput(nil, value(0)))
+		r.ApplyHealthCheck(start.Add(10*time.Minute), progress, throughput(nil, value(0)))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.True(t, r.Health.Passed)
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// MaintenanceWindow is a recurring period of time when the phases of a rollout can advance. The window opens at each
+// time matching Cron and stays open for Duration.
+type MaintenanceWindow struct {
+	// Cron is a standard 5 field cron expression (minute hour day-of-month month day-of-week) for the start of the window
+	Cron string `json:"cron" yaml:"cron" mapstructure:"cron"`
+
+	// Duration is the length of the window, e.g. 2h or 90m
+	Duration string `json:"duration" yaml:"duration" mapstructure:"duration"`
+
+	// Timezone is the IANA timezone used to evaluate Cron, e.g. America/New_York. UTC is used if it is empty.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty" mapstructure:"timezone"`
+}
+
+// Validate returns an error if the cron expression, duration, or timezone of the window is invalid
+func (w *MaintenanceWindow) Validate() error {
+	errs := validation.NewErrors()
+	if _, err := parseCron(w.Cron); err != nil {
+		errs.Add(fmt.Errorf("invalid maintenance window: %w", err))
+	}
+	if _, err := w.duration(); err != nil {
+		errs.Add(fmt.Errorf("invalid maintenance window: %w", err))
+	}
+	if _, err := w.location(); err != nil {
+		errs.Add(fmt.Errorf("invalid maintenance window: %w", err))
+	}
+	return errs.Result()
+}
+
+// Contains returns true if the window is open at the specified time. A window that is not valid is never open so that a
+// misconfigured rollout doesn't advance.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	schedule, duration, loc, err := w.parse()
+	if err != nil {
+		return false
+	}
+	// the window is open if it started within the last duration
+	start := schedule.next(t.In(loc).Add(-duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// NextOpen returns the next time after t that the window opens or the zero time if the window is not valid
+func (w *MaintenanceWindow) NextOpen(t time.Time) time.Time {
+	schedule, _, loc, err := w.parse()
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.next(t.In(loc))
+}
+
+// String returns a description of the window, e.g. "0 2 * * 1-5 for 2h (America/New_York)"
+func (w *MaintenanceWindow) String() string {
+	timezone := w.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	return fmt.Sprintf("%s for %s (%s)", w.Cron, w.Duration, timezone)
+}
+
+func (w *MaintenanceWindow) parse() (*cronSchedule, time.Duration, *time.Location, error) {
+	schedule, err := parseCron(w.Cron)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	duration, err := w.duration()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	loc, err := w.location()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return schedule, duration, loc, nil
+}
+
+func (w *MaintenanceWindow) duration() (time.Duration, error) {
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", w.Duration, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %q must be greater than zero", w.Duration)
+	}
+	return duration, nil
+}
+
+func (w *MaintenanceWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	if !validation.IsTimezone(w.Timezone) {
+		return nil, fmt.Errorf("%s is not a valid timezone", w.Timezone)
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// ----------------------------------------------------------------------
+
+// Validate returns an error if the maintenance window, health check, or canary selector of the rollout options is
+// invalid
+func (o *RolloutOptions) Validate() error {
+	errs := validation.NewErrors()
+	if o.MaintenanceWindow != nil {
+		errs.Add(o.MaintenanceWindow.Validate())
+	}
+	if o.HealthCheck != nil {
+		errs.Add(o.HealthCheck.Validate())
+	}
+	if o.CanarySelector != nil {
+		errs.Add(validateCanarySelector(o.CanarySelector))
+	}
+	return errs.Result()
+}
+
+// ScheduleOpen returns true if the phases of a rollout with these options can advance at the specified time. A rollout
+// can advance after StartAt and while the MaintenanceWindow is open.
+func (o *RolloutOptions) ScheduleOpen(t time.Time) bool {
+	if o.StartAt != nil && t.Before(*o.StartAt) {
+		return false
+	}
+	if o.MaintenanceWindow != nil && !o.MaintenanceWindow.Contains(t) {
+		return false
+	}
+	return true
+}
+
+// ApplySchedule pauses a started rollout when its schedule is closed and resumes it when the schedule opens again.
+// Rollouts paused by a user are not resumed.
+func (r *Rollout) ApplySchedule(t time.Time) {
+	open := r.Options.ScheduleOpen(t)
+	switch {
+	case r.Status == RolloutStatusStarted && !open:
+		r.Status = RolloutStatusPaused
+		r.PausedBySchedule = true
+	case r.Status == RolloutStatusPaused && r.PausedBySchedule && open:
+		r.Status = RolloutStatusStarted
+		r.PausedBySchedule = false
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr      string
+		expectErr string
+	}{
+		{expr: "0 2 * * *"},
+		{expr: "*/15 0-6 1,15 * mon-fri"},
+		{expr: "30 22 * jan-mar 7"},
+		{expr: "0 2 * *", expectErr: "must have 5 fields"},
+		{expr: "60 2 * * *", expectErr: "invalid value \"60\" in minute field"},
+		{expr: "0 5-2 * * *", expectErr: "invalid range \"5-2\" in hour field"},
+		{expr: "*/0 * * * *", expectErr: "invalid step \"0\" in minute field"},
+		{expr: "0 0 * * funday", expectErr: "day of week field"},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := parseCron(test.expr)
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		after  time.Time
+		expect time.Time
+	}{
+		{
+			name:   "later the same day",
+			expr:   "0 2 * * *",
+			after:  time.Date(2023, 5, 1, 1, 30, 0, 0, time.UTC),
+			expect: time.Date(2023, 5, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "strictly after",
+			expr:   "0 2 * * *",
+			after:  time.Date(2023, 5, 1, 2, 0, 0, 0, time.UTC),
+			expect: time.Date(2023, 5, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "weekdays skip the weekend",
+			expr:   "0 22 * * mon-fri",
+			after:  time.Date(2023, 5, 5, 23, 0, 0, 0, time.UTC), // Friday
+			expect: time.Date(2023, 5, 8, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "7 is sunday",
+			expr:   "0 0 * * 7",
+			after:  time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+			expect: time.Date(2023, 5, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "day of month or day of week",
+			expr:   "0 0 15 * sun",
+			after:  time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC),
+			expect: time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "wraps to next year",
+			expr:   "*/20 3 1 jan *",
+			after:  time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+			expect: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "never matches",
+			expr:  "0 0 30 feb *",
+			after: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseCron(test.expr)
+			require.NoError(t, err)
+			require.Equal(t, test.expect, schedule.next(test.after))
+		})
+	}
+}
+
+func TestCronScheduleNextDST(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// in Sao Paulo, clocks were set forward from 2018-11-04 00:00 to 01:00 (-03:00 to -02:00). in New York, clocks
+	// were set forward from 2023-03-12 02:00 EST to 03:00 EDT and set back from 2023-11-05 02:00 EDT to 01:00 EST, so
+	// 01:00-02:00 is 05:00-06:00 UTC and again 06:00-07:00 UTC.
+	tests := []struct {
+		name   string
+		expr   string
+		loc    *time.Location
+		after  time.Time
+		expect time.Time
+	}{
+		{
+			name:   "skipped midnight is not the next day",
+			expr:   "0 1 3 * *",
+			loc:    saoPaulo,
+			after:  time.Date(2018, 11, 3, 5, 0, 0, 0, time.UTC), // 02:00 on the 3rd
+			expect: time.Date(2018, 12, 3, 3, 0, 0, 0, time.UTC), // 01:00 on the next 3rd
+		},
+		{
+			name:   "skipped midnight never matches",
+			expr:   "0 0 * * *",
+			loc:    saoPaulo,
+			after:  time.Date(2018, 11, 3, 15, 0, 0, 0, time.UTC),
+			expect: time.Date(2018, 11, 5, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "first hour after skipped midnight",
+			expr:   "30 1 * * *",
+			loc:    saoPaulo,
+			after:  time.Date(2018, 11, 3, 15, 0, 0, 0, time.UTC),
+			expect: time.Date(2018, 11, 4, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "skipped hour",
+			expr:   "0 3 * * *",
+			loc:    newYork,
+			after:  time.Date(2023, 3, 12, 5, 30, 0, 0, time.UTC), // 00:30 EST
+			expect: time.Date(2023, 3, 12, 7, 0, 0, 0, time.UTC),  // 03:00 EDT
+		},
+		{
+			name:   "repeated time only matches once",
+			expr:   "30 1 * * *",
+			loc:    newYork,
+			after:  time.Date(2023, 11, 5, 5, 45, 0, 0, time.UTC), // 01:45 EDT
+			expect: time.Date(2023, 11, 6, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "first of repeated times",
+			expr:   "30 1 * * *",
+			loc:    newYork,
+			after:  time.Date(2023, 11, 5, 5, 0, 0, 0, time.UTC), // 01:00 EDT
+			expect: time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "after a repeated time",
+			expr:   "20 1 * * *",
+			loc:    newYork,
+			after:  time.Date(2023, 11, 5, 6, 15, 0, 0, time.UTC), // 01:15 EST
+			expect: time.Date(2023, 11, 6, 6, 20, 0, 0, time.UTC),
+		},
+		{
+			name:   "hourly schedule runs in the repeated hour",
+			expr:   "30 * * * *",
+			loc:    newYork,
+			after:  time.Date(2023, 11, 5, 5, 45, 0, 0, time.UTC), // 01:45 EDT
+			expect: time.Date(2023, 11, 5, 6, 30, 0, 0, time.UTC), // 01:30 EST
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseCron(test.expr)
+			require.NoError(t, err)
+			next := schedule.next(test.after.In(test.loc))
+			require.Equal(t, test.loc, next.Location())
+			require.Equal(t, test.expect, next.UTC())
+		})
+	}
+}
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    MaintenanceWindow
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			window: MaintenanceWindow{Cron: "0 2 * * *", Duration: "2h", Timezone: "America/New_York"},
+		},
+		{
+			name:   "timezone defaults to UTC",
+			window: MaintenanceWindow{Cron: "0 2 * * *", Duration: "90m"},
+		},
+		{
+			name:      "invalid cron",
+			window:    MaintenanceWindow{Cron: "0 2", Duration: "2h"},
+			expectErr: "must have 5 fields",
+		},
+		{
+			name:      "invalid duration",
+			window:    MaintenanceWindow{Cron: "0 2 * * *", Duration: "two hours"},
+			expectErr: "invalid duration",
+		},
+		{
+			name:      "negative duration",
+			window:    MaintenanceWindow{Cron: "0 2 * * *", Duration: "-1h"},
+			expectErr: "must be greater than zero",
+		},
+		{
+			name:      "invalid timezone",
+			window:    MaintenanceWindow{Cron: "0 2 * * *", Duration: "2h", Timezone: "Mars/Olympus_Mons"},
+			expectErr: "Mars/Olympus_Mons is not a valid timezone",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.window.Validate()
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	window := &MaintenanceWindow{Cron: "0 22 * * *", Duration: "4h", Timezone: "America/New_York"}
+
+	// 22:00 in New York is 02:00 UTC during daylight saving time
+	tests := []struct {
+		name   string
+		time   time.Time
+		expect bool
+	}{
+		{name: "before the window", time: time.Date(2023, 5, 2, 1, 59, 0, 0, time.UTC), expect: false},
+		{name: "start of the window", time: time.Date(2023, 5, 2, 2, 0, 0, 0, time.UTC), expect: true},
+		{name: "window spans midnight", time: time.Date(2023, 5, 2, 5, 0, 0, 0, time.UTC), expect: true},
+		{name: "end of the window", time: time.Date(2023, 5, 2, 6, 0, 0, 0, time.UTC), expect: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, window.Contains(test.time))
+		})
+	}
+
+	invalid := &MaintenanceWindow{Cron: "0 22 * * *", Duration: "forever"}
+	require.False(t, invalid.Contains(time.Date(2023, 5, 1, 23, 0, 0, 0, time.UTC)))
+
+	next := window.NextOpen(time.Date(2023, 5, 2, 3, 0, 0, 0, time.UTC))
+	require.True(t, next.Equal(time.Date(2023, 5, 3, 2, 0, 0, 0, time.UTC)))
+}
+
+func TestRolloutApplySchedule(t *testing.T) {
+	startAt := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	options := RolloutOptions{
+		StartAt: &startAt,
+		MaintenanceWindow: &MaintenanceWindow{
+			Cron:     "0 2 * * *",
+			Duration: "2h",
+		},
+	}
+
+	t.Run("paused before start time", func(t *testing.T) {
+		r := &Rollout{Status: RolloutStatusStarted, Options: options}
+		r.ApplySchedule(time.Date(2023, 4, 30, 3, 0, 0, 0, time.UTC))
+		require.Equal(t, RolloutStatusPaused, r.Status)
+		require.True(t, r.PausedBySchedule)
+	})
+
+	t.Run("paused outside the window and resumed inside it", func(t *testing.T) {
+		r := &Rollout{Status: RolloutStatusStarted, Options: options}
+		r.ApplySchedule(time.Date(2023, 5, 1, 5, 0, 0, 0, time.UTC))
+		require.Equal(t, RolloutStatusPaused, r.Status)
+		require.True(t, r.PausedBySchedule)
+		require.Equal(t, "Paused (scheduled)", r.PrintableFieldValue("Status"))
+
+		r.ApplySchedule(time.Date(2023, 5, 2, 2, 30, 0, 0, time.UTC))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+		require.False(t, r.PausedBySchedule)
+	})
+
+	t.Run("paused by user is not resumed", func(t *testing.T) {
+		r := &Rollout{Status: RolloutStatusPaused, Options: options}
+		r.ApplySchedule(time.Date(2023, 5, 2, 2, 30, 0, 0, time.UTC))
+		require.Equal(t, RolloutStatusPaused, r.Status)
+		require.False(t, r.PausedBySchedule)
+	})
+
+	t.Run("no schedule", func(t *testing.T) {
+		r := &Rollout{Status: RolloutStatusStarted}
+		r.ApplySchedule(time.Date(2023, 5, 1, 5, 0, 0, 0, time.UTC))
+		require.Equal(t, RolloutStatusStarted, r.Status)
+	})
+
+	t.Run("printable schedule", func(t *testing.T) {
+		r := &Rollout{Options: options}
+		require.Equal(t, "2023-05-01T00:00:00Z", r.PrintableFieldValue("Start"))
+		require.Equal(t, "0 2 * * * for 2h (UTC)", r.PrintableFieldValue("Window"))
+	})
+}
@@ -0,0 +1,346 @@
+// Copyright  observIQ, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/observiq/bindplane-op/otlp/record"
+)
+
+// Snapshot is the telemetry captured by a snapshot processor of an agent. Snapshots are saved so that they can be
+// downloaded and compared after the agent has moved on, e.g. to compare the telemetry before and after processors.
+type Snapshot struct {
+	// ID orders snapshots by the time they were captured
+	ID        string    `json:"id" yaml:"id"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	AgentID   string `json:"agentId" yaml:"agentId"`
+	AgentName string `json:"agentName" yaml:"agentName"`
+
+	// Configuration is the name and version of the configuration of the agent, e.g. linux:3
+	Configuration string `json:"configuration" yaml:"configuration"`
+
+	PipelineType otel.PipelineType `json:"pipelineType" yaml:"pipelineType"`
+
+	// Position and ResourceName identify the snapshot processor that captured the telemetry, e.g. s0 of a source is
+	// before the source processors and d0 of a destination is before the destination processors. If they are empty,
+	// the telemetry was captured at the end of each pipeline after all processors.
+	Position     MeasurementPosition `json:"position,omitempty" yaml:"position,omitempty"`
+	ResourceName string              `json:"resourceName,omitempty" yaml:"resourceName,omitempty"`
+
+	// Count is the number of log records, metric data points, or spans in the snapshot
+	Count int `json:"count" yaml:"count"`
+
+	// Data is the telemetry encoded as OTLP JSON. It is omitted when snapshots are listed.
+	Data json.RawMessage `json:"data,omitempty" yaml:"-"`
+}
+
+// NewSnapshot returns a new Snapshot captured now from the agent with the data encoded as OTLP JSON
+func NewSnapshot(agent *Agent, pipelineType otel.PipelineType, position MeasurementPosition, resourceName string, count int, data []byte) *Snapshot {
+	createdAt := time.Now().UTC()
+	return &Snapshot{
+		ID:            newOrderedID(createdAt),
+		CreatedAt:     createdAt,
+		AgentID:       agent.ID,
+		AgentName:     agent.Name,
+		Configuration: agent.ConfigurationStatus.Current,
+		PipelineType:  pipelineType,
+		Position:      position,
+		ResourceName:  resourceName,
+		Count:         count,
+		Data:          data,
+	}
+}
+
+// Summary returns a copy of the snapshot without the data
+func (s *Snapshot) Summary() *Snapshot {
+	summary := *s
+	summary.Data = nil
+	return &summary
+}
+
+// Processor returns the ComponentID of the snapshot processor that captured the telemetry
+func (s *Snapshot) Processor() otel.ComponentID {
+	return SnapshotProcessor(s.Position, s.ResourceName)
+}
+
+// Logs returns the log records of a logs snapshot
+func (s *Snapshot) Logs() ([]*record.Log, error) {
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(s.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot logs: %w", err)
+	}
+	return record.ConvertLogs(logs), nil
+}
+
+// Metrics returns the metric data points of a metrics snapshot
+func (s *Snapshot) Metrics(ctx context.Context) ([]*record.Metric, error) {
+	metrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(s.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot metrics: %w", err)
+	}
+	return record.ConvertMetrics(ctx, metrics), nil
+}
+
+// Traces returns the spans of a traces snapshot
+func (s *Snapshot) Traces() ([]*record.Trace, error) {
+	traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(s.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot traces: %w", err)
+	}
+	return record.ConvertTraces(traces), nil
+}
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Configuration"
+func (s *Snapshot) PrintableKindSingular() string {
+	return "Snapshot"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Configurations"
+func (s *Snapshot) PrintableKindPlural() string {
+	return "Snapshots"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (s *Snapshot) PrintableFieldTitles() []string {
+	return []string{"ID", "Created", "Agent", "Configuration", "Type", "Position", "Resource", "Count"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (s *Snapshot) PrintableFieldValue(title string) string {
+	switch title {
+	case "ID":
+		return s.ID
+	case "Created":
+		return s.CreatedAt.Format(time.RFC3339)
+	case "Agent":
+		return valueOrDash(s.AgentName)
+	case "Configuration":
+		return valueOrDash(s.Configuration)
+	case "Type":
+		return string(s.PipelineType)
+	case "Position":
+		return valueOrDash(string(s.Position))
+	case "Resource":
+		return valueOrDash(s.ResourceName)
+	case "Count":
+		return strconv.Itoa(s.Count)
+	default:
+		return "-"
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// SnapshotDiff compares the records of two snapshots, e.g. captured before and after processors. Records are matched
+// by their Key, which is the timestamp of a log record, the name and timestamp of a metric data point, or the trace
+// and span ID of a span.
+type SnapshotDiff struct {
+	From *Snapshot `json:"from"`
+	To   *Snapshot `json:"to"`
+
+	// Unchanged is the number of records that are the same in both snapshots
+	Unchanged int `json:"unchanged"`
+
+	// Removed are the records in From that are not in To, e.g. because they were dropped by a filter processor
+	Removed []SnapshotRecord `json:"removed"`
+
+	// Added are the records in To that are not in From
+	Added []SnapshotRecord `json:"added"`
+
+	// Changed are the records in both snapshots with different fields
+	Changed []SnapshotRecordChange `json:"changed"`
+}
+
+// SnapshotRecord is a log record, metric data point, or span of a snapshot
+type SnapshotRecord struct {
+	Key    string         `json:"key"`
+	Record map[string]any `json:"record"`
+}
+
+// SnapshotRecordChange are the fields of a record that are different in the two snapshots
+type SnapshotRecordChange struct {
+	Key     string                `json:"key"`
+	Changes []SnapshotFieldChange `json:"changes"`
+}
+
+// SnapshotFieldChange is a field that was added, removed, or modified. Attributes and resource attributes are compared
+// individually, e.g. attributes.host. From is nil if the field was added and To is nil if it was removed.
+type SnapshotFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// NewSnapshotDiff compares the records of two snapshots with the same pipeline type
+func NewSnapshotDiff(ctx context.Context, from, to *Snapshot) (*SnapshotDiff, error) {
+	if from.PipelineType != to.PipelineType {
+		return nil, fmt.Errorf("unable to compare a %s snapshot with a %s snapshot", from.PipelineType, to.PipelineType)
+	}
+	fromKeys, fromRecords, err := from.keyedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toKeys, toRecords, err := to.keyedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{
+		From:    from.Summary(),
+		To:      to.Summary(),
+		Removed: []SnapshotRecord{},
+		Added:   []SnapshotRecord{},
+		Changed: []SnapshotRecordChange{},
+	}
+	for _, key := range fromKeys {
+		toRecord, ok := toRecords[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, SnapshotRecord{Key: key, Record: fromRecords[key]})
+			continue
+		}
+		changes := recordChanges(fromRecords[key], toRecord)
+		if len(changes) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, SnapshotRecordChange{Key: key, Changes: changes})
+	}
+	for _, key := range toKeys {
+		if _, ok := fromRecords[key]; !ok {
+			diff.Added = append(diff.Added, SnapshotRecord{Key: key, Record: toRecords[key]})
+		}
+	}
+	return diff, nil
+}
+
+// keyedRecords returns the keys of the records in order and the records by key. If multiple records have the same
+// key, the occurrence is appended to the key, e.g. the second log record with the same timestamp has the key
+// 2023-06-01T00:00:00Z#1.
+func (s *Snapshot) keyedRecords(ctx context.Context) ([]string, map[string]map[string]any, error) {
+	var (
+		records []any
+		keyOf   func(i int) string
+	)
+	switch s.PipelineType {
+	case otel.Logs:
+		logs, err := s.Logs()
+		if err != nil {
+			return nil, nil, err
+		}
+		keyOf = func(i int) string { return logs[i].Timestamp.Format(time.RFC3339Nano) }
+		for _, log := range logs {
+			records = append(records, log)
+		}
+	case otel.Metrics:
+		metrics, err := s.Metrics(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyOf = func(i int) string { return metrics[i].Name + " " + metrics[i].Timestamp.Format(time.RFC3339Nano) }
+		for _, metric := range metrics {
+			records = append(records, metric)
+		}
+	case otel.Traces:
+		traces, err := s.Traces()
+		if err != nil {
+			return nil, nil, err
+		}
+		keyOf = func(i int) string { return traces[i].TraceID + "/" + traces[i].SpanID }
+		for _, trace := range traces {
+			records = append(records, trace)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown pipeline type: %s", s.PipelineType)
+	}
+
+	keys := make([]string, 0, len(records))
+	byKey := make(map[string]map[string]any, len(records))
+	occurrences := map[string]int{}
+	for i, r := range records {
+		key := keyOf(i)
+		if n := occurrences[key]; n > 0 {
+			occurrences[key]++
+			key = fmt.Sprintf("%s#%d", key, n)
+		} else {
+			occurrences[key] = 1
+		}
+
+		// compare records as JSON so that values of different types are compared the same way
+		data, err := jsoniter.Marshal(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields := map[string]any{}
+		if err := jsoniter.Unmarshal(data, &fields); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		byKey[key] = fields
+	}
+	return keys, byKey, nil
+}
+
+// recordChanges returns the fields that are different in the two records sorted by field
+func recordChanges(from, to map[string]any) []SnapshotFieldChange {
+	fromFields, toFields := flattenRecord(from), flattenRecord(to)
+
+	changes := []SnapshotFieldChange{}
+	for field, fromValue := range fromFields {
+		toValue, ok := toFields[field]
+		if !ok {
+			changes = append(changes, SnapshotFieldChange{Field: field, From: fromValue})
+		} else if !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, SnapshotFieldChange{Field: field, From: fromValue, To: toValue})
+		}
+	}
+	for field, toValue := range toFields {
+		if _, ok := fromFields[field]; !ok {
+			changes = append(changes, SnapshotFieldChange{Field: field, To: toValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// flattenRecord returns the fields of the record with each attribute and resource attribute as a separate field
+func flattenRecord(r map[string]any) map[string]any {
+	fields := map[string]any{}
+	for name, value := range r {
+		if nested, ok := value.(map[string]any); ok && (name == "attributes" || name == "resource") {
+			for k, v := range nested {
+				fields[name+"."+k] = v
+			}
+			continue
+		}
+		if value != nil {
+			fields[name] = value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,123 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// testSnapshotLogs returns OTLP JSON logs with a record for each body at consecutive seconds and the attributes
+func testSnapshotLogs(t *testing.T, start time.Time, bodies []string, attributes map[string]string) []byte {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "agent-1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	for i, body := range bodies {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i) * time.Second)))
+		lr.Body().SetStr(body)
+		for k, v := range attributes {
+			lr.Attributes().PutStr(k, v)
+		}
+	}
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	return data
+}
+
+func TestNewSnapshot(t *testing.T) {
+	agent := &Agent{ID: "1", Name: "agent-1"}
+	agent.ConfigurationStatus.Current = "linux:3"
+
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := NewSnapshot(agent, otel.Logs, MeasurementPositionSourceBeforeProcessors, "host", 2, testSnapshotLogs(t, start, []string{"a", "b"}, nil))
+	require.NotEmpty(t, snapshot.ID)
+	require.Equal(t, "1", snapshot.AgentID)
+	require.Equal(t, "linux:3", snapshot.Configuration)
+	require.Equal(t, SnapshotProcessor(MeasurementPositionSourceBeforeProcessors, "host"), snapshot.Processor())
+
+	logs, err := snapshot.Logs()
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, "a", logs[0].Body)
+
+	summary := snapshot.Summary()
+	require.Nil(t, summary.Data)
+	require.NotNil(t, snapshot.Data, "summary does not modify the snapshot")
+	require.Equal(t, "2", summary.PrintableFieldValue("Count"))
+	require.Equal(t, "s0", summary.PrintableFieldValue("Position"))
+}
+
+func TestNewSnapshotDiff(t *testing.T) {
+	agent := &Agent{ID: "1", Name: "agent-1"}
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	before := NewSnapshot(agent, otel.Logs, MeasurementPositionSourceBeforeProcessors, "host", 3,
+		testSnapshotLogs(t, start, []string{"a", "b", "c"}, map[string]string{"env": "dev", "drop": "true"}))
+
+	// the first record is dropped and an attribute is added and removed from the others
+	after := NewSnapshot(agent, otel.Logs, "", "", 2,
+		testSnapshotLogs(t, start.Add(time.Second), []string{"b", "changed"}, map[string]string{"env": "dev", "team": "a"}))
+
+	diff, err := NewSnapshotDiff(context.Background(), before, after)
+	require.NoError(t, err)
+	require.Nil(t, diff.From.Data)
+	require.Nil(t, diff.To.Data)
+	require.Equal(t, 0, diff.Unchanged)
+	require.Empty(t, diff.Added)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "2023-06-01T00:00:00Z", diff.Removed[0].Key)
+	require.Equal(t, "a", diff.Removed[0].Record["body"])
+
+	require.Equal(t, []SnapshotRecordChange{
+		{
+			Key: "2023-06-01T00:00:01Z",
+			Changes: []SnapshotFieldChange{
+				{Field: "attributes.drop", From: "true"},
+				{Field: "attributes.team", To: "a"},
+			},
+		},
+		{
+			Key: "2023-06-01T00:00:02Z",
+			Changes: []SnapshotFieldChange{
+				{Field: "attributes.drop", From: "true"},
+				{Field: "attributes.team", To: "a"},
+				{Field: "body", From: "c", To: "changed"},
+			},
+		},
+	}, diff.Changed)
+
+	t.Run("same snapshot", func(t *testing.T) {
+		diff, err := NewSnapshotDiff(context.Background(), before, before)
+		require.NoError(t, err)
+		require.Equal(t, 3, diff.Unchanged)
+		require.Empty(t, diff.Removed)
+		require.Empty(t, diff.Added)
+		require.Empty(t, diff.Changed)
+	})
+
+	t.Run("different pipeline types", func(t *testing.T) {
+		metrics := NewSnapshot(agent, otel.Metrics, "", "", 0, []byte(`{}`))
+		_, err := NewSnapshotDiff(context.Background(), before, metrics)
+		require.ErrorContains(t, err, "unable to compare a logs snapshot with a metrics snapshot")
+	})
+}
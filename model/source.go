@@ -151,3 +151,8 @@ func (s *Source) MaskSensitiveParameters(ctx context.Context) {
 func (s *Source) PreserveSensitiveParameters(ctx context.Context, existing *AnyResource) error {
 	return PreserveSensitiveParameters(ctx, s, existing)
 }
+
+// TransformSensitiveParameters replaces the value of each sensitive parameter with the value returned by the transform
+func (s *Source) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	return s.Spec.transformSensitiveParameters(transform)
+}
@@ -0,0 +1,196 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role determines the operations a user is allowed to perform. Each role includes the permissions of the roles below
+// it, i.e. an admin can do everything a user can do and a user can do everything a viewer can do.
+type Role string
+
+const (
+	// RoleAdmin can perform any operation, including managing users
+	RoleAdmin Role = "admin"
+
+	// RoleUser can view and modify resources, agents, and rollouts
+	RoleUser Role = "user"
+
+	// RoleViewer can only view resources, agents, and rollouts
+	RoleViewer Role = "viewer"
+)
+
+var roleLevels = map[Role]int{
+	RoleViewer: 1,
+	RoleUser:   2,
+	RoleAdmin:  3,
+}
+
+// ParseRole returns the Role with the specified name or an error if it is not a valid role
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(name))
+	if !role.Valid() {
+		return "", fmt.Errorf("invalid role %q, must be one of: admin, user, viewer", name)
+	}
+	return role, nil
+}
+
+// Valid returns true if the role is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// Allows returns true if a user with this role is allowed to perform operations that require the specified role
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && roleLevels[r] >= roleLevels[required]
+}
+
+// String returns the name of the role
+func (r Role) String() string {
+	return string(r)
+}
+
+// ----------------------------------------------------------------------
+
+// User is a user that can login to BindPlane. Users are stored separately from resources because they contain
+// credentials and are not applied or retrieved with other resources.
+type User struct {
+	Name string `json:"name" yaml:"name"`
+	Role Role   `json:"role" yaml:"role"`
+
+	// PasswordHash is the bcrypt hash of the user's password. It should be removed with Redacted before a user is
+	// returned to a client.
+	PasswordHash string `json:"passwordHash,omitempty" yaml:"passwordHash,omitempty"`
+
+	// Issuer and Subject identify the single sign-on identity of a user created by an OIDC login. They are empty for
+	// users with a password.
+	Issuer  string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+}
+
+// NewUser returns a new user with the specified name and role and the hash of the specified password
+func NewUser(name string, role Role, password string) (*User, error) {
+	user := &User{
+		Name: name,
+		Role: role,
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Validate returns an error if the user does not have a name, has a name reserved for API keys, or has an invalid role.
+// The username of the server profile is also reserved and must be checked with ValidateUserName.
+func (u *User) Validate() error {
+	if u.Name == "" {
+		return errors.New("user name must be specified")
+	}
+	if err := ValidateUserName(u.Name, ""); err != nil {
+		return err
+	}
+	if !u.Role.Valid() {
+		return fmt.Errorf("invalid role %q, must be one of: admin, user, viewer", u.Role)
+	}
+	return nil
+}
+
+// ValidateUserName returns an error if the name is reserved because the user would be authenticated with a different
+// role than its own: the username of the server profile is always an admin and names beginning with APIKeyLoginPrefix
+// have the role of the API key.
+func ValidateUserName(name, serverUsername string) error {
+	if serverUsername != "" && name == serverUsername {
+		return fmt.Errorf("user name %s is reserved for the server profile user", name)
+	}
+	if strings.HasPrefix(name, APIKeyLoginPrefix) {
+		return fmt.Errorf("user name %s is reserved, names beginning with %s are API keys", name, APIKeyLoginPrefix)
+	}
+	return nil
+}
+
+// SetPassword replaces the PasswordHash with the hash of the specified password
+func (u *User) SetPassword(password string) error {
+	if password == "" {
+		return errors.New("password must be specified")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// CheckPassword returns true if the password matches the PasswordHash
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// Redacted returns a copy of the user without the PasswordHash
+func (u *User) Redacted() *User {
+	return &User{
+		Name:    u.Name,
+		Role:    u.Role,
+		Issuer:  u.Issuer,
+		Subject: u.Subject,
+	}
+}
+
+// UniqueKey returns the name of the user
+func (u *User) UniqueKey() string {
+	return u.Name
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Configuration"
+func (u *User) PrintableKindSingular() string {
+	return "User"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Configurations"
+func (u *User) PrintableKindPlural() string {
+	return "Users"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (u *User) PrintableFieldTitles() []string {
+	return []string{"Name", "Role"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (u *User) PrintableFieldValue(title string) string {
+	switch title {
+	case "Name":
+		return u.Name
+	case "Role":
+		return u.Role.String()
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		expect   bool
+	}{
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleUser, true},
+		{RoleAdmin, RoleViewer, true},
+		{RoleUser, RoleAdmin, false},
+		{RoleUser, RoleUser, true},
+		{RoleUser, RoleViewer, true},
+		{RoleViewer, RoleAdmin, false},
+		{RoleViewer, RoleUser, false},
+		{RoleViewer, RoleViewer, true},
+		{Role(""), RoleViewer, false},
+		{Role("owner"), RoleViewer, false},
+	}
+	for _, test := range tests {
+		t.Run(string(test.role)+"/"+string(test.required), func(t *testing.T) {
+			require.Equal(t, test.expect, test.role.Allows(test.required))
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("Admin")
+	require.NoError(t, err)
+	require.Equal(t, RoleAdmin, role)
+
+	_, err = ParseRole("owner")
+	require.Error(t, err)
+}
+
+func TestNewUser(t *testing.T) {
+	user, err := NewUser("alice", RoleUser, "secret")
+	require.NoError(t, err)
+	require.Equal(t, "alice", user.Name)
+	require.Equal(t, RoleUser, user.Role)
+	require.NotEqual(t, "secret", user.PasswordHash)
+	require.True(t, user.CheckPassword("secret"))
+	require.False(t, user.CheckPassword("wrong"))
+
+	redacted := user.Redacted()
+	require.Empty(t, redacted.PasswordHash)
+	require.False(t, redacted.CheckPassword("secret"))
+
+	_, err = NewUser("", RoleUser, "secret")
+	require.Error(t, err)
+	_, err = NewUser("alice", Role("owner"), "secret")
+	require.Error(t, err)
+	_, err = NewUser("alice", RoleViewer, "")
+	require.Error(t, err)
+	_, err = NewUser(APIKeyLoginPrefix+"1", RoleViewer, "secret")
+	require.Error(t, err)
+}
+
+func TestValidateUserName(t *testing.T) {
+	tests := []struct {
+		name        string
+		expectError bool
+	}{
+		{name: "alice"},
+		{name: "admin", expectError: true},
+		{name: "admin2"},
+		{name: APIKeyLoginPrefix + "1", expectError: true},
+		{name: "my" + APIKeyLoginPrefix},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateUserName(test.name, "admin")
+			if test.expectError {
+				require.ErrorContains(t, err, "is reserved")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// no server profile user
+	require.NoError(t, ValidateUserName("admin", ""))
+}
@@ -82,6 +82,14 @@ func TestConfigurationValidate(t *testing.T) {
 			testfile:   "configuration-ok-versioned-resources.yaml",
 			expectYAML: "apiVersion: bindplane.observiq.com/v1\nkind: Configuration\nmetadata:\n    name: macos\n    labels:\n        app: cabin\n        platform: macos\n    version: 1\nspec:\n    contentType: text/yaml\n    measurementInterval: \"\"\n    sources:\n        - id: MacOS\n          type: MacOS:3\n        - id: MacOS:1\n          type: MacOS:3\n        - id: MacOS:2\n          type: MacOS:3\n        - id: MacOS:3\n          type: MacOS:3\n        - id: MacOS:latest\n          type: MacOS:3\n    destinations:\n        - id: cabin-production-logs\n          name: cabin-production-logs:1\n        - id: cabin-production-logs:1\n          name: cabin-production-logs:1\n        - id: cabin-production-logs:latest\n          name: cabin-production-logs:1\n    selector:\n        matchLabels:\n            configuration: macos\n",
 		},
+		{
+			testfile:   "configuration-ok-pinned-resources.yaml",
+			expectYAML: "apiVersion: bindplane.observiq.com/v1\nkind: Configuration\nmetadata:\n    name: macos\n    labels:\n        app: cabin\n        platform: macos\n    version: 1\nspec:\n    contentType: text/yaml\n    measurementInterval: \"\"\n    sources:\n        - id: MacOS\n          type: MacOS:3\n    destinations:\n        - id: cabin-production-logs\n          name: cabin-production-logs:1\n          version: 1\n    selector:\n        matchLabels:\n            configuration: macos\n",
+		},
+		{
+			testfile:                     "configuration-bad-pinned-resources.yaml",
+			expectValidateWithStoreError: "3 errors occurred:\n\t* Source version 2 can only be specified with the name of a Source\n\t* unknown Destination: cabin-production-logs:2\n\t* Destination cabin-production-logs has an invalid version -1\n\n",
+		},
 	}
 
 	store := newTestResourceStore()
@@ -0,0 +1,332 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/model/validation"
+	"github.com/observiq/bindplane-op/model/version"
+	"golang.org/x/exp/slices"
+)
+
+// MaxWebhookDeliveries is the number of deliveries kept in the delivery log of a Webhook
+const MaxWebhookDeliveries = 20
+
+// DefaultWebhookMaxRetries is the number of times a failed delivery is retried if the Webhook doesn't specify
+// MaxRetries
+const DefaultWebhookMaxRetries = 3
+
+type webhookKind struct{}
+
+func (k *webhookKind) NewEmptyResource() *Webhook { return &Webhook{} }
+
+var _ HasSensitiveParameters = (*Webhook)(nil)
+
+// Webhook is a resource that subscribes to changes in the store and delivers them as signed JSON payloads to a URL
+type Webhook struct {
+	ResourceMeta              `yaml:",inline" mapstructure:",squash"`
+	Spec                      WebhookSpec `json:"spec" yaml:"spec" mapstructure:"spec"`
+	StatusType[WebhookStatus] `yaml:",inline" mapstructure:",squash"`
+}
+
+// WebhookEventType is the type of change to a resource delivered by a Webhook
+type WebhookEventType string
+
+// Insert, Update, Remove, Label, and Rollout are the types of changes delivered by a Webhook. They correspond to the
+// event types of store updates.
+const (
+	WebhookEventInsert  WebhookEventType = "insert"
+	WebhookEventUpdate  WebhookEventType = "update"
+	WebhookEventRemove  WebhookEventType = "remove"
+	WebhookEventLabel   WebhookEventType = "label"
+	WebhookEventRollout WebhookEventType = "rollout"
+)
+
+var webhookEventTypes = []WebhookEventType{
+	WebhookEventInsert,
+	WebhookEventUpdate,
+	WebhookEventRemove,
+	WebhookEventLabel,
+	WebhookEventRollout,
+}
+
+// webhookKinds are the kinds of resources included in store updates
+var webhookKinds = []Kind{
+	KindAgent,
+	KindAgentVersion,
+	KindConfiguration,
+	KindSource,
+	KindSourceType,
+	KindProcessor,
+	KindProcessorType,
+	KindDestination,
+	KindDestinationType,
+}
+
+// WebhookSpec is the spec for a Webhook
+type WebhookSpec struct {
+	// URL receives a POST with a WebhookPayload for each batch of matching events
+	URL string `json:"url" yaml:"url" mapstructure:"url"`
+
+	// Secret is used to sign payloads with HMAC-SHA256. The signature is sent in the X-BindPlane-Signature header. Payloads
+	// are not signed if it is empty. It is sensitive: it is encrypted by stores with an encryption key and it is masked
+	// when the webhook is read.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty" mapstructure:"secret"`
+
+	// Kinds limits the events to resources of these kinds, e.g. Agent or Configuration. Events for all kinds are
+	// delivered if it is empty.
+	Kinds []Kind `json:"kinds,omitempty" yaml:"kinds,omitempty" mapstructure:"kinds"`
+
+	// EventTypes limits the events to these types of changes: insert, update, remove, label, or rollout. Events of all
+	// types are delivered if it is empty.
+	EventTypes []WebhookEventType `json:"eventTypes,omitempty" yaml:"eventTypes,omitempty" mapstructure:"eventTypes"`
+
+	// Selector limits the events to resources with labels matching the selector. Events for all resources are delivered
+	// if it is not specified.
+	Selector *AgentSelector `json:"selector,omitempty" yaml:"selector,omitempty" mapstructure:"selector"`
+
+	// MaxRetries is the number of times a failed delivery is retried with exponential backoff. The default is 3.
+	MaxRetries *int `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty" mapstructure:"maxRetries"`
+}
+
+// WebhookStatus is the status of a Webhook. It is managed by the server.
+type WebhookStatus struct {
+	// Deliveries is the log of the most recent deliveries, newest first
+	Deliveries []WebhookDelivery `json:"deliveries,omitempty" yaml:"deliveries,omitempty" mapstructure:"deliveries"`
+}
+
+// WebhookDelivery is an entry in the delivery log of a Webhook
+type WebhookDelivery struct {
+	// ID is the ID of the payload, also sent in the X-BindPlane-Delivery header
+	ID string `json:"id" yaml:"id" mapstructure:"id"`
+
+	// Time is the time of the last attempt
+	Time time.Time `json:"time" yaml:"time" mapstructure:"time"`
+
+	// Events is the number of events in the payload
+	Events int `json:"events" yaml:"events" mapstructure:"events"`
+
+	// Attempts is the number of attempts made to deliver the payload
+	Attempts int `json:"attempts" yaml:"attempts" mapstructure:"attempts"`
+
+	// StatusCode is the HTTP status code of the response to the last attempt or 0 if there was no response
+	StatusCode int `json:"statusCode,omitempty" yaml:"statusCode,omitempty" mapstructure:"statusCode"`
+
+	// Error is the error of the last attempt if the delivery failed
+	Error string `json:"error,omitempty" yaml:"error,omitempty" mapstructure:"error"`
+}
+
+// Succeeded returns true if the payload was delivered
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Error == ""
+}
+
+// WebhookPayload is the body of a request sent by a Webhook
+type WebhookPayload struct {
+	ID      string         `json:"id"`
+	Webhook string         `json:"webhook"`
+	Time    time.Time      `json:"time"`
+	Events  []WebhookEvent `json:"events"`
+}
+
+// WebhookEvent is a change to a resource delivered by a Webhook
+type WebhookEvent struct {
+	Kind Kind             `json:"kind"`
+	Type WebhookEventType `json:"type"`
+	Name string           `json:"name"`
+	Item any              `json:"item"`
+}
+
+// NewWebhook creates a new Webhook with the specified name and spec
+func NewWebhook(name string, spec WebhookSpec) *Webhook {
+	return &Webhook{
+		ResourceMeta: ResourceMeta{
+			APIVersion: version.V1,
+			Kind:       KindWebhook,
+			Metadata: Metadata{
+				Name: name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// GetKind returns "Webhook"
+func (w *Webhook) GetKind() Kind {
+	return KindWebhook
+}
+
+// GetSpec returns the spec for this resource.
+func (w *Webhook) GetSpec() any {
+	return w.Spec
+}
+
+// MaxRetriesOrDefault returns the number of times a failed delivery is retried
+func (s *WebhookSpec) MaxRetriesOrDefault() int {
+	if s.MaxRetries == nil {
+		return DefaultWebhookMaxRetries
+	}
+	return *s.MaxRetries
+}
+
+// Matches returns true if the webhook subscribes to events of the specified kind and type for a resource with the
+// specified labels
+func (s *WebhookSpec) Matches(kind Kind, eventType WebhookEventType, labels Labels) bool {
+	if len(s.Kinds) > 0 && !slices.Contains(s.Kinds, kind) {
+		return false
+	}
+	if len(s.EventTypes) > 0 && !slices.Contains(s.EventTypes, eventType) {
+		return false
+	}
+	if s.Selector != nil && !s.Selector.Selector().Matches(labels) {
+		return false
+	}
+	return true
+}
+
+// AddDelivery adds the delivery to the front of the delivery log and removes the oldest deliveries beyond
+// MaxWebhookDeliveries
+func (s *WebhookStatus) AddDelivery(delivery WebhookDelivery) {
+	deliveries := append([]WebhookDelivery{delivery}, s.Deliveries...)
+	if len(deliveries) > MaxWebhookDeliveries {
+		deliveries = deliveries[:MaxWebhookDeliveries]
+	}
+	s.Deliveries = deliveries
+}
+
+// ----------------------------------------------------------------------
+// validation
+
+// ValidateWithStore validates the webhook. Webhooks don't refer to other resources so the store is not used.
+func (w *Webhook) ValidateWithStore(_ context.Context, _ ResourceStore) (warnings string, errors error) {
+	return w.Validate()
+}
+
+// Validate returns an error if the url, kinds, event types, or selector are invalid
+func (w *Webhook) Validate() (warnings string, errors error) {
+	errs := validation.NewErrors()
+	w.ResourceMeta.validate(errs)
+	w.Spec.validate(errs)
+	return errs.Warnings(), errs.Result()
+}
+
+func (s *WebhookSpec) validate(errs validation.Errors) {
+	if s.URL == "" {
+		errs.Add(errors.New("webhook must specify a url"))
+	} else if err := common.ValidateURL(s.URL, []string{"http", "https"}); err != nil {
+		errs.Add(err)
+	}
+	for _, kind := range s.Kinds {
+		if !slices.Contains(webhookKinds, kind) {
+			errs.Add(fmt.Errorf("webhook has an invalid kind %q: must be one of %s", kind, joinStrings(webhookKinds)))
+		}
+	}
+	for _, eventType := range s.EventTypes {
+		if !slices.Contains(webhookEventTypes, eventType) {
+			errs.Add(fmt.Errorf("webhook has an invalid event type %q: must be one of %s", eventType, joinStrings(webhookEventTypes)))
+		}
+	}
+	if s.Selector != nil {
+		s.Selector.validate(errs)
+	}
+	if s.MaxRetries != nil && *s.MaxRetries < 0 {
+		errs.Add(fmt.Errorf("webhook maxRetries must not be negative: %d", *s.MaxRetries))
+	}
+}
+
+func joinStrings[T ~string](values []T) string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, string(value))
+	}
+	return strings.Join(strs, ", ")
+}
+
+// ----------------------------------------------------------------------
+// sensitive values
+
+// MaskSensitiveParameters replaces the secret with SensitiveParameterPlaceholder
+func (w *Webhook) MaskSensitiveParameters(ctx context.Context) {
+	if IsWithoutSensitiveParameterMasking(ctx) || w.Spec.Secret == "" {
+		return
+	}
+	w.Spec.Secret = SensitiveParameterPlaceholder
+}
+
+// PreserveSensitiveParameters replaces a secret with the SensitiveParameterPlaceholder value with the secret of the
+// existing webhook. This does nothing if existing is nil because there is no existing webhook.
+func (w *Webhook) PreserveSensitiveParameters(_ context.Context, existing *AnyResource) error {
+	if existing == nil || w.Spec.Secret != SensitiveParameterPlaceholder {
+		return nil
+	}
+	parsed, err := ParseResource(existing)
+	if err != nil {
+		return fmt.Errorf("unable to parse existing resource: %v %w", existing, err)
+	}
+	if existingWebhook, ok := parsed.(*Webhook); ok {
+		w.Spec.Secret = existingWebhook.Spec.Secret
+	}
+	return nil
+}
+
+// TransformSensitiveParameters replaces the secret with the value returned by the transform
+func (w *Webhook) TransformSensitiveParameters(transform SensitiveParameterTransform) error {
+	if w.Spec.Secret == "" {
+		return nil
+	}
+	secret, err := transformSensitiveString(w.Spec.Secret, transform)
+	if err != nil {
+		return fmt.Errorf("secret: %w", err)
+	}
+	w.Spec.Secret = secret
+	return nil
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (w *Webhook) PrintableFieldTitles() []string {
+	return []string{"Name", "URL", "Last Delivery", "Result"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (w *Webhook) PrintableFieldValue(title string) string {
+	switch title {
+	case "URL":
+		return w.Spec.URL
+	case "Last Delivery":
+		if len(w.Status.Deliveries) == 0 {
+			return "-"
+		}
+		return w.Status.Deliveries[0].Time.Format(time.RFC3339)
+	case "Result":
+		if len(w.Status.Deliveries) == 0 {
+			return "-"
+		}
+		if last := w.Status.Deliveries[0]; !last.Succeeded() {
+			return last.Error
+		}
+		return "ok"
+	default:
+		return w.ResourceMeta.PrintableFieldValue(title)
+	}
+}
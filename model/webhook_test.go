@@ -0,0 +1,185 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name      string
+		spec      WebhookSpec
+		expectErr string
+	}{
+		{
+			name: "minimal",
+			spec: WebhookSpec{URL: "https://example.com/hook"},
+		},
+		{
+			name: "all fields",
+			spec: WebhookSpec{
+				URL:        "http://example.com/hook",
+				Secret:     "secret",
+				Kinds:      []Kind{KindAgent, KindConfiguration},
+				EventTypes: []WebhookEventType{WebhookEventInsert, WebhookEventRollout},
+				Selector:   &AgentSelector{MatchLabels: MatchLabels{"env": "prod"}},
+			},
+		},
+		{
+			name:      "missing url",
+			spec:      WebhookSpec{},
+			expectErr: "webhook must specify a url",
+		},
+		{
+			name:      "invalid kind",
+			spec:      WebhookSpec{URL: "https://example.com/hook", Kinds: []Kind{KindWebhook}},
+			expectErr: `webhook has an invalid kind "Webhook"`,
+		},
+		{
+			name:      "invalid event type",
+			spec:      WebhookSpec{URL: "https://example.com/hook", EventTypes: []WebhookEventType{"delete"}},
+			expectErr: `webhook has an invalid event type "delete"`,
+		},
+		{
+			name:      "negative max retries",
+			spec:      WebhookSpec{URL: "https://example.com/hook", MaxRetries: &negative},
+			expectErr: "webhook maxRetries must not be negative: -1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhook := NewWebhook("webhook", test.spec)
+			_, err := webhook.Validate()
+			_, storeErr := webhook.ValidateWithStore(context.Background(), nil)
+			require.Equal(t, err, storeErr)
+			if test.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.expectErr)
+		})
+	}
+}
+
+func TestWebhookMatches(t *testing.T) {
+	labels := Labels{Set: map[string]string{"env": "prod"}}
+	tests := []struct {
+		name   string
+		spec   WebhookSpec
+		kind   Kind
+		expect bool
+	}{
+		{
+			name:   "no filters",
+			spec:   WebhookSpec{},
+			kind:   KindAgent,
+			expect: true,
+		},
+		{
+			name:   "matching kind",
+			spec:   WebhookSpec{Kinds: []Kind{KindAgent}},
+			kind:   KindAgent,
+			expect: true,
+		},
+		{
+			name:   "other kind",
+			spec:   WebhookSpec{Kinds: []Kind{KindConfiguration}},
+			kind:   KindAgent,
+			expect: false,
+		},
+		{
+			name:   "other event type",
+			spec:   WebhookSpec{EventTypes: []WebhookEventType{WebhookEventRemove}},
+			kind:   KindAgent,
+			expect: false,
+		},
+		{
+			name:   "matching selector",
+			spec:   WebhookSpec{Selector: &AgentSelector{MatchLabels: MatchLabels{"env": "prod"}}},
+			kind:   KindAgent,
+			expect: true,
+		},
+		{
+			name:   "other selector",
+			spec:   WebhookSpec{Selector: &AgentSelector{MatchLabels: MatchLabels{"env": "dev"}}},
+			kind:   KindAgent,
+			expect: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, test.spec.Matches(test.kind, WebhookEventUpdate, labels))
+		})
+	}
+}
+
+func TestWebhookStatusAddDelivery(t *testing.T) {
+	status := WebhookStatus{}
+	for i := 0; i < MaxWebhookDeliveries+5; i++ {
+		status.AddDelivery(WebhookDelivery{ID: fmt.Sprintf("%d", i)})
+	}
+	require.Len(t, status.Deliveries, MaxWebhookDeliveries)
+	require.Equal(t, fmt.Sprintf("%d", MaxWebhookDeliveries+4), status.Deliveries[0].ID)
+	require.Equal(t, "5", status.Deliveries[MaxWebhookDeliveries-1].ID)
+}
+
+func TestWebhookSensitiveSecret(t *testing.T) {
+	ctx := context.Background()
+	existing := NewWebhook("hook", WebhookSpec{URL: "https://example.com", Secret: "secret"})
+	existingAny, err := AsAny(existing)
+	require.NoError(t, err)
+
+	t.Run("masked", func(t *testing.T) {
+		webhook := NewWebhook("hook", existing.Spec)
+		webhook.MaskSensitiveParameters(ContextWithoutSensitiveParameterMasking(ctx))
+		require.Equal(t, "secret", webhook.Spec.Secret)
+		webhook.MaskSensitiveParameters(ctx)
+		require.Equal(t, SensitiveParameterPlaceholder, webhook.Spec.Secret)
+
+		unsigned := NewWebhook("hook", WebhookSpec{URL: "https://example.com"})
+		unsigned.MaskSensitiveParameters(ctx)
+		require.Empty(t, unsigned.Spec.Secret)
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		webhook := NewWebhook("hook", WebhookSpec{URL: "https://example.com", Secret: SensitiveParameterPlaceholder})
+		require.NoError(t, webhook.PreserveSensitiveParameters(ctx, existingAny))
+		require.Equal(t, "secret", webhook.Spec.Secret)
+
+		changed := NewWebhook("hook", WebhookSpec{URL: "https://example.com", Secret: "new"})
+		require.NoError(t, changed.PreserveSensitiveParameters(ctx, existingAny))
+		require.Equal(t, "new", changed.Spec.Secret)
+	})
+
+	t.Run("transformed", func(t *testing.T) {
+		webhook := NewWebhook("hook", existing.Spec)
+		require.NoError(t, webhook.TransformSensitiveParameters(func(value any) (any, error) {
+			return "encrypted:" + value.(string), nil
+		}))
+		require.Equal(t, "encrypted:secret", webhook.Spec.Secret)
+
+		err := webhook.TransformSensitiveParameters(func(value any) (any, error) { return 42, nil })
+		require.ErrorContains(t, err, "secret: expected a string")
+	})
+}
@@ -16,10 +16,15 @@
 package rest
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel"
@@ -28,78 +33,128 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/observiq/bindplane-op/audit"
+	"github.com/observiq/bindplane-op/authenticator"
+	"github.com/observiq/bindplane-op/gitsync"
+	"github.com/observiq/bindplane-op/internal/server"
+	"github.com/observiq/bindplane-op/middleware"
 	"github.com/observiq/bindplane-op/model"
+	bpotel "github.com/observiq/bindplane-op/model/otel"
 	exposedserver "github.com/observiq/bindplane-op/server"
 	"github.com/observiq/bindplane-op/store"
 	"github.com/observiq/bindplane-op/store/search"
+	"github.com/observiq/bindplane-op/store/stats"
 	"github.com/observiq/bindplane-op/version"
 )
 
 var tracer = otel.Tracer("rest")
 
-// AddRestRoutes adds all API routes to the gin HTTP router
+// AddRestRoutes adds all API routes to the gin HTTP router. Each route requires a role which is checked against the
+// role set on the request by middleware.ResolveRole. Reading requires the viewer role, modifying requires the user role,
+// and managing users requires the admin role.
 func AddRestRoutes(router gin.IRouter, bindplane exposedserver.BindPlane) {
-	router.GET("/agents", func(c *gin.Context) { Agents(c, bindplane) })
-	router.GET("/agents/:id", func(c *gin.Context) { GetAgent(c, bindplane) })
-	router.DELETE("/agents", func(c *gin.Context) { DeleteAgents(c, bindplane) })
-	router.PATCH("/agents/labels", func(c *gin.Context) { LabelAgents(c, bindplane) })
-	router.GET("/agents/:id/labels", func(c *gin.Context) { GetAgentLabels(c, bindplane) })
-	router.PATCH("/agents/:id/labels", func(c *gin.Context) { PatchAgentLabels(c, bindplane) })
-	router.PUT("/agents/:id/restart", func(c *gin.Context) { RestartAgent(c, bindplane) })
-	router.POST("/agents/:id/version", func(c *gin.Context) { UpgradeAgent(c, bindplane) })
-	router.PATCH("/agents/version", func(c *gin.Context) { UpgradeAgents(c, bindplane) })
-	router.GET("/agents/:id/configuration", func(c *gin.Context) { GetAgentConfiguration(c, bindplane) })
-
-	router.GET("/agent-versions", func(c *gin.Context) { AgentVersions(c, bindplane) })
-	router.GET("/agent-versions/:name", func(c *gin.Context) { AgentVersion(c, bindplane) })
-	router.DELETE("/agent-versions/:name", func(c *gin.Context) { DeleteAgentVersion(c, bindplane) })
-	router.GET("/agent-versions/:name/install-command", func(c *gin.Context) { getInstallCommand(c, bindplane) })
-	router.POST("/agent-versions/:name/sync", func(c *gin.Context) { SyncAgentVersion(c, bindplane) })
-
-	router.GET("/configurations", func(c *gin.Context) { Configurations(c, bindplane) })
-	router.GET("/configurations/:name", func(c *gin.Context) { Configuration(c, bindplane) })
-	router.DELETE("/configurations/:name", func(c *gin.Context) { DeleteConfiguration(c, bindplane) })
-	router.POST("/configurations/:name/copy", func(c *gin.Context) { CopyConfig(c, bindplane) })
-
-	router.GET("/sources", func(c *gin.Context) { Sources(c, bindplane) })
-	router.GET("/sources/:name", func(c *gin.Context) { Source(c, bindplane) })
-	router.DELETE("/sources/:name", func(c *gin.Context) { DeleteSource(c, bindplane) })
-
-	router.GET("/source-types", func(c *gin.Context) { SourceTypes(c, bindplane) })
-	router.GET("/source-types/:name", func(c *gin.Context) { SourceType(c, bindplane) })
-	router.DELETE("/source-types/:name", func(c *gin.Context) { DeleteSourceType(c, bindplane) })
-
-	router.GET("/processors", func(c *gin.Context) { Processors(c, bindplane) })
-	router.GET("/processors/:name", func(c *gin.Context) { Processor(c, bindplane) })
-	router.DELETE("/processors/:name", func(c *gin.Context) { DeleteProcessor(c, bindplane) })
-
-	router.GET("/processor-types", func(c *gin.Context) { ProcessorTypes(c, bindplane) })
-	router.GET("/processor-types/:name", func(c *gin.Context) { ProcessorType(c, bindplane) })
-	router.DELETE("/processor-types/:name", func(c *gin.Context) { DeleteProcessorType(c, bindplane) })
-
-	router.GET("/destinations", func(c *gin.Context) { Destinations(c, bindplane) })
-	router.GET("/destinations/:name", func(c *gin.Context) { Destination(c, bindplane) })
-	router.DELETE("/destinations/:name", func(c *gin.Context) { DeleteDestination(c, bindplane) })
-
-	router.GET("/destination-types", func(c *gin.Context) { DestinationTypes(c, bindplane) })
-	router.GET("/destination-types/:name", func(c *gin.Context) { DestinationType(c, bindplane) })
-	router.DELETE("/destination-types/:name", func(c *gin.Context) { DeleteDestinationType(c, bindplane) })
-
-	router.POST("/apply", func(c *gin.Context) { ApplyResources(c, bindplane) })
-	router.POST("/delete", func(c *gin.Context) { DeleteResources(c, bindplane) })
-
-	router.GET("/version", func(c *gin.Context) { BindplaneVersion(c) })
-
-	router.GET("/rollouts", func(c *gin.Context) { Rollouts(c, bindplane) })
-	router.POST("/rollouts", func(c *gin.Context) { RolloutsUpdate(c, bindplane) })
-	router.GET("/rollouts/:name", func(c *gin.Context) { Rollout(c, bindplane) })
-	router.GET("/rollouts/:name/status", func(c *gin.Context) { RolloutStatus(c, bindplane) })
-	router.POST("/rollouts/:name/start", func(c *gin.Context) { RolloutStart(c, bindplane) })
-	router.PUT("/rollouts/:name/pause", func(c *gin.Context) { RolloutPause(c, bindplane) })
-	router.PUT("/rollouts/:name/resume", func(c *gin.Context) { RolloutResume(c, bindplane) })
-	router.POST("/rollouts/:name/update", func(c *gin.Context) { RolloutUpdate(c, bindplane) })
-
-	router.GET("/:kind/:name/history", func(c *gin.Context) { History(c, bindplane) })
+	viewer := router.Group("/", middleware.RequireRole(model.RoleViewer))
+	user := router.Group("/", middleware.RequireRole(model.RoleUser))
+	admin := router.Group("/", middleware.RequireRole(model.RoleAdmin))
+
+	viewer.GET("/agents", func(c *gin.Context) { Agents(c, bindplane) })
+	viewer.GET("/agents/:id", func(c *gin.Context) { GetAgent(c, bindplane) })
+	user.DELETE("/agents", func(c *gin.Context) { DeleteAgents(c, bindplane) })
+	user.PATCH("/agents/labels", func(c *gin.Context) { LabelAgents(c, bindplane) })
+	viewer.GET("/agents/:id/labels", func(c *gin.Context) { GetAgentLabels(c, bindplane) })
+	user.PATCH("/agents/:id/labels", func(c *gin.Context) { PatchAgentLabels(c, bindplane) })
+	user.PUT("/agents/:id/restart", func(c *gin.Context) { RestartAgent(c, bindplane) })
+	user.POST("/agents/:id/version", func(c *gin.Context) { UpgradeAgent(c, bindplane) })
+	user.PATCH("/agents/version", func(c *gin.Context) { UpgradeAgents(c, bindplane) })
+	viewer.GET("/agents/:id/configuration", func(c *gin.Context) { GetAgentConfiguration(c, bindplane) })
+
+	viewer.GET("/agent-versions", func(c *gin.Context) { AgentVersions(c, bindplane) })
+	viewer.GET("/agent-versions/:name", func(c *gin.Context) { AgentVersion(c, bindplane) })
+	user.DELETE("/agent-versions/:name", func(c *gin.Context) { DeleteAgentVersion(c, bindplane) })
+	viewer.GET("/agent-versions/:name/install-command", func(c *gin.Context) { getInstallCommand(c, bindplane) })
+	user.POST("/agent-versions/:name/sync", func(c *gin.Context) { SyncAgentVersion(c, bindplane) })
+
+	viewer.GET("/sync/git", func(c *gin.Context) { GitSyncStatus(c, bindplane) })
+	user.POST("/sync/git", func(c *gin.Context) { SyncGit(c, bindplane) })
+
+	viewer.GET("/configurations", func(c *gin.Context) { Configurations(c, bindplane) })
+	viewer.GET("/configurations/:name", func(c *gin.Context) { Configuration(c, bindplane) })
+	user.DELETE("/configurations/:name", func(c *gin.Context) { DeleteConfiguration(c, bindplane) })
+	user.POST("/configurations/:name/copy", func(c *gin.Context) { CopyConfig(c, bindplane) })
+	viewer.GET("/configurations/:name/diff", func(c *gin.Context) { ConfigurationDiff(c, bindplane) })
+	user.POST("/configurations/:name/revert", func(c *gin.Context) { RevertConfiguration(c, bindplane) })
+
+	viewer.GET("/sources", func(c *gin.Context) { Sources(c, bindplane) })
+	viewer.GET("/sources/:name", func(c *gin.Context) { Source(c, bindplane) })
+	user.DELETE("/sources/:name", func(c *gin.Context) { DeleteSource(c, bindplane) })
+
+	viewer.GET("/source-types", func(c *gin.Context) { SourceTypes(c, bindplane) })
+	viewer.GET("/source-types/:name", func(c *gin.Context) { SourceType(c, bindplane) })
+	user.DELETE("/source-types/:name", func(c *gin.Context) { DeleteSourceType(c, bindplane) })
+
+	viewer.GET("/processors", func(c *gin.Context) { Processors(c, bindplane) })
+	viewer.GET("/processors/:name", func(c *gin.Context) { Processor(c, bindplane) })
+	user.DELETE("/processors/:name", func(c *gin.Context) { DeleteProcessor(c, bindplane) })
+
+	viewer.GET("/processor-types", func(c *gin.Context) { ProcessorTypes(c, bindplane) })
+	viewer.GET("/processor-types/:name", func(c *gin.Context) { ProcessorType(c, bindplane) })
+	user.DELETE("/processor-types/:name", func(c *gin.Context) { DeleteProcessorType(c, bindplane) })
+
+	viewer.GET("/destinations", func(c *gin.Context) { Destinations(c, bindplane) })
+	viewer.GET("/destinations/:name", func(c *gin.Context) { Destination(c, bindplane) })
+	user.DELETE("/destinations/:name", func(c *gin.Context) { DeleteDestination(c, bindplane) })
+
+	viewer.GET("/destination-types", func(c *gin.Context) { DestinationTypes(c, bindplane) })
+	viewer.GET("/destination-types/:name", func(c *gin.Context) { DestinationType(c, bindplane) })
+	user.DELETE("/destination-types/:name", func(c *gin.Context) { DeleteDestinationType(c, bindplane) })
+
+	viewer.GET("/alerts", func(c *gin.Context) { Alerts(c, bindplane) })
+	viewer.GET("/alerts/:name", func(c *gin.Context) { Alert(c, bindplane) })
+	user.DELETE("/alerts/:name", func(c *gin.Context) { DeleteAlert(c, bindplane) })
+
+	viewer.GET("/webhooks", func(c *gin.Context) { Webhooks(c, bindplane) })
+	viewer.GET("/webhooks/:name", func(c *gin.Context) { Webhook(c, bindplane) })
+	user.DELETE("/webhooks/:name", func(c *gin.Context) { DeleteWebhook(c, bindplane) })
+
+	user.POST("/apply", func(c *gin.Context) { ApplyResources(c, bindplane) })
+	user.POST("/delete", func(c *gin.Context) { DeleteResources(c, bindplane) })
+
+	viewer.GET("/version", func(c *gin.Context) { BindplaneVersion(c) })
+
+	viewer.GET("/rollouts", func(c *gin.Context) { Rollouts(c, bindplane) })
+	user.POST("/rollouts", func(c *gin.Context) { RolloutsUpdate(c, bindplane) })
+	viewer.GET("/rollouts/:name", func(c *gin.Context) { Rollout(c, bindplane) })
+	viewer.GET("/rollouts/:name/status", func(c *gin.Context) { RolloutStatus(c, bindplane) })
+	user.POST("/rollouts/:name/start", func(c *gin.Context) { RolloutStart(c, bindplane) })
+	user.PUT("/rollouts/:name/pause", func(c *gin.Context) { RolloutPause(c, bindplane) })
+	user.PUT("/rollouts/:name/resume", func(c *gin.Context) { RolloutResume(c, bindplane) })
+	user.POST("/rollouts/:name/update", func(c *gin.Context) { RolloutUpdate(c, bindplane) })
+
+	viewer.GET("/:kind/:name/history", func(c *gin.Context) { History(c, bindplane) })
+
+	viewer.GET("/measurements", func(c *gin.Context) { Measurements(c, bindplane) })
+
+	viewer.GET("/snapshots", func(c *gin.Context) { Snapshots(c, bindplane) })
+	user.POST("/snapshots", func(c *gin.Context) { SaveSnapshot(c, bindplane) })
+	viewer.GET("/snapshots/:id", func(c *gin.Context) { Snapshot(c, bindplane) })
+	viewer.GET("/snapshots/:id/diff", func(c *gin.Context) { SnapshotDiff(c, bindplane) })
+	user.DELETE("/snapshots/:id", func(c *gin.Context) { DeleteSnapshot(c, bindplane) })
+
+	admin.GET("/users", func(c *gin.Context) { Users(c, bindplane) })
+	admin.GET("/users/:name", func(c *gin.Context) { User(c, bindplane) })
+	admin.POST("/users", func(c *gin.Context) { CreateUser(c, bindplane) })
+	admin.PUT("/users/:name/role", func(c *gin.Context) { SetUserRole(c, bindplane) })
+	admin.DELETE("/users/:name", func(c *gin.Context) { DeleteUser(c, bindplane) })
+
+	admin.GET("/api-keys", func(c *gin.Context) { APIKeys(c, bindplane) })
+	admin.POST("/api-keys", func(c *gin.Context) { CreateAPIKey(c, bindplane) })
+	admin.DELETE("/api-keys/:id", func(c *gin.Context) { RevokeAPIKey(c, bindplane) })
+
+	admin.GET("/audit", func(c *gin.Context) { AuditEvents(c, bindplane) })
+
+	admin.GET("/backup", func(c *gin.Context) { Backup(c, bindplane) })
+	admin.POST("/restore", func(c *gin.Context) { Restore(c, bindplane) })
+	admin.POST("/encryption/rotate", func(c *gin.Context) { RotateEncryptionKey(c, bindplane) })
 }
 
 // Agents returns a list of agents
@@ -186,6 +241,12 @@ func DeleteAgents(c *gin.Context, bindplane exposedserver.BindPlane) {
 		return
 	}
 
+	events := make([]*model.AuditEvent, 0, len(deleted))
+	for _, agent := range deleted {
+		events = append(events, audit.NewEvent(ctx, model.AuditActionDelete, model.KindAgent, agent.ID, 0))
+	}
+	bindplane.Audit().Record(ctx, events...)
+
 	c.JSON(http.StatusOK, &model.DeleteAgentsResponse{
 		Agents: deleted,
 	})
@@ -345,13 +406,21 @@ func LabelAgents(c *gin.Context, bindplane exposedserver.BindPlane) {
 
 	bindplane.Logger().Info("bulkApplyAgentLabels", zap.String("payloadLabels", newLabels.String()), zap.Any("ids", p.IDs), zap.Error(err))
 
-	_, err = bindplane.Store().UpsertAgents(ctx, p.IDs, updater)
+	labeled, err := bindplane.Store().UpsertAgents(ctx, p.IDs, updater)
 
 	if err != nil {
 		HandleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	events := make([]*model.AuditEvent, 0, len(labeled))
+	for _, agent := range labeled {
+		event := audit.NewEvent(ctx, model.AuditActionAgentLabels, model.KindAgent, agent.ID, 0)
+		event.Details = agent.Labels.String()
+		events = append(events, event)
+	}
+	bindplane.Audit().Record(ctx, events...)
+
 	c.JSON(http.StatusOK, &model.BulkAgentLabelsResponse{
 		Errors: apiErrors,
 	})
@@ -429,6 +498,11 @@ func PatchAgentLabels(c *gin.Context, bindplane exposedserver.BindPlane) {
 		zap.String("payloadLabels", newLabels.String()),
 		zap.String("newLabels", newAgent.Labels.String()),
 	)
+
+	event := audit.NewEvent(ctx, model.AuditActionAgentLabels, model.KindAgent, id, 0)
+	event.Details = newAgent.Labels.String()
+	bindplane.Audit().Record(ctx, event)
+
 	c.JSON(http.StatusOK, model.AgentLabelsResponse{
 		Labels: &newAgent.Labels,
 	})
@@ -490,6 +564,10 @@ func UpgradeAgents(c *gin.Context, bindplane exposedserver.BindPlane) {
 			HandleErrorResponse(c, http.StatusInternalServerError, err)
 			return
 		}
+
+		event := audit.NewEvent(ctx, model.AuditActionAgentUpgrade, model.KindAgent, id, 0)
+		event.Details = version
+		bindplane.Audit().Record(ctx, event)
 	}
 
 	c.Status(http.StatusNoContent)
@@ -539,6 +617,10 @@ func UpgradeAgent(c *gin.Context, bindplane exposedserver.BindPlane) {
 		return
 	}
 
+	event := audit.NewEvent(ctx, model.AuditActionAgentUpgrade, model.KindAgent, id, 0)
+	event.Details = req.Version
+	bindplane.Audit().Record(ctx, event)
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -598,6 +680,7 @@ func DeleteAgentVersion(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	agentVersion, err := bindplane.Store().DeleteAgentVersion(ctx, name)
 	if OkResource(c, agentVersion == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, agentVersion))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -669,6 +752,7 @@ func DeleteConfiguration(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	configuration, err := bindplane.Store().DeleteConfiguration(ctx, name)
 	if OkResource(c, configuration == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, configuration))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -738,6 +822,10 @@ func CopyConfig(c *gin.Context, bindplane exposedserver.BindPlane) {
 	}
 
 	if update.Status == model.StatusCreated {
+		event := audit.ResourceEvent(ctx, model.AuditActionCreate, update.Resource)
+		event.Details = fmt.Sprintf("copied from %s", name)
+		bindplane.Audit().Record(ctx, event)
+
 		c.JSON(http.StatusCreated, model.PostCopyConfigResponse{
 			Name: update.Resource.Name(),
 		})
@@ -752,6 +840,176 @@ func CopyConfig(c *gin.Context, bindplane exposedserver.BindPlane) {
 	HandleErrorResponse(c, http.StatusBadRequest, err)
 }
 
+// ConfigurationDiff renders two versions of a configuration and compares the agent configurations
+// @Summary Compare the rendered agent configuration of two versions of a configuration
+// @Produce json
+// @Router /configurations/{name}/diff [get]
+// @Param 	name	path	string	true "the name of the configuration"
+// @Param 	from	query	string	true "the version to compare from, e.g. 3 or current"
+// @Param 	to	query	string	false "the version to compare to, latest if not specified"
+// @Param 	agent	query	string	false "the ID of the agent to render for, an agent using the configuration if not specified"
+// @Success 200 {object} model.ConfigurationDiffResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func ConfigurationDiff(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/ConfigurationDiff")
+	defer span.End()
+
+	name := model.TrimVersion(c.Param("name"))
+
+	fromVersion, err := model.ParseVersion(c.Query("from"))
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("from: %w", err))
+		return
+	}
+	toVersion, err := model.ParseVersion(c.DefaultQuery("to", "latest"))
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("to: %w", err))
+		return
+	}
+
+	from, err := bindplane.Store().Configuration(ctx, model.JoinVersion(name, fromVersion))
+	if !OkResource(c, from == nil, err) {
+		return
+	}
+	to, err := bindplane.Store().Configuration(ctx, model.JoinVersion(name, toVersion))
+	if !OkResource(c, to == nil, err) {
+		return
+	}
+
+	var agent *model.Agent
+	if agentID := c.Query("agent"); agentID != "" {
+		agent, err = bindplane.Store().Agent(ctx, agentID)
+		if !OkResource(c, agent == nil, err) {
+			return
+		}
+	} else {
+		agent, err = representativeAgent(ctx, bindplane.Store(), to)
+		if !OkResponse(c, err) {
+			return
+		}
+	}
+
+	diff, err := model.NewConfigurationDiff(ctx, from, to, agent, bindplane.BindPlaneURL(), bindplane.BindPlaneInsecureSkipVerify(), bindplane.Store(), model.GetOssOtelHeaders())
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ConfigurationDiffResponse{
+		Diff: diff,
+	})
+}
+
+// RevertConfiguration applies the spec of a previous version of a configuration. A new version is created unless the
+// latest version has not been rolled out yet, in which case it is modified. References to sources, processors,
+// destinations, and their types use the latest versions of those resources and sensitive parameter values are
+// preserved from the latest version.
+// @Summary Revert a configuration to a previous version
+// @Produce json
+// @Router /configurations/{name}/revert [post]
+// @Param 	name	path	string	true "the name of the configuration"
+// @Param 	payload	body	model.RevertConfigurationPayload	true "the version to revert to and whether to start a rollout"
+// @Success 200 {object} model.RevertConfigurationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func RevertConfiguration(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/RevertConfiguration")
+	defer span.End()
+
+	name := model.TrimVersion(c.Param("name"))
+
+	payload := &model.RevertConfigurationPayload{}
+	if err := c.BindJSON(payload); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if payload.Version < 1 {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("invalid version %d: must be a version number", payload.Version))
+		return
+	}
+	if payload.StartRollout && payload.Options != nil {
+		if err := payload.Options.Validate(); err != nil {
+			HandleErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	target, err := bindplane.Store().Configuration(ctx, model.JoinVersion(name, payload.Version))
+	if !OkResource(c, target == nil, err) {
+		return
+	}
+	latest, err := bindplane.Store().Configuration(ctx, name)
+	if !OkResource(c, latest == nil, err) {
+		return
+	}
+
+	reverted, err := model.Clone(latest)
+	if !OkResponse(c, err) {
+		return
+	}
+	reverted.Spec = target.Spec
+
+	updates, err := bindplane.Store().ApplyResources(ctx, []model.Resource{reverted})
+	if !OkResponse(c, err) {
+		return
+	}
+	if len(updates) != 1 {
+		HandleErrorResponse(c, http.StatusInternalServerError, fmt.Errorf("expected 1 update, got %d", len(updates)))
+		return
+	}
+	update := updates[0]
+	switch update.Status {
+	case model.StatusInvalid, model.StatusError:
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("failed to revert %s to version %d: %s", name, payload.Version, update.Reason))
+		return
+	case model.StatusConfigured:
+		event := audit.ResourceEvent(ctx, model.AuditActionUpdate, update.Resource)
+		event.Details = fmt.Sprintf("reverted to version %d", payload.Version)
+		bindplane.Audit().Record(ctx, event)
+	}
+
+	var config *model.Configuration
+	if payload.StartRollout {
+		config, err = bindplane.Store().StartRollout(ctx, name, payload.Options)
+		if !OkResource(c, config == nil, err) {
+			return
+		}
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionRolloutStart, config))
+	} else {
+		config, err = bindplane.Store().Configuration(ctx, name)
+		if !OkResource(c, config == nil, err) {
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, model.RevertConfigurationResponse{
+		Status:        update.Status,
+		Configuration: config,
+	})
+}
+
+// representativeAgent returns the first agent, sorted by ID, that uses the configuration or nil if no agents use it
+func representativeAgent(ctx context.Context, s store.Store, configuration *model.Configuration) (*model.Agent, error) {
+	ids, err := s.AgentsIDsMatchingConfiguration(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		agent, err := s.Agent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if agent != nil {
+			return agent, nil
+		}
+	}
+	return nil, nil
+}
+
 // ----------------------------------------------------------------------
 
 // Sources returns a list of sources
@@ -809,6 +1067,7 @@ func DeleteSource(c *gin.Context, bindplane exposedserver.BindPlane) {
 	source, err := bindplane.Store().DeleteSource(ctx, name)
 
 	if OkResource(c, source == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, source))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -869,6 +1128,7 @@ func DeleteSourceType(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	sourceType, err := bindplane.Store().DeleteSourceType(ctx, name)
 	if OkResource(c, sourceType == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, sourceType))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -929,6 +1189,7 @@ func DeleteProcessor(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	processor, err := bindplane.Store().DeleteProcessor(ctx, name)
 	if OkResource(c, processor == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, processor))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -989,6 +1250,7 @@ func DeleteProcessorType(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	processorType, err := bindplane.Store().DeleteProcessorType(ctx, name)
 	if OkResource(c, processorType == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, processorType))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -1049,6 +1311,7 @@ func DeleteDestination(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	destination, err := bindplane.Store().DeleteDestination(ctx, name)
 	if OkResource(c, destination == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, destination))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -1109,6 +1372,129 @@ func DeleteDestinationType(c *gin.Context, bindplane exposedserver.BindPlane) {
 	name := c.Param("name")
 	destinationType, err := bindplane.Store().DeleteDestinationType(ctx, name)
 	if OkResource(c, destinationType == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, destinationType))
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// Alerts returns a list of alerts
+// @Summary List alerts
+// @Produce json
+// @Router /alerts [get]
+// @Success 200 {object} model.AlertsResponse
+// @Failure 500 {object} ErrorResponse
+func Alerts(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Alerts")
+	defer span.End()
+
+	alerts, err := bindplane.Store().Alerts(ctx)
+	if OkResponse(c, err) {
+		c.JSON(http.StatusOK, model.AlertsResponse{
+			Alerts: alerts,
+		})
+	}
+}
+
+// Alert returns an alert by name
+// @Summary Get alert by name
+// @Produce json
+// @Router /alerts/{name} [get]
+// @Param 	name	path	string	true "the name of the alert"
+// @Success 200 {object} model.AlertResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Alert(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Alert")
+	defer span.End()
+
+	name := c.Param("name")
+	alert, err := bindplane.Store().Alert(ctx, name)
+	if OkResource(c, alert == nil, err) {
+		c.JSON(http.StatusOK, model.AlertResponse{
+			Alert: alert,
+		})
+	}
+}
+
+// DeleteAlert deletes an alert by name
+// @Summary Delete alert by name
+// @Produce json
+// @Router /alerts/{name} [delete]
+// @Param 	name	path	string	true "the name of the alert to delete"
+// @Success 204	"Successful Delete, no content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func DeleteAlert(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/DeleteAlert")
+	defer span.End()
+
+	name := c.Param("name")
+	alert, err := bindplane.Store().DeleteAlert(ctx, name)
+	if OkResource(c, alert == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, alert))
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// Webhooks returns a list of webhooks
+// @Summary List webhooks
+// @Produce json
+// @Router /webhooks [get]
+// @Success 200 {object} model.WebhooksResponse
+// @Failure 500 {object} ErrorResponse
+func Webhooks(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Webhooks")
+	defer span.End()
+
+	webhooks, err := bindplane.Store().Webhooks(ctx)
+	if OkResponse(c, err) {
+		c.JSON(http.StatusOK, model.WebhooksResponse{
+			Webhooks: webhooks,
+		})
+	}
+}
+
+// Webhook returns a webhook by name
+// @Summary Get webhook by name
+// @Produce json
+// @Router /webhooks/{name} [get]
+// @Param 	name	path	string	true "the name of the webhook"
+// @Success 200 {object} model.WebhookResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Webhook(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Webhook")
+	defer span.End()
+
+	name := c.Param("name")
+	webhook, err := bindplane.Store().Webhook(ctx, name)
+	if OkResource(c, webhook == nil, err) {
+		c.JSON(http.StatusOK, model.WebhookResponse{
+			Webhook: webhook,
+		})
+	}
+}
+
+// DeleteWebhook deletes a webhook by name
+// @Summary Delete webhook by name
+// @Produce json
+// @Router /webhooks/{name} [delete]
+// @Param 	name	path	string	true "the name of the webhook to delete"
+// @Success 204	"Successful Delete, no content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func DeleteWebhook(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/DeleteWebhook")
+	defer span.End()
+
+	name := c.Param("name")
+	webhook, err := bindplane.Store().DeleteWebhook(ctx, name)
+	if OkResource(c, webhook == nil, err) {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionDelete, webhook))
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -1120,9 +1506,12 @@ func DeleteDestinationType(c *gin.Context, bindplane exposedserver.BindPlane) {
 // @Description The /apply route will try to parse resources
 // @Description and upsert them into the store.  Additionally
 // @Description it will send reconfigure tasks to affected agents.
+// @Description If dryRun is true, the resources are validated and compared
+// @Description with the current resources without modifying the store.
 // @Produce json
 // @Router /apply [post]
 // @Param resources 	body	[]model.AnyResource	true "Resources"
+// @Param dryRun	query	bool	false	"validate and diff the resources without applying them"
 // @Success 200 {object} model.ApplyResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -1153,6 +1542,16 @@ func ApplyResources(c *gin.Context, bindplane exposedserver.BindPlane) {
 	// We do this, because the config may depend on resources that are currently being applied (e.g. destinations),
 	// which are not yet stored.
 	memoryFirstStore := NewMemoryFirstResourceStore(resources, bindplane.Store())
+
+	if c.DefaultQuery("dryRun", "false") == "true" {
+		resourceStatuses, diffs := dryRunApplyResources(ctx, bindplane, memoryFirstStore, resources)
+		c.JSON(http.StatusOK, &model.ApplyResponse{
+			Updates: resourceStatuses,
+			Diffs:   diffs,
+		})
+		return
+	}
+
 	// Extra validation for configs; We want to ensure that the configuration CAN be rendered before saving it.
 	for _, res := range resources {
 		if conf, ok := res.(*model.Configuration); ok {
@@ -1171,6 +1570,7 @@ func ApplyResources(c *gin.Context, bindplane exposedserver.BindPlane) {
 		HandleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.StatusEvents(ctx, resourceStatuses)...)
 
 	// Make sure to mask any sensitive parameters before they are returned
 	for _, status := range resourceStatuses {
@@ -1223,6 +1623,7 @@ func DeleteResources(c *gin.Context, bindplane exposedserver.BindPlane) {
 		HandleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.StatusEvents(ctx, resourceStatuses)...)
 
 	c.JSON(http.StatusAccepted, &model.DeleteResponse{
 		Updates: resourceStatuses,
@@ -1353,12 +1754,51 @@ func SyncAgentVersion(c *gin.Context, bindplane exposedserver.BindPlane) {
 		HandleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.StatusEvents(ctx, resourceStatuses)...)
 
 	c.JSON(http.StatusAccepted, &model.ApplyResponse{
 		Updates: resourceStatuses,
 	})
 }
 
+// GitSyncStatus returns the status of the last sync of the git repository
+// @Summary Get git sync status
+// @Description Returns the status of the last sync of resources from the configured git repository, including drift
+// @Description between the store and the repository.
+// @Produce json
+// @Router /sync/git [get]
+// @Success 200 {object} model.GitSyncResponse
+func GitSyncStatus(c *gin.Context, bindplane exposedserver.BindPlane) {
+	_, span := tracer.Start(c.Request.Context(), "api/GitSyncStatus")
+	defer span.End()
+
+	c.JSON(http.StatusOK, &model.GitSyncResponse{
+		Status: bindplane.GitSync().Status(),
+	})
+}
+
+// SyncGit syncs resources from the git repository now
+// @Summary Sync git repository
+// @Description Applies the resources in the configured git repository and returns the status of the sync. If the sync
+// @Description fails, the error is included in the status.
+// @Produce json
+// @Router /sync/git [post]
+// @Success 200 {object} model.GitSyncResponse
+// @Failure 400 {object} ErrorResponse
+func SyncGit(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/SyncGit")
+	defer span.End()
+
+	status, err := bindplane.GitSync().Sync(ctx)
+	if errors.Is(err, gitsync.ErrNotConfigured) {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, &model.GitSyncResponse{
+		Status: status,
+	})
+}
+
 // Rollouts returns all configurations with active rollouts.
 // @Summary Get all rollouts
 // @Produce json
@@ -1434,6 +1874,7 @@ var mp metric.Meter = otel.Meter("rest")
 // @Param 	name	path	string	true "the name of the configuration"
 // @Param   options body model.RolloutOptions false "the options for the rollout"
 // @Success 202 {object} model.ConfigurationResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func RolloutStart(c *gin.Context, bindplane exposedserver.BindPlane) {
 	ctx, span := tracer.Start(c.Request.Context(), "api/RolloutStart")
@@ -1449,12 +1890,20 @@ func RolloutStart(c *gin.Context, bindplane exposedserver.BindPlane) {
 		HandleErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
+	if payload.Options != nil {
+		if err := payload.Options.Validate(); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			HandleErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+	}
 
 	config, err := bindplane.Store().StartRollout(ctx, name, payload.Options)
 
 	if !OkResource(c, config == nil, err) {
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionRolloutStart, config))
 	c.JSON(http.StatusAccepted, model.ConfigurationResponse{
 		Configuration: config,
 	})
@@ -1478,6 +1927,7 @@ func RolloutResume(c *gin.Context, bindplane exposedserver.BindPlane) {
 	if !OkResource(c, configuration == nil, err) {
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionRolloutResume, configuration))
 	c.JSON(http.StatusAccepted, model.ConfigurationResponse{
 		Configuration: configuration,
 	})
@@ -1501,6 +1951,7 @@ func RolloutPause(c *gin.Context, bindplane exposedserver.BindPlane) {
 	if !OkResource(c, configuration == nil, err) {
 		return
 	}
+	bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionRolloutPause, configuration))
 	c.JSON(http.StatusAccepted, model.ConfigurationResponse{
 		Configuration: configuration,
 	})
@@ -1527,6 +1978,9 @@ func RolloutUpdate(c *gin.Context, bindplane exposedserver.BindPlane) {
 	if !OkResponse(c, err) {
 		return
 	}
+	if config != nil {
+		bindplane.Audit().Record(ctx, audit.ResourceEvent(ctx, model.AuditActionRolloutUpdate, config))
+	}
 
 	c.JSON(http.StatusAccepted, model.ConfigurationResponse{
 		Configuration: config,
@@ -1549,6 +2003,12 @@ func RolloutsUpdate(c *gin.Context, bindplane exposedserver.BindPlane) {
 		return
 	}
 
+	events := make([]*model.AuditEvent, 0, len(configurations))
+	for _, configuration := range configurations {
+		events = append(events, audit.ResourceEvent(ctx, model.AuditActionRolloutUpdate, configuration))
+	}
+	bindplane.Audit().Record(ctx, events...)
+
 	c.JSON(http.StatusAccepted, model.ConfigurationsResponse{
 		Configurations: configurations,
 	})
@@ -1594,6 +2054,691 @@ func History(c *gin.Context, bindplane exposedserver.BindPlane) {
 
 // ----------------------------------------------------------------------
 
+// Measurements returns the throughput of agents in a time range as time series
+// @Summary Query the throughput measurements of agents
+// @Produce json
+// @Router /measurements [get]
+// @Param 	start	query	string	false "the RFC3339 time of the first point, 1h before end if not specified"
+// @Param 	end	query	string	false "the RFC3339 time of the last point, now if not specified"
+// @Param 	step	query	string	false "the time between points, e.g. 30s, 5m, or 1d, 1m if not specified"
+// @Param 	metric	query	string	false "the name of the metric, all metrics if not specified"
+// @Param 	groupBy	query	[]string	false "the dimensions used to group the time series: agent, configuration, processor, position, pipelineType, or resource"
+// @Param 	selector	query	string	false "a label selector for the agents to include, all agents if not specified"
+// @Success 200 {object} model.MeasurementsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Measurements(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Measurements")
+	defer span.End()
+
+	query := stats.MeasurementsQuery{
+		Metric: c.Query("metric"),
+	}
+
+	var err error
+	if query.End, err = timeQuery(c, "end"); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if query.End.IsZero() {
+		query.End = time.Now()
+	}
+	if query.Start, err = timeQuery(c, "start"); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if query.Start.IsZero() {
+		query.Start = query.End.Add(-time.Hour)
+	}
+	if query.Step, err = stats.ParsePeriod(c.DefaultQuery("step", "1m")); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("step: %w", err))
+		return
+	}
+	for _, groupBy := range c.QueryArray("groupBy") {
+		query.GroupBy = append(query.GroupBy, strings.Split(groupBy, ",")...)
+	}
+	if err := query.Validate(); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if selectorString := c.Query("selector"); selectorString != "" {
+		selector, err := model.SelectorFromString(selectorString)
+		if err != nil {
+			HandleErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		agents, err := bindplane.Store().Agents(ctx, store.WithSelector(selector))
+		if !OkResponse(c, err) {
+			return
+		}
+		if len(agents) == 0 {
+			c.JSON(http.StatusOK, model.MeasurementsResponse{Series: []*stats.TimeSeries{}})
+			return
+		}
+		for _, agent := range agents {
+			query.Agents = append(query.Agents, agent.ID)
+		}
+	}
+
+	series, err := bindplane.Store().Measurements().QueryMeasurements(ctx, query)
+	if !OkResponse(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, model.MeasurementsResponse{
+		Series: series,
+	})
+}
+
+// ----------------------------------------------------------------------
+
+// Snapshots returns the saved snapshots without their data, starting with the most recent
+// @Summary List saved snapshots
+// @Produce json
+// @Router /snapshots [get]
+// @Param 	agent	query	string	false "only include snapshots of the agent with this ID"
+// @Success 200 {object} model.SnapshotsResponse
+// @Failure 500 {object} ErrorResponse
+func Snapshots(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Snapshots")
+	defer span.End()
+
+	snapshots, err := bindplane.Store().Snapshots(ctx)
+	if !OkResponse(c, err) {
+		return
+	}
+
+	if agentID := c.Query("agent"); agentID != "" {
+		filtered := []*model.Snapshot{}
+		for _, snapshot := range snapshots {
+			if snapshot.AgentID == agentID {
+				filtered = append(filtered, snapshot)
+			}
+		}
+		snapshots = filtered
+	}
+
+	c.JSON(http.StatusOK, model.SnapshotsResponse{
+		Snapshots: snapshots,
+	})
+}
+
+// saveSnapshotTimeout is the maximum time to wait for an agent to report a snapshot that will be saved. It is less
+// than the timeout of the CLI client so that the client receives the error from the server.
+var saveSnapshotTimeout = 15 * time.Second
+
+// SaveSnapshot requests a snapshot from an agent, waits for the agent to report it, and saves it
+// @Summary Capture and save a snapshot
+// @Produce json
+// @Router /snapshots [post]
+// @Param 	request	body	model.PostSnapshotRequest	true "the agent, pipeline type, and snapshot processor"
+// @Success 201 {object} model.SnapshotResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+func SaveSnapshot(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/SaveSnapshot")
+	defer span.End()
+
+	var req model.PostSnapshotRequest
+	if err := c.BindJSON(&req); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := validateSnapshotRequest(req); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	captureCtx, cancel := context.WithTimeout(ctx, saveSnapshotTimeout)
+	defer cancel()
+
+	snapshot, err := server.CaptureSnapshot(captureCtx, bindplane, req.AgentID, req.PipelineType, req.Position, req.ResourceName)
+	switch {
+	case errors.Is(err, server.ErrSnapshotAgentNotFound):
+		HandleErrorResponse(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, server.ErrSnapshotTimeout):
+		HandleErrorResponse(c, http.StatusGatewayTimeout, err)
+		return
+	case !OkResponse(c, err):
+		return
+	}
+
+	if !OkResponse(c, bindplane.Store().SaveSnapshot(ctx, snapshot)) {
+		return
+	}
+	event := audit.NewEvent(ctx, model.AuditActionCreate, model.AuditKindSnapshot, snapshot.ID, 0)
+	event.Details = fmt.Sprintf("%s of agent %s at %s", snapshot.PipelineType, snapshot.AgentID, snapshot.Processor())
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusCreated, model.SnapshotResponse{
+		Snapshot: snapshot,
+	})
+}
+
+// validateSnapshotRequest returns an error if the request does not identify an agent and a snapshot processor
+func validateSnapshotRequest(req model.PostSnapshotRequest) error {
+	if req.AgentID == "" {
+		return errors.New("agentId is required")
+	}
+	switch req.PipelineType {
+	case bpotel.Logs, bpotel.Metrics, bpotel.Traces:
+	default:
+		return fmt.Errorf("pipelineType must be one of logs, metrics, or traces")
+	}
+	if req.ResourceName == "" {
+		if req.Position != "" {
+			return errors.New("resourceName is required with position")
+		}
+		return nil
+	}
+	// snapshot processors are only inserted before the processors of sources and destinations
+	switch req.Position {
+	case model.MeasurementPositionSourceBeforeProcessors, model.MeasurementPositionDestinationBeforeProcessors:
+		return nil
+	default:
+		return fmt.Errorf("position must be %s or %s with resourceName", model.MeasurementPositionSourceBeforeProcessors, model.MeasurementPositionDestinationBeforeProcessors)
+	}
+}
+
+// Snapshot returns a saved snapshot including its data
+// @Summary Get a saved snapshot by ID
+// @Produce json
+// @Router /snapshots/{id} [get]
+// @Param 	id	path	string	true "the ID of the snapshot"
+// @Success 200 {object} model.SnapshotResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Snapshot(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Snapshot")
+	defer span.End()
+
+	snapshot, err := bindplane.Store().Snapshot(ctx, c.Param("id"))
+	if OkResource(c, snapshot == nil, err) {
+		c.JSON(http.StatusOK, model.SnapshotResponse{
+			Snapshot: snapshot,
+		})
+	}
+}
+
+// SnapshotDiff compares the records of two saved snapshots, e.g. captured before and after processors
+// @Summary Compare two saved snapshots
+// @Produce json
+// @Router /snapshots/{id}/diff [get]
+// @Param 	id	path	string	true "the ID of the snapshot to compare from"
+// @Param 	to	query	string	true "the ID of the snapshot to compare to"
+// @Success 200 {object} model.SnapshotDiffResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func SnapshotDiff(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/SnapshotDiff")
+	defer span.End()
+
+	toID := c.Query("to")
+	if toID == "" {
+		HandleErrorResponse(c, http.StatusBadRequest, errors.New("to is required"))
+		return
+	}
+
+	from, err := bindplane.Store().Snapshot(ctx, c.Param("id"))
+	if !OkResource(c, from == nil, err) {
+		return
+	}
+	to, err := bindplane.Store().Snapshot(ctx, toID)
+	if !OkResource(c, to == nil, err) {
+		return
+	}
+
+	diff, err := model.NewSnapshotDiff(ctx, from, to)
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SnapshotDiffResponse{
+		Diff: diff,
+	})
+}
+
+// DeleteSnapshot removes a saved snapshot by ID
+// @Summary Delete a saved snapshot by ID
+// @Produce json
+// @Router /snapshots/{id} [delete]
+// @Param 	id	path	string	true "the ID of the snapshot"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func DeleteSnapshot(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/DeleteSnapshot")
+	defer span.End()
+
+	snapshot, err := bindplane.Store().DeleteSnapshot(ctx, c.Param("id"))
+	if OkResource(c, snapshot == nil, err) {
+		event := audit.NewEvent(ctx, model.AuditActionDelete, model.AuditKindSnapshot, snapshot.ID, 0)
+		event.Details = fmt.Sprintf("%s of agent %s", snapshot.PipelineType, snapshot.AgentID)
+		bindplane.Audit().Record(ctx, event)
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// Users returns a list of users
+// @Summary List users
+// @Produce json
+// @Router /users [get]
+// @Success 200 {object} model.UsersResponse
+// @Failure 500 {object} ErrorResponse
+func Users(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Users")
+	defer span.End()
+
+	users, err := bindplane.Store().Users(ctx)
+	if !OkResponse(c, err) {
+		return
+	}
+	for i, user := range users {
+		users[i] = user.Redacted()
+	}
+	c.JSON(http.StatusOK, model.UsersResponse{
+		Users: users,
+	})
+}
+
+// User returns a user by name
+// @Summary Get user by name
+// @Produce json
+// @Router /users/{name} [get]
+// @Param 	name	path	string	true "the name of the user"
+// @Success 200 {object} model.UserResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func User(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/User")
+	defer span.End()
+
+	user, err := bindplane.Store().User(ctx, c.Param("name"))
+	if OkResource(c, user == nil, err) {
+		c.JSON(http.StatusOK, model.UserResponse{
+			User: user.Redacted(),
+		})
+	}
+}
+
+// CreateUser creates a new user with a password and role
+// @Summary Create user
+// @Produce json
+// @Router /users [post]
+// @Param 	user	body	model.PostUserRequest	true "the user to create"
+// @Success 201 {object} model.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func CreateUser(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/CreateUser")
+	defer span.End()
+
+	var req model.PostUserRequest
+	if err := c.BindJSON(&req); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := model.NewUser(req.Name, req.Role, req.Password)
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := model.ValidateUserName(user.Name, bindplane.ServerUsername()); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	existing, err := bindplane.Store().User(ctx, user.Name)
+	if !OkResponse(c, err) {
+		return
+	}
+	if existing != nil {
+		HandleErrorResponse(c, http.StatusConflict, fmt.Errorf("a user with the name %s already exists", user.Name))
+		return
+	}
+
+	if !OkResponse(c, bindplane.Store().UpsertUser(ctx, user)) {
+		return
+	}
+	event := audit.NewEvent(ctx, model.AuditActionCreate, model.AuditKindUser, user.Name, 0)
+	event.Details = fmt.Sprintf("role %s", user.Role)
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusCreated, model.UserResponse{
+		User: user.Redacted(),
+	})
+}
+
+// SetUserRole sets the role of an existing user
+// @Summary Set user role
+// @Produce json
+// @Router /users/{name}/role [put]
+// @Param 	name	path	string	true "the name of the user"
+// @Param 	role	body	model.PutUserRoleRequest	true "the new role of the user"
+// @Success 200 {object} model.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func SetUserRole(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/SetUserRole")
+	defer span.End()
+
+	var req model.PutUserRoleRequest
+	if err := c.BindJSON(&req); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	role, err := model.ParseRole(string(req.Role))
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := bindplane.Store().User(ctx, c.Param("name"))
+	if !OkResource(c, user == nil, err) {
+		return
+	}
+	user.Role = role
+	if !OkResponse(c, bindplane.Store().UpsertUser(ctx, user)) {
+		return
+	}
+	event := audit.NewEvent(ctx, model.AuditActionUpdate, model.AuditKindUser, user.Name, 0)
+	event.Details = fmt.Sprintf("role %s", user.Role)
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusOK, model.UserResponse{
+		User: user.Redacted(),
+	})
+}
+
+// DeleteUser deletes a user by name
+// @Summary Delete user by name
+// @Produce json
+// @Router /users/{name} [delete]
+// @Param 	name	path	string	true "the name of the user"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func DeleteUser(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/DeleteUser")
+	defer span.End()
+
+	user, err := bindplane.Store().DeleteUser(ctx, c.Param("name"))
+	if OkResource(c, user == nil, err) {
+		bindplane.Audit().Record(ctx, audit.NewEvent(ctx, model.AuditActionDelete, model.AuditKindUser, user.Name, 0))
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// APIKeys returns a list of API keys
+// @Summary List API keys
+// @Produce json
+// @Router /api-keys [get]
+// @Success 200 {object} model.APIKeysResponse
+// @Failure 500 {object} ErrorResponse
+func APIKeys(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/APIKeys")
+	defer span.End()
+
+	apiKeys, err := bindplane.Store().APIKeys(ctx)
+	if !OkResponse(c, err) {
+		return
+	}
+	for i, apiKey := range apiKeys {
+		apiKeys[i] = apiKey.Redacted()
+	}
+	c.JSON(http.StatusOK, model.APIKeysResponse{
+		APIKeys: apiKeys,
+	})
+}
+
+// CreateAPIKey creates a new API key. The key is only included in this response and cannot be retrieved later.
+// @Summary Create API key
+// @Produce json
+// @Router /api-keys [post]
+// @Param 	apiKey	body	model.PostAPIKeyRequest	true "the name and role of the API key"
+// @Success 201 {object} model.PostAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func CreateAPIKey(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/CreateAPIKey")
+	defer span.End()
+
+	var req model.PostAPIKeyRequest
+	if err := c.BindJSON(&req); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	apiKey, key, err := model.NewAPIKey(req.Name, req.Role, c.GetString(authenticator.LoginKey))
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if !OkResponse(c, bindplane.Store().UpsertAPIKey(ctx, apiKey)) {
+		return
+	}
+	event := audit.NewEvent(ctx, model.AuditActionCreate, model.AuditKindAPIKey, apiKey.ID, 0)
+	event.Details = fmt.Sprintf("%s with role %s", apiKey.Name, apiKey.Role)
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusCreated, model.PostAPIKeyResponse{
+		APIKey: apiKey.Redacted(),
+		Key:    key,
+	})
+}
+
+// RevokeAPIKey revokes an API key by ID. Requests using the key are rejected immediately.
+// @Summary Revoke API key by ID
+// @Produce json
+// @Router /api-keys/{id} [delete]
+// @Param 	id	path	string	true "the ID of the API key"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func RevokeAPIKey(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/RevokeAPIKey")
+	defer span.End()
+
+	apiKey, err := bindplane.Store().DeleteAPIKey(ctx, c.Param("id"))
+	if OkResource(c, apiKey == nil, err) {
+		event := audit.NewEvent(ctx, model.AuditActionDelete, model.AuditKindAPIKey, apiKey.ID, 0)
+		event.Details = apiKey.Name
+		bindplane.Audit().Record(ctx, event)
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// AuditEvents returns the audit events of changes made through the API, starting with the most recent
+// @Summary List audit events
+// @Produce json
+// @Router /audit [get]
+// @Param actor query string false "only include events by the user or API key with this login ID"
+// @Param action query string false "only include events with this action, e.g. create or rollout.start"
+// @Param kind query string false "only include events for resources of this kind"
+// @Param name query string false "only include events for resources with this name"
+// @Param since query string false "only include events at or after this RFC3339 time"
+// @Param until query string false "only include events before this RFC3339 time"
+// @Param limit query int false "the maximum number of events to return"
+// @Success 200 {object} model.AuditEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func AuditEvents(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/AuditEvents")
+	defer span.End()
+
+	filter := model.AuditEventFilter{
+		Actor:        c.Query("actor"),
+		Action:       model.AuditAction(c.Query("action")),
+		ResourceKind: model.Kind(c.Query("kind")),
+		ResourceName: c.Query("name"),
+	}
+
+	var err error
+	if filter.Since, err = timeQuery(c, "since"); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if filter.Until, err = timeQuery(c, "until"); err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || filter.Limit < 0 {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("limit must be a positive number"))
+		return
+	}
+
+	events, err := bindplane.Store().AuditEvents(ctx, filter)
+	if !OkResponse(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, model.AuditEventsResponse{
+		Events: events,
+	})
+}
+
+// timeQuery returns the RFC3339 time in the query parameter or the zero time if the parameter is not set
+func timeQuery(c *gin.Context, param string) (time.Time, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 time: %v", param, err)
+	}
+	return parsed, nil
+}
+
+// ----------------------------------------------------------------------
+
+// Backup returns a backup of the resources and agents in the store as a gzip compressed tar archive. Sensitive
+// parameter values are included so that they can be restored.
+// @Summary Backup the store
+// @Produce application/gzip
+// @Router /backup [get]
+// @Param measurements query bool false "include the measurements reported by agents"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Backup(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Backup")
+	defer span.End()
+
+	includeMeasurements, err := strconv.ParseBool(c.DefaultQuery("measurements", "false"))
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("measurements must be true or false"))
+		return
+	}
+
+	backup, err := store.NewBackup(ctx, bindplane.Store(), includeMeasurements)
+	if errors.Is(err, store.ErrMeasurementsBackupNotSupported) {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !OkResponse(c, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := backup.Write(&buf); !OkResponse(c, err) {
+		return
+	}
+
+	event := audit.NewEvent(ctx, model.AuditActionBackup, model.AuditKindBackup, backup.Name(), 0)
+	event.Details = backup.Summary().String()
+	bindplane.Audit().Record(ctx, event)
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.gz"`, backup.Name()))
+	c.Data(http.StatusOK, "application/gzip", buf.Bytes())
+}
+
+// Restore restores a backup made with GET /backup. Resources and agents in the backup replace resources and agents
+// with the same name or ID. Other resources and agents are not changed.
+// @Summary Restore a backup of the store
+// @Accept application/gzip
+// @Produce json
+// @Router /restore [post]
+// @Success 200 {object} model.RestoreResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func Restore(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/Restore")
+	defer span.End()
+
+	backup, err := store.ReadBackup(c.Request.Body)
+	if err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	restored, err := store.Restore(ctx, bindplane.Store(), backup)
+	response := model.RestoreResponse{Restored: restored}
+	if err != nil {
+		bindplane.Logger().Error("failed to restore backup", zap.Error(err))
+		response.Error = err.Error()
+	}
+
+	event := audit.NewEvent(ctx, model.AuditActionRestore, model.AuditKindBackup, backup.Name(), 0)
+	event.Details = restored.String()
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RotateEncryptionKey re-encrypts the sensitive parameter values of every resource and archived version with the
+// current encryption key. Previous encryption keys can be removed from the configuration afterwards.
+// @Summary Rotate the store encryption key
+// @Produce json
+// @Router /encryption/rotate [post]
+// @Success 200 {object} model.RotateEncryptionKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func RotateEncryptionKey(c *gin.Context, bindplane exposedserver.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "api/RotateEncryptionKey")
+	defer span.End()
+
+	rotator, ok := bindplane.Store().(store.EncryptionKeyRotator)
+	if !ok {
+		HandleErrorResponse(c, http.StatusBadRequest, store.ErrEncryptionNotSupported)
+		return
+	}
+
+	count, err := rotator.RotateEncryptionKey(ctx)
+	if errors.Is(err, store.ErrEncryptionKeyMissing) {
+		HandleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if !OkResponse(c, err) {
+		return
+	}
+
+	event := audit.NewEvent(ctx, model.AuditActionRotateEncryptionKey, model.AuditKindEncryptionKey, "", 0)
+	event.Details = fmt.Sprintf("%d resources re-encrypted", count)
+	bindplane.Audit().Record(ctx, event)
+
+	c.JSON(http.StatusOK, model.RotateEncryptionKeyResponse{Resources: count})
+}
+
+// ----------------------------------------------------------------------
+
 // OkResponse returns true if there should be an OK response based on the error provided. It will set an error response on the
 // gin.Context if appropriate.
 func OkResponse(c *gin.Context, err error) bool {
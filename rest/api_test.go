@@ -15,14 +15,17 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-resty/resty/v2"
@@ -32,15 +35,26 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/observiq/bindplane-op/audit"
+	"github.com/observiq/bindplane-op/authenticator"
 	"github.com/observiq/bindplane-op/config"
 	"github.com/observiq/bindplane-op/internal/server"
+	"github.com/observiq/bindplane-op/middleware"
 	"github.com/observiq/bindplane-op/model"
+	bpotel "github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/model/version"
+	"github.com/observiq/bindplane-op/otlp/record"
+	bpserver "github.com/observiq/bindplane-op/server"
+	servermocks "github.com/observiq/bindplane-op/server/mocks"
+	"github.com/observiq/bindplane-op/server/protocol"
 	"github.com/observiq/bindplane-op/store"
 	storeMocks "github.com/observiq/bindplane-op/store/mocks"
+	"github.com/observiq/bindplane-op/store/stats"
 	statsmocks "github.com/observiq/bindplane-op/store/stats/mocks"
 	"github.com/observiq/bindplane-op/store/storetest"
 	"github.com/observiq/bindplane-op/util"
@@ -206,6 +220,7 @@ func TestREST(t *testing.T) {
 
 	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
 	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), store, nil, mockBatcher)
+	router.Use(withRole(model.RoleAdmin))
 	AddRestRoutes(router, bindplane)
 
 	client := resty.New()
@@ -258,6 +273,21 @@ func TestREST(t *testing.T) {
 		require.Equal(t, http.StatusNotFound, resp.StatusCode())
 		require.NoError(t, err)
 	})
+	seq.Run("|rollouts|config1|start produces error for invalid maintenance window", func(t *testing.T) {
+		result := &model.ConfigurationResponse{}
+		payload := &model.StartRolloutPayload{
+			Options: &model.RolloutOptions{
+				PhaseAgentCount: model.PhaseAgentCount{Initial: 1, Multiplier: 1, Maximum: 1},
+				MaintenanceWindow: &model.MaintenanceWindow{
+					Cron:     "0 2 * *",
+					Duration: "2h",
+				},
+			},
+		}
+		resp, err := client.R().SetBody(payload).SetResult(result).Post("/rollouts/config1/start")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
 	seq.Run("|rollouts|config1|start starts rollout for config1", func(t *testing.T) {
 		result := &model.ConfigurationResponse{}
 		payload := &model.StartRolloutPayload{}
@@ -1882,11 +1912,15 @@ func TestRESTMock(t *testing.T) {
 			store := &storeMocks.MockStore{}
 			mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
 			bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), store, nil, mockBatcher)
+			router.Use(withRole(model.RoleAdmin))
 			AddRestRoutes(router, bindplane)
 
 			client := resty.New()
 			client.SetBaseURL(svr.URL)
 
+			// successful changes are recorded in the audit log
+			store.On("AddAuditEvents", mock.Anything, mock.Anything).Return(nil).Maybe()
+
 			// Set the return for the mocked store method
 			if len(test.mockArgs) > 0 {
 				store.On(test.mockFunction, test.mockArgs...).Return(test.mockReturn...)
@@ -1916,3 +1950,1286 @@ func TestRESTMock(t *testing.T) {
 		})
 	}
 }
+
+func TestRESTRoles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	tests := []struct {
+		role         model.Role
+		method       string
+		endpoint     string
+		expectStatus int
+	}{
+		{"", http.MethodGet, "/configurations", http.StatusForbidden},
+		{model.RoleViewer, http.MethodGet, "/configurations", http.StatusOK},
+		{model.RoleViewer, http.MethodDelete, "/configurations/missing", http.StatusForbidden},
+		{model.RoleUser, http.MethodDelete, "/configurations/missing", http.StatusNotFound},
+		{model.RoleUser, http.MethodGet, "/users", http.StatusForbidden},
+		{model.RoleAdmin, http.MethodGet, "/users", http.StatusOK},
+		{model.RoleAdmin, http.MethodDelete, "/configurations/missing", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join([]string{string(test.role), test.method, test.endpoint}, " "), func(t *testing.T) {
+			router := gin.Default()
+			if test.role != "" {
+				router.Use(withRole(test.role))
+			}
+			AddRestRoutes(router, bindplane)
+			svr := httptest.NewServer(router)
+			defer svr.Close()
+
+			resp, err := resty.New().SetBaseURL(svr.URL).R().Execute(test.method, test.endpoint)
+			require.NoError(t, err)
+			require.Equal(t, test.expectStatus, resp.StatusCode())
+		})
+	}
+}
+
+func TestRESTUsers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{Auth: config.Auth{Username: "admin"}}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	router := gin.Default()
+	router.Use(withRole(model.RoleAdmin))
+	AddRestRoutes(router, bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	client := resty.New()
+	client.SetBaseURL(svr.URL)
+
+	// create
+	userResponse := &model.UserResponse{}
+	resp, err := client.R().
+		SetBody(model.PostUserRequest{Name: "bob", Password: "password", Role: model.RoleViewer}).
+		SetResult(userResponse).
+		Post("/users")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	require.Equal(t, &model.User{Name: "bob", Role: model.RoleViewer}, userResponse.User)
+
+	// create again conflicts
+	resp, err = client.R().
+		SetBody(model.PostUserRequest{Name: "bob", Password: "password", Role: model.RoleViewer}).
+		Post("/users")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+	// invalid role
+	resp, err = client.R().
+		SetBody(model.PostUserRequest{Name: "alice", Password: "password", Role: "superuser"}).
+		Post("/users")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	// names that would be authenticated as the server profile user or an API key are reserved
+	for _, name := range []string{"admin", authenticator.APIKeyLoginPrefix + "1"} {
+		resp, err = client.R().
+			SetBody(model.PostUserRequest{Name: name, Password: "password", Role: model.RoleViewer}).
+			Post("/users")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode(), name)
+		require.Contains(t, string(resp.Body()), "is reserved")
+
+		user, err := s.User(ctx, name)
+		require.NoError(t, err)
+		require.Nil(t, user)
+	}
+
+	// set role
+	resp, err = client.R().
+		SetBody(model.PutUserRoleRequest{Role: model.RoleUser}).
+		SetResult(userResponse).
+		Put("/users/bob/role")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, model.RoleUser, userResponse.User.Role)
+
+	// the password is kept when the role changes and is never returned
+	user, err := s.User(ctx, "bob")
+	require.NoError(t, err)
+	require.True(t, user.CheckPassword("password"))
+
+	usersResponse := &model.UsersResponse{}
+	resp, err = client.R().SetResult(usersResponse).Get("/users")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, []*model.User{{Name: "bob", Role: model.RoleUser}}, usersResponse.Users)
+
+	// delete
+	resp, err = client.R().Delete("/users/bob")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	resp, err = client.R().Get("/users/bob")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
+
+func TestRESTAPIKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	cfg := &config.Config{Auth: config.Auth{Username: "admin", Password: "admin"}}
+	bindplane := server.NewBindPlane(cfg, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	// use the full authentication chain so that the api key is verified by the authenticator
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", middleware.Chain(bindplane)...), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	admin := resty.New().SetBaseURL(svr.URL).SetBasicAuth("admin", "admin")
+
+	createResponse := &model.PostAPIKeyResponse{}
+	resp, err := admin.R().
+		SetBody(model.PostAPIKeyRequest{Name: "ci", Role: model.RoleUser}).
+		SetResult(createResponse).
+		Post("/api-keys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	require.Equal(t, "ci", createResponse.APIKey.Name)
+	require.Equal(t, "admin", createResponse.APIKey.CreatedBy)
+	require.Empty(t, createResponse.APIKey.SecretHash)
+	require.NotEmpty(t, createResponse.Key)
+
+	resp, err = admin.R().
+		SetBody(model.PostAPIKeyRequest{Name: "invalid", Role: "superuser"}).
+		Post("/api-keys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	listResponse := &model.APIKeysResponse{}
+	resp, err = admin.R().SetResult(listResponse).Get("/api-keys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, listResponse.APIKeys, 1)
+	require.Empty(t, listResponse.APIKeys[0].SecretHash)
+
+	// the key authenticates with its own role
+	ci := resty.New().SetBaseURL(svr.URL).SetAuthToken(createResponse.Key)
+	resp, err = ci.R().Get("/configurations")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	resp, err = ci.R().Get("/api-keys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+	// revoked keys are rejected
+	resp, err = admin.R().Delete("/api-keys/" + createResponse.APIKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+	resp, err = admin.R().Delete("/api-keys/" + createResponse.APIKey.ID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+	resp, err = ci.R().Get("/configurations")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode())
+}
+
+func TestRESTAuditEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	cfg := &config.Config{Auth: config.Auth{Username: "admin", Password: "admin"}}
+	bindplane := server.NewBindPlane(cfg, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	// use the full authentication chain so that the actor is set on the request
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", middleware.Chain(bindplane)...), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	admin := resty.New().SetBaseURL(svr.URL).SetBasicAuth("admin", "admin")
+
+	createResponse := &model.PostAPIKeyResponse{}
+	resp, err := admin.R().
+		SetBody(model.PostAPIKeyRequest{Name: "ci", Role: model.RoleUser}).
+		SetResult(createResponse).
+		Post("/api-keys")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	ci := resty.New().SetBaseURL(svr.URL).SetAuthToken(createResponse.Key)
+
+	resp, err = ci.R().
+		SetBody(model.ApplyPayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, "1", "config1")}}).
+		Post("/apply")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode())
+
+	// unchanged resources are not recorded
+	resp, err = ci.R().
+		SetBody(model.ApplyPayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, "1", "config1")}}).
+		Post("/apply")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode())
+
+	resp, err = admin.R().Delete("/configurations/config1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	eventsResponse := &model.AuditEventsResponse{}
+	resp, err = admin.R().SetResult(eventsResponse).Get("/audit")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, eventsResponse.Events, 3)
+
+	deleted, created, apiKey := eventsResponse.Events[0], eventsResponse.Events[1], eventsResponse.Events[2]
+	require.Equal(t, "admin", deleted.Actor)
+	require.Equal(t, model.AuditActionDelete, deleted.Action)
+	require.Equal(t, model.KindConfiguration, deleted.ResourceKind)
+	require.Equal(t, "config1", deleted.ResourceName)
+	require.Equal(t, "127.0.0.1", deleted.Origin)
+
+	require.Equal(t, authenticator.APIKeyLoginPrefix+createResponse.APIKey.ID, created.Actor)
+	require.Equal(t, model.AuditActionCreate, created.Action)
+	require.Equal(t, "config1", created.ResourceName)
+
+	require.Equal(t, "admin", apiKey.Actor)
+	require.Equal(t, model.AuditKindAPIKey, apiKey.ResourceKind)
+	require.Equal(t, createResponse.APIKey.ID, apiKey.ResourceName)
+
+	resp, err = admin.R().
+		SetQueryParams(map[string]string{"actor": "admin", "kind": "configuration"}).
+		SetResult(eventsResponse).
+		Get("/audit")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, eventsResponse.Events, 1)
+	require.Equal(t, model.AuditActionDelete, eventsResponse.Events[0].Action)
+
+	resp, err = admin.R().SetQueryParam("since", "yesterday").Get("/audit")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	// only admins can read the audit log
+	resp, err = ci.R().Get("/audit")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode())
+}
+
+func TestRESTAlerts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	alert := model.NewAlert("errors", model.AlertSpec{
+		Condition: model.AlertCondition{Type: model.AlertConditionAgentStatus, AgentStatus: "Error"},
+		Channels:  []model.AlertChannel{{Type: model.AlertChannelWebhook, URL: "https://example.com/hook"}},
+	})
+	_, err := s.ApplyResources(ctx, []model.Resource{alert})
+	require.NoError(t, err)
+	_, err = s.UpdateAlertStatus(ctx, "errors", model.AlertStatus{State: model.AlertStateFiring, Message: "1 agent has status Error"})
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	alertsResponse := &model.AlertsResponse{}
+	resp, err := client.R().SetResult(alertsResponse).Get("/alerts")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, alertsResponse.Alerts, 1)
+	require.Equal(t, "errors", alertsResponse.Alerts[0].Name())
+
+	alertResponse := &model.AlertResponse{}
+	resp, err = client.R().SetResult(alertResponse).Get("/alerts/errors")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, model.AlertConditionAgentStatus, alertResponse.Alert.Spec.Condition.Type)
+	require.Equal(t, model.AlertStateFiring, alertResponse.Alert.Status.State)
+
+	resp, err = client.R().Get("/alerts/missing")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+	resp, err = client.R().Delete("/alerts/errors")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	resp, err = client.R().Delete("/alerts/errors")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
+
+func TestRESTWebhooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	webhook := model.NewWebhook("changes", model.WebhookSpec{
+		URL:    "https://example.com/hook",
+		Secret: "signing-secret",
+		Kinds:  []model.Kind{model.KindConfiguration},
+	})
+	_, err := s.ApplyResources(ctx, []model.Resource{webhook})
+	require.NoError(t, err)
+	_, err = s.AddWebhookDelivery(ctx, "changes", model.WebhookDelivery{ID: "delivery", Events: 1, Attempts: 1, StatusCode: http.StatusOK})
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	webhooksResponse := &model.WebhooksResponse{}
+	resp, err := client.R().SetResult(webhooksResponse).Get("/webhooks")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, webhooksResponse.Webhooks, 1)
+	require.Equal(t, "changes", webhooksResponse.Webhooks[0].Name())
+	require.Equal(t, model.SensitiveParameterPlaceholder, webhooksResponse.Webhooks[0].Spec.Secret)
+
+	webhookResponse := &model.WebhookResponse{}
+	resp, err = client.R().SetResult(webhookResponse).Get("/webhooks/changes")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, "https://example.com/hook", webhookResponse.Webhook.Spec.URL)
+	require.Equal(t, model.SensitiveParameterPlaceholder, webhookResponse.Webhook.Spec.Secret)
+	require.Len(t, webhookResponse.Webhook.Status.Deliveries, 1)
+	require.Equal(t, "delivery", webhookResponse.Webhook.Status.Deliveries[0].ID)
+
+	resp, err = client.R().Get("/webhooks/missing")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+	resp, err = client.R().Delete("/webhooks/changes")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	resp, err = client.R().Delete("/webhooks/changes")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
+
+func TestRESTGitSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+
+	newClient := func(cfg *config.Config) *resty.Client {
+		bindplane := server.NewBindPlane(cfg, zaptest.NewLogger(t), s, nil, mockBatcher)
+		router := gin.Default()
+		AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+		svr := httptest.NewServer(router)
+		t.Cleanup(svr.Close)
+		return resty.New().SetBaseURL(svr.URL)
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		client := newClient(&config.Config{})
+
+		response := &model.GitSyncResponse{}
+		resp, err := client.R().SetResult(response).Get("/sync/git")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.False(t, response.Status.Enabled)
+
+		resp, err = client.R().Post("/sync/git")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		client := newClient(&config.Config{
+			GitSync: config.GitSync{Repository: filepath.Join(t.TempDir(), "missing.git"), Interval: time.Minute},
+		})
+
+		response := &model.GitSyncResponse{}
+		resp, err := client.R().SetResult(response).Post("/sync/git")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.True(t, response.Status.Enabled)
+		require.Contains(t, response.Status.Error, "git clone failed")
+
+		response = &model.GitSyncResponse{}
+		resp, err = client.R().SetResult(response).Get("/sync/git")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.NotNil(t, response.Status.LastSync)
+		require.Contains(t, response.Status.Error, "git clone failed")
+	})
+}
+
+func TestRESTApplyDryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	_, err := s.ApplyResources(ctx, []model.Resource{
+		model.NewWebhook("changes", model.WebhookSpec{URL: "https://example.com/hook"}),
+		model.NewWebhook("same", model.WebhookSpec{URL: "https://example.com/same"}),
+	})
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	var resources []*model.AnyResource
+	for _, webhook := range []*model.Webhook{
+		model.NewWebhook("changes", model.WebhookSpec{URL: "https://example.com/changed"}),
+		model.NewWebhook("added", model.WebhookSpec{URL: "https://example.com/added"}),
+		model.NewWebhook("same", model.WebhookSpec{URL: "https://example.com/same"}),
+		model.NewWebhook("broken", model.WebhookSpec{}),
+	} {
+		resource, err := model.AsAny(webhook)
+		require.NoError(t, err)
+		resources = append(resources, resource)
+	}
+
+	applyResponse := &model.ApplyResponseClientSide{}
+	resp, err := client.R().
+		SetBody(&model.ApplyPayload{Resources: resources}).
+		SetResult(applyResponse).
+		Post("/apply?dryRun=true")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, applyResponse.Diffs, 4)
+	require.Len(t, applyResponse.Updates, 4)
+
+	changes := applyResponse.Diffs[0]
+	require.Equal(t, model.StatusConfigured, changes.Status)
+	require.Equal(t, model.Version(1), changes.CurrentVersion)
+	require.Contains(t, changes.Unified(), "-    url: https://example.com/hook\n+    url: https://example.com/changed\n")
+
+	require.Equal(t, model.StatusCreated, applyResponse.Diffs[1].Status)
+	require.Empty(t, applyResponse.Diffs[1].Current)
+	require.Contains(t, applyResponse.Diffs[1].Proposed, "url: https://example.com/added")
+
+	require.Equal(t, model.StatusUnchanged, applyResponse.Diffs[2].Status)
+
+	require.Equal(t, model.StatusInvalid, applyResponse.Diffs[3].Status)
+	require.Contains(t, applyResponse.Diffs[3].Reason, "webhook must specify a url")
+
+	// the store is not modified
+	webhook, err := s.Webhook(ctx, "changes")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/hook", webhook.Spec.URL)
+	webhook, err = s.Webhook(ctx, "added")
+	require.NoError(t, err)
+	require.Nil(t, webhook)
+}
+
+func TestRESTApplyDryRunSensitiveParameters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	newDestination := func(name string, password string) *model.Destination {
+		return model.NewDestination(name, "secure", []model.Parameter{
+			{Name: "username", Value: "user"},
+			{Name: "password", Value: password},
+		})
+	}
+	_, err := s.ApplyResources(ctx, []model.Resource{
+		model.NewDestinationType("secure", []model.ParameterDefinition{
+			{Name: "username", Type: "string"},
+			{Name: "password", Type: "string", Options: model.ParameterOptions{Sensitive: true}},
+		}),
+		newDestination("same", "secret"),
+		newDestination("masked", "secret"),
+		newDestination("changes", "secret"),
+	})
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	var resources []*model.AnyResource
+	for _, destination := range []*model.Destination{
+		newDestination("same", "secret"),
+		newDestination("masked", model.SensitiveParameterPlaceholder),
+		newDestination("changes", "changed"),
+	} {
+		resource, err := model.AsAny(destination)
+		require.NoError(t, err)
+		resources = append(resources, resource)
+	}
+
+	applyResponse := &model.ApplyResponseClientSide{}
+	resp, err := client.R().
+		SetBody(&model.ApplyPayload{Resources: resources}).
+		SetResult(applyResponse).
+		Post("/apply?dryRun=true")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Len(t, applyResponse.Diffs, 3)
+
+	// the current value is compared, not the masked value
+	require.Equal(t, model.StatusUnchanged, applyResponse.Diffs[0].Status)
+	require.Equal(t, model.StatusUnchanged, applyResponse.Diffs[1].Status)
+	require.Equal(t, model.StatusConfigured, applyResponse.Diffs[2].Status)
+
+	// sensitive values are masked in the diff
+	for _, diff := range applyResponse.Diffs {
+		require.NotContains(t, diff.Current, "secret")
+		require.NotContains(t, diff.Proposed, "secret")
+		require.NotContains(t, diff.Proposed, "changed")
+		require.Contains(t, diff.Proposed, model.SensitiveParameterPlaceholder)
+	}
+
+	// the store is not modified
+	destination, err := s.Destination(model.ContextWithoutSensitiveParameterMasking(ctx), "changes")
+	require.NoError(t, err)
+	require.Equal(t, "secret", model.ParameterValue(destination.Spec.Parameters, "password"))
+}
+
+func TestRESTConfigurationDiff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+	})
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	applyYAML := func(t *testing.T, text string) {
+		anyResources, err := model.ResourcesFromReader(strings.NewReader(text))
+		require.NoError(t, err)
+		resources, err := model.ParseResources(anyResources)
+		require.NoError(t, err)
+		statuses, err := s.ApplyResources(ctx, resources)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			require.NotEqual(t, model.StatusInvalid, status.Status, status.Reason)
+		}
+	}
+	sourceYAML := func(path string) string {
+		return fmt.Sprintf(`apiVersion: bindplane.observiq.com/v1
+kind: Source
+metadata:
+  name: files
+spec:
+  type: file
+  parameters:
+    - name: path
+      value: %s
+`, path)
+	}
+
+	applyYAML(t, `apiVersion: bindplane.observiq.com/v1
+kind: SourceType
+metadata:
+  name: file
+spec:
+  supportedPlatforms:
+    - linux
+  parameters:
+    - name: path
+      type: string
+  logs:
+    receivers: |
+      - filelog:
+          include: ["{{ .path }}"]
+---
+apiVersion: bindplane.observiq.com/v1
+kind: DestinationType
+metadata:
+  name: otlp
+spec:
+  logs:
+    exporters: |
+      - otlp:
+          endpoint: otelcol:4317
+---
+apiVersion: bindplane.observiq.com/v1
+kind: Destination
+metadata:
+  name: collector
+spec:
+  type: otlp
+`)
+	applyYAML(t, sourceYAML("/var/log/a.log"))
+	applyYAML(t, `apiVersion: bindplane.observiq.com/v1
+kind: Configuration
+metadata:
+  name: c1
+  labels:
+    platform: linux
+spec:
+  sources:
+    - name: files
+  destinations:
+    - name: collector
+`)
+
+	// start the rollout of version 1 so that changing the source creates version 2
+	_, err = s.StartRollout(ctx, "c1", &model.RolloutOptions{})
+	require.NoError(t, err)
+	applyYAML(t, sourceYAML("/var/log/b.log"))
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleViewer)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	t.Run("changes to referenced sources are included", func(t *testing.T) {
+		response := &model.ConfigurationDiffResponse{}
+		resp, err := client.R().SetResult(response).Get("/configurations/c1/diff?from=1&to=2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, model.Version(1), response.Diff.From)
+		require.Equal(t, model.Version(2), response.Diff.To)
+		require.Empty(t, response.Diff.Agent)
+		require.Contains(t, response.Diff.Diff, "--- c1:1\n+++ c1:2\n")
+		require.Contains(t, response.Diff.Diff, "-            - /var/log/a.log\n+            - /var/log/b.log\n")
+	})
+
+	t.Run("to defaults to latest", func(t *testing.T) {
+		response := &model.ConfigurationDiffResponse{}
+		resp, err := client.R().SetResult(response).Get("/configurations/c1/diff?from=v2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, model.Version(2), response.Diff.To)
+		require.Empty(t, response.Diff.Diff)
+	})
+
+	t.Run("rendered for an agent using the configuration", func(t *testing.T) {
+		_, err := s.UpsertAgent(ctx, "agent-1", func(agent *model.Agent) {
+			agent.Labels = model.LabelsFromValidatedMap(map[string]string{"configuration": "c1"})
+		})
+		require.NoError(t, err)
+
+		response := &model.ConfigurationDiffResponse{}
+		resp, err := client.R().SetResult(response).Get("/configurations/c1/diff?from=1&to=2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, "agent-1", response.Diff.Agent)
+
+		resp, err = client.R().Get("/configurations/c1/diff?from=1&to=2&agent=missing")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("invalid and missing versions", func(t *testing.T) {
+		resp, err := client.R().Get("/configurations/c1/diff?from=one")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().Get("/configurations/c1/diff?from=1&to=9")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+		resp, err = client.R().Get("/configurations/missing/diff?from=1")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+}
+
+func TestRESTRevertConfiguration(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+	})
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	// create versions 1 and 2 with a rollout of each
+	for _, raw := range []string{"receivers:\n  first:\n", "receivers:\n  second:\n"} {
+		_, err := s.ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("c1", raw)})
+		require.NoError(t, err)
+		_, err = s.StartRollout(ctx, "c1", &model.RolloutOptions{})
+		require.NoError(t, err)
+	}
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	t.Run("revert creates a new version with the spec of the previous version", func(t *testing.T) {
+		response := &model.RevertConfigurationResponse{}
+		resp, err := client.R().
+			SetBody(model.RevertConfigurationPayload{Version: 1, StartRollout: true}).
+			SetResult(response).
+			Post("/configurations/c1/revert")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, model.StatusConfigured, response.Status)
+		require.Equal(t, model.Version(3), response.Configuration.Version())
+		require.Equal(t, "receivers:\n  first:\n", response.Configuration.Spec.Raw)
+		require.NotEqual(t, model.RolloutStatusPending, response.Configuration.Status.Rollout.Status)
+
+		latest, err := s.Configuration(ctx, "c1")
+		require.NoError(t, err)
+		require.Equal(t, model.Version(3), latest.Version())
+		require.Equal(t, "receivers:\n  first:\n", latest.Spec.Raw)
+	})
+
+	t.Run("revert to the same spec is unchanged", func(t *testing.T) {
+		response := &model.RevertConfigurationResponse{}
+		resp, err := client.R().
+			SetBody(model.RevertConfigurationPayload{Version: 1}).
+			SetResult(response).
+			Post("/configurations/c1/revert")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, model.StatusUnchanged, response.Status)
+		require.Equal(t, model.Version(3), response.Configuration.Version())
+	})
+
+	t.Run("invalid and missing versions", func(t *testing.T) {
+		resp, err := client.R().SetBody(model.RevertConfigurationPayload{}).Post("/configurations/c1/revert")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetBody(model.RevertConfigurationPayload{Version: 9}).Post("/configurations/c1/revert")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+		resp, err = client.R().SetBody(model.RevertConfigurationPayload{Version: 1}).Post("/configurations/missing/revert")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+}
+
+func TestRESTBackupRestore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+	})
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	for _, raw := range []string{"receivers:\n  first:\n", "receivers:\n  second:\n"} {
+		_, err := s.ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("c1", raw)})
+		require.NoError(t, err)
+		_, err = s.StartRollout(ctx, "c1", &model.RolloutOptions{})
+		require.NoError(t, err)
+	}
+	_, err = s.UpsertAgent(ctx, "a1", func(current *model.Agent) { current.Name = "agent-1" })
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleAdmin)), bindplane)
+	userRouter := gin.Default()
+	AddRestRoutes(userRouter.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	userSvr := httptest.NewServer(userRouter)
+	defer userSvr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	resp, err := client.R().SetQueryParam("measurements", "true").Get("/backup")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+	require.Equal(t, "application/gzip", resp.Header().Get("Content-Type"))
+	require.Contains(t, resp.Header().Get("Content-Disposition"), "bindplane-backup-")
+	archive := resp.Body()
+
+	backup, err := store.ReadBackup(bytes.NewReader(archive))
+	require.NoError(t, err)
+	require.Equal(t, model.BackupSummary{Resources: 2, Agents: 1}, backup.Summary())
+
+	s.Clear()
+
+	t.Run("restore", func(t *testing.T) {
+		response := &model.RestoreResponse{}
+		resp, err := client.R().SetBody(archive).SetResult(response).Post("/restore")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, model.BackupSummary{Resources: 2, Agents: 1}, response.Restored)
+		require.Empty(t, response.Error)
+
+		configuration, err := s.Configuration(ctx, "c1")
+		require.NoError(t, err)
+		require.Equal(t, model.Version(2), configuration.Version())
+		require.Equal(t, "receivers:\n  second:\n", configuration.Spec.Raw)
+
+		agent, err := s.Agent(ctx, "a1")
+		require.NoError(t, err)
+		require.Equal(t, "agent-1", agent.Name)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		resp, err := client.R().SetQueryParam("measurements", "maybe").Get("/backup")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetBody([]byte("not a backup")).Post("/restore")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("only admins can backup and restore", func(t *testing.T) {
+		user := resty.New().SetBaseURL(userSvr.URL)
+		resp, err := user.R().Get("/backup")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+		resp, err = user.R().SetBody(archive).Post("/restore")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+}
+
+func TestRESTRotateEncryptionKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+	})
+	require.NoError(t, err)
+	cipher, err := store.NewParameterCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+		ParameterCipher:  cipher,
+	}, zap.NewNop())
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	destinationType := model.NewDestinationType("secure", []model.ParameterDefinition{
+		{Name: "password", Type: "string", Options: model.ParameterOptions{Sensitive: true}},
+	})
+	destination := model.NewDestination("d1", "secure", []model.Parameter{{Name: "password", Value: "secret"}})
+	_, err = s.ApplyResources(ctx, []model.Resource{destinationType, destination})
+	require.NoError(t, err)
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleAdmin)), bindplane)
+	userRouter := gin.Default()
+	AddRestRoutes(userRouter.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	userSvr := httptest.NewServer(userRouter)
+	defer userSvr.Close()
+
+	t.Run("rotate", func(t *testing.T) {
+		response := &model.RotateEncryptionKeyResponse{}
+		resp, err := resty.New().SetBaseURL(svr.URL).R().SetResult(response).Post("/encryption/rotate")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, 1, response.Resources)
+	})
+
+	t.Run("only admins can rotate the key", func(t *testing.T) {
+		resp, err := resty.New().SetBaseURL(userSvr.URL).R().Post("/encryption/rotate")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+}
+
+func TestRESTMeasurements(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+	})
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:             "super-secret-key",
+		MaxEventsToMerge:           1,
+		DisableMeasurementsCleanup: true,
+	}, zap.NewNop())
+	// create the measurements buckets
+	s.Clear()
+	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
+	bindplane := server.NewBindPlane(&config.Config{}, zaptest.NewLogger(t), s, nil, mockBatcher)
+
+	for id, env := range map[string]string{"a1": "prod", "a2": "dev"} {
+		env := env
+		_, err := s.UpsertAgent(ctx, id, func(current *model.Agent) {
+			current.Labels = model.LabelsFromValidatedMap(map[string]string{"env": env})
+		})
+		require.NoError(t, err)
+	}
+
+	// a1 sends 1 B/s and a2 sends 2 B/s from the source
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	var metrics []*record.Metric
+	for i := 0; i <= 5; i++ {
+		for agent, rate := range map[string]float64{"a1": 1, "a2": 2} {
+			metrics = append(metrics, &record.Metric{
+				Name:           stats.LogDataSizeMetricName,
+				Timestamp:      start.Add(time.Duration(i) * time.Minute),
+				StartTimestamp: start.Add(-time.Hour),
+				Value:          rate * 60 * float64(i),
+				Type:           "Sum",
+				Attributes: map[string]interface{}{
+					stats.AgentAttributeName:         agent,
+					stats.ConfigurationAttributeName: "c1",
+					stats.ProcessorAttributeName:     "throughputmeasurement/_s0_logs_source0",
+				},
+			})
+		}
+	}
+	require.NoError(t, s.Measurements().SaveAgentMetrics(ctx, metrics))
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleViewer)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	points := func(value float64) []stats.TimeSeriesPoint {
+		var result []stats.TimeSeriesPoint
+		for i := 1; i <= 5; i++ {
+			result = append(result, stats.TimeSeriesPoint{Timestamp: start.Add(time.Duration(i) * time.Minute), Value: value})
+		}
+		return result
+	}
+	params := map[string]string{
+		"start": start.Format(time.RFC3339),
+		"end":   start.Add(5 * time.Minute).Format(time.RFC3339),
+		"step":  "1m",
+	}
+
+	t.Run("total of all agents", func(t *testing.T) {
+		response := &model.MeasurementsResponse{}
+		resp, err := client.R().SetQueryParams(params).SetResult(response).Get("/measurements")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, []*stats.TimeSeries{
+			{Metric: stats.LogDataSizeMetricName, Labels: map[string]string{}, Points: points(3)},
+		}, response.Series)
+	})
+
+	t.Run("grouped by agent", func(t *testing.T) {
+		response := &model.MeasurementsResponse{}
+		resp, err := client.R().
+			SetQueryParams(params).
+			SetQueryParam("groupBy", "agent,pipelineType").
+			SetResult(response).
+			Get("/measurements")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, []*stats.TimeSeries{
+			{Metric: stats.LogDataSizeMetricName, Labels: map[string]string{"agent": "a1", "pipelineType": "logs"}, Points: points(1)},
+			{Metric: stats.LogDataSizeMetricName, Labels: map[string]string{"agent": "a2", "pipelineType": "logs"}, Points: points(2)},
+		}, response.Series)
+	})
+
+	t.Run("agents matching the selector", func(t *testing.T) {
+		response := &model.MeasurementsResponse{}
+		resp, err := client.R().
+			SetQueryParams(params).
+			SetQueryParam("selector", "env=dev").
+			SetResult(response).
+			Get("/measurements")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, []*stats.TimeSeries{
+			{Metric: stats.LogDataSizeMetricName, Labels: map[string]string{}, Points: points(2)},
+		}, response.Series)
+
+		resp, err = client.R().
+			SetQueryParams(params).
+			SetQueryParam("selector", "env=test").
+			SetResult(response).
+			Get("/measurements")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Empty(t, response.Series)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, invalid := range []map[string]string{
+			{"start": "yesterday"},
+			{"step": "1x"},
+			{"step": "1s"},
+			{"end": start.Add(-time.Minute).Format(time.RFC3339)},
+			{"metric": "otelcol_processor_other"},
+			{"groupBy": "host"},
+			{"selector": "env=="},
+		} {
+			resp, err := client.R().SetQueryParams(params).SetQueryParams(invalid).Get("/measurements")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode(), invalid)
+		}
+	})
+}
+
+func TestRESTSnapshots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := storetest.InitTestBboltDB(t, []string{
+		store.BucketResources,
+		store.BucketAgents,
+		store.BucketMeasurements,
+		store.BucketArchive,
+		store.BucketAuditEvents,
+		store.BucketSnapshots,
+	})
+	require.NoError(t, err)
+	s := store.NewBoltStore(ctx, db, store.Options{
+		SessionsSecret:             "super-secret-key",
+		MaxEventsToMerge:           1,
+		DisableMeasurementsCleanup: true,
+	}, zap.NewNop())
+	s.Clear()
+
+	_, err = s.ApplyResources(ctx, []model.Resource{model.NewConfiguration("linux")})
+	require.NoError(t, err)
+	for _, id := range []string{"a1", "a2"} {
+		_, err := s.UpsertAgent(ctx, id, func(current *model.Agent) {
+			current.Name = id
+			current.ConfigurationStatus.Current = "linux:1"
+		})
+		require.NoError(t, err)
+	}
+
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	reportedLogs := func(bodies ...string) bpserver.RelayLogs {
+		logs := plog.NewLogs()
+		records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for i, body := range bodies {
+			record := records.AppendEmpty()
+			record.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i) * time.Second)))
+			record.Body().SetStr(body)
+		}
+		return bpserver.NewRelayLogs(logs)
+	}
+
+	// a1 reports both records before processors and only the second record at the end of the pipeline. a2 never reports.
+	logger := zaptest.NewLogger(t)
+	relayers := server.NewRelayers(logger)
+	manager := servermocks.NewMockManager(t)
+	manager.On("RequestReport", mock.Anything, "a1", mock.Anything).Run(func(args mock.Arguments) {
+		report := args.Get(2).(protocol.Report)
+		id := report.Snapshot.Endpoint.Header[server.HeaderSessionID][0]
+		logs := reportedLogs("dropped", "kept")
+		if report.Snapshot.Processor == string(bpotel.SnapshotProcessorName) {
+			logs = reportedLogs("kept")
+			logs.OTLP().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+		}
+		go relayers.Logs().SendResult(id, logs)
+	}).Return(nil).Maybe()
+	manager.On("RequestReport", mock.Anything, "a2", mock.Anything).Return(nil).Maybe()
+
+	bindplane := servermocks.NewMockBindPlane(t)
+	bindplane.On("Store").Return(s).Maybe()
+	bindplane.On("Manager").Return(manager).Maybe()
+	bindplane.On("Relayers").Return(relayers).Maybe()
+	bindplane.On("BindPlaneURL").Return("http://localhost:3001").Maybe()
+	bindplane.On("Logger").Return(logger).Maybe()
+	bindplane.On("Audit").Return(audit.NewRecorder(s, "", 0, logger)).Maybe()
+
+	router := gin.Default()
+	AddRestRoutes(router.Group("/", withRole(model.RoleUser)), bindplane)
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+	client := resty.New().SetBaseURL(svr.URL)
+
+	save := func(t *testing.T, request model.PostSnapshotRequest) *model.Snapshot {
+		response := &model.SnapshotResponse{}
+		resp, err := client.R().SetBody(request).SetResult(response).Post("/snapshots")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode(), resp.String())
+		return response.Snapshot
+	}
+
+	before := save(t, model.PostSnapshotRequest{AgentID: "a1", PipelineType: bpotel.Logs, Position: model.MeasurementPositionSourceBeforeProcessors, ResourceName: "source0"})
+	require.Equal(t, "linux:1", before.Configuration)
+	require.Equal(t, 2, before.Count)
+	after := save(t, model.PostSnapshotRequest{AgentID: "a1", PipelineType: bpotel.Logs})
+	require.Equal(t, 1, after.Count)
+	require.Empty(t, after.Position)
+
+	t.Run("lists snapshots", func(t *testing.T) {
+		response := &model.SnapshotsResponse{}
+		resp, err := client.R().SetResult(response).Get("/snapshots")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Len(t, response.Snapshots, 2)
+		require.Equal(t, after.ID, response.Snapshots[0].ID)
+		require.Nil(t, response.Snapshots[0].Data)
+
+		resp, err = client.R().SetQueryParam("agent", "a2").SetResult(response).Get("/snapshots")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Empty(t, response.Snapshots)
+	})
+
+	t.Run("gets snapshot with data", func(t *testing.T) {
+		response := &model.SnapshotResponse{}
+		resp, err := client.R().SetResult(response).Get("/snapshots/" + before.ID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		logs, err := response.Snapshot.Logs()
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		require.Equal(t, "dropped", logs[0].Body)
+
+		resp, err = client.R().Get("/snapshots/missing")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("compares snapshots", func(t *testing.T) {
+		response := &model.SnapshotDiffResponse{}
+		resp, err := client.R().SetQueryParam("to", after.ID).SetResult(response).Get("/snapshots/" + before.ID + "/diff")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode(), resp.String())
+		require.Equal(t, 1, response.Diff.Unchanged)
+		require.Len(t, response.Diff.Removed, 1)
+		require.Equal(t, "dropped", response.Diff.Removed[0].Record["body"])
+		require.Empty(t, response.Diff.Added)
+		require.Empty(t, response.Diff.Changed)
+
+		resp, err = client.R().Get("/snapshots/" + before.ID + "/diff")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetQueryParam("to", "missing").Get("/snapshots/" + before.ID + "/diff")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, invalid := range []model.PostSnapshotRequest{
+			{PipelineType: bpotel.Logs},
+			{AgentID: "a1", PipelineType: "profiles"},
+			{AgentID: "a1", PipelineType: bpotel.Logs, Position: model.MeasurementPositionSourceBeforeProcessors},
+			{AgentID: "a1", PipelineType: bpotel.Logs, Position: model.MeasurementPositionSourceAfterProcessors, ResourceName: "source0"},
+		} {
+			resp, err := client.R().SetBody(invalid).Post("/snapshots")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode(), invalid)
+		}
+
+		resp, err := client.R().SetBody(model.PostSnapshotRequest{AgentID: "missing", PipelineType: bpotel.Logs}).Post("/snapshots")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("agent does not report", func(t *testing.T) {
+		timeout := server.SnapshotTimeout
+		defer func() { server.SnapshotTimeout = timeout }()
+		server.SnapshotTimeout = 100 * time.Millisecond
+
+		resp, err := client.R().SetBody(model.PostSnapshotRequest{AgentID: "a2", PipelineType: bpotel.Logs}).Post("/snapshots")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusGatewayTimeout, resp.StatusCode())
+	})
+
+	t.Run("deletes snapshot", func(t *testing.T) {
+		resp, err := client.R().Delete("/snapshots/" + before.ID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+		resp, err = client.R().Delete("/snapshots/" + before.ID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+		events, err := s.AuditEvents(ctx, model.AuditEventFilter{ResourceKind: model.AuditKindSnapshot})
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		require.Equal(t, model.AuditActionDelete, events[0].Action)
+		require.Equal(t, before.ID, events[0].ResourceName)
+	})
+}
+
+// withRole sets the role of the authenticated user on the request the same way as middleware.ResolveRole
+func withRole(role model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(authenticator.WithRole(c.Request.Context(), role))
+	}
+}
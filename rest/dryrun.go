@@ -0,0 +1,182 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op/model"
+	exposedserver "github.com/observiq/bindplane-op/server"
+	"github.com/observiq/bindplane-op/store"
+)
+
+// dryRunApplyResources determines how ApplyResources would change each resource without modifying the store.
+// Resources are validated with the resourceStore so that they can refer to other resources being applied.
+func dryRunApplyResources(ctx context.Context, bindplane exposedserver.BindPlane, resourceStore model.ResourceStore, resources []model.Resource) ([]model.ResourceStatus, []*model.ResourceDiff) {
+	statuses := make([]model.ResourceStatus, 0, len(resources))
+	diffs := make([]*model.ResourceDiff, 0, len(resources))
+	for _, resource := range resources {
+		diff := dryRunApplyResource(ctx, bindplane, resourceStore, resource)
+		statuses = append(statuses, *model.NewResourceStatusWithReason(resource, diff.Status, diff.Reason))
+		diffs = append(diffs, diff)
+	}
+	return statuses, diffs
+}
+
+func dryRunApplyResource(ctx context.Context, bindplane exposedserver.BindPlane, resourceStore model.ResourceStore, resource model.Resource) *model.ResourceDiff {
+	diff := &model.ResourceDiff{
+		Kind: resource.GetKind(),
+		Name: resource.Name(),
+	}
+
+	warn, err := resource.ValidateWithStore(ctx, resourceStore)
+	if err == nil {
+		if conf, ok := resource.(*model.Configuration); ok {
+			if _, err = conf.Render(ctx, nil, bindplane.BindPlaneURL(), bindplane.BindPlaneInsecureSkipVerify(), resourceStore, model.GetOssOtelHeaders()); err != nil {
+				err = fmt.Errorf("failed to render config: %w", err)
+			}
+		}
+	}
+	if err != nil {
+		diff.Status = model.StatusInvalid
+		diff.Reason = err.Error()
+		store.MaskSensitiveParameters(ctx, resource)
+		diff.Proposed, _ = model.DiffDocument(resource)
+		return diff
+	}
+	diff.Reason = warn
+
+	// read the current resource without masking so that changes to sensitive parameters are detected. only the diff
+	// documents are masked.
+	current, err := currentResource(model.ContextWithoutSensitiveParameterMasking(ctx), bindplane.Store(), resource.GetKind(), resource.Name())
+	if err != nil {
+		diff.Status = model.StatusError
+		diff.Reason = err.Error()
+		return diff
+	}
+
+	if current == nil {
+		diff.Status = model.StatusCreated
+	} else {
+		diff.CurrentVersion = current.Version()
+
+		if err := dryRunCompare(ctx, diff, current, resource); err != nil {
+			diff.Status = model.StatusError
+			diff.Reason = err.Error()
+			return diff
+		}
+		store.MaskSensitiveParameters(ctx, current)
+		diff.Current, _ = model.DiffDocument(current)
+	}
+
+	store.MaskSensitiveParameters(ctx, resource)
+	diff.Proposed, _ = model.DiffDocument(resource)
+	return diff
+}
+
+// dryRunCompare sets the status of the diff to configured or unchanged depending on whether the resource differs from
+// the current resource. The current resource must not be masked.
+func dryRunCompare(ctx context.Context, diff *model.ResourceDiff, current model.Resource, resource model.Resource) error {
+	currentAny, err := toAnyResource(current)
+	if err != nil {
+		return err
+	}
+	if err := store.PreserveSensitiveParameters(ctx, resource, currentAny); err != nil {
+		return err
+	}
+
+	before, err := model.DiffDocument(current)
+	if err != nil {
+		return err
+	}
+	after, err := model.DiffDocument(resource)
+	if err != nil {
+		return err
+	}
+	if before == after {
+		diff.Status = model.StatusUnchanged
+	} else {
+		diff.Status = model.StatusConfigured
+	}
+	return nil
+}
+
+// currentResource returns a copy of the latest version of the resource in the store or nil if it does not exist. A copy
+// is returned so that it can be masked without modifying the store. Sensitive parameters are masked unless the context
+// was created with model.ContextWithoutSensitiveParameterMasking.
+func currentResource(ctx context.Context, s store.Store, kind model.Kind, name string) (model.Resource, error) {
+	var (
+		resource model.Resource
+		err      error
+	)
+	switch kind {
+	case model.KindConfiguration:
+		resource, err = nilIfMissing(s.Configuration(ctx, name))
+	case model.KindSource:
+		resource, err = nilIfMissing(s.Source(ctx, name))
+	case model.KindSourceType:
+		resource, err = nilIfMissing(s.SourceType(ctx, name))
+	case model.KindProcessor:
+		resource, err = nilIfMissing(s.Processor(ctx, name))
+	case model.KindProcessorType:
+		resource, err = nilIfMissing(s.ProcessorType(ctx, name))
+	case model.KindDestination:
+		resource, err = nilIfMissing(s.Destination(ctx, name))
+	case model.KindDestinationType:
+		resource, err = nilIfMissing(s.DestinationType(ctx, name))
+	case model.KindAgentVersion:
+		resource, err = nilIfMissing(s.AgentVersion(ctx, name))
+	case model.KindAlert:
+		resource, err = nilIfMissing(s.Alert(ctx, name))
+	case model.KindWebhook:
+		resource, err = nilIfMissing(s.Webhook(ctx, name))
+	default:
+		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
+	}
+	if err != nil || resource == nil {
+		return nil, err
+	}
+
+	anyResource, err := toAnyResource(resource)
+	if err != nil {
+		return nil, err
+	}
+	return model.ParseResource(anyResource)
+}
+
+// nilIfMissing converts a typed nil resource to an untyped nil
+func nilIfMissing[R model.Resource](r R, err error) (model.Resource, error) {
+	if err != nil {
+		return nil, err
+	}
+	if any(r) == any(*new(R)) {
+		return nil, nil
+	}
+	return r, nil
+}
+
+func toAnyResource(r model.Resource) (*model.AnyResource, error) {
+	data, err := jsoniter.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	anyResource := &model.AnyResource{}
+	if err := jsoniter.Unmarshal(data, anyResource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource: %w", err)
+	}
+	return anyResource, nil
+}
@@ -16,7 +16,9 @@ package server
 
 import (
 	"github.com/observiq/bindplane-op/agent"
+	"github.com/observiq/bindplane-op/audit"
 	"github.com/observiq/bindplane-op/authenticator"
+	"github.com/observiq/bindplane-op/gitsync"
 	"github.com/observiq/bindplane-op/store"
 	"github.com/observiq/bindplane-op/store/stats"
 	"go.uber.org/zap"
@@ -46,9 +48,17 @@ type BindPlane interface {
 	WebsocketURL() string
 	// SecretKey returns the secret key used to authenticate agents with the BindPlane server
 	SecretKey() string
+	// ServerUsername returns the username of the server profile user, which is reserved and can't be used by other users
+	ServerUsername() string
 
 	// Authenticator returns the authenticator for validating user credentials
 	Authenticator() authenticator.Authenticator
+
+	// Audit returns the recorder for changes made through the REST and GraphQL APIs
+	Audit() audit.Recorder
+
+	// GitSync returns the syncer that applies resources from a git repository
+	GitSync() gitsync.Syncer
 }
 
 // Relayers is a wrapper around multiple Relayer instances used for different types of results
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	jsoniter "github.com/json-iterator/go"
 	"github.com/observiq/bindplane-op/agent"
 	"github.com/observiq/bindplane-op/config"
 	"github.com/observiq/bindplane-op/eventbus"
@@ -133,13 +134,31 @@ func (m *DefaultManager) AgentMessages(_ context.Context) eventbus.Source[Messag
 func (m *DefaultManager) Start(ctx context.Context) {
 	m.ManagerCtx, m.CancelManager = context.WithCancelCause(ctx)
 
-	m.Messages = broadcast.NewLocalBroadcast[Message](m.ManagerCtx, m.Logger)
+	m.Messages = m.newMessagesBroadcast(m.ManagerCtx)
 
 	m.StartCleanupAgents()
 	m.StartAgentReporting()
 	m.StartAgentDisconnect()
 }
 
+// PostgresMessagesChannel is the postgres channel used to broadcast agent messages when the postgres event bus is
+// configured
+const PostgresMessagesChannel = "bindplane_agent_messages"
+
+// newMessagesBroadcast returns the broadcast used to deliver agent messages to the server connected to the agent
+func (m *DefaultManager) newMessagesBroadcast(ctx context.Context) broadcast.Broadcast[Message] {
+	if m.config != nil && m.config.EventBusType() == config.EventBusTypePostgres {
+		return broadcast.NewPostgresBroadcast[Message](ctx, m.Logger, m.config.Store.Postgres.ConnectionString(), PostgresMessagesChannel,
+			broadcast.WithParseFunc(func(data []byte) (Message, error) {
+				var message AgentMessage
+				err := jsoniter.Unmarshal(data, &message)
+				return message, err
+			}),
+		)
+	}
+	return broadcast.NewLocalBroadcast[Message](ctx, m.Logger)
+}
+
 // StartCleanupAgents starts a goroutine that will handle agent cleanup.
 // It will stop once the ManagerCtx is closed
 func (m *DefaultManager) StartCleanupAgents() {
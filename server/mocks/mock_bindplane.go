@@ -4,8 +4,12 @@ package mocks
 
 import (
 	agent "github.com/observiq/bindplane-op/agent"
+	audit "github.com/observiq/bindplane-op/audit"
+
 	authenticator "github.com/observiq/bindplane-op/authenticator"
 
+	gitsync "github.com/observiq/bindplane-op/gitsync"
+
 	mock "github.com/stretchr/testify/mock"
 
 	server "github.com/observiq/bindplane-op/server"
@@ -22,6 +26,22 @@ type MockBindPlane struct {
 	mock.Mock
 }
 
+// Audit provides a mock function with given fields:
+func (_m *MockBindPlane) Audit() audit.Recorder {
+	ret := _m.Called()
+
+	var r0 audit.Recorder
+	if rf, ok := ret.Get(0).(func() audit.Recorder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(audit.Recorder)
+		}
+	}
+
+	return r0
+}
+
 // Authenticator provides a mock function with given fields:
 func (_m *MockBindPlane) Authenticator() authenticator.Authenticator {
 	ret := _m.Called()
@@ -66,6 +86,22 @@ func (_m *MockBindPlane) BindPlaneURL() string {
 	return r0
 }
 
+// GitSync provides a mock function with given fields:
+func (_m *MockBindPlane) GitSync() gitsync.Syncer {
+	ret := _m.Called()
+
+	var r0 gitsync.Syncer
+	if rf, ok := ret.Get(0).(func() gitsync.Syncer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gitsync.Syncer)
+		}
+	}
+
+	return r0
+}
+
 // Logger provides a mock function with given fields:
 func (_m *MockBindPlane) Logger() *zap.Logger {
 	ret := _m.Called()
@@ -144,6 +180,20 @@ func (_m *MockBindPlane) SecretKey() string {
 	return r0
 }
 
+// ServerUsername provides a mock function with given fields:
+func (_m *MockBindPlane) ServerUsername() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // Store provides a mock function with given fields:
 func (_m *MockBindPlane) Store() store.Store {
 	ret := _m.Called()
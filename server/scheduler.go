@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/observiq/bindplane-op/alerts"
 	"github.com/observiq/bindplane-op/store"
 	"go.uber.org/zap"
 )
@@ -39,18 +40,36 @@ type defaultScheduler struct {
 	logger   *zap.Logger
 	interval time.Duration
 
+	alertEvaluator alerts.Evaluator
+	alertsInterval time.Duration
+
 	ctx    context.Context
 	cancel context.CancelCauseFunc
 	wg     sync.WaitGroup
 }
 
+// SchedulerOption is an option used to configure the scheduler
+type SchedulerOption func(*defaultScheduler)
+
+// WithAlertEvaluator evaluates alerts at the specified interval
+func WithAlertEvaluator(evaluator alerts.Evaluator, interval time.Duration) SchedulerOption {
+	return func(s *defaultScheduler) {
+		s.alertEvaluator = evaluator
+		s.alertsInterval = interval
+	}
+}
+
 // NewScheduler creates a new scheduler
-func NewScheduler(s store.Store, logger *zap.Logger, interval time.Duration) Scheduler {
-	return &defaultScheduler{
+func NewScheduler(s store.Store, logger *zap.Logger, interval time.Duration, options ...SchedulerOption) Scheduler {
+	scheduler := &defaultScheduler{
 		store:    s,
 		logger:   logger,
 		interval: interval,
 	}
+	for _, option := range options {
+		option(scheduler)
+	}
+	return scheduler
 }
 
 // Start starts the scheduler
@@ -73,6 +92,26 @@ func (s *defaultScheduler) Start(ctx context.Context) {
 			}
 		}
 	}()
+
+	if s.alertEvaluator != nil && s.alertsInterval > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+
+			evaluateAlertsTicker := time.NewTicker(s.alertsInterval)
+			defer evaluateAlertsTicker.Stop()
+			for {
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-evaluateAlertsTicker.C:
+					if err := s.alertEvaluator.EvaluateAlerts(s.ctx); err != nil {
+						s.logger.Error("failed to evaluate alerts", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
 }
 
 // Stop stops the scheduler and all running tasks
@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -47,6 +48,31 @@ func TestScheduler(t *testing.T) {
 	}, 1*time.Second, 100*time.Millisecond)
 }
 
+// testEvaluator counts the number of times alerts are evaluated
+type testEvaluator struct {
+	evaluations atomic.Int32
+}
+
+func (e *testEvaluator) EvaluateAlerts(context.Context) error {
+	e.evaluations.Add(1)
+	return nil
+}
+
+func TestSchedulerAlertEvaluator(t *testing.T) {
+	mockStore := storemocks.NewMockStore(t)
+	mockStore.On("UpdateAllRollouts", mock.Anything).Return(nil).Maybe()
+
+	evaluator := &testEvaluator{}
+	scheduler := NewScheduler(mockStore, zap.NewNop(), time.Hour, WithAlertEvaluator(evaluator, 50*time.Millisecond))
+	scheduler.Start(context.Background())
+
+	require.Eventually(t, func() bool {
+		return evaluator.evaluations.Load() >= 2
+	}, 2*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, scheduler.Stop(context.Background()))
+}
+
 func TestStopSchedulerNoStart(t *testing.T) {
 	mockStore := storemocks.NewMockStore(t)
 	scheduler := NewScheduler(mockStore, zap.NewNop(), config.DefaultRolloutsInterval)
@@ -36,13 +36,17 @@ func Login(ctx *gin.Context, bindplane exposedserver.BindPlane) {
 	}
 
 	loginInfo, err := bindplane.Authenticator().Login(ctx, session)
-	if err != nil {
+	switch {
+	case errors.Is(err, authenticator.ErrLoginRedirect):
+		// the session is saved so that the login can be completed when the identity provider redirects back
+		bindplane.Logger().Debug("redirecting user to identity provider")
+	case err != nil:
 		bindplane.Logger().Error(fmt.Sprintf("failed to authenticate user: %s", loginInfo.Username), zap.Error(err))
 		return
+	default:
+		bindplane.Logger().Info("logging in user.", zap.String("user", loginInfo.Username))
 	}
 
-	bindplane.Logger().Info("logging in user.", zap.String("user", loginInfo.Username))
-
 	// Save and write the session
 	if err := session.Save(ctx.Request, ctx.Writer); err != nil {
 		ctx.AbortWithError(http.StatusInternalServerError, errors.New("failed to save session"))
@@ -91,6 +95,8 @@ func Verify(c *gin.Context, bindplane exposedserver.BindPlane) {
 // AddRoutes adds the login, logout, and verify route used for session authentication.
 func AddRoutes(router gin.IRouter, bindplane exposedserver.BindPlane) {
 	router.POST("/login", func(ctx *gin.Context) { Login(ctx, bindplane) })
+	// /login/sso starts single sign-on with an identity provider and is the redirect URL registered with the provider
+	router.GET("/login/sso", func(ctx *gin.Context) { Login(ctx, bindplane) })
 	router.PUT("/logout", func(ctx *gin.Context) { Logout(ctx, bindplane) })
 	router.GET("/verify", func(ctx *gin.Context) { Verify(ctx, bindplane) })
 }
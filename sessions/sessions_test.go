@@ -56,12 +56,13 @@ func TestAddRoutes(t *testing.T) {
 	mockBatcher := statsmocks.NewMockMeasurementBatcher(t)
 	bindplane := server.NewBindPlane(&config.Config{}, zap.NewNop(), s, nil, mockBatcher)
 
-	t.Run("adds /login /logout and /verify", func(t *testing.T) {
+	t.Run("adds /login /login/sso /logout and /verify", func(t *testing.T) {
 		AddRoutes(router, bindplane)
 
 		routes := router.Routes()
 
 		var hasLogin bool
+		var hasSSOLogin bool
 		var hasLogout bool
 		var hasVerify bool
 
@@ -69,6 +70,8 @@ func TestAddRoutes(t *testing.T) {
 			switch r.Path {
 			case "/login":
 				hasLogin = true
+			case "/login/sso":
+				hasSSOLogin = true
 			case "/logout":
 				hasLogout = true
 			case "/verify":
@@ -77,6 +80,7 @@ func TestAddRoutes(t *testing.T) {
 		}
 
 		require.True(t, hasLogin)
+		require.True(t, hasSSOLogin)
 		require.True(t, hasLogout)
 		require.True(t, hasVerify)
 	})
@@ -229,6 +233,35 @@ func TestLogin(t *testing.T) {
 		Login(ctx, mockServer)
 		require.Equal(t, w.Result().StatusCode, http.StatusOK)
 	})
+
+	t.Run("saves the session when redirecting to the identity provider", func(t *testing.T) {
+		issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"issuer":"http://%[1]s","authorization_endpoint":"http://%[1]s/authorize","token_endpoint":"http://%[1]s/token","jwks_uri":"http://%[1]s/keys"}`, r.Host)
+		}))
+		defer issuer.Close()
+
+		oidcCfg := *cfg
+		oidcCfg.Auth.OIDC = config.OIDC{
+			Issuer:      issuer.URL,
+			ClientID:    "bindplane",
+			RedirectURL: "http://localhost:3001/login/sso",
+		}
+		oidcServer := server.NewBindPlane(&oidcCfg, zap.NewNop(), s, nil, mockBatcher)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/login/sso", nil)
+
+		Login(ctx, oidcServer)
+		ctx.Writer.WriteHeaderNow()
+
+		resp := w.Result()
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		require.True(t, strings.HasPrefix(resp.Header.Get("Location"), issuer.URL+"/authorize?"))
+		require.Len(t, resp.Cookies(), 1)
+		require.Equal(t, authenticator.CookieName, resp.Cookies()[0].Name)
+		require.Equal(t, http.SameSiteLaxMode, resp.Cookies()[0].SameSite)
+	})
 }
 
 type mockCookieStore struct{}
@@ -0,0 +1,61 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// cleanupAuditEventsInterval is the interval at which audit events older than the retention are removed
+const cleanupAuditEventsInterval = time.Hour
+
+// auditEventsCleaner is implemented by stores that can remove old audit events
+type auditEventsCleaner interface {
+	// deleteAuditEventsBefore removes the audit events recorded before the time and returns the number removed
+	deleteAuditEventsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// startAuditEventsCleanup starts the background process that removes audit events older than the retention
+func startAuditEventsCleanup(ctx context.Context, s auditEventsCleaner, retention time.Duration, logger *zap.Logger) {
+	logger.Info("starting audit events cleanup", zap.Duration("retention", retention))
+	go func() {
+		ticker := time.NewTicker(cleanupAuditEventsInterval)
+		defer ticker.Stop()
+
+		for {
+			cleanupAuditEvents(ctx, s, retention, logger)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// cleanupAuditEvents removes the audit events older than the retention
+func cleanupAuditEvents(ctx context.Context, s auditEventsCleaner, retention time.Duration, logger *zap.Logger) {
+	count, err := s.deleteAuditEventsBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.Error("failed to clean up audit events", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		logger.Debug("cleaned up audit events", zap.Int("count", count))
+	}
+}
@@ -0,0 +1,484 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/otlp/record"
+	"golang.org/x/exp/slices"
+)
+
+// BackupFormatVersion is the version of the archive written by Backup.Write. ReadBackup rejects archives with a newer
+// version.
+const BackupFormatVersion = 1
+
+// names of the files in a backup archive
+const (
+	backupManifestFile     = "manifest.json"
+	backupResourcesFile    = "resources.json"
+	backupAgentsFile       = "agents.json"
+	backupMeasurementsFile = "measurements.json"
+)
+
+// ErrMeasurementsBackupNotSupported is returned when measurements are requested in a backup of a store that cannot read
+// its measurements
+var ErrMeasurementsBackupNotSupported = errors.New("store does not support backing up measurements")
+
+// backupKinds are the kinds of resources in a backup in the order they are restored, so that resources are restored
+// before the resources that refer to them
+var backupKinds = []model.Kind{
+	model.KindSourceType,
+	model.KindProcessorType,
+	model.KindDestinationType,
+	model.KindSource,
+	model.KindProcessor,
+	model.KindDestination,
+	model.KindConfiguration,
+	model.KindAgentVersion,
+	model.KindAlert,
+	model.KindWebhook,
+}
+
+// Backup is a copy of the resources, agents, and measurements in a store. It is written as a gzip compressed tar
+// archive and can be restored into any store. Users, API keys, and audit events are not included.
+//
+// Sensitive parameter values are not masked in a backup so that they can be restored. Values that are encrypted at
+// rest stay encrypted and can only be restored into a store with the same encryption key. Backups should be kept as
+// securely as the store itself.
+type Backup struct {
+	// Version is the version of the archive format
+	Version int
+
+	// Time is the time the backup was made
+	Time time.Time
+
+	// Resources contains every version of every resource, sorted by kind, name, and version
+	Resources []*model.AnyResource
+
+	// Agents contains all of the agents
+	Agents []*model.Agent
+
+	// Measurements contains the measurements reported by agents. It is empty if measurements were not included.
+	Measurements []*record.Metric
+}
+
+// backupManifest is the content of manifest.json in a backup archive
+type backupManifest struct {
+	Version int                 `json:"version"`
+	Time    time.Time           `json:"time"`
+	Summary model.BackupSummary `json:"summary"`
+}
+
+// BackupStore is implemented by stores that can read all of their resources, agents, and measurements in a single
+// transaction
+type BackupStore interface {
+	// Backup returns a backup of the store, including measurements if requested
+	Backup(ctx context.Context, includeMeasurements bool) (*Backup, error)
+}
+
+// RestoreStore is implemented by stores that can restore every version of a resource
+type RestoreStore interface {
+	// RestoreResources replaces the resources with the same kind and name, including their history. The newest version
+	// of each resource becomes the latest version and older versions are archived.
+	RestoreResources(ctx context.Context, resources []*model.AnyResource) error
+}
+
+func newBackup() *Backup {
+	return &Backup{
+		Version: BackupFormatVersion,
+		Time:    time.Now().UTC(),
+	}
+}
+
+// NewBackup returns a backup of the store. Stores that implement BackupStore are read in a single transaction. Other
+// stores are read one kind of resource at a time and their measurements cannot be included.
+func NewBackup(ctx context.Context, s Store, includeMeasurements bool) (*Backup, error) {
+	ctx, span := tracer.Start(ctx, "store/NewBackup")
+	defer span.End()
+
+	if backupStore, ok := s.(BackupStore); ok {
+		return backupStore.Backup(ctx, includeMeasurements)
+	}
+	if includeMeasurements {
+		return nil, ErrMeasurementsBackupNotSupported
+	}
+
+	ctx = model.ContextWithoutSensitiveParameterMasking(ctx)
+
+	backup := newBackup()
+	for _, kind := range backupKinds {
+		resources, err := storeResources(ctx, s, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s resources: %w", kind, err)
+		}
+		for _, resource := range resources {
+			versions, err := backupVersions(ctx, s, resource)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get history of %s %s: %w", kind, resource.UniqueKey(), err)
+			}
+			backup.Resources = append(backup.Resources, versions...)
+		}
+	}
+
+	agents, err := s.Agents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	backup.Agents = agents
+
+	backup.sort()
+	return backup, nil
+}
+
+// backupVersions returns all versions of the resource or just the resource if the store doesn't keep its history
+func backupVersions(ctx context.Context, s Store, resource model.Resource) ([]*model.AnyResource, error) {
+	if model.HasVersionKind(resource.GetKind()) {
+		history, err := s.ResourceHistory(ctx, resource.GetKind(), resource.UniqueKey())
+		if err != nil {
+			return nil, err
+		}
+		if len(history) > 0 {
+			return history, nil
+		}
+	}
+	anyResource, err := model.AsAny(resource)
+	if err != nil {
+		return nil, err
+	}
+	return []*model.AnyResource{anyResource}, nil
+}
+
+// storeResources returns all of the resources of the kind in the store
+func storeResources(ctx context.Context, s Store, kind model.Kind) ([]model.Resource, error) {
+	switch kind {
+	case model.KindSourceType:
+		return asResources(s.SourceTypes(ctx))
+	case model.KindProcessorType:
+		return asResources(s.ProcessorTypes(ctx))
+	case model.KindDestinationType:
+		return asResources(s.DestinationTypes(ctx))
+	case model.KindSource:
+		return asResources(s.Sources(ctx))
+	case model.KindProcessor:
+		return asResources(s.Processors(ctx))
+	case model.KindDestination:
+		return asResources(s.Destinations(ctx))
+	case model.KindConfiguration:
+		return asResources(s.Configurations(ctx))
+	case model.KindAgentVersion:
+		return asResources(s.AgentVersions(ctx))
+	case model.KindAlert:
+		return asResources(s.Alerts(ctx))
+	case model.KindWebhook:
+		return asResources(s.Webhooks(ctx))
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+}
+
+func asResources[R model.Resource](items []R, err error) ([]model.Resource, error) {
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]model.Resource, 0, len(items))
+	for _, item := range items {
+		resources = append(resources, item)
+	}
+	return resources, nil
+}
+
+// Summary returns the number of resource versions, agents, and measurements in the backup
+func (b *Backup) Summary() model.BackupSummary {
+	return model.BackupSummary{
+		Resources:    len(b.Resources),
+		Agents:       len(b.Agents),
+		Measurements: len(b.Measurements),
+	}
+}
+
+// Name returns the name of the backup based on its time, e.g. bindplane-backup-20230601-120000
+func (b *Backup) Name() string {
+	return fmt.Sprintf("bindplane-backup-%s", b.Time.UTC().Format("20060102-150405"))
+}
+
+// sort sorts the resources in the order they are restored and the agents by ID
+func (b *Backup) sort() {
+	sortBackupResources(b.Resources)
+	sort.SliceStable(b.Agents, func(i, j int) bool {
+		return b.Agents[i].ID < b.Agents[j].ID
+	})
+}
+
+// sortBackupResources sorts resources by kind in the order they are restored, then by name and version
+func sortBackupResources(resources []*model.AnyResource) {
+	rank := func(kind model.Kind) int {
+		if i := slices.Index(backupKinds, kind); i >= 0 {
+			return i
+		}
+		return len(backupKinds)
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if rank(a.GetKind()) != rank(b.GetKind()) {
+			return rank(a.GetKind()) < rank(b.GetKind())
+		}
+		if a.UniqueKey() != b.UniqueKey() {
+			return a.UniqueKey() < b.UniqueKey()
+		}
+		return a.Version() < b.Version()
+	})
+}
+
+// ----------------------------------------------------------------------
+// archive
+
+// Write writes the backup as a gzip compressed tar archive
+func (b *Backup) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{backupManifestFile, backupManifest{Version: b.Version, Time: b.Time, Summary: b.Summary()}},
+		{backupResourcesFile, b.Resources},
+		{backupAgentsFile, b.Agents},
+		{backupMeasurementsFile, b.Measurements},
+	}
+	for _, file := range files {
+		data, err := jsoniter.Marshal(file.value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", file.name, err)
+		}
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: b.Time,
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		if _, err := archive.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return gz.Close()
+}
+
+// ReadBackup reads a backup written by Backup.Write
+func ReadBackup(r io.Reader) (*Backup, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer gz.Close()
+
+	var manifest *backupManifest
+	backup := &Backup{}
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		var value any
+		switch header.Name {
+		case backupManifestFile:
+			manifest = &backupManifest{}
+			value = manifest
+		case backupResourcesFile:
+			value = &backup.Resources
+		case backupAgentsFile:
+			value = &backup.Agents
+		case backupMeasurementsFile:
+			value = &backup.Measurements
+		default:
+			// ignore unknown files
+			continue
+		}
+		if err := jsoniter.NewDecoder(archive).Decode(value); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("failed to read backup: missing %s", backupManifestFile)
+	}
+	if manifest.Version < 1 || manifest.Version > BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+	backup.Version = manifest.Version
+	backup.Time = manifest.Time
+	return backup, nil
+}
+
+// ----------------------------------------------------------------------
+// restore
+
+// Restore restores the resources, agents, and measurements in the backup. Stores that implement RestoreStore restore
+// every version of each resource. Other stores apply the newest version of each resource as a new version. Restore
+// continues after errors and returns what was restored along with the errors.
+func Restore(ctx context.Context, s Store, backup *Backup) (model.BackupSummary, error) {
+	ctx, span := tracer.Start(ctx, "store/Restore")
+	defer span.End()
+
+	var summary model.BackupSummary
+	var errs error
+
+	resources := slices.Clone(backup.Resources)
+	sortBackupResources(resources)
+
+	if restoreStore, ok := s.(RestoreStore); ok {
+		if err := restoreStore.RestoreResources(ctx, resources); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to restore resources: %w", err))
+		} else {
+			summary.Resources = len(resources)
+		}
+	} else {
+		restored, err := applyBackupResources(ctx, s, resources)
+		summary.Resources = restored
+		errs = errors.Join(errs, err)
+	}
+
+	if len(backup.Agents) > 0 {
+		agents := map[string]*model.Agent{}
+		ids := make([]string, 0, len(backup.Agents))
+		for _, agent := range backup.Agents {
+			agents[agent.ID] = agent
+			ids = append(ids, agent.ID)
+		}
+		restored, err := s.UpsertAgents(ctx, ids, func(current *model.Agent) {
+			if agent, ok := agents[current.ID]; ok {
+				*current = *agent
+			}
+		})
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to restore agents: %w", err))
+		}
+		summary.Agents = len(restored)
+	}
+
+	if len(backup.Measurements) > 0 {
+		if err := s.Measurements().SaveAgentMetrics(ctx, backup.Measurements); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to restore measurements: %w", err))
+		} else {
+			summary.Measurements = len(backup.Measurements)
+		}
+	}
+
+	return summary, errs
+}
+
+// applyBackupResources applies the newest version of each resource and returns the number of resources applied
+func applyBackupResources(ctx context.Context, s Store, resources []*model.AnyResource) (int, error) {
+	groups, err := groupBackupVersions(resources)
+	if err != nil {
+		return 0, err
+	}
+
+	latest := make([]model.Resource, 0, len(groups))
+	for _, group := range groups {
+		latest = append(latest, group.latest)
+	}
+
+	statuses, err := s.ApplyResources(ctx, latest)
+	if err != nil {
+		err = fmt.Errorf("failed to restore resources: %w", err)
+	}
+
+	restored := 0
+	for _, status := range statuses {
+		switch status.Status {
+		case model.StatusInvalid, model.StatusError:
+			err = errors.Join(err, fmt.Errorf("failed to restore %s %s: %s", status.Resource.GetKind(), status.Resource.Name(), status.Reason))
+		default:
+			restored++
+		}
+	}
+	return restored, err
+}
+
+// backupVersionGroup contains the versions of one resource in a backup
+type backupVersionGroup struct {
+	latest   model.Resource
+	archived []model.Resource
+}
+
+// groupBackupVersions groups the versions of each resource, keeping the order of the resources. Resources of the same
+// kind and name with the highest version are the latest version.
+func groupBackupVersions(resources []*model.AnyResource) ([]*backupVersionGroup, error) {
+	var groups []*backupVersionGroup
+	byKey := map[string]*backupVersionGroup{}
+
+	for _, r := range resources {
+		resource, err := parseBackupResource(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", r.GetKind(), r.UniqueKey(), err)
+		}
+
+		key := string(ResourceKey(resource.GetKind(), resource.UniqueKey()))
+		group, ok := byKey[key]
+		switch {
+		case !ok:
+			group = &backupVersionGroup{latest: resource}
+			byKey[key] = group
+			groups = append(groups, group)
+		case resource.Version() > group.latest.Version():
+			group.archived = append(group.archived, group.latest)
+			group.latest = resource
+		default:
+			group.archived = append(group.archived, resource)
+		}
+	}
+	return groups, nil
+}
+
+// parseBackupResource returns the resource as its specific kind of resource. It is marshaled and unmarshaled with json
+// so that the resource matches the resources written by the store.
+func parseBackupResource(r *model.AnyResource) (model.Resource, error) {
+	data, err := jsoniter.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := model.NewEmptyResource(r.GetKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := jsoniter.Unmarshal(data, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,119 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/otlp/record"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupWriteRead(t *testing.T) {
+	destination, err := model.AsAny(model.NewDestination("cabin-1", "cabin", nil))
+	require.NoError(t, err)
+
+	backup := &Backup{
+		Version:      BackupFormatVersion,
+		Time:         time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Resources:    []*model.AnyResource{destination},
+		Agents:       []*model.Agent{{ID: "1", Name: "agent-1"}},
+		Measurements: []*record.Metric{{Name: "otelcol_processor_throughputmeasurement_log_data_size", Value: 1.0}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, backup.Write(&buf))
+
+	read, err := ReadBackup(&buf)
+	require.NoError(t, err)
+	require.Equal(t, backup.Version, read.Version)
+	require.True(t, backup.Time.Equal(read.Time))
+	require.Equal(t, model.BackupSummary{Resources: 1, Agents: 1, Measurements: 1}, read.Summary())
+	require.Equal(t, "cabin-1", read.Resources[0].Name())
+	require.Equal(t, model.KindDestination, read.Resources[0].GetKind())
+	require.Equal(t, "agent-1", read.Agents[0].Name)
+	require.Equal(t, 1.0, read.Measurements[0].Value)
+}
+
+func TestReadBackupErrors(t *testing.T) {
+	// archive writes a backup archive with the files
+	archive := func(t *testing.T, files map[string]string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		w := tar.NewWriter(gz)
+		for name, content := range files {
+			require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+			_, err := w.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		require.NoError(t, gz.Close())
+		return &buf
+	}
+
+	tests := []struct {
+		name      string
+		data      func(t *testing.T) *bytes.Buffer
+		expectErr string
+	}{
+		{
+			name:      "not gzip",
+			data:      func(t *testing.T) *bytes.Buffer { return bytes.NewBufferString("not a backup") },
+			expectErr: "failed to read backup",
+		},
+		{
+			name: "missing manifest",
+			data: func(t *testing.T) *bytes.Buffer {
+				return archive(t, map[string]string{backupResourcesFile: "[]"})
+			},
+			expectErr: "failed to read backup: missing manifest.json",
+		},
+		{
+			name: "newer version",
+			data: func(t *testing.T) *bytes.Buffer {
+				return archive(t, map[string]string{backupManifestFile: `{"version":2}`})
+			},
+			expectErr: "unsupported backup version 2",
+		},
+		{
+			name: "invalid resources",
+			data: func(t *testing.T) *bytes.Buffer {
+				return archive(t, map[string]string{backupManifestFile: `{"version":1}`, backupResourcesFile: "{"})
+			},
+			expectErr: "failed to read resources.json",
+		},
+		{
+			name: "unknown files are ignored",
+			data: func(t *testing.T) *bytes.Buffer {
+				return archive(t, map[string]string{backupManifestFile: `{"version":1}`, "users.json": "{"})
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadBackup(test.data(t))
+			if test.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.expectErr)
+		})
+	}
+}
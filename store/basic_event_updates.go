@@ -519,16 +519,31 @@ func NewEventUpdates() BasicEventUpdates {
 // BuildBasicEventBroadcast returns a BroadCastBuilder that builds a broadcast.Broadcast[BasicUpdates] using routing and broadcast options for oss.
 func BuildBasicEventBroadcast() BroadCastBuilder[BasicEventUpdates] {
 	return func(ctx context.Context, options Options, logger *zap.Logger, maxEventsToMerge int) broadcast.Broadcast[BasicEventUpdates] {
-		return broadcast.NewLocalBroadcast(ctx, logger,
-			broadcast.WithUnboundedChannel[BasicEventUpdates](100*time.Millisecond),
-			broadcast.WithParseFunc(func(data []byte) (BasicEventUpdates, error) {
-				var updates EventUpdates
-				err := jsoniter.Unmarshal(data, &updates)
-				return &updates, err
-			}),
-			broadcast.WithMerge(func(into, single BasicEventUpdates) bool {
-				return into.Merge(single)
-			}, 100*time.Millisecond, maxEventsToMerge),
-		)
+		return broadcast.NewLocalBroadcast(ctx, logger, basicEventBroadcastOptions(maxEventsToMerge)...)
+	}
+}
+
+// BuildPostgresEventBroadcast returns a BroadCastBuilder that builds a broadcast.Broadcast[BasicUpdates] which uses
+// postgres LISTEN/NOTIFY to deliver updates to every server connected to the same database.
+func BuildPostgresEventBroadcast(connectionString string) BroadCastBuilder[BasicEventUpdates] {
+	return func(ctx context.Context, options Options, logger *zap.Logger, maxEventsToMerge int) broadcast.Broadcast[BasicEventUpdates] {
+		return broadcast.NewPostgresBroadcast(ctx, logger, connectionString, PostgresUpdatesChannel, basicEventBroadcastOptions(maxEventsToMerge)...)
+	}
+}
+
+// PostgresUpdatesChannel is the postgres channel used by BuildPostgresEventBroadcast
+const PostgresUpdatesChannel = "bindplane_updates"
+
+func basicEventBroadcastOptions(maxEventsToMerge int) []broadcast.Option[BasicEventUpdates] {
+	return []broadcast.Option[BasicEventUpdates]{
+		broadcast.WithUnboundedChannel[BasicEventUpdates](100 * time.Millisecond),
+		broadcast.WithParseFunc(func(data []byte) (BasicEventUpdates, error) {
+			var updates EventUpdates
+			err := jsoniter.Unmarshal(data, &updates)
+			return &updates, err
+		}),
+		broadcast.WithMerge(func(into, single BasicEventUpdates) bool {
+			return into.Merge(single)
+		}, 100*time.Millisecond, maxEventsToMerge),
 	}
 }
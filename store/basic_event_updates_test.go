@@ -17,6 +17,8 @@ package store
 import (
 	"testing"
 
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op/eventbus/broadcast"
 	"github.com/observiq/bindplane-op/model"
 	"github.com/stretchr/testify/require"
 )
@@ -64,6 +66,31 @@ func TestUpdatesIncludeResource(t *testing.T) {
 	require.Equal(t, configuration, updates.ConfigurationsField[configuration.UniqueKey()].Item)
 }
 
+func TestUpdatesParseFunc(t *testing.T) {
+	agent := &model.Agent{
+		ID:   "test",
+		Name: "agent",
+	}
+	configuration := model.NewConfiguration("config")
+
+	updates := NewEventUpdates()
+	updates.IncludeAgent(agent, EventTypeUpdate)
+	updates.IncludeResource(configuration, EventTypeRemove)
+
+	data, err := jsoniter.Marshal(updates)
+	require.NoError(t, err)
+
+	opts := broadcast.MakeBroadcastOptions(basicEventBroadcastOptions(1))
+	parsed, err := opts.ParseTo(data)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, parsed.Size())
+	require.True(t, parsed.Agents().Contains(agent.UniqueKey(), EventTypeUpdate))
+	require.Equal(t, "agent", parsed.Agents()[agent.UniqueKey()].Item.Name)
+	require.True(t, parsed.Configurations().Contains(configuration.UniqueKey(), EventTypeRemove))
+	require.True(t, parsed.Sources().Empty())
+}
+
 func TestUpdatesEmpty(t *testing.T) {
 	testCases := []struct {
 		name     string
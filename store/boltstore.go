@@ -38,6 +38,10 @@ const (
 	BucketAgents       = "Agents"
 	BucketMeasurements = "Measurements"
 	BucketArchive      = "Archive"
+	BucketUsers        = "Users"
+	BucketAPIKeys      = "APIKeys"
+	BucketAuditEvents  = "AuditEvents"
+	BucketSnapshots    = "Snapshots"
 )
 
 type boltstore struct {
@@ -49,9 +53,39 @@ type boltstore struct {
 
 var _ Store = (*boltstore)(nil)
 var _ ArchiveStore = (*boltstore)(nil)
+var _ BackupStore = (*boltstore)(nil)
+var _ RestoreStore = (*boltstore)(nil)
+var _ EncryptionKeyRotator = (*boltstore)(nil)
 
 // NewBoltStore returns a new store boltstore struct that implements the store.Store interface.
 func NewBoltStore(ctx context.Context, db *bbolt.DB, options Options, logger *zap.Logger) Store {
+	return newBoltstore(ctx, NewBoltDB(db), options, logger)
+}
+
+// newBoltstore returns a boltstore that uses the database. It is used for bbolt and the in-memory database of the
+// mapstore.
+func newBoltstore(ctx context.Context, db BucketDB, options Options, logger *zap.Logger) *boltstore {
+	store := newBoltstoreCore(ctx, db, options, logger)
+
+	// boltstore is not used for clusters, disconnect all agents
+	store.disconnectAllAgents(ctx)
+
+	// start the timer that runs cleanup on measurements
+	if !options.DisableMeasurementsCleanup {
+		// start the timer that runs cleanup on measurements
+		store.StartMeasurements(ctx)
+	}
+	if options.AuditEventsRetention > 0 {
+		startAuditEventsCleanup(ctx, store, options.AuditEventsRetention, logger)
+	}
+	SeedSearchIndexes(ctx, store, logger)
+
+	return store
+}
+
+// newBoltstoreCore returns a boltstore that uses the database without starting any of the background work or seeding
+// the search indexes. It is shared by newBoltstore and NewPostgresStore.
+func newBoltstoreCore(ctx context.Context, db BucketDB, options Options, logger *zap.Logger) *boltstore {
 	store := &boltstore{
 		agentIndex:         search.NewInMemoryIndex("agent"),
 		configurationIndex: search.NewInMemoryIndex("configuration"),
@@ -60,6 +94,8 @@ func NewBoltStore(ctx context.Context, db *bbolt.DB, options Options, logger *za
 			Logger:         logger,
 			RolloutBatcher: NewNopRolloutBatcher(),
 			SessionStorage: NewBPCookieStore(options.SessionsSecret),
+			Cipher:         options.ParameterCipher,
+			Retention:      options.measurementsRetention(),
 		},
 	}
 
@@ -68,21 +104,11 @@ func NewBoltStore(ctx context.Context, db *bbolt.DB, options Options, logger *za
 	// 	// Assign a real batcher if we are not disabling rollout updater
 	// 	store.RolloutBatcher = NewDefaultBatcher(ctx, logger, DefaultRolloutBatchFlushInterval, store)
 	// }
-	store.StoreUpdates = NewUpdates(ctx, options, logger, store.RolloutBatcher, BuildBasicEventBroadcast())
+	store.StoreUpdates = NewUpdates(ctx, options, logger, store.RolloutBatcher, options.eventBroadcast())
 
 	// it might seem unintuitive, but it's important to point the boltstoreCommon interface to the store
 	store.BoltstoreCommon = store
 
-	// boltstore is not used for clusters, disconnect all agents
-	store.disconnectAllAgents(ctx)
-
-	// start the timer that runs cleanup on measurements
-	if !options.DisableMeasurementsCleanup {
-		// start the timer that runs cleanup on measurements
-		store.StartMeasurements(ctx)
-	}
-	SeedSearchIndexes(ctx, store, logger)
-
 	return store
 }
 
@@ -93,25 +119,36 @@ func InitBoltstoreDB(storageFilePath string) (*bbolt.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error while opening bbolt storage file: %s, %w", storageFilePath, err)
 	}
+	if err := initBuckets(NewBoltDB(db)); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
 
+// initBuckets creates the buckets used by boltstore if they don't exist
+func initBuckets(db BucketDB) error {
 	buckets := []string{
 		BucketResources,
 		BucketAgents,
 		BucketMeasurements,
 		BucketArchive,
+		BucketUsers,
+		BucketAPIKeys,
+		BucketAuditEvents,
+		BucketSnapshots,
 	}
 
-	err = db.Update(func(tx *bbolt.Tx) error {
+	err := db.Update(func(tx BucketTx) error {
 		for _, bucket := range buckets {
 			_, _ = tx.CreateBucketIfNotExists([]byte(bucket))
 		}
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to create bbolt storage bucket: %w", err)
+		return fmt.Errorf("unable to create bbolt storage bucket: %w", err)
 	}
 
-	err = db.Update(func(tx *bbolt.Tx) error {
+	err = db.Update(func(tx BucketTx) error {
 		b := tx.Bucket([]byte(BucketMeasurements))
 		for _, metric := range stats.SupportedMetricNames {
 			_, _ = b.CreateBucketIfNotExists([]byte(metric))
@@ -119,10 +156,9 @@ func InitBoltstoreDB(storageFilePath string) (*bbolt.DB, error) {
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to create bbolt metrics bucket: %w", err)
+		return fmt.Errorf("unable to create bbolt metrics bucket: %w", err)
 	}
-
-	return db, nil
+	return nil
 }
 
 func (s *boltstore) Close() error {
@@ -141,6 +177,8 @@ func (s *boltstore) Close() error {
 // Apply resources iterates through a slice of resources, then adds them to storage,
 // and calls notify updates on the updated resources.
 func (s *boltstore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	defer measureOperation(ctx, "ApplyResources")()
+
 	updates, ctx, shouldNotify := UpdatesForContext(ctx)
 
 	// resourceStatuses to return for the applied resources
@@ -155,7 +193,7 @@ func (s *boltstore) ApplyResources(ctx context.Context, resources []model.Resour
 			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusInvalid, err.Error()))
 			continue
 		}
-		err = s.DB.Update(func(tx *bbolt.Tx) error {
+		err = s.DB.Update(func(tx BucketTx) error {
 			// update the resource in the database
 			status, err := UpsertResource(ctx, s, tx, resource)
 			if err != nil {
@@ -270,7 +308,7 @@ func (s *boltstore) CreateEventUpdate() BasicEventUpdates {
 // Clear clears the db store of resources, agents, and tasks.  Mostly used for testing.
 func (s *boltstore) Clear() {
 	// Disregarding error from update because these actions errors are known and prevented
-	_ = s.DB.Update(func(tx *bbolt.Tx) error {
+	_ = s.DB.Update(func(tx BucketTx) error {
 		// Delete all the buckets.
 		// Disregarding errors because it will only error if the bucket doesn't exist
 		// or isn't a bucket key - which we're confident its not.
@@ -278,6 +316,10 @@ func (s *boltstore) Clear() {
 		_ = tx.DeleteBucket([]byte(BucketAgents))
 		_ = tx.DeleteBucket([]byte(BucketMeasurements))
 		_ = tx.DeleteBucket([]byte(BucketArchive))
+		_ = tx.DeleteBucket([]byte(BucketUsers))
+		_ = tx.DeleteBucket([]byte(BucketAPIKeys))
+		_ = tx.DeleteBucket([]byte(BucketAuditEvents))
+		_ = tx.DeleteBucket([]byte(BucketSnapshots))
 
 		// create them again
 		// Disregarding errors because bucket names are valid.
@@ -285,6 +327,10 @@ func (s *boltstore) Clear() {
 		_, _ = tx.CreateBucketIfNotExists([]byte(BucketAgents))
 		b, _ := tx.CreateBucketIfNotExists([]byte(BucketMeasurements))
 		_, _ = tx.CreateBucketIfNotExists([]byte(BucketArchive))
+		_, _ = tx.CreateBucketIfNotExists([]byte(BucketUsers))
+		_, _ = tx.CreateBucketIfNotExists([]byte(BucketAPIKeys))
+		_, _ = tx.CreateBucketIfNotExists([]byte(BucketAuditEvents))
+		_, _ = tx.CreateBucketIfNotExists([]byte(BucketSnapshots))
 
 		for _, metric := range stats.SupportedMetricNames {
 			_, _ = b.CreateBucketIfNotExists([]byte(metric))
@@ -297,7 +343,7 @@ func (s *boltstore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mod
 	updates := s.CreateEventUpdate()
 	deleted := make([]*model.Agent, 0, len(agentIDs))
 
-	err := s.DB.Update(func(tx *bbolt.Tx) error {
+	err := s.DB.Update(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -354,6 +400,219 @@ func (s *boltstore) UserSessions() sessions.Store {
 	return s.SessionStorage
 }
 
+// User returns the user with the specified name. If the user does not exist, nil is returned with no error.
+func (s *boltstore) User(_ context.Context, name string) (*model.User, error) {
+	user, err := boltGet[model.User](s.DB, BucketUsers, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get user %s: %w", name, err)
+	}
+	return user, nil
+}
+
+// Users returns all users sorted by name
+func (s *boltstore) Users(_ context.Context) ([]*model.User, error) {
+	users, err := boltList[model.User](s.DB, BucketUsers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list users: %w", err)
+	}
+	return users, nil
+}
+
+// UpsertUser adds the user or replaces an existing user with the same name
+func (s *boltstore) UpsertUser(_ context.Context, user *model.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	if err := boltPut(s.DB, BucketUsers, user.Name, user); err != nil {
+		return fmt.Errorf("unable to upsert user %s: %w", user.Name, err)
+	}
+	return nil
+}
+
+// DeleteUser removes the user with the specified name and returns it
+func (s *boltstore) DeleteUser(_ context.Context, name string) (*model.User, error) {
+	user, err := boltDelete[model.User](s.DB, BucketUsers, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete user %s: %w", name, err)
+	}
+	return user, nil
+}
+
+// APIKey returns the API key with the specified ID. If the key does not exist, nil is returned with no error.
+func (s *boltstore) APIKey(_ context.Context, id string) (*model.APIKey, error) {
+	apiKey, err := boltGet[model.APIKey](s.DB, BucketAPIKeys, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get api key %s: %w", id, err)
+	}
+	return apiKey, nil
+}
+
+// APIKeys returns all API keys sorted by ID
+func (s *boltstore) APIKeys(_ context.Context) ([]*model.APIKey, error) {
+	apiKeys, err := boltList[model.APIKey](s.DB, BucketAPIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list api keys: %w", err)
+	}
+	return apiKeys, nil
+}
+
+// UpsertAPIKey adds the API key or replaces an existing key with the same ID
+func (s *boltstore) UpsertAPIKey(_ context.Context, apiKey *model.APIKey) error {
+	if err := apiKey.Validate(); err != nil {
+		return err
+	}
+	if err := boltPut(s.DB, BucketAPIKeys, apiKey.ID, apiKey); err != nil {
+		return fmt.Errorf("unable to upsert api key %s: %w", apiKey.ID, err)
+	}
+	return nil
+}
+
+// DeleteAPIKey removes the API key with the specified ID and returns it
+func (s *boltstore) DeleteAPIKey(_ context.Context, id string) (*model.APIKey, error) {
+	apiKey, err := boltDelete[model.APIKey](s.DB, BucketAPIKeys, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete api key %s: %w", id, err)
+	}
+	return apiKey, nil
+}
+
+// AddAuditEvents records the audit events
+func (s *boltstore) AddAuditEvents(_ context.Context, events []*model.AuditEvent) error {
+	err := s.DB.Update(func(tx BucketTx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(BucketAuditEvents))
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			data, err := jsoniter.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(event.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add audit events: %w", err)
+	}
+	return nil
+}
+
+// AuditEvents returns the audit events that match the filter, starting with the most recent
+func (s *boltstore) AuditEvents(_ context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	events := []*model.AuditEvent{}
+	err := s.DB.View(func(tx BucketTx) error {
+		bucket := tx.Bucket([]byte(BucketAuditEvents))
+		if bucket == nil {
+			return nil
+		}
+
+		// event IDs sort by time so the most recent events are at the end of the bucket and the time range is a range
+		// of keys
+		var since []byte
+		if !filter.Since.IsZero() {
+			since = []byte(model.OrderedIDPrefix(filter.Since))
+		}
+		cursor := bucket.Cursor()
+		k, v := cursor.Last()
+		if !filter.Until.IsZero() {
+			if k, _ = cursor.Seek([]byte(model.OrderedIDPrefix(filter.Until))); k == nil {
+				k, v = cursor.Last()
+			} else {
+				k, v = cursor.Prev()
+			}
+		}
+		for ; k != nil; k, v = cursor.Prev() {
+			if filter.Limit > 0 && len(events) >= filter.Limit {
+				break
+			}
+			if since != nil && bytes.Compare(k, since) < 0 {
+				break
+			}
+			event := &model.AuditEvent{}
+			if err := jsoniter.Unmarshal(v, event); err != nil {
+				return err
+			}
+			if filter.Matches(event) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list audit events: %w", err)
+	}
+	return events, nil
+}
+
+// deleteAuditEventsBefore removes the audit events recorded before the time
+func (s *boltstore) deleteAuditEventsBefore(_ context.Context, before time.Time) (int, error) {
+	prefix := []byte(model.OrderedIDPrefix(before))
+	count := 0
+	err := s.DB.Update(func(tx BucketTx) error {
+		bucket := tx.Bucket([]byte(BucketAuditEvents))
+		if bucket == nil {
+			return nil
+		}
+
+		// collect the keys first because the cursor is invalidated by changes to the bucket
+		var keys [][]byte
+		cursor := bucket.Cursor()
+		for k, _ := cursor.First(); k != nil && bytes.Compare(k, prefix) < 0; k, _ = cursor.Next() {
+			keys = append(keys, bytes.Clone(k))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		count = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete audit events: %w", err)
+	}
+	return count, nil
+}
+
+// SaveSnapshot saves the snapshot or replaces an existing snapshot with the same ID
+func (s *boltstore) SaveSnapshot(_ context.Context, snapshot *model.Snapshot) error {
+	if err := boltPut(s.DB, BucketSnapshots, snapshot.ID, snapshot); err != nil {
+		return fmt.Errorf("unable to save snapshot %s: %w", snapshot.ID, err)
+	}
+	return nil
+}
+
+// Snapshots returns the summaries of all snapshots without their data, starting with the most recent
+func (s *boltstore) Snapshots(_ context.Context) ([]*model.Snapshot, error) {
+	snapshots, err := boltList[model.Snapshot](s.DB, BucketSnapshots)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list snapshots: %w", err)
+	}
+	return snapshotSummaries(snapshots), nil
+}
+
+// Snapshot returns the snapshot with the specified ID including its data. If the snapshot does not exist, nil is
+// returned with no error.
+func (s *boltstore) Snapshot(_ context.Context, id string) (*model.Snapshot, error) {
+	snapshot, err := boltGet[model.Snapshot](s.DB, BucketSnapshots, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot %s: %w", id, err)
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot removes the snapshot with the specified ID and returns it
+func (s *boltstore) DeleteSnapshot(_ context.Context, id string) (*model.Snapshot, error) {
+	snapshot, err := boltDelete[model.Snapshot](s.DB, BucketSnapshots, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete snapshot %s: %w", id, err)
+	}
+	return snapshot, nil
+}
+
 // Measurements stores stats for agents and configurations
 func (s *boltstore) Measurements() stats.Measurements {
 	return s
@@ -411,7 +670,7 @@ func KeyFromResource(r model.Resource) []byte {
 /* ------- These helper functions happen inside of a bbolt transaction ------ */
 
 // UpsertResource upserts a resource into the store.  If the resource already exists, it will be updated.
-func UpsertResource(ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, r model.Resource) (model.UpdateStatus, error) {
+func UpsertResource(ctx context.Context, s BoltstoreCommon, tx BucketTx, r model.Resource) (model.UpdateStatus, error) {
 	key := KeyFromResource(r)
 	bucket, err := s.ResourcesBucket(ctx, tx, r.GetKind())
 	if err != nil {
@@ -419,9 +678,19 @@ func UpsertResource(ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, r mode
 		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
 	}
 
+	// encrypted values are only accepted if they are unchanged. they are decrypted before the hash is calculated.
+	stored := bucket.Get(key)
+	if err := s.ParameterCipher().decryptUnchangedValues(r, stored); err != nil {
+		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
+
 	r.EnsureMetadata(r.GetSpec())
 
-	existing := bucket.Get(key)
+	// decrypt the existing resource so that it can be compared with the new resource
+	existing, err := s.ParameterCipher().decryptData(r.GetKind(), stored)
+	if err != nil {
+		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
 	hasExisting := len(existing) > 0
 	var cur *model.AnyResource
 
@@ -458,7 +727,7 @@ func UpsertResource(ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, r mode
 // UpdateResource updates a resource in the store. If the resource does not exist, model.StatusUnchanged is returned
 // with the error ErrResourceMissing. If the resource does exist, it is updated using the updater function and the
 // updated resource is returned.
-func UpdateResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, kind model.Kind, name string, updater func(R) error) (r R, status model.UpdateStatus, err error) {
+func UpdateResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx BucketTx, kind model.Kind, name string, updater func(R) error) (r R, status model.UpdateStatus, err error) {
 	key := ResourceKey(kind, name)
 	bucket, err := s.ResourcesBucket(ctx, tx, kind)
 	if err != nil {
@@ -466,7 +735,10 @@ func UpdateResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx
 		return r, model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
 	}
 
-	existing := bucket.Get(key)
+	existing, err := s.ParameterCipher().decryptData(kind, bucket.Get(key))
+	if err != nil {
+		return r, model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
 	hasExisting := len(existing) > 0
 	if !hasExisting {
 		return r, model.StatusUnchanged, ErrStoreResourceMissing
@@ -499,7 +771,7 @@ func UpdateResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx
 // updateOrUpsertAgentTx is a transaction helper that updates the given agent,
 // puts it into the agent bucket and includes it in the passed updates.
 // it does *not* update the search index or notify any subscribers of updates.
-func (s *BoltstoreCore) updateOrUpsertAgentTx(ctx context.Context, requireExists bool, bucket *bbolt.Bucket, agentID string, updater AgentUpdater, updates BasicEventUpdates) (*model.Agent, error) {
+func (s *BoltstoreCore) updateOrUpsertAgentTx(ctx context.Context, requireExists bool, bucket Bucket, agentID string, updater AgentUpdater, updates BasicEventUpdates) (*model.Agent, error) {
 	key := AgentKey(agentID)
 
 	agentEventType := EventTypeInsert
@@ -563,20 +835,20 @@ func (s *BoltstoreCore) updateOrUpsertAgentTx(ctx context.Context, requireExists
 
 var _ BoltstoreCommon = (*boltstore)(nil)
 
-func (s *boltstore) AgentsBucket(_ context.Context, tx *bbolt.Tx) (*bbolt.Bucket, error) {
+func (s *boltstore) AgentsBucket(_ context.Context, tx BucketTx) (Bucket, error) {
 	return tx.Bucket([]byte(BucketAgents)), nil
 }
-func (s *boltstore) MeasurementsBucket(_ context.Context, tx *bbolt.Tx, metric string) (*bbolt.Bucket, error) {
+func (s *boltstore) MeasurementsBucket(_ context.Context, tx BucketTx, metric string) (Bucket, error) {
 	b := tx.Bucket([]byte(BucketMeasurements))
 	if b != nil {
 		return b.Bucket([]byte(metric)), nil
 	}
 	return nil, nil
 }
-func (s *boltstore) ResourcesBucket(_ context.Context, tx *bbolt.Tx, _ model.Kind) (*bbolt.Bucket, error) {
+func (s *boltstore) ResourcesBucket(_ context.Context, tx BucketTx, _ model.Kind) (Bucket, error) {
 	return tx.Bucket([]byte(BucketResources)), nil
 }
-func (s *boltstore) ArchiveBucket(_ context.Context, tx *bbolt.Tx) (*bbolt.Bucket, error) {
+func (s *boltstore) ArchiveBucket(_ context.Context, tx BucketTx) (Bucket, error) {
 	return tx.Bucket([]byte(BucketArchive)), nil
 }
 func (s *boltstore) ResourceKey(r model.Resource) []byte {
@@ -592,11 +864,88 @@ func (s *boltstore) ZapLogger() *zap.Logger {
 	return s.Logger
 }
 
+// ----------------------------------------------------------------------
+// generic json document accessors used for users, api keys, and snapshots
+
+// boltGet returns the document with the specified key or nil if it does not exist
+func boltGet[T any](db BucketDB, bucket, key string) (*T, error) {
+	var result *T
+	err := db.View(func(tx BucketTx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		data := b.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		result = new(T)
+		return jsoniter.Unmarshal(data, result)
+	})
+	return result, err
+}
+
+// boltList returns all of the documents in the bucket sorted by key
+func boltList[T any](db BucketDB, bucket string) ([]*T, error) {
+	results := []*T{}
+	err := db.View(func(tx BucketTx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			result := new(T)
+			if err := jsoniter.Unmarshal(v, result); err != nil {
+				return err
+			}
+			results = append(results, result)
+			return nil
+		})
+	})
+	return results, err
+}
+
+// boltPut stores the document with the specified key, creating the bucket if necessary
+func boltPut(db BucketDB, bucket, key string, value any) error {
+	data, err := jsoniter.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx BucketTx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), data)
+	})
+}
+
+// boltDelete removes the document with the specified key and returns it or nil if it does not exist
+func boltDelete[T any](db BucketDB, bucket, key string) (*T, error) {
+	var result *T
+	err := db.Update(func(tx BucketTx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		data := b.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		result = new(T)
+		if err := jsoniter.Unmarshal(data, result); err != nil {
+			return err
+		}
+		return b.Delete([]byte(key))
+	})
+	return result, err
+}
+
 // ----------------------------------------------------------------------
 // generic resource accessors
 
 // FindResource finds a resource by kind and unique key. If the resource is versioned, the latest version is returned.
-func FindResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, kind model.Kind, uniqueKey string) (resource R, key []byte, bucket *bbolt.Bucket, exists bool, err error) {
+func FindResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx BucketTx, kind model.Kind, uniqueKey string) (resource R, key []byte, bucket Bucket, exists bool, err error) {
 	uniqueKey, version := model.SplitVersion(uniqueKey)
 
 	// start with the latest version from the resources bucket
@@ -610,7 +959,7 @@ func FindResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *
 		return
 	}
 	exists = true
-	err = jsoniter.Unmarshal(data, &resource)
+	err = s.ParameterCipher().unmarshalResource(data, &resource)
 	if err != nil {
 		return
 	}
@@ -684,7 +1033,7 @@ func FindResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *
 		return
 	}
 	exists = true
-	err = jsoniter.Unmarshal(data, &archiveResource)
+	err = s.ParameterCipher().unmarshalResource(data, &archiveResource)
 	if err != nil {
 		return
 	}
@@ -697,7 +1046,7 @@ func FindResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *
 
 // Resource returns a resource of the given kind and unique key.
 func Resource[R model.Resource](ctx context.Context, s BoltstoreCommon, kind model.Kind, uniqueKey string) (resource R, exists bool, err error) {
-	err = s.Database().View(func(tx *bbolt.Tx) error {
+	err = s.Database().View(func(tx BucketTx) error {
 		resource, _, _, exists, err = FindResource[R](ctx, s, tx, kind, uniqueKey)
 		return err
 	})
@@ -722,7 +1071,7 @@ func resourcesWithFilter[R model.Resource](ctx context.Context, s BoltstoreCommo
 		}
 	}
 
-	err := s.Database().View(func(tx *bbolt.Tx) error {
+	err := s.Database().View(func(tx BucketTx) error {
 		prefix := ResourcesPrefix(kind)
 		bucket, err := s.ResourcesBucket(ctx, tx, kind)
 		if err != nil {
@@ -732,7 +1081,7 @@ func resourcesWithFilter[R model.Resource](ctx context.Context, s BoltstoreCommo
 
 		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
 			var resource R
-			if err := jsoniter.Unmarshal(v, &resource); err != nil {
+			if err := s.ParameterCipher().unmarshalResource(v, &resource); err != nil {
 				// TODO(andy): if it can't be unmarshaled, it should probably be removed from the store. ignore it for now.
 				s.ZapLogger().Error("failed to unmarshal resource", zap.String("key", string(k)), zap.String("kind", string(kind)), zap.Error(err))
 				continue
@@ -793,7 +1142,7 @@ func DeleteResourceAndNotify[R model.Resource](ctx context.Context, s BoltstoreC
 func DeleteResource[R model.Resource](ctx context.Context, s BoltstoreCommon, kind model.Kind, uniqueKey string, emptyResource R) (resource R, exists bool, err error) {
 	var dependencies DependentResources
 
-	err = s.Database().Update(func(tx *bbolt.Tx) error {
+	err = s.Database().Update(func(tx BucketTx) error {
 		key := ResourceKey(kind, uniqueKey)
 		bucket, err := s.ResourcesBucket(ctx, tx, kind)
 		if err != nil {
@@ -804,7 +1153,7 @@ func DeleteResource[R model.Resource](ctx context.Context, s BoltstoreCommon, ki
 
 		if bytes.Equal(k, key) {
 			// populate the emptyResource with the data before deleting
-			err := jsoniter.Unmarshal(v, emptyResource)
+			err := s.ParameterCipher().unmarshalResource(v, emptyResource)
 			if err != nil {
 				return err
 			}
@@ -866,12 +1215,12 @@ func DeleteResource[R model.Resource](ctx context.Context, s BoltstoreCommon, ki
 // new version of a resource and is meant to only be used by the store when modifying the status of an existing
 // resource. The updater function is called with the resource and should modify it in place. If the resource does not
 // exist, ErrResourceMissing is returned. If tx is nil, a new transaction is created and committed.
-func editResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, kind model.Kind, uniqueKey string, updater func(resource R) error) (resource R, wasModified bool, err error) {
-	updateUsingTx := func(tx *bbolt.Tx) error {
+func editResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx BucketTx, kind model.Kind, uniqueKey string, updater func(resource R) error) (resource R, wasModified bool, err error) {
+	updateUsingTx := func(tx BucketTx) error {
 		// find the existing resource
 		var (
 			key    []byte
-			bucket *bbolt.Bucket
+			bucket Bucket
 			exists bool
 		)
 		// don't mask sensitive parameters when updating a resource
@@ -916,6 +1265,10 @@ func editResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *
 			return nil
 		}
 
+		data, err = s.ParameterCipher().marshalResource(resource)
+		if err != nil {
+			return fmt.Errorf("error marshaling resource: %w", err)
+		}
 		err = bucket.Put(key, data)
 		if err != nil {
 			return fmt.Errorf("error storing resource: %w", err)
@@ -948,7 +1301,7 @@ func editResource[R model.Resource](ctx context.Context, s BoltstoreCommon, tx *
 }
 
 // storeResource handles storing a resource and archiving the existing resource if it is versioned.
-func storeResource(ctx context.Context, s BoltstoreCommon, bucket *bbolt.Bucket, tx *bbolt.Tx, curBytes []byte, curResource *model.AnyResource, newResource model.Resource) (model.UpdateStatus, error) {
+func storeResource(ctx context.Context, s BoltstoreCommon, bucket Bucket, tx BucketTx, curBytes []byte, curResource *model.AnyResource, newResource model.Resource) (model.UpdateStatus, error) {
 	if curResource != nil {
 		// preserve sensitive parameter values
 		if err := PreserveSensitiveParameters(ctx, newResource, curResource); err != nil {
@@ -978,7 +1331,7 @@ func storeResource(ctx context.Context, s BoltstoreCommon, bucket *bbolt.Bucket,
 			}
 
 			// marshal before attempting to archive
-			existing, err := jsoniter.Marshal(curResource)
+			existing, err := s.ParameterCipher().marshalResource(curResource)
 			if err != nil {
 				// error, status unchanged
 				return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
@@ -1005,7 +1358,7 @@ func storeResource(ctx context.Context, s BoltstoreCommon, bucket *bbolt.Bucket,
 	newResource.SetDateModified(&now)
 	newResource.SetLatest(true)
 
-	data, err := jsoniter.Marshal(newResource)
+	data, err := s.ParameterCipher().marshalResource(newResource)
 	if err != nil {
 		// error, status unchanged
 		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
@@ -1024,7 +1377,7 @@ func storeResource(ctx context.Context, s BoltstoreCommon, bucket *bbolt.Bucket,
 	return model.StatusConfigured, nil
 }
 
-func archiveResource(ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, r model.Resource, data []byte) error {
+func archiveResource(ctx context.Context, s BoltstoreCommon, tx BucketTx, r model.Resource, data []byte) error {
 	key := archiveKeyFromResource(r)
 	bucket, err := s.ArchiveBucket(ctx, tx)
 	if err != nil || bucket == nil {
@@ -1036,7 +1389,7 @@ func archiveResource(ctx context.Context, s BoltstoreCommon, tx *bbolt.Tx, r mod
 func resourceHistory[R model.Resource](ctx context.Context, s BoltstoreCommon, kind model.Kind, uniqueKey string) ([]R, error) {
 	var history []R
 
-	err := s.Database().View(func(tx *bbolt.Tx) error {
+	err := s.Database().View(func(tx BucketTx) error {
 		uniqueKey, _ := model.SplitVersion(uniqueKey)
 
 		// start with the latest version from the resources bucket
@@ -1050,7 +1403,7 @@ func resourceHistory[R model.Resource](ctx context.Context, s BoltstoreCommon, k
 			return nil
 		}
 		var resource R
-		err = jsoniter.Unmarshal(data, &resource)
+		err = s.ParameterCipher().unmarshalResource(data, &resource)
 		if err != nil {
 			return err
 		}
@@ -1086,7 +1439,7 @@ func resourceHistory[R model.Resource](ctx context.Context, s BoltstoreCommon, k
 		cursor := bucket.Cursor()
 		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
 			var archiveResource R
-			err = jsoniter.Unmarshal(v, &archiveResource)
+			err = s.ParameterCipher().unmarshalResource(v, &archiveResource)
 			if err != nil {
 				return err
 			}
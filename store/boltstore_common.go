@@ -31,7 +31,6 @@ import (
 	"github.com/observiq/bindplane-op/otlp/record"
 	"github.com/observiq/bindplane-op/store/search"
 	"github.com/observiq/bindplane-op/store/stats"
-	"go.etcd.io/bbolt"
 	"go.uber.org/zap"
 )
 
@@ -43,27 +42,35 @@ const (
 // BoltstoreCore is an implementation of the store interface that uses BoltDB as the underlying storage mechanism
 type BoltstoreCore struct {
 	StoreUpdates   *Updates
-	DB             *bbolt.DB
+	DB             BucketDB
 	Logger         *zap.Logger
 	SessionStorage sessions.Store
 	RolloutBatcher RolloutBatcher
+	Cipher         *ParameterCipher
+	Retention      []stats.RetentionTier
 	sync.RWMutex
 	BoltstoreCommon
 }
 
 // BoltstoreCommon is an interface for common implementation details between different boltstore implementations
 type BoltstoreCommon interface {
-	Database() *bbolt.DB
-	AgentsBucket(ctx context.Context, tx *bbolt.Tx) (*bbolt.Bucket, error)
-	MeasurementsBucket(ctx context.Context, tx *bbolt.Tx, metric string) (*bbolt.Bucket, error)
-	ResourcesBucket(ctx context.Context, tx *bbolt.Tx, kind model.Kind) (*bbolt.Bucket, error)
-	ArchiveBucket(ctx context.Context, tx *bbolt.Tx) (*bbolt.Bucket, error)
+	Database() BucketDB
+	AgentsBucket(ctx context.Context, tx BucketTx) (Bucket, error)
+	MeasurementsBucket(ctx context.Context, tx BucketTx, metric string) (Bucket, error)
+	ResourcesBucket(ctx context.Context, tx BucketTx, kind model.Kind) (Bucket, error)
+	ArchiveBucket(ctx context.Context, tx BucketTx) (Bucket, error)
 	ResourceKey(r model.Resource) []byte
 	AgentsIndex(ctx context.Context) search.Index
 	ConfigurationsIndex(ctx context.Context) search.Index
 	ZapLogger() *zap.Logger
 	Notify(ctx context.Context, updates BasicEventUpdates)
 	CreateEventUpdate() BasicEventUpdates
+	ParameterCipher() *ParameterCipher
+}
+
+// ParameterCipher returns the cipher used to encrypt sensitive parameter values or nil if they are stored in plaintext
+func (s *BoltstoreCore) ParameterCipher() *ParameterCipher {
+	return s.Cipher
 }
 
 // AgentConfiguration returns the configuration that should be applied to an agent.
@@ -102,7 +109,7 @@ func (s *BoltstoreCore) FindAgentConfiguration(ctx context.Context, agent *model
 
 	var matchingConfiguration *model.Configuration
 
-	err := s.DB.View(func(tx *bbolt.Tx) error {
+	err := s.DB.View(func(tx BucketTx) error {
 		// iterate over the configurations looking for one that applies
 		prefix := []byte(model.KindConfiguration)
 		bucket, err := s.ResourcesBucket(ctx, tx, model.KindConfiguration)
@@ -113,7 +120,7 @@ func (s *BoltstoreCore) FindAgentConfiguration(ctx context.Context, agent *model
 
 		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
 			configuration := &model.Configuration{}
-			if err := jsoniter.Unmarshal(v, configuration); err != nil {
+			if err := s.Cipher.unmarshalResource(v, configuration); err != nil {
 				s.ZapLogger().Error("unable to unmarshal configuration, ignoring", zap.Error(err))
 				continue
 			}
@@ -203,6 +210,7 @@ func (s *BoltstoreCore) Updates(_ context.Context) eventbus.Source[BasicEventUpd
 // Sends any successful pipeline deletes to the pipelineDeletes channel, to be handled by the manager.
 // Exporter and receiver deletes are sent to the manager via notifyUpdates.
 func (s *boltstore) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	defer measureOperation(ctx, "DeleteResources")()
 	return s.DeleteResourcesCore(ctx, resources)
 }
 
@@ -253,6 +261,7 @@ func (s *BoltstoreCore) DeleteResourcesCore(ctx context.Context, resources []mod
 func (s *BoltstoreCore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
 	ctx, span := tracer.Start(ctx, "store/UpsertAgents")
 	defer span.End()
+	defer measureOperation(ctx, "UpsertAgents")()
 	return s.updateOrUpsertAgents(ctx, false, agentIDs, updater)
 }
 
@@ -261,6 +270,7 @@ func (s *BoltstoreCore) UpsertAgents(ctx context.Context, agentIDs []string, upd
 func (s *BoltstoreCore) UpdateAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
 	ctx, span := tracer.Start(ctx, "store/UpdateAgents")
 	defer span.End()
+	defer measureOperation(ctx, "UpdateAgents")()
 	return s.updateOrUpsertAgents(ctx, true, agentIDs, updater)
 }
 
@@ -268,7 +278,7 @@ func (s *BoltstoreCore) updateOrUpsertAgents(ctx context.Context, requireExists
 	agents := make([]*model.Agent, 0, len(agentIDs))
 	updates := s.CreateEventUpdate()
 
-	err := s.DB.Update(func(tx *bbolt.Tx) error {
+	err := s.DB.Update(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -306,6 +316,7 @@ func (s *BoltstoreCore) updateOrUpsertAgents(ctx context.Context, requireExists
 func (s *BoltstoreCore) UpsertAgent(ctx context.Context, agentID string, updater AgentUpdater) (*model.Agent, error) {
 	ctx, span := tracer.Start(ctx, "store/UpsertAgent")
 	defer span.End()
+	defer measureOperation(ctx, "UpsertAgent")()
 	return s.updateOrUpsertAgent(ctx, false, agentID, updater)
 }
 
@@ -314,6 +325,7 @@ func (s *BoltstoreCore) UpsertAgent(ctx context.Context, agentID string, updater
 func (s *BoltstoreCore) UpdateAgent(ctx context.Context, agentID string, updater AgentUpdater) (*model.Agent, error) {
 	ctx, span := tracer.Start(ctx, "store/UpsertAgent")
 	defer span.End()
+	defer measureOperation(ctx, "UpdateAgent")()
 	return s.updateOrUpsertAgent(ctx, true, agentID, updater)
 }
 
@@ -332,7 +344,7 @@ func (s *BoltstoreCore) updateOrUpsertAgent(ctx context.Context, requireExists b
 	var updatedAgent *model.Agent
 	updates := s.CreateEventUpdate()
 
-	err := s.DB.Update(func(tx *bbolt.Tx) error {
+	err := s.DB.Update(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -384,7 +396,7 @@ func (s *BoltstoreCore) Agents(ctx context.Context, options ...QueryOption) ([]*
 
 	agents := []*model.Agent{}
 
-	err := s.DB.View(func(tx *bbolt.Tx) error {
+	err := s.DB.View(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -419,7 +431,7 @@ func (s *BoltstoreCore) Agents(ctx context.Context, options ...QueryOption) ([]*
 func (s *BoltstoreCore) agentsByID(ctx context.Context, ids []string, opts QueryOptions) ([]*model.Agent, error) {
 	var agents []*model.Agent
 
-	err := s.DB.View(func(tx *bbolt.Tx) error {
+	err := s.DB.View(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -458,7 +470,7 @@ func (s *BoltstoreCore) AgentsCount(ctx context.Context, options ...QueryOption)
 func (s *BoltstoreCore) Agent(ctx context.Context, id string) (*model.Agent, error) {
 	var agent *model.Agent
 
-	err := s.DB.View(func(tx *bbolt.Tx) error {
+	err := s.DB.View(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return err
@@ -501,6 +513,76 @@ func (s *BoltstoreCore) DeleteAgentVersion(ctx context.Context, name string) (*m
 	return item, err
 }
 
+// Alert returns the alert with the given name.
+func (s *BoltstoreCore) Alert(ctx context.Context, name string) (*model.Alert, error) {
+	item, exists, err := Resource[*model.Alert](ctx, s, model.KindAlert, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+
+// Alerts returns all alerts in the store sorted by name.
+func (s *BoltstoreCore) Alerts(ctx context.Context) ([]*model.Alert, error) {
+	return Resources[*model.Alert](ctx, s, model.KindAlert)
+}
+
+// DeleteAlert deletes the alert with the given name.
+func (s *BoltstoreCore) DeleteAlert(ctx context.Context, name string) (*model.Alert, error) {
+	item, exists, err := DeleteResourceAndNotify(ctx, s, model.KindAlert, name, &model.Alert{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+// UpdateAlertStatus replaces the status of the alert with the given name.
+func (s *BoltstoreCore) UpdateAlertStatus(ctx context.Context, name string, status model.AlertStatus) (*model.Alert, error) {
+	alert, _, err := editResource(ctx, s, nil, model.KindAlert, name, func(alert *model.Alert) error {
+		alert.Status = status
+		return nil
+	})
+	if errors.Is(err, ErrStoreResourceMissing) {
+		return nil, nil
+	}
+	return alert, err
+}
+
+// Webhook returns the webhook with the given name.
+func (s *BoltstoreCore) Webhook(ctx context.Context, name string) (*model.Webhook, error) {
+	item, exists, err := Resource[*model.Webhook](ctx, s, model.KindWebhook, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+
+// Webhooks returns all webhooks in the store sorted by name.
+func (s *BoltstoreCore) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	return Resources[*model.Webhook](ctx, s, model.KindWebhook)
+}
+
+// DeleteWebhook deletes the webhook with the given name.
+func (s *BoltstoreCore) DeleteWebhook(ctx context.Context, name string) (*model.Webhook, error) {
+	item, exists, err := DeleteResourceAndNotify(ctx, s, model.KindWebhook, name, &model.Webhook{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+// AddWebhookDelivery adds the delivery to the delivery log of the webhook with the given name.
+func (s *BoltstoreCore) AddWebhookDelivery(ctx context.Context, name string, delivery model.WebhookDelivery) (*model.Webhook, error) {
+	webhook, _, err := editResource(ctx, s, nil, model.KindWebhook, name, func(webhook *model.Webhook) error {
+		webhook.Status.AddDelivery(delivery)
+		return nil
+	})
+	if errors.Is(err, ErrStoreResourceMissing) {
+		return nil, nil
+	}
+	return webhook, err
+}
+
 // Configurations returns the configurations in the store with the given options.
 func (s *BoltstoreCore) Configurations(ctx context.Context, options ...QueryOption) ([]*model.Configuration, error) {
 	opts := MakeQueryOptions(options)
@@ -531,10 +613,11 @@ func (s *BoltstoreCore) Configuration(ctx context.Context, name string) (*model.
 func (s *BoltstoreCore) UpdateConfiguration(ctx context.Context, name string, updater ConfigurationUpdater) (config *model.Configuration, status model.UpdateStatus, err error) {
 	ctx, span := tracer.Start(ctx, "store/UpdateConfiguration")
 	defer span.End()
+	defer measureOperation(ctx, "UpdateConfiguration")()
 
 	updates := s.CreateEventUpdate()
 
-	err = s.DB.Update(func(tx *bbolt.Tx) error {
+	err = s.DB.Update(func(tx BucketTx) error {
 		config, status, err = UpdateResource(ctx, s, tx, model.KindConfiguration, name, func(config *model.Configuration) error {
 			updater(config)
 			return nil
@@ -716,11 +799,12 @@ func (s *BoltstoreCore) DeleteDestinationType(ctx context.Context, name string)
 func (s *BoltstoreCore) ReportConnectedAgents(ctx context.Context, agentIDs []string, time time.Time) error {
 	ctx, span := tracer.Start(ctx, "store/ReportConnectedAgents")
 	defer span.End()
+	defer measureOperation(ctx, "ReportConnectedAgents")()
 
 	// these updates will not be reported to the eventbus
 	updates := s.CreateEventUpdate()
 
-	err := s.DB.Update(func(tx *bbolt.Tx) error {
+	err := s.DB.Update(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return fmt.Errorf("failed to get agents bucket: %w", err)
@@ -748,10 +832,11 @@ func (s *BoltstoreCore) ReportConnectedAgents(ctx context.Context, agentIDs []st
 func (s *BoltstoreCore) DisconnectUnreportedAgents(ctx context.Context, since time.Time) error {
 	ctx, span := tracer.Start(ctx, "store/DisconnectUnreportedAgents")
 	defer span.End()
+	defer measureOperation(ctx, "DisconnectUnreportedAgents")()
 
 	changes := s.CreateEventUpdate()
 
-	err := s.DB.Update(func(tx *bbolt.Tx) error {
+	err := s.DB.Update(func(tx BucketTx) error {
 		bucket, err := s.AgentsBucket(ctx, tx)
 		if err != nil {
 			return fmt.Errorf("failed to get agents bucket: %w", err)
@@ -811,7 +896,7 @@ func (s *BoltstoreCore) CleanupDisconnectedAgents(ctx context.Context, since tim
 
 	for _, agent := range agents {
 		if agent.DisconnectedSince(since) {
-			err := s.DB.Update(func(tx *bbolt.Tx) error {
+			err := s.DB.Update(func(tx BucketTx) error {
 				bucket, err := s.AgentsBucket(ctx, tx)
 				if err != nil {
 					return err
@@ -846,7 +931,7 @@ func (s *BoltstoreCore) ConfigurationIndex(ctx context.Context) search.Index {
 }
 
 // Database returns the underlying bbolt database
-func (s *BoltstoreCore) Database() *bbolt.DB {
+func (s *BoltstoreCore) Database() BucketDB {
 	return s.DB
 }
 
@@ -902,6 +987,8 @@ func (s *BoltstoreCore) StartRollout(ctx context.Context, configurationName stri
 	config, _, err = editResource(ctx, s, nil, model.KindConfiguration, configurationName, func(config *model.Configuration) error {
 		config.Status.Rollout.Status = model.RolloutStatusStarted
 		config.Status.Rollout.Phase = 0
+		config.Status.Rollout.PausedBySchedule = false
+		config.Status.Rollout.Canary = nil
 		config.Status.Rollout.Options = *options
 		return nil
 	})
@@ -937,14 +1024,17 @@ func (s *BoltstoreCore) PauseRollout(ctx context.Context, configurationName stri
 		return nil, err
 	}
 
-	// pause does nothing if its not started
-	if config.Status.Rollout.Status != model.RolloutStatusStarted {
+	// pause does nothing if its not started. A rollout paused by its schedule is paused again by the user so that it
+	// isn't resumed when the schedule opens.
+	pausedBySchedule := config.Status.Rollout.Status == model.RolloutStatusPaused && config.Status.Rollout.PausedBySchedule
+	if config.Status.Rollout.Status != model.RolloutStatusStarted && !pausedBySchedule {
 		return config, nil
 	}
 
 	// set the rollout status to paused
 	_, _, err = editResource(ctx, s, nil, model.KindConfiguration, configurationName, func(config *model.Configuration) error {
 		config.Status.Rollout.Status = model.RolloutStatusPaused
+		config.Status.Rollout.PausedBySchedule = false
 		return nil
 	})
 	if err != nil {
@@ -959,6 +1049,7 @@ func (s *BoltstoreCore) PauseRollout(ctx context.Context, configurationName stri
 // For RolloutStatusError - it will increase the maxErrors of the
 // rollout by the current number of errors + 1.
 // For RolloutStatusStarted - it will pause the rollout.
+// For a rollout paused after its canary phase - it will approve the canary phase.
 func (s *BoltstoreCore) ResumeRollout(ctx context.Context, configurationName string) (*model.Configuration, error) {
 	config, err := s.Configuration(ctx, configurationName)
 	if err != nil || config == nil {
@@ -966,9 +1057,11 @@ func (s *BoltstoreCore) ResumeRollout(ctx context.Context, configurationName str
 	}
 
 	if config.Status.Rollout.Status == model.RolloutStatusPaused {
-		// set the rollout status to started
+		// set the rollout status to started, approving the canary phase if the rollout is waiting for approval
 		config, _, err = editResource(ctx, s, nil, model.KindConfiguration, configurationName, func(config *model.Configuration) error {
+			config.Status.Rollout.ApproveCanary()
 			config.Status.Rollout.Status = model.RolloutStatusStarted
+			config.Status.Rollout.PausedBySchedule = false
 			return nil
 		})
 	}
@@ -978,6 +1071,8 @@ func (s *BoltstoreCore) ResumeRollout(ctx context.Context, configurationName str
 		config, _, err = editResource(ctx, s, nil, model.KindConfiguration, configurationName, func(config *model.Configuration) error {
 			config.Status.Rollout.Options.MaxErrors = config.Status.Rollout.Progress.Errors + 1
 			config.Status.Rollout.Status = model.RolloutStatusStarted
+			config.Status.Rollout.PausedBySchedule = false
+			config.Status.Rollout.OverrideHealthCheck()
 			return nil
 		})
 	}
@@ -992,10 +1087,27 @@ func (s *BoltstoreCore) ResumeRollout(ctx context.Context, configurationName str
 // UpdateRollout updates a rollout in progress. Does nothing if the rollout does not have a RolloutStatusStarted
 // status. Returns the current Configuration with its Rollout status.
 func (s *BoltstoreCore) UpdateRollout(ctx context.Context, configuration string) (updatedConfig *model.Configuration, err error) {
+	defer measureOperation(ctx, "UpdateRollout")()
+
+	// throughput is only measured for rollouts with a health check and it is measured before the transaction because
+	// measurements can't be queried in it
+	current, err := s.Configuration(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+	var throughput model.RolloutThroughput
+	if current != nil && current.Status.Rollout.Options.HealthCheck != nil {
+		throughput, err = MeasureRolloutThroughput(ctx, s.AgentIndex(ctx), s, current)
+		if err != nil {
+			return nil, fmt.Errorf("measure rollout throughput: %w", err)
+		}
+	}
+
 	updates := s.CreateEventUpdate()
+	var agents []*model.Agent
 
 	// get the configuration
-	err = s.DB.Update(func(tx *bbolt.Tx) error {
+	err = s.DB.Update(func(tx BucketTx) error {
 		var (
 			agentsWaiting []string
 			agentsNext    int
@@ -1005,7 +1117,7 @@ func (s *BoltstoreCore) UpdateRollout(ctx context.Context, configuration string)
 		config, wasModified, err := editResource(ctx, s, tx, model.KindConfiguration, configuration, func(r *model.Configuration) error {
 			oldRolloutStatus = r.Status.Rollout.Status
 
-			agentsWaiting, agentsNext, err = UpdateRolloutMetrics(ctx, s.AgentIndex(ctx), r)
+			agentsWaiting, agentsNext, err = UpdateRolloutMetrics(ctx, s.AgentIndex(ctx), r, throughput)
 			if err != nil {
 				return err
 			}
@@ -1027,6 +1139,14 @@ func (s *BoltstoreCore) UpdateRollout(ctx context.Context, configuration string)
 			}
 			config.SetCurrent(true)
 		}
+		if shouldRollback(config, oldRolloutStatus) {
+			var rolledBack []*model.Agent
+			config, rolledBack, err = s.rollbackTx(ctx, tx, config, updates)
+			if err != nil {
+				return fmt.Errorf("rollback %s: %w", configuration, err)
+			}
+			agents = append(agents, rolledBack...)
+		}
 
 		updatedConfig = config
 
@@ -1037,8 +1157,6 @@ func (s *BoltstoreCore) UpdateRollout(ctx context.Context, configuration string)
 		updates.IncludeResource(config, EventTypeUpdate)
 
 		if agentsNext > 0 {
-			var agents []*model.Agent
-
 			// update the next batch of agents
 			agentsBucket, err := s.AgentsBucket(ctx, tx)
 			if err != nil {
@@ -1058,18 +1176,20 @@ func (s *BoltstoreCore) UpdateRollout(ctx context.Context, configuration string)
 				}
 				agents = append(agents, agent)
 			}
-
-			// update the search index with changes
-			for _, a := range agents {
-				if err := s.AgentsIndex(ctx).Upsert(ctx, a); err != nil {
-					s.Logger.Error("failed to update the search index", zap.String("agentID", a.ID))
-				}
-			}
 		}
 
 		return err
 	})
 
+	// update the search index with changes after they are committed
+	if err == nil {
+		for _, a := range agents {
+			if err := s.AgentsIndex(ctx).Upsert(ctx, a); err != nil {
+				s.Logger.Error("failed to update the search index", zap.String("agentID", a.ID))
+			}
+		}
+	}
+
 	// config may have changed
 	s.Notify(ctx, updates)
 
@@ -1114,9 +1234,81 @@ func (s *BoltstoreCore) currentRollouts(ctx context.Context) ([]string, error) {
 	return StartedRolloutsFromIndex(ctx, s.ConfigurationsIndex(ctx))
 }
 
+// rollbackTx restores the previous version of a configuration to the agents that received the failed version, stops
+// the rollout to agents waiting for it, and records the rollback on the failed version. Agents receive the previous
+// version with an EventTypeRollout update. It returns the updated agents so that the search index can be updated after
+// the transaction.
+func (s *BoltstoreCore) rollbackTx(ctx context.Context, tx BucketTx, failed *model.Configuration, updates BasicEventUpdates) (*model.Configuration, []*model.Agent, error) {
+	nameAndVersion := failed.NameAndVersion()
+
+	// CurrentVersion is the version that most recently completed a rollout
+	var previous *model.Configuration
+	if version := failed.Status.CurrentVersion; version != 0 && version != failed.Version() {
+		config, _, _, exists, err := FindResource[*model.Configuration](ctx, s, tx, model.KindConfiguration, model.JoinVersion(failed.Name(), version))
+		if err != nil {
+			return nil, nil, fmt.Errorf("previous version: %w", err)
+		}
+		if exists {
+			previous = config
+		}
+	}
+
+	received, waiting, err := FindRolloutAgents(ctx, s.AgentIndex(ctx), nameAndVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	agentsBucket, err := s.AgentsBucket(ctx, tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get agents bucket: %w", err)
+	}
+	var agents []*model.Agent
+	for _, agentID := range append(received, waiting...) {
+		agent, err := s.updateOrUpsertAgentTx(ctx, true, agentsBucket, agentID, func(agent *model.Agent) {
+			agent.RollbackConfiguration(nameAndVersion, previous)
+		}, updates)
+		if err != nil {
+			return nil, nil, err
+		}
+		if agent != nil {
+			agents = append(agents, agent)
+		}
+	}
+
+	rollback := &model.RolloutRollback{
+		Agents: len(received),
+		Date:   time.Now(),
+	}
+	if previous != nil {
+		rollback.Version = previous.Version()
+	}
+	config, _, err := editResource(ctx, s, tx, model.KindConfiguration, nameAndVersion, func(r *model.Configuration) error {
+		r.Status.Rollback = rollback
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the failed version is no longer pending so that new agents receive the current version
+	latest, _, err := editResource(ctx, s, tx, model.KindConfiguration, failed.Name(), func(r *model.Configuration) error {
+		if r.Status.PendingVersion == failed.Version() {
+			r.Status.PendingVersion = 0
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest.Version() == config.Version() {
+		return latest, agents, nil
+	}
+	return config, agents, nil
+}
+
 // updateCurrentVersion updates the CurrentVersion of a Configuration resource which is maintained by the most recent
 // version. If a Rollout completes for a specific version, that version becomes the current version.
-func (s *BoltstoreCore) updateCurrentVersion(ctx context.Context, tx *bbolt.Tx, configVersion *model.Configuration) error {
+func (s *BoltstoreCore) updateCurrentVersion(ctx context.Context, tx BucketTx, configVersion *model.Configuration) error {
 	// pass configVersion.Name() to edit the latest version of the configuration
 	_, _, err := editResource(ctx, s, tx, model.KindConfiguration, configVersion.Name(), func(r *model.Configuration) error {
 		r.Status.CurrentVersion = configVersion.Version()
@@ -1133,13 +1325,225 @@ func (s *BoltstoreCore) ResourceHistory(ctx context.Context, resourceKind model.
 	return resourceHistory[*model.AnyResource](ctx, s, resourceKind, resourceName)
 }
 
+// ----------------------------------------------------------------------
+// BackupStore and RestoreStore
+
+// Backup returns a backup of every version of every resource, all agents, and optionally all measurements, read in a
+// single transaction
+func (s *BoltstoreCore) Backup(ctx context.Context, includeMeasurements bool) (*Backup, error) {
+	ctx, span := tracer.Start(ctx, "store/Backup")
+	defer span.End()
+
+	backup := newBackup()
+	err := s.Database().View(func(tx BucketTx) error {
+		archive, err := s.ArchiveBucket(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, kind := range backupKinds {
+			bucket, err := s.ResourcesBucket(ctx, tx, kind)
+			if err != nil {
+				return err
+			}
+			if err := appendBoltValues(bucket, ResourcesPrefix(kind), &backup.Resources); err != nil {
+				return fmt.Errorf("%s resources: %w", kind, err)
+			}
+			if err := appendBoltValues(archive, ResourcesPrefix(kind), &backup.Resources); err != nil {
+				return fmt.Errorf("%s archive: %w", kind, err)
+			}
+		}
+
+		agents, err := s.AgentsBucket(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := appendBoltValues(agents, AgentPrefix(), &backup.Agents); err != nil {
+			return fmt.Errorf("agents: %w", err)
+		}
+
+		if !includeMeasurements {
+			return nil
+		}
+		for _, metricName := range stats.SupportedMetricNames {
+			bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
+			if err != nil {
+				return err
+			}
+			// each measurement is stored for both the agent and the configuration, only the agent copy is needed
+			if err := appendBoltValues(bucket, []byte(model.KindAgent+"|"), &backup.Measurements); err != nil {
+				return fmt.Errorf("%s measurements: %w", metricName, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	backup.sort()
+	return backup, nil
+}
+
+// appendBoltValues unmarshals the values of the keys in the bucket with the prefix and appends them to values
+func appendBoltValues[T any](bucket Bucket, prefix []byte, values *[]*T) error {
+	if bucket == nil {
+		return nil
+	}
+	cursor := bucket.Cursor()
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		value := new(T)
+		if err := jsoniter.Unmarshal(v, value); err != nil {
+			return err
+		}
+		*values = append(*values, value)
+	}
+	return nil
+}
+
+// RestoreResources replaces the resources and their history with the versions in the backup in a single transaction
+func (s *BoltstoreCore) RestoreResources(ctx context.Context, resources []*model.AnyResource) error {
+	ctx, span := tracer.Start(ctx, "store/RestoreResources")
+	defer span.End()
+
+	groups, err := groupBackupVersions(resources)
+	if err != nil {
+		return err
+	}
+
+	updates := s.CreateEventUpdate()
+	err = s.Database().Update(func(tx BucketTx) error {
+		archive, err := s.ArchiveBucket(ctx, tx)
+		if err != nil || archive == nil {
+			return fmt.Errorf("archive bucket: %w", err)
+		}
+		for _, group := range groups {
+			kind, name := group.latest.GetKind(), group.latest.UniqueKey()
+
+			// remove the existing history, it is replaced by the history in the backup
+			var keys [][]byte
+			prefix := archivePrefix(kind, name)
+			cursor := archive.Cursor()
+			for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+				keys = append(keys, k)
+			}
+			for _, k := range keys {
+				if err := archive.Delete(k); err != nil {
+					return err
+				}
+			}
+
+			for _, r := range group.archived {
+				data, err := s.Cipher.marshalBackupResource(r)
+				if err != nil {
+					return err
+				}
+				if err := archive.Put(archiveKeyFromResource(r), data); err != nil {
+					return err
+				}
+			}
+
+			bucket, err := s.ResourcesBucket(ctx, tx, kind)
+			if err != nil || bucket == nil {
+				return fmt.Errorf("resources bucket: %w", err)
+			}
+			data, err := s.Cipher.marshalBackupResource(group.latest)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(s.ResourceKey(group.latest), data); err != nil {
+				return err
+			}
+			updates.IncludeResource(group.latest, EventTypeInsert)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("restore resources: %w", err)
+	}
+
+	for _, group := range groups {
+		if configuration, ok := group.latest.(*model.Configuration); ok {
+			if err := s.ConfigurationsIndex(ctx).Upsert(ctx, configuration); err != nil {
+				s.ZapLogger().Error("failed to update the search index", zap.String("configuration", configuration.Name()))
+			}
+		}
+	}
+	s.Notify(ctx, updates)
+	return nil
+}
+
+// RotateEncryptionKey re-encrypts the sensitive parameter values of every resource and archived version with the
+// current encryption key in a single transaction
+func (s *BoltstoreCore) RotateEncryptionKey(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "store/RotateEncryptionKey")
+	defer span.End()
+
+	if s.Cipher == nil {
+		return 0, ErrEncryptionKeyMissing
+	}
+
+	count := 0
+	err := s.Database().Update(func(tx BucketTx) error {
+		archive, err := s.ArchiveBucket(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("archive bucket: %w", err)
+		}
+		for _, kind := range encryptedKinds {
+			bucket, err := s.ResourcesBucket(ctx, tx, kind)
+			if err != nil {
+				return fmt.Errorf("resources bucket: %w", err)
+			}
+			// archive keys also start with the resources prefix
+			for _, b := range []Bucket{bucket, archive} {
+				n, err := reencryptBoltValues(s.Cipher, b, kind)
+				if err != nil {
+					return err
+				}
+				count += n
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("rotate encryption key: %w", err)
+	}
+	return count, nil
+}
+
+// reencryptBoltValues re-encrypts the values of the resources of the kind in the bucket and returns the number of values
+func reencryptBoltValues(c *ParameterCipher, bucket Bucket, kind model.Kind) (int, error) {
+	if bucket == nil {
+		return 0, nil
+	}
+
+	// collect the values first because the cursor is invalidated by changes to the bucket
+	var keys, values [][]byte
+	prefix := ResourcesPrefix(kind)
+	cursor := bucket.Cursor()
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		data, err := c.reencryptResource(kind, v)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", k, err)
+		}
+		keys = append(keys, bytes.Clone(k))
+		values = append(values, data)
+	}
+
+	for i, k := range keys {
+		if err := bucket.Put(k, values[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
 // -----------------------------------------------------------------------------
 
 // getObjectIds will retrieve identifiers for all objects in a bucket where the keys are formatted KIND|IDENTIFIER
-func (s *BoltstoreCore) getObjectIds(bucketFunc func(tx *bbolt.Tx) (*bbolt.Bucket, error), kind model.Kind) ([]string, error) {
+func (s *BoltstoreCore) getObjectIds(bucketFunc func(tx BucketTx) (Bucket, error), kind model.Kind) ([]string, error) {
 	ids := []string{}
 	prefix := []byte(fmt.Sprintf("%s|", kind))
-	err := s.Database().View(func(tx *bbolt.Tx) error {
+	err := s.Database().View(func(tx BucketTx) error {
 		bucket, err := bucketFunc(tx)
 		if err != nil {
 			return nil
@@ -1169,7 +1573,7 @@ func (s *BoltstoreCore) AgentMetrics(ctx context.Context, ids []string, options
 	// Empty string single key or empty array of ids is a request for all Agents
 	if len(ids) == 0 || (len(ids) == 1 && ids[0] == "") {
 		var err error
-		ids, err = s.getObjectIds(func(tx *bbolt.Tx) (*bbolt.Bucket, error) {
+		ids, err = s.getObjectIds(func(tx BucketTx) (Bucket, error) {
 			return s.AgentsBucket(ctx, tx)
 		}, model.KindAgent)
 		if err != nil {
@@ -1189,7 +1593,7 @@ func (s *BoltstoreCore) ConfigurationMetrics(ctx context.Context, name string, o
 	var err error
 	// Empty name is a request for all configurations
 	if name == "" {
-		names, err = s.getObjectIds(func(tx *bbolt.Tx) (*bbolt.Bucket, error) {
+		names, err = s.getObjectIds(func(tx BucketTx) (Bucket, error) {
 			return s.ResourcesBucket(ctx, tx, model.KindConfiguration)
 		}, model.KindConfiguration)
 		if err != nil {
@@ -1235,16 +1639,61 @@ func (s *BoltstoreCore) OverviewMetrics(ctx context.Context, options ...stats.Qu
 	return s.ConfigurationMetrics(ctx, "", options...)
 }
 
+// QueryMeasurements returns the rates of the measurements of agents in the time range of the query as time series
+func (s *BoltstoreCore) QueryMeasurements(ctx context.Context, query stats.MeasurementsQuery) ([]*stats.TimeSeries, error) {
+	prefixes := [][]byte{[]byte(fmt.Sprintf("%s|", model.KindAgent))}
+	if len(query.Agents) > 0 {
+		prefixes = prefixes[:0]
+		for _, id := range query.Agents {
+			prefixes = append(prefixes, []byte(fmt.Sprintf("%s|%s|", model.KindAgent, sanitizeKey(id))))
+		}
+	}
+	from := query.From().UTC().Format(measurementsDateFormat)
+	to := query.End.UTC().Format(measurementsDateFormat)
+
+	var measurements []*record.Metric
+	err := s.Database().View(func(tx BucketTx) error {
+		for _, metricName := range query.Metrics() {
+			bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
+			if err != nil || bucket == nil {
+				return err
+			}
+			cursor := bucket.Cursor()
+			for _, prefix := range prefixes {
+				// keys are ordered by time for each agent, but all agents are scanned if no agents are specified
+				for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+					if ts := keyTimestamp(k); ts < from || ts > to {
+						continue
+					}
+					m := &record.Metric{}
+					if err := jsoniter.Unmarshal(v, m); err != nil {
+						return err
+					}
+					measurements = append(measurements, m)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return query.TimeSeries(measurements), nil
+}
+
 // MeasurementsSize returns the count of keys in the store, and is used only for testing
 func (s *BoltstoreCore) MeasurementsSize(ctx context.Context) (int, error) {
 	count := 0
-	err := s.Database().View(func(tx *bbolt.Tx) error {
+	err := s.Database().View(func(tx BucketTx) error {
 		for _, metricName := range stats.SupportedMetricNames {
 			bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
 			if err != nil {
 				return err
 			}
-			count += bucket.Stats().KeyN
+			cursor := bucket.Cursor()
+			for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+				count++
+			}
 		}
 
 		return nil
@@ -1261,7 +1710,7 @@ var getCurrentTime = func() time.Time {
 func (s *BoltstoreCore) retrieveMetrics(ctx context.Context, metricNames []string, objectType string, ids []string, options ...stats.QueryOption) (stats.MetricData, error) {
 	result := stats.MetricData{}
 	opts := stats.MakeQueryOptions(options)
-	rollup := stats.GetDurationFromPeriod(opts)
+	rollup := stats.GetDurationFromPeriod(s.Retention, opts)
 
 	endDate := getCurrentTime().Add(-10 * time.Second).Truncate(10 * time.Second)
 	startDate := endDate.Add(-1 * opts.Period).Truncate(rollup)
@@ -1269,7 +1718,7 @@ func (s *BoltstoreCore) retrieveMetrics(ctx context.Context, metricNames []strin
 	endDateString := endDate.Format(measurementsDateFormat)
 	startDateString := startDate.Format(measurementsDateFormat)
 
-	err := s.Database().View(func(tx *bbolt.Tx) error {
+	err := s.Database().View(func(tx BucketTx) error {
 		var errs error
 		for metricIndex, metricName := range metricNames {
 			mBucket, err := s.MeasurementsBucket(ctx, tx, metricName)
@@ -1339,7 +1788,7 @@ func (s *BoltstoreCore) retrieveMetrics(ctx context.Context, metricNames []strin
 	return result, err
 }
 
-func findEndMetrics(c *bbolt.Cursor, time, objectType, id string) (map[string]*record.Metric, error) {
+func findEndMetrics(c BucketCursor, time, objectType, id string) (map[string]*record.Metric, error) {
 	identifier := fmt.Sprintf("%s|%s", objectType, sanitizeKey(id))
 	prefix := []byte(fmt.Sprintf("%s|%s|", identifier, time))
 	metrics := map[string]*record.Metric{}
@@ -1357,7 +1806,7 @@ func findEndMetrics(c *bbolt.Cursor, time, objectType, id string) (map[string]*r
 	return metrics, nil
 }
 
-func findStartMetrics(c *bbolt.Cursor, time string, endTime time.Time, objectType, id string, desiredKeys map[string]interface{}) (map[string]*record.Metric, error) {
+func findStartMetrics(c BucketCursor, time string, endTime time.Time, objectType, id string, desiredKeys map[string]interface{}) (map[string]*record.Metric, error) {
 	identifier := fmt.Sprintf("%s|%s", objectType, sanitizeKey(id))
 	startingPrefix := []byte(fmt.Sprintf("%s|%s|", identifier, time))
 	metrics := map[string]*record.Metric{}
@@ -1436,12 +1885,15 @@ func (s *BoltstoreCore) SaveAgentMetrics(ctx context.Context, metrics []*record.
 func (s *BoltstoreCore) ProcessMetrics(ctx context.Context) error {
 	var errs error
 	for _, m := range stats.SupportedMetricNames {
+		if err := s.rollupMeasurements(ctx, m); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 		if err := s.cleanupMeasurements(ctx, m); err != nil {
 			errs = multierror.Append(errs, err)
 		}
 	}
 	if errs != nil {
-		s.ZapLogger().Error("error cleaning up measurements", zap.Error(errs))
+		s.ZapLogger().Error("error processing measurements", zap.Error(errs))
 	}
 	return errs
 }
@@ -1471,7 +1923,7 @@ func (s *BoltstoreCore) storeMeasurements(ctx context.Context, metricName string
 	if len(metrics) == 0 {
 		return nil
 	}
-	return s.Database().Update(func(tx *bbolt.Tx) error {
+	return s.Database().Update(func(tx BucketTx) error {
 		var errs error
 		bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
 		if err != nil {
@@ -1497,8 +1949,11 @@ func (s *BoltstoreCore) storeMeasurements(ctx context.Context, metricName string
 	})
 }
 
-func (s *BoltstoreCore) cleanupMeasurements(ctx context.Context, metricName string) error {
-	return s.Database().Update(func(tx *bbolt.Tx) error {
+// rollupMeasurements copies the first measurement in each interval of the retention tiers to the start of the interval
+// if no measurement is stored there. This keeps a measurement for each interval after cleanupMeasurements removes the
+// measurements that are not retained.
+func (s *BoltstoreCore) rollupMeasurements(ctx context.Context, metricName string) error {
+	return s.Database().Update(func(tx BucketTx) error {
 		var errs error
 		bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
 		if err != nil {
@@ -1509,30 +1964,53 @@ func (s *BoltstoreCore) cleanupMeasurements(ctx context.Context, metricName stri
 		// Capture now to re-use for all the date math
 		now := getCurrentTime()
 
-		// Only look at data points older than 100 seconds, and we keep all the latest 10s intervals
-		endCleanupDate := now.Add(-100 * time.Second)
+		// Keys are ordered by object and time, so the first measurement in each interval is found first. The rollups
+		// are written after iterating because the cursor is invalidated by changes to the bucket.
+		rollups := map[string][]byte{}
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			ts, err := time.Parse(measurementsDateFormat, keyTimestamp(k))
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			for _, rollupTime := range stats.RollupTimes(s.Retention, now, ts) {
+				rollupKey := replaceKeyTimestamp(k, rollupTime.Format(measurementsDateFormat))
+				if _, ok := rollups[rollupKey]; ok || bucket.Get([]byte(rollupKey)) != nil {
+					continue
+				}
+				rollups[rollupKey] = append([]byte(nil), v...)
+			}
+		}
+
+		for k, v := range rollups {
+			if err := bucket.Put([]byte(k), v); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+
+		return errs
+	})
+}
+
+func (s *BoltstoreCore) cleanupMeasurements(ctx context.Context, metricName string) error {
+	return s.Database().Update(func(tx BucketTx) error {
+		var errs error
+		bucket, err := s.MeasurementsBucket(ctx, tx, metricName)
+		if err != nil {
+			return err
+		}
+		c := bucket.Cursor()
+
+		// Capture now to re-use for all the date math
+		now := getCurrentTime()
 
 		// Iterate through all points in the bucket, due to ordering we can't just scan by date
 		for k, _ := c.First(); k != nil; k, _ = c.Next() {
 			if ts, err := time.Parse(measurementsDateFormat, keyTimestamp(k)); err != nil {
 				errs = multierror.Append(errs, err)
-			} else {
-				// Assume anything older than the cutoff is being deleted, unless the
-				// normalized timestamp matches one of the rollup times
-				keep := ts.Sub(endCleanupDate) > 0
-				// For last 10 minutes keep the minute data
-				keep = keep || ts.Truncate(time.Minute*1).Equal(ts) && now.Sub(ts) <= 10*time.Minute
-				// for the last 6 hours keep the 5 minute data
-				keep = keep || ts.Truncate(time.Minute*5).Equal(ts) && now.Sub(ts) <= 6*time.Hour
-				// for the last 24 hours keep the hourly data
-				keep = keep || ts.Truncate(time.Hour*1).Equal(ts) && now.Sub(ts) <= 24*time.Hour
-				// for the last 31 days keep the daily data
-				keep = keep || ts.Truncate(time.Hour*24).Equal(ts) && now.Sub(ts) <= 24*31*time.Hour
-
-				if !keep {
-					if err := c.Delete(); err != nil {
-						errs = multierror.Append(errs, err)
-					}
+			} else if !stats.Retained(s.Retention, now, ts) {
+				if err := c.Delete(); err != nil {
+					errs = multierror.Append(errs, err)
 				}
 			}
 		}
@@ -1590,6 +2068,13 @@ func keyTimestamp(k []byte) string {
 	return strings.Split(string(k), "|")[2]
 }
 
+// replaceKeyTimestamp returns the measurement key with the timestamp replaced
+func replaceKeyTimestamp(k []byte, timestamp string) string {
+	keyParts := strings.Split(string(k), "|")
+	keyParts[2] = timestamp
+	return strings.Join(keyParts, "|")
+}
+
 func archivePrefix(kind model.Kind, name string) []byte {
 	return []byte(fmt.Sprintf("%s|%s|", kind, name))
 }
@@ -22,7 +22,6 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
-	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -31,7 +30,6 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/observiq/bindplane-op/model"
-	"github.com/observiq/bindplane-op/model/search"
 	"github.com/observiq/bindplane-op/store/storetest"
 )
 
@@ -40,6 +38,10 @@ var testBuckets = []string{
 	BucketAgents,
 	BucketMeasurements,
 	BucketArchive,
+	BucketUsers,
+	BucketAPIKeys,
+	BucketAuditEvents,
+	BucketSnapshots,
 }
 
 func TestBoltStoreClear(t *testing.T) {
@@ -104,46 +106,6 @@ func TestBoltStoreAddAgent(t *testing.T) {
 	assert.ElementsMatch(t, agents, []interface{}{a1, a2})
 }
 
-type mockUnknownResource struct {
-	model.StatusType[any]
-}
-
-func (x mockUnknownResource) ID() string                                { return "" }
-func (x mockUnknownResource) SetID(string)                              {}
-func (x mockUnknownResource) EnsureID()                                 {}
-func (x mockUnknownResource) Version() model.Version                    { return model.VersionLatest }
-func (x mockUnknownResource) SetVersion(model.Version)                  {}
-func (x mockUnknownResource) GetSpec() any                              { return nil }
-func (x mockUnknownResource) EnsureHash(any)                            {}
-func (x mockUnknownResource) Hash() string                              { return "" }
-func (x mockUnknownResource) DateModified() *time.Time                  { return nil }
-func (x mockUnknownResource) SetDateModified(*time.Time)                {}
-func (x mockUnknownResource) EnsureMetadata(_ any)                      {}
-func (x mockUnknownResource) GetKind() model.Kind                       { return model.KindUnknown }
-func (x mockUnknownResource) Name() string                              { return "" }
-func (x mockUnknownResource) Description() string                       { return "" }
-func (x mockUnknownResource) Validate() (warnings string, errors error) { return "", nil }
-func (x mockUnknownResource) ValidateWithStore(context.Context, model.ResourceStore) (warnings string, errors error) {
-	return "", nil
-}
-func (x mockUnknownResource) UpdateDependencies(_ context.Context, _ model.ResourceStore) error {
-	return nil
-}
-func (x mockUnknownResource) GetLabels() model.Labels  { return model.MakeLabels() }
-func (x mockUnknownResource) SetLabels(_ model.Labels) {}
-func (x mockUnknownResource) UniqueKey() string        { return x.ID() }
-
-func (x mockUnknownResource) IndexID() string                   { return "" }
-func (x mockUnknownResource) IndexFields(_ search.Indexer)      {}
-func (x mockUnknownResource) IndexLabels(_ search.Indexer)      {}
-func (x mockUnknownResource) PrintableKindSingular() string     { return string(model.KindUnknown) }
-func (x mockUnknownResource) PrintableKindPlural() string       { return string(model.KindUnknown) }
-func (x mockUnknownResource) PrintableFieldTitles() []string    { return []string{} }
-func (x mockUnknownResource) PrintableFieldValue(string) string { return "-" }
-func (x mockUnknownResource) IsDeprecated() bool                { return false }
-
-var _ model.Resource = (*mockUnknownResource)(nil)
-
 func TestKeyFromResource(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -236,13 +198,6 @@ func TestAgent(t *testing.T) {
 	assert.Equal(t, a1, agent)
 }
 
-var updaterCalled bool
-
-func testUpdater(agent *model.Agent) {
-	updaterCalled = true
-	agent.Name = "updated"
-}
-
 func TestUpsertAgent(t *testing.T) {
 	db, err := storetest.InitTestBboltDB(t, testBuckets)
 	require.NoError(t, err, "error while initializing test database", err)
@@ -409,13 +364,17 @@ func TestInitDB(t *testing.T) {
 			// 6. - otelcol_processor_throughputmeasurement_log_data_size
 			// 7. - otelcol_processor_throughputmeasurement_metric_data_size
 			// 8. - otelcol_processor_throughputmeasurement_trace_data_size
-			bucketCount := int64(8)
+			// 9. users
+			// 10. api keys
+			// 11. audit events
+			// 12. snapshots
+			bucketCount := int64(12)
 			stats := db.Stats().TxStats
 			require.Equal(t, bucketCount*2, stats.GetCursorCount())
 
 			// InitDB creates buckets: Resources, Tasks, Agents, Measurements, and sub-buckets in measurements for each metric
 			_ = db.Update(func(tx *bbolt.Tx) error {
-				for _, bucket := range []string{BucketResources, BucketAgents, BucketMeasurements, BucketArchive} {
+				for _, bucket := range []string{BucketResources, BucketAgents, BucketMeasurements, BucketArchive, BucketUsers, BucketAPIKeys, BucketAuditEvents, BucketSnapshots} {
 					// Deleting the bucket
 					err := tx.DeleteBucket([]byte(bucket))
 					require.NoError(t, err, "expected bucket %s to exist", bucket)
@@ -451,7 +410,7 @@ func TestNewBoltStore(t *testing.T) {
 			output := NewBoltStore(ctx, db, testOptions, zap.NewNop())
 			require.NotNil(t, output)
 			require.IsType(t, &boltstore{}, output)
-			require.Equal(t, db, output.(*boltstore).DB)
+			require.Equal(t, NewBoltDB(db), output.(*boltstore).DB)
 			require.Equal(t, 0, output.Updates(ctx).Subscribers())
 		})
 	}
@@ -580,6 +539,117 @@ func TestBoltstoreMeasurements(t *testing.T) {
 	runTestMeasurements(t, store)
 }
 
+func TestBoltstoreUsers(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runUsersTests(ctx, t, store)
+}
+
+func TestBoltstoreAPIKeys(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runAPIKeysTests(ctx, t, store)
+}
+
+func TestBoltstoreAuditEvents(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runAuditEventsTests(ctx, t, store)
+}
+
+func TestBoltstoreAlerts(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runAlertsTests(ctx, t, store)
+}
+
+func TestBoltstoreWebhooks(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runWebhooksTests(ctx, t, store)
+}
+
+func TestBoltstoreSnapshots(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runSnapshotsTests(ctx, t, store)
+}
+
+func TestBoltstoreBackup(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+	runBackupTests(ctx, t, store)
+}
+
+func TestBoltstoreEncryption(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newStore := func(cipher *ParameterCipher) Store {
+		options := testOptions
+		options.ParameterCipher = cipher
+		options.DisableMeasurementsCleanup = true
+		return NewBoltStore(ctx, db, options, zap.NewNop())
+	}
+	stored := func() string {
+		var data []byte
+		require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+			for _, bucket := range []string{BucketResources, BucketArchive} {
+				err := tx.Bucket([]byte(bucket)).ForEach(func(_, v []byte) error {
+					data = append(data, v...)
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+		return string(data)
+	}
+	defer db.Close()
+
+	runEncryptionTests(ctx, t, newStore, stored)
+}
+
 func TestCleanupDisconnectedAgents(t *testing.T) {
 	db, err := storetest.InitTestBboltDB(t, testBuckets)
 	require.NoError(t, err)
@@ -674,6 +744,54 @@ func TestStartRollout(t *testing.T) {
 	testStartRollout(ctx, t, store)
 }
 
+func TestScheduledRollout(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+
+	testScheduledRollout(ctx, t, store)
+}
+
+func TestRolloutHealthCheck(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+
+	testRolloutHealthCheck(ctx, t, store)
+}
+
+func TestRolloutRollback(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+
+	testRolloutRollback(ctx, t, store)
+}
+
+func TestCanaryRollout(t *testing.T) {
+	db, err := storetest.InitTestBboltDB(t, testBuckets)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	defer store.Close()
+
+	testCanaryRollout(ctx, t, store)
+}
+
 func TestDependencyUpdates(t *testing.T) {
 	db, err := storetest.InitTestBboltDB(t, testBuckets)
 	require.NoError(t, err)
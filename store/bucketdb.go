@@ -0,0 +1,180 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"go.etcd.io/bbolt"
+)
+
+// BucketDB is a transactional key value database with nested buckets. It has the semantics of bbolt so that
+// BoltstoreCore can be used with bbolt and with the in-memory database used by the mapstore.
+type BucketDB interface {
+	// View executes the function in a read-only transaction
+	View(fn func(tx BucketTx) error) error
+
+	// Update executes the function in a read-write transaction. The transaction is committed if the function returns nil
+	// and rolled back if it returns an error.
+	Update(fn func(tx BucketTx) error) error
+
+	// Close releases the resources of the database
+	Close() error
+}
+
+// BucketTx is a transaction on a BucketDB
+type BucketTx interface {
+	// Bucket returns the bucket with the name or nil if it does not exist
+	Bucket(name []byte) Bucket
+
+	// CreateBucketIfNotExists creates the bucket with the name if it does not exist and returns it
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+
+	// DeleteBucket deletes the bucket with the name and everything in it
+	DeleteBucket(name []byte) error
+}
+
+// Bucket is a collection of keys and values in a BucketDB. Values returned by a Bucket are only valid for the life of
+// the transaction.
+type Bucket interface {
+	// Get returns the value of the key or nil if it does not exist
+	Get(key []byte) []byte
+
+	// Put sets the value of the key
+	Put(key []byte, value []byte) error
+
+	// Delete removes the key
+	Delete(key []byte) error
+
+	// ForEach calls the function with each key and value in the bucket in key order
+	ForEach(fn func(k, v []byte) error) error
+
+	// Cursor returns a cursor to iterate over the keys of the bucket in order
+	Cursor() BucketCursor
+
+	// Bucket returns the nested bucket with the name or nil if it does not exist
+	Bucket(name []byte) Bucket
+
+	// CreateBucketIfNotExists creates the nested bucket with the name if it does not exist and returns it
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+}
+
+// BucketCursor iterates over the keys of a Bucket in order. Each method returns nil for the key and value when there
+// are no more keys.
+type BucketCursor interface {
+	First() (key []byte, value []byte)
+	Last() (key []byte, value []byte)
+	Seek(seek []byte) (key []byte, value []byte)
+	Next() (key []byte, value []byte)
+	Prev() (key []byte, value []byte)
+
+	// Delete removes the key at the current position of the cursor
+	Delete() error
+}
+
+// ----------------------------------------------------------------------
+// bbolt
+
+// boltDB is a BucketDB that uses bbolt
+type boltDB struct {
+	db *bbolt.DB
+}
+
+var _ BucketDB = (*boltDB)(nil)
+
+// NewBoltDB returns a BucketDB that uses the bbolt database
+func NewBoltDB(db *bbolt.DB) BucketDB {
+	return &boltDB{db: db}
+}
+
+func (d *boltDB) View(fn func(tx BucketTx) error) error {
+	return d.db.View(func(tx *bbolt.Tx) error {
+		return fn(boltTx{tx: tx})
+	})
+}
+
+func (d *boltDB) Update(fn func(tx BucketTx) error) error {
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		return fn(boltTx{tx: tx})
+	})
+}
+
+func (d *boltDB) Close() error {
+	return d.db.Close()
+}
+
+type boltTx struct {
+	tx *bbolt.Tx
+}
+
+func (t boltTx) Bucket(name []byte) Bucket {
+	return boltBucketOrNil(t.tx.Bucket(name))
+}
+
+func (t boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return boltBucket{b}, nil
+}
+
+func (t boltTx) DeleteBucket(name []byte) error {
+	return t.tx.DeleteBucket(name)
+}
+
+// boltBucket is a Bucket that uses a bbolt bucket
+type boltBucket struct {
+	b *bbolt.Bucket
+}
+
+func (b boltBucket) Get(key []byte) []byte {
+	return b.b.Get(key)
+}
+
+func (b boltBucket) Put(key []byte, value []byte) error {
+	return b.b.Put(key, value)
+}
+
+func (b boltBucket) Delete(key []byte) error {
+	return b.b.Delete(key)
+}
+
+func (b boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return b.b.ForEach(fn)
+}
+
+func (b boltBucket) Cursor() BucketCursor {
+	return b.b.Cursor()
+}
+
+func (b boltBucket) Bucket(name []byte) Bucket {
+	return boltBucketOrNil(b.b.Bucket(name))
+}
+
+func (b boltBucket) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	nested, err := b.b.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return boltBucket{nested}, nil
+}
+
+// boltBucketOrNil returns a nil Bucket instead of a Bucket containing a nil *bbolt.Bucket so that callers can check
+// for missing buckets
+func boltBucketOrNil(b *bbolt.Bucket) Bucket {
+	if b == nil {
+		return nil
+	}
+	return boltBucket{b}
+}
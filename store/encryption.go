@@ -0,0 +1,405 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// encryptedValuePrefix starts every encrypted sensitive parameter value. The rest of the value is
+// <key id>:<encrypted data key>:<encrypted value>, where the encrypted parts are base64 encoded.
+const encryptedValuePrefix = "bindplane:encrypted:v1:"
+
+// encryptionKeySize is the size in bytes of the key encryption keys and data keys
+const encryptionKeySize = 32
+
+var (
+	// ErrEncryptionKeyMissing is returned when encrypted sensitive parameter values are read from a store without an
+	// encryption key or when the encryption key is rotated without one
+	ErrEncryptionKeyMissing = errors.New("sensitive parameter values are encrypted but no encryption key is configured")
+
+	// ErrEncryptionNotSupported is returned when rotating the encryption key of a store that does not encrypt sensitive
+	// parameter values
+	ErrEncryptionNotSupported = errors.New("the store does not encrypt sensitive parameter values")
+
+	// ErrEncryptedParameterValue is returned when a resource is written with an encrypted sensitive parameter value that
+	// is not the stored value of the same parameter
+	ErrEncryptedParameterValue = errors.New("sensitive parameter values cannot be written in encrypted form")
+
+	// encryptedKinds are the kinds of resources that can have sensitive parameters or other sensitive values, like the
+	// secret of a webhook
+	encryptedKinds = []model.Kind{
+		model.KindSource,
+		model.KindProcessor,
+		model.KindDestination,
+		model.KindConfiguration,
+		model.KindAlert,
+		model.KindWebhook,
+	}
+)
+
+// EncryptionKeyRotator is implemented by stores that encrypt sensitive parameter values at rest
+type EncryptionKeyRotator interface {
+	// RotateEncryptionKey re-encrypts the sensitive parameter values of every resource and archived version with the
+	// current encryption key. It returns the number of resources and versions that were re-encrypted. After rotation,
+	// previous encryption keys are no longer needed.
+	RotateEncryptionKey(ctx context.Context) (int, error)
+}
+
+// ParameterCipher encrypts the values of sensitive parameters before resources are written to the store and decrypts
+// them when resources are read. It uses envelope encryption: each value is encrypted with AES-256-GCM using a new
+// random data key, and the data key is encrypted with the key encryption key supplied in the configuration.
+//
+// A nil *ParameterCipher leaves values in plaintext.
+type ParameterCipher struct {
+	current *keyEncryptionKey
+	keys    map[string]*keyEncryptionKey
+}
+
+type keyEncryptionKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewParameterCipher returns a ParameterCipher that encrypts values with the key and decrypts values encrypted with the
+// key or any of the previous keys. Keys must be 32 bytes.
+func NewParameterCipher(key []byte, previousKeys ...[]byte) (*ParameterCipher, error) {
+	current, err := newKeyEncryptionKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key: %w", err)
+	}
+	c := &ParameterCipher{
+		current: current,
+		keys:    map[string]*keyEncryptionKey{current.id: current},
+	}
+	for i, previousKey := range previousKeys {
+		previous, err := newKeyEncryptionKey(previousKey)
+		if err != nil {
+			return nil, fmt.Errorf("previous encryption key %d: %w", i, err)
+		}
+		if _, ok := c.keys[previous.id]; !ok {
+			c.keys[previous.id] = previous
+		}
+	}
+	return c, nil
+}
+
+func newKeyEncryptionKey(key []byte) (*keyEncryptionKey, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	// the id identifies the key used to encrypt a value without revealing the key
+	sum := sha256.Sum256(key)
+	return &keyEncryptionKey{
+		id:   hex.EncodeToString(sum[:4]),
+		aead: aead,
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("must be %d bytes, got %d", encryptionKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext and prepends the random nonce
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts data encrypted by seal
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// isEncryptedValue returns true if the value is a value encrypted by encryptValue
+func isEncryptedValue(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, encryptedValuePrefix)
+}
+
+// rejectEncryptedValue is a SensitiveParameterTransform that returns ErrEncryptedParameterValue for encrypted values
+func rejectEncryptedValue(value any) (any, error) {
+	if isEncryptedValue(value) {
+		return nil, ErrEncryptedParameterValue
+	}
+	return value, nil
+}
+
+// encryptValue returns the encrypted json of the value. Values that are already encrypted are rejected so that a
+// resource cannot be written with a value encrypted for another parameter.
+func (c *ParameterCipher) encryptValue(value any) (any, error) {
+	if isEncryptedValue(value) {
+		return nil, ErrEncryptedParameterValue
+	}
+	plaintext, err := jsoniter.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, encryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(dataAEAD, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := seal(c.current.aead, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptedValuePrefix + strings.Join([]string{
+		c.current.id,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+	}, ":"), nil
+}
+
+// decryptParameter is a SensitiveParameterTransform that decrypts values encrypted by encryptValue. Other values are
+// returned unchanged.
+func (c *ParameterCipher) decryptParameter(value any) (any, error) {
+	if !isEncryptedValue(value) {
+		return value, nil
+	}
+	plaintext, err := c.decryptValue(value.(string))
+	if err != nil {
+		return nil, err
+	}
+	var decrypted any
+	if err := jsoniter.Unmarshal(plaintext, &decrypted); err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	return decrypted, nil
+}
+
+// decryptValue returns the json of the value encrypted by encryptValue
+func (c *ParameterCipher) decryptValue(value string) ([]byte, error) {
+	if c == nil {
+		return nil, ErrEncryptionKeyMissing
+	}
+	parts := strings.Split(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed encrypted value")
+	}
+	kek, ok := c.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("value is encrypted with unknown encryption key %s", parts[0])
+	}
+	encryptedKey, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+
+	dataKey, err := open(kek.aead, encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataAEAD, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// marshalResource returns the json of the resource with the values of sensitive parameters encrypted. The resource
+// itself is not modified. Sensitive parameter values that are already encrypted are rejected, even if the cipher is
+// nil, because they would not be readable. Use decryptUnchangedValues first to accept the stored values of parameters.
+func (c *ParameterCipher) marshalResource(resource model.Resource) ([]byte, error) {
+	data, err := jsoniter.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	parameterized, ok := resource.(model.HasSensitiveParameters)
+	if !ok {
+		return data, nil
+	}
+	if c == nil {
+		if err := parameterized.TransformSensitiveParameters(rejectEncryptedValue); err != nil {
+			return nil, fmt.Errorf("sensitive parameters: %w", err)
+		}
+		return data, nil
+	}
+
+	// encrypt a copy of the resource so that the caller keeps the plaintext values
+	copied, ok := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(model.HasSensitiveParameters)
+	if !ok {
+		return data, nil
+	}
+	if err := jsoniter.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	if err := copied.TransformSensitiveParameters(c.encryptValue); err != nil {
+		return nil, fmt.Errorf("encrypt sensitive parameters: %w", err)
+	}
+	return jsoniter.Marshal(copied)
+}
+
+// marshalBackupResource returns the json of a resource restored from a backup. Sensitive parameter values that were
+// encrypted in the backup are decrypted with the current or a previous key before they are encrypted again, so a backup
+// with encrypted values can only be restored into a store with the key.
+func (c *ParameterCipher) marshalBackupResource(resource model.Resource) ([]byte, error) {
+	if err := c.decryptResource(resource); err != nil {
+		return nil, err
+	}
+	return c.marshalResource(resource)
+}
+
+// unmarshalResource unmarshals the json into the resource and decrypts the values of its sensitive parameters. The
+// resource may be a pointer to a resource pointer.
+func (c *ParameterCipher) unmarshalResource(data []byte, resource any) error {
+	if err := jsoniter.Unmarshal(data, resource); err != nil {
+		return err
+	}
+	return c.decryptResource(resource)
+}
+
+// decryptResource decrypts the values of the sensitive parameters of the resource. Only parameters marked Sensitive
+// are decrypted. The resource may be a pointer to a resource pointer.
+func (c *ParameterCipher) decryptResource(resource any) error {
+	v := reflect.ValueOf(resource)
+	for v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+	parameterized, ok := v.Interface().(model.HasSensitiveParameters)
+	if !ok {
+		return nil
+	}
+	if err := parameterized.TransformSensitiveParameters(c.decryptParameter); err != nil {
+		return fmt.Errorf("decrypt sensitive parameters: %w", err)
+	}
+	return nil
+}
+
+// decryptData returns the stored json of a resource of the specified kind with the values of its sensitive parameters
+// decrypted. It returns the data unchanged if nothing is encrypted.
+func (c *ParameterCipher) decryptData(kind model.Kind, data []byte) ([]byte, error) {
+	if len(data) == 0 || !bytes.Contains(data, []byte(encryptedValuePrefix)) {
+		return data, nil
+	}
+	resource, err := model.NewEmptyResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.unmarshalResource(data, resource); err != nil {
+		return nil, err
+	}
+	return jsoniter.Marshal(resource)
+}
+
+// decryptUnchangedValues decrypts the encrypted sensitive parameter values of the resource that are unchanged from the
+// stored json of the existing resource so that they can be written again. Parameters are matched by their position
+// among the sensitive parameters of the resource. Any other encrypted value is rejected with
+// ErrEncryptedParameterValue.
+func (c *ParameterCipher) decryptUnchangedValues(resource model.Resource, stored []byte) error {
+	parameterized, ok := resource.(model.HasSensitiveParameters)
+	if !ok {
+		return nil
+	}
+
+	var storedValues []any
+	if len(stored) > 0 {
+		existing, err := model.NewEmptyResource(resource.GetKind())
+		if err != nil {
+			return err
+		}
+		if err := jsoniter.Unmarshal(stored, existing); err != nil {
+			return err
+		}
+		if existingParameterized, ok := existing.(model.HasSensitiveParameters); ok {
+			err := existingParameterized.TransformSensitiveParameters(func(value any) (any, error) {
+				storedValues = append(storedValues, value)
+				return value, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	i := 0
+	return parameterized.TransformSensitiveParameters(func(value any) (any, error) {
+		index := i
+		i++
+		if !isEncryptedValue(value) {
+			return value, nil
+		}
+		if index >= len(storedValues) || storedValues[index] != value.(string) {
+			return nil, ErrEncryptedParameterValue
+		}
+		return c.decryptParameter(value)
+	})
+}
+
+// reencryptResource returns the stored json of a resource of the specified kind with its sensitive parameter values
+// encrypted with the current key. Values stored in plaintext before encryption was enabled are also encrypted.
+func (c *ParameterCipher) reencryptResource(kind model.Kind, data []byte) ([]byte, error) {
+	if c == nil {
+		return nil, ErrEncryptionKeyMissing
+	}
+	resource, err := model.NewEmptyResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.unmarshalResource(data, resource); err != nil {
+		return nil, err
+	}
+	return c.marshalResource(resource)
+}
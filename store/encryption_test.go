@@ -0,0 +1,197 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterCipher(t *testing.T) {
+	key1 := bytes.Repeat([]byte{1}, 32)
+	key2 := bytes.Repeat([]byte{2}, 32)
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := NewParameterCipher([]byte("short"))
+		require.Error(t, err)
+		_, err = NewParameterCipher(key1, []byte("short"))
+		require.ErrorContains(t, err, "previous encryption key 0")
+	})
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		c, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+
+		for _, value := range []any{"secret", 42.0, []any{"a", "b"}} {
+			encrypted, err := c.encryptValue(value)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(encrypted.(string), encryptedValuePrefix))
+
+			// encrypted values are not encrypted again
+			_, err = c.encryptValue(encrypted)
+			require.ErrorIs(t, err, ErrEncryptedParameterValue)
+
+			plaintext, err := c.decryptValue(encrypted.(string))
+			require.NoError(t, err)
+			expected, _ := jsoniter.Marshal(value)
+			require.Equal(t, expected, plaintext)
+		}
+	})
+
+	t.Run("values are encrypted with a unique data key", func(t *testing.T) {
+		c, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+		first, err := c.encryptValue("secret")
+		require.NoError(t, err)
+		second, err := c.encryptValue("secret")
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+
+	t.Run("decrypt with previous key", func(t *testing.T) {
+		c1, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+		encrypted, err := c1.encryptValue("secret")
+		require.NoError(t, err)
+
+		c2, err := NewParameterCipher(key2, key1)
+		require.NoError(t, err)
+		plaintext, err := c2.decryptValue(encrypted.(string))
+		require.NoError(t, err)
+		require.Equal(t, `"secret"`, string(plaintext))
+
+		c2Only, err := NewParameterCipher(key2)
+		require.NoError(t, err)
+		_, err = c2Only.decryptValue(encrypted.(string))
+		require.ErrorContains(t, err, "unknown encryption key")
+	})
+
+	t.Run("nil cipher", func(t *testing.T) {
+		var c *ParameterCipher
+		destination := model.NewDestination("d", "t", []model.Parameter{
+			{Name: "password", Value: "plain", Sensitive: true},
+		})
+		data, err := c.marshalResource(destination)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"plain"`)
+		decrypted, err := c.decryptData(model.KindDestination, data)
+		require.NoError(t, err)
+		require.Equal(t, data, decrypted)
+
+		c1, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+		encrypted, err := c1.encryptValue("secret")
+		require.NoError(t, err)
+		destination.Spec.Parameters[0].Value = encrypted
+		_, err = c.marshalResource(destination)
+		require.ErrorIs(t, err, ErrEncryptedParameterValue)
+
+		data, err = c1.marshalResource(model.NewDestination("d", "t", []model.Parameter{
+			{Name: "password", Value: "secret", Sensitive: true},
+		}))
+		require.NoError(t, err)
+		_, err = c.decryptData(model.KindDestination, data)
+		require.ErrorIs(t, err, ErrEncryptionKeyMissing)
+	})
+
+	t.Run("only sensitive parameters are decrypted", func(t *testing.T) {
+		c, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+		encrypted, err := c.encryptValue("secret")
+		require.NoError(t, err)
+
+		// a value with the prefix in any other field is left unchanged
+		destination := model.NewDestination("d", "t", []model.Parameter{
+			{Name: "username", Value: encrypted},
+		})
+		destination.Metadata.Description = encrypted.(string)
+		data, err := c.marshalResource(destination)
+		require.NoError(t, err)
+
+		decrypted, err := c.decryptData(model.KindDestination, data)
+		require.NoError(t, err)
+		require.NotContains(t, string(decrypted), "secret")
+
+		var result model.Destination
+		require.NoError(t, c.unmarshalResource(data, &result))
+		require.Equal(t, encrypted, result.Spec.Parameters[0].Value)
+		require.Equal(t, encrypted, result.Metadata.Description)
+	})
+
+	t.Run("decrypt unchanged values", func(t *testing.T) {
+		c, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+		newDestination := func(password1, password2 any) *model.Destination {
+			return model.NewDestination("d", "t", []model.Parameter{
+				{Name: "password1", Value: password1, Sensitive: true},
+				{Name: "password2", Value: password2, Sensitive: true},
+			})
+		}
+		stored, err := c.marshalResource(newDestination("secret1", "secret2"))
+		require.NoError(t, err)
+		var storedDestination model.Destination
+		require.NoError(t, jsoniter.Unmarshal(stored, &storedDestination))
+		encrypted1 := storedDestination.Spec.Parameters[0].Value
+		encrypted2 := storedDestination.Spec.Parameters[1].Value
+		other, err := c.encryptValue("other")
+		require.NoError(t, err)
+
+		// the stored value of the same parameter is decrypted
+		destination := newDestination(encrypted1, "changed")
+		require.NoError(t, c.decryptUnchangedValues(destination, stored))
+		require.Equal(t, "secret1", destination.Spec.Parameters[0].Value)
+		require.Equal(t, "changed", destination.Spec.Parameters[1].Value)
+
+		// values of other parameters or resources are rejected
+		for _, destination := range []*model.Destination{
+			newDestination(encrypted2, encrypted1),
+			newDestination(other, "secret2"),
+		} {
+			require.ErrorIs(t, c.decryptUnchangedValues(destination, stored), ErrEncryptedParameterValue)
+		}
+		require.ErrorIs(t, c.decryptUnchangedValues(newDestination(encrypted1, "secret2"), nil), ErrEncryptedParameterValue)
+	})
+
+	t.Run("marshal resource", func(t *testing.T) {
+		c, err := NewParameterCipher(key1)
+		require.NoError(t, err)
+
+		destination := model.NewDestination("d", "t", []model.Parameter{
+			{Name: "username", Value: "user"},
+			{Name: "password", Value: "secret", Sensitive: true},
+		})
+		plaintext, err := jsoniter.Marshal(destination)
+		require.NoError(t, err)
+
+		data, err := c.marshalResource(destination)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "secret")
+		require.Contains(t, string(data), `"user"`)
+		require.Equal(t, "secret", destination.Spec.Parameters[1].Value)
+
+		decrypted, err := c.decryptData(model.KindDestination, data)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+
+		var result model.Destination
+		require.NoError(t, c.unmarshalResource(data, &result))
+		require.Equal(t, "secret", result.Spec.Parameters[1].Value)
+	})
+}
@@ -0,0 +1,345 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	errMemoryBucketNotFound = errors.New("bucket not found")
+	errMemoryTxNotWritable  = errors.New("tx not writable")
+)
+
+// memoryDB is an in-memory BucketDB. Like bbolt, updates are serialized and each transaction sees a consistent
+// snapshot of the database. Committed buckets are never modified. An update copies each bucket the first time it
+// writes to it and the copies replace the committed buckets when the update is committed, so a failed update leaves
+// the database unchanged and readers are never blocked by an update.
+type memoryDB struct {
+	// writeMtx serializes updates
+	writeMtx sync.Mutex
+
+	// mtx protects root, the committed state of the database
+	mtx  sync.RWMutex
+	root *memoryBucket
+}
+
+var _ BucketDB = (*memoryDB)(nil)
+
+// newMemoryDB returns an empty in-memory BucketDB
+func newMemoryDB() *memoryDB {
+	return &memoryDB{
+		root: newMemoryBucket(),
+	}
+}
+
+func (d *memoryDB) View(fn func(tx BucketTx) error) error {
+	d.mtx.RLock()
+	root := d.root
+	d.mtx.RUnlock()
+
+	return fn(&memoryTx{root: root})
+}
+
+func (d *memoryDB) Update(fn func(tx BucketTx) error) error {
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+
+	d.mtx.RLock()
+	root := d.root
+	d.mtx.RUnlock()
+
+	tx := &memoryTx{
+		root:  root.clone(),
+		owned: map[*memoryBucket]bool{},
+	}
+	tx.owned[tx.root] = true
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	d.mtx.Lock()
+	d.root = tx.root
+	d.mtx.Unlock()
+	return nil
+}
+
+func (d *memoryDB) Close() error {
+	return nil
+}
+
+// memoryBucket contains values and nested buckets by key. keys contains the keys of the values in order.
+type memoryBucket struct {
+	keys    []string
+	values  map[string][]byte
+	buckets map[string]*memoryBucket
+}
+
+func newMemoryBucket() *memoryBucket {
+	return &memoryBucket{
+		values:  map[string][]byte{},
+		buckets: map[string]*memoryBucket{},
+	}
+}
+
+// clone returns a copy of the bucket that can be modified. Values and nested buckets are not copied because they are
+// never modified.
+func (b *memoryBucket) clone() *memoryBucket {
+	c := &memoryBucket{
+		keys:    make([]string, len(b.keys)),
+		values:  make(map[string][]byte, len(b.values)),
+		buckets: make(map[string]*memoryBucket, len(b.buckets)),
+	}
+	copy(c.keys, b.keys)
+	for k, v := range b.values {
+		c.values[k] = v
+	}
+	for k, v := range b.buckets {
+		c.buckets[k] = v
+	}
+	return c
+}
+
+func (b *memoryBucket) put(key string, value []byte) {
+	if _, ok := b.values[key]; !ok {
+		i := sort.SearchStrings(b.keys, key)
+		b.keys = append(b.keys, "")
+		copy(b.keys[i+1:], b.keys[i:])
+		b.keys[i] = key
+	}
+	b.values[key] = value
+}
+
+func (b *memoryBucket) delete(key string) {
+	if _, ok := b.values[key]; !ok {
+		return
+	}
+	i := sort.SearchStrings(b.keys, key)
+	b.keys = append(b.keys[:i], b.keys[i+1:]...)
+	delete(b.values, key)
+}
+
+// memoryTx is a transaction on a memoryDB. Writable transactions own the buckets they have copied.
+type memoryTx struct {
+	root  *memoryBucket
+	owned map[*memoryBucket]bool
+}
+
+var _ BucketTx = (*memoryTx)(nil)
+
+func (tx *memoryTx) Bucket(name []byte) Bucket {
+	return tx.bucket([]string{string(name)})
+}
+
+func (tx *memoryTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	return tx.createBucketIfNotExists(nil, string(name))
+}
+
+func (tx *memoryTx) DeleteBucket(name []byte) error {
+	if tx.owned == nil {
+		return errMemoryTxNotWritable
+	}
+	if _, ok := tx.root.buckets[string(name)]; !ok {
+		return errMemoryBucketNotFound
+	}
+	delete(tx.root.buckets, string(name))
+	return nil
+}
+
+// bucket returns the bucket at the path or nil if it does not exist
+func (tx *memoryTx) bucket(path []string) Bucket {
+	if tx.resolve(path) == nil {
+		return nil
+	}
+	return &memoryBucketHandle{tx: tx, path: path}
+}
+
+func (tx *memoryTx) createBucketIfNotExists(parentPath []string, name string) (Bucket, error) {
+	parent, err := tx.writable(parentPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := parent.buckets[name]; !ok {
+		b := newMemoryBucket()
+		tx.owned[b] = true
+		parent.buckets[name] = b
+	}
+	path := append(append([]string{}, parentPath...), name)
+	return &memoryBucketHandle{tx: tx, path: path}, nil
+}
+
+// resolve returns the bucket at the path or nil if it does not exist. Buckets are resolved by path for each operation
+// so that every handle to a bucket sees the copy made by the first write.
+func (tx *memoryTx) resolve(path []string) *memoryBucket {
+	b := tx.root
+	for _, name := range path {
+		if b = b.buckets[name]; b == nil {
+			return nil
+		}
+	}
+	return b
+}
+
+// writable returns the bucket at the path, copying it and its parents if they are not owned by the transaction
+func (tx *memoryTx) writable(path []string) (*memoryBucket, error) {
+	if tx.owned == nil {
+		return nil, errMemoryTxNotWritable
+	}
+	b := tx.root
+	for _, name := range path {
+		child := b.buckets[name]
+		if child == nil {
+			return nil, errMemoryBucketNotFound
+		}
+		if !tx.owned[child] {
+			child = child.clone()
+			tx.owned[child] = true
+			b.buckets[name] = child
+		}
+		b = child
+	}
+	return b, nil
+}
+
+// memoryBucketHandle is a Bucket in a memoryTx
+type memoryBucketHandle struct {
+	tx   *memoryTx
+	path []string
+}
+
+var _ Bucket = (*memoryBucketHandle)(nil)
+
+func (h *memoryBucketHandle) Get(key []byte) []byte {
+	b := h.tx.resolve(h.path)
+	if b == nil {
+		return nil
+	}
+	return b.values[string(key)]
+}
+
+func (h *memoryBucketHandle) Put(key []byte, value []byte) error {
+	b, err := h.tx.writable(h.path)
+	if err != nil {
+		return err
+	}
+	// like bbolt, the value is copied so that the caller can reuse it
+	b.put(string(key), append([]byte{}, value...))
+	return nil
+}
+
+func (h *memoryBucketHandle) Delete(key []byte) error {
+	b, err := h.tx.writable(h.path)
+	if err != nil {
+		return err
+	}
+	b.delete(string(key))
+	return nil
+}
+
+func (h *memoryBucketHandle) ForEach(fn func(k, v []byte) error) error {
+	b := h.tx.resolve(h.path)
+	if b == nil {
+		return errMemoryBucketNotFound
+	}
+	// iterate over the keys at the start so that fn can modify the bucket
+	for _, k := range append([]string{}, b.keys...) {
+		if err := fn([]byte(k), b.values[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *memoryBucketHandle) Cursor() BucketCursor {
+	return &memoryCursor{bucket: h}
+}
+
+func (h *memoryBucketHandle) Bucket(name []byte) Bucket {
+	return h.tx.bucket(append(append([]string{}, h.path...), string(name)))
+}
+
+func (h *memoryBucketHandle) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	return h.tx.createBucketIfNotExists(h.path, string(name))
+}
+
+// memoryCursor is a BucketCursor on a memoryBucketHandle. It keeps the current key instead of an index so that the
+// bucket can be modified while iterating.
+type memoryCursor struct {
+	bucket *memoryBucketHandle
+	key    string
+	valid  bool
+}
+
+var _ BucketCursor = (*memoryCursor)(nil)
+
+// at moves the cursor to the key at index i of the bucket keys and returns it
+func (c *memoryCursor) at(b *memoryBucket, i int) ([]byte, []byte) {
+	if b == nil || i < 0 || i >= len(b.keys) {
+		c.valid = false
+		return nil, nil
+	}
+	c.key, c.valid = b.keys[i], true
+	return []byte(c.key), b.values[c.key]
+}
+
+func (c *memoryCursor) resolve() *memoryBucket {
+	return c.bucket.tx.resolve(c.bucket.path)
+}
+
+func (c *memoryCursor) First() ([]byte, []byte) {
+	return c.at(c.resolve(), 0)
+}
+
+func (c *memoryCursor) Last() ([]byte, []byte) {
+	b := c.resolve()
+	if b == nil {
+		return nil, nil
+	}
+	return c.at(b, len(b.keys)-1)
+}
+
+func (c *memoryCursor) Seek(seek []byte) ([]byte, []byte) {
+	b := c.resolve()
+	if b == nil {
+		return nil, nil
+	}
+	return c.at(b, sort.SearchStrings(b.keys, string(seek)))
+}
+
+func (c *memoryCursor) Next() ([]byte, []byte) {
+	b := c.resolve()
+	if !c.valid || b == nil {
+		return nil, nil
+	}
+	return c.at(b, sort.Search(len(b.keys), func(i int) bool { return b.keys[i] > c.key }))
+}
+
+func (c *memoryCursor) Prev() ([]byte, []byte) {
+	b := c.resolve()
+	if !c.valid || b == nil {
+		return nil, nil
+	}
+	return c.at(b, sort.SearchStrings(b.keys, c.key)-1)
+}
+
+func (c *memoryCursor) Delete() error {
+	if !c.valid {
+		return nil
+	}
+	return c.bucket.Delete([]byte(c.key))
+}
@@ -0,0 +1,160 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDB(t *testing.T) {
+	newDB := func(t *testing.T) *memoryDB {
+		db := newMemoryDB()
+		require.NoError(t, db.Update(func(tx BucketTx) error {
+			b, err := tx.CreateBucketIfNotExists([]byte("bucket"))
+			require.NoError(t, err)
+			for _, key := range []string{"b", "a", "c"} {
+				require.NoError(t, b.Put([]byte(key), []byte(key+"-value")))
+			}
+			_, err = b.CreateBucketIfNotExists([]byte("nested"))
+			return err
+		}))
+		return db
+	}
+	keys := func(b Bucket) []string {
+		var result []string
+		_ = b.ForEach(func(k, _ []byte) error {
+			result = append(result, string(k))
+			return nil
+		})
+		return result
+	}
+
+	t.Run("keys are ordered", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			b := tx.Bucket([]byte("bucket"))
+			require.Equal(t, []string{"a", "b", "c"}, keys(b))
+
+			c := b.Cursor()
+			k, v := c.Seek([]byte("b"))
+			require.Equal(t, "b", string(k))
+			require.Equal(t, "b-value", string(v))
+			k, _ = c.Next()
+			require.Equal(t, "c", string(k))
+			k, _ = c.Next()
+			require.Nil(t, k)
+			k, _ = c.Last()
+			require.Equal(t, "c", string(k))
+			k, _ = c.Prev()
+			require.Equal(t, "b", string(k))
+			return nil
+		}))
+	})
+
+	t.Run("missing buckets are nil", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			require.Nil(t, tx.Bucket([]byte("missing")))
+			require.Nil(t, tx.Bucket([]byte("bucket")).Bucket([]byte("missing")))
+			require.NotNil(t, tx.Bucket([]byte("bucket")).Bucket([]byte("nested")))
+			return nil
+		}))
+	})
+
+	t.Run("views are read only", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			require.ErrorIs(t, tx.Bucket([]byte("bucket")).Put([]byte("d"), nil), errMemoryTxNotWritable)
+			_, err := tx.CreateBucketIfNotExists([]byte("other"))
+			require.ErrorIs(t, err, errMemoryTxNotWritable)
+			return nil
+		}))
+	})
+
+	t.Run("failed updates are rolled back", func(t *testing.T) {
+		db := newDB(t)
+		err := db.Update(func(tx BucketTx) error {
+			b := tx.Bucket([]byte("bucket"))
+			require.NoError(t, b.Put([]byte("d"), []byte("d-value")))
+			require.NoError(t, b.Delete([]byte("a")))
+			nested, err := b.Bucket([]byte("nested")).CreateBucketIfNotExists([]byte("deeper"))
+			require.NoError(t, err)
+			require.NoError(t, nested.Put([]byte("e"), nil))
+
+			// writes are visible in the transaction through every handle to the bucket
+			require.Equal(t, []string{"b", "c", "d"}, keys(tx.Bucket([]byte("bucket"))))
+			return errors.New("failed")
+		})
+		require.EqualError(t, err, "failed")
+
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			b := tx.Bucket([]byte("bucket"))
+			require.Equal(t, []string{"a", "b", "c"}, keys(b))
+			require.Nil(t, b.Bucket([]byte("nested")).Bucket([]byte("deeper")))
+			return nil
+		}))
+	})
+
+	t.Run("views see the committed state", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.Update(func(tx BucketTx) error {
+			require.NoError(t, tx.Bucket([]byte("bucket")).Put([]byte("d"), []byte("d-value")))
+
+			// a view in the update does not see the uncommitted write
+			return db.View(func(tx BucketTx) error {
+				require.Nil(t, tx.Bucket([]byte("bucket")).Get([]byte("d")))
+				return nil
+			})
+		}))
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			require.Equal(t, "d-value", string(tx.Bucket([]byte("bucket")).Get([]byte("d"))))
+			return nil
+		}))
+	})
+
+	t.Run("cursor deletes while iterating", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.Update(func(tx BucketTx) error {
+			c := tx.Bucket([]byte("bucket")).Cursor()
+			var visited []string
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				visited = append(visited, string(k))
+				require.NoError(t, c.Delete())
+			}
+			require.Equal(t, []string{"a", "b", "c"}, visited)
+			return nil
+		}))
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			require.Empty(t, keys(tx.Bucket([]byte("bucket"))))
+			return nil
+		}))
+	})
+
+	t.Run("delete bucket", func(t *testing.T) {
+		db := newDB(t)
+		require.NoError(t, db.Update(func(tx BucketTx) error {
+			require.NoError(t, tx.DeleteBucket([]byte("bucket")))
+			require.ErrorIs(t, tx.DeleteBucket([]byte("bucket")), errMemoryBucketNotFound)
+			return nil
+		}))
+		require.NoError(t, db.View(func(tx BucketTx) error {
+			require.Nil(t, tx.Bucket([]byte("bucket")))
+			return nil
+		}))
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+var mp metric.Meter = otel.Meter("store")
+
+// operationDuration records the latency of store operations by operation name
+var operationDuration = newOperationDuration()
+
+func newOperationDuration() metric.Float64Histogram {
+	h, err := mp.Float64Histogram("store_operation_duration",
+		metric.WithDescription("Duration of store operations in milliseconds"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return noop.Float64Histogram{}
+	}
+	return h
+}
+
+// measureOperation starts timing a store operation and returns a function that records its duration. It is used as:
+//
+//	defer measureOperation(ctx, "UpdateAgents")()
+func measureOperation(ctx context.Context, operation string) func() {
+	start := time.Now()
+	return func() {
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		operationDuration.Record(ctx, elapsed, metric.WithAttributes(attribute.String("operation", operation)))
+	}
+}
@@ -32,6 +32,214 @@ func (_m *mockStore) EXPECT() *mockStore_Expecter {
 	return &mockStore_Expecter{mock: &_m.Mock}
 }
 
+// APIKey provides a mock function with given fields: ctx, id
+func (_m *mockStore) APIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_APIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKey'
+type mockStore_APIKey_Call struct {
+	*mock.Call
+}
+
+// APIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *mockStore_Expecter) APIKey(ctx interface{}, id interface{}) *mockStore_APIKey_Call {
+	return &mockStore_APIKey_Call{Call: _e.mock.On("APIKey", ctx, id)}
+}
+
+func (_c *mockStore_APIKey_Call) Run(run func(ctx context.Context, id string)) *mockStore_APIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_APIKey_Call) Return(_a0 *model.APIKey, _a1 error) *mockStore_APIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_APIKey_Call) RunAndReturn(run func(context.Context, string) (*model.APIKey, error)) *mockStore_APIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// APIKeys provides a mock function with given fields: ctx
+func (_m *mockStore) APIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.APIKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_APIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKeys'
+type mockStore_APIKeys_Call struct {
+	*mock.Call
+}
+
+// APIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStore_Expecter) APIKeys(ctx interface{}) *mockStore_APIKeys_Call {
+	return &mockStore_APIKeys_Call{Call: _e.mock.On("APIKeys", ctx)}
+}
+
+func (_c *mockStore_APIKeys_Call) Run(run func(ctx context.Context)) *mockStore_APIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStore_APIKeys_Call) Return(_a0 []*model.APIKey, _a1 error) *mockStore_APIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_APIKeys_Call) RunAndReturn(run func(context.Context) ([]*model.APIKey, error)) *mockStore_APIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddAuditEvents provides a mock function with given fields: ctx, events
+func (_m *mockStore) AddAuditEvents(ctx context.Context, events []*model.AuditEvent) error {
+	ret := _m.Called(ctx, events)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AuditEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockStore_AddAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEvents'
+type mockStore_AddAuditEvents_Call struct {
+	*mock.Call
+}
+
+// AddAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []*model.AuditEvent
+func (_e *mockStore_Expecter) AddAuditEvents(ctx interface{}, events interface{}) *mockStore_AddAuditEvents_Call {
+	return &mockStore_AddAuditEvents_Call{Call: _e.mock.On("AddAuditEvents", ctx, events)}
+}
+
+func (_c *mockStore_AddAuditEvents_Call) Run(run func(ctx context.Context, events []*model.AuditEvent)) *mockStore_AddAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*model.AuditEvent))
+	})
+	return _c
+}
+
+func (_c *mockStore_AddAuditEvents_Call) Return(_a0 error) *mockStore_AddAuditEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockStore_AddAuditEvents_Call) RunAndReturn(run func(context.Context, []*model.AuditEvent) error) *mockStore_AddAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddWebhookDelivery provides a mock function with given fields: ctx, name, delivery
+func (_m *mockStore) AddWebhookDelivery(ctx context.Context, name string, delivery model.WebhookDelivery) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name, delivery)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.WebhookDelivery) (*model.Webhook, error)); ok {
+		return rf(ctx, name, delivery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.WebhookDelivery) *model.Webhook); ok {
+		r0 = rf(ctx, name, delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.WebhookDelivery) error); ok {
+		r1 = rf(ctx, name, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_AddWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWebhookDelivery'
+type mockStore_AddWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// AddWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - delivery model.WebhookDelivery
+func (_e *mockStore_Expecter) AddWebhookDelivery(ctx interface{}, name interface{}, delivery interface{}) *mockStore_AddWebhookDelivery_Call {
+	return &mockStore_AddWebhookDelivery_Call{Call: _e.mock.On("AddWebhookDelivery", ctx, name, delivery)}
+}
+
+func (_c *mockStore_AddWebhookDelivery_Call) Run(run func(ctx context.Context, name string, delivery model.WebhookDelivery)) *mockStore_AddWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *mockStore_AddWebhookDelivery_Call) Return(_a0 *model.Webhook, _a1 error) *mockStore_AddWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_AddWebhookDelivery_Call) RunAndReturn(run func(context.Context, string, model.WebhookDelivery) (*model.Webhook, error)) *mockStore_AddWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Agent provides a mock function with given fields: ctx, id
 func (_m *mockStore) Agent(ctx context.Context, id string) (*model.Agent, error) {
 	ret := _m.Called(ctx, id)
@@ -486,6 +694,115 @@ func (_c *mockStore_AgentsIDsMatchingConfiguration_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// Alert provides a mock function with given fields: ctx, name
+func (_m *mockStore) Alert(ctx context.Context, name string) (*model.Alert, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Alert, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Alert); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Alert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Alert'
+type mockStore_Alert_Call struct {
+	*mock.Call
+}
+
+// Alert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockStore_Expecter) Alert(ctx interface{}, name interface{}) *mockStore_Alert_Call {
+	return &mockStore_Alert_Call{Call: _e.mock.On("Alert", ctx, name)}
+}
+
+func (_c *mockStore_Alert_Call) Run(run func(ctx context.Context, name string)) *mockStore_Alert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_Alert_Call) Return(_a0 *model.Alert, _a1 error) *mockStore_Alert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Alert_Call) RunAndReturn(run func(context.Context, string) (*model.Alert, error)) *mockStore_Alert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Alerts provides a mock function with given fields: ctx
+func (_m *mockStore) Alerts(ctx context.Context) ([]*model.Alert, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Alert, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Alert); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Alerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Alerts'
+type mockStore_Alerts_Call struct {
+	*mock.Call
+}
+
+// Alerts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStore_Expecter) Alerts(ctx interface{}) *mockStore_Alerts_Call {
+	return &mockStore_Alerts_Call{Call: _e.mock.On("Alerts", ctx)}
+}
+
+func (_c *mockStore_Alerts_Call) Run(run func(ctx context.Context)) *mockStore_Alerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStore_Alerts_Call) Return(_a0 []*model.Alert, _a1 error) *mockStore_Alerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Alerts_Call) RunAndReturn(run func(context.Context) ([]*model.Alert, error)) *mockStore_Alerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ApplyResources provides a mock function with given fields: ctx, resources
 func (_m *mockStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
 	ret := _m.Called(ctx, resources)
@@ -541,6 +858,61 @@ func (_c *mockStore_ApplyResources_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// AuditEvents provides a mock function with given fields: ctx, filter
+func (_m *mockStore) AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*model.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) ([]*model.AuditEvent, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) []*model.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_AuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEvents'
+type mockStore_AuditEvents_Call struct {
+	*mock.Call
+}
+
+// AuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.AuditEventFilter
+func (_e *mockStore_Expecter) AuditEvents(ctx interface{}, filter interface{}) *mockStore_AuditEvents_Call {
+	return &mockStore_AuditEvents_Call{Call: _e.mock.On("AuditEvents", ctx, filter)}
+}
+
+func (_c *mockStore_AuditEvents_Call) Run(run func(ctx context.Context, filter model.AuditEventFilter)) *mockStore_AuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.AuditEventFilter))
+	})
+	return _c
+}
+
+func (_c *mockStore_AuditEvents_Call) Return(_a0 []*model.AuditEvent, _a1 error) *mockStore_AuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_AuditEvents_Call) RunAndReturn(run func(context.Context, model.AuditEventFilter) ([]*model.AuditEvent, error)) *mockStore_AuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupDisconnectedAgents provides a mock function with given fields: ctx, since
 func (_m *mockStore) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
 	ret := _m.Called(ctx, since)
@@ -825,6 +1197,61 @@ func (_c *mockStore_Configurations_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteAPIKey provides a mock function with given fields: ctx, id
+func (_m *mockStore) DeleteAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_DeleteAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIKey'
+type mockStore_DeleteAPIKey_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *mockStore_Expecter) DeleteAPIKey(ctx interface{}, id interface{}) *mockStore_DeleteAPIKey_Call {
+	return &mockStore_DeleteAPIKey_Call{Call: _e.mock.On("DeleteAPIKey", ctx, id)}
+}
+
+func (_c *mockStore_DeleteAPIKey_Call) Run(run func(ctx context.Context, id string)) *mockStore_DeleteAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_DeleteAPIKey_Call) Return(_a0 *model.APIKey, _a1 error) *mockStore_DeleteAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_DeleteAPIKey_Call) RunAndReturn(run func(context.Context, string) (*model.APIKey, error)) *mockStore_DeleteAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteAgentVersion provides a mock function with given fields: ctx, name
 func (_m *mockStore) DeleteAgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
 	ret := _m.Called(ctx, name)
@@ -886,19 +1313,74 @@ func (_m *mockStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mo
 
 	var r0 []*model.Agent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Agent, error)); ok {
-		return rf(ctx, agentIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Agent, error)); ok {
+		return rf(ctx, agentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.Agent); ok {
+		r0 = rf(ctx, agentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Agent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, agentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_DeleteAgents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAgents'
+type mockStore_DeleteAgents_Call struct {
+	*mock.Call
+}
+
+// DeleteAgents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - agentIDs []string
+func (_e *mockStore_Expecter) DeleteAgents(ctx interface{}, agentIDs interface{}) *mockStore_DeleteAgents_Call {
+	return &mockStore_DeleteAgents_Call{Call: _e.mock.On("DeleteAgents", ctx, agentIDs)}
+}
+
+func (_c *mockStore_DeleteAgents_Call) Run(run func(ctx context.Context, agentIDs []string)) *mockStore_DeleteAgents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *mockStore_DeleteAgents_Call) Return(_a0 []*model.Agent, _a1 error) *mockStore_DeleteAgents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_DeleteAgents_Call) RunAndReturn(run func(context.Context, []string) ([]*model.Agent, error)) *mockStore_DeleteAgents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlert provides a mock function with given fields: ctx, name
+func (_m *mockStore) DeleteAlert(ctx context.Context, name string) (*model.Alert, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Alert, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.Agent); ok {
-		r0 = rf(ctx, agentIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Alert); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Agent)
+			r0 = ret.Get(0).(*model.Alert)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, agentIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -906,31 +1388,31 @@ func (_m *mockStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mo
 	return r0, r1
 }
 
-// mockStore_DeleteAgents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAgents'
-type mockStore_DeleteAgents_Call struct {
+// mockStore_DeleteAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlert'
+type mockStore_DeleteAlert_Call struct {
 	*mock.Call
 }
 
-// DeleteAgents is a helper method to define mock.On call
+// DeleteAlert is a helper method to define mock.On call
 //   - ctx context.Context
-//   - agentIDs []string
-func (_e *mockStore_Expecter) DeleteAgents(ctx interface{}, agentIDs interface{}) *mockStore_DeleteAgents_Call {
-	return &mockStore_DeleteAgents_Call{Call: _e.mock.On("DeleteAgents", ctx, agentIDs)}
+//   - name string
+func (_e *mockStore_Expecter) DeleteAlert(ctx interface{}, name interface{}) *mockStore_DeleteAlert_Call {
+	return &mockStore_DeleteAlert_Call{Call: _e.mock.On("DeleteAlert", ctx, name)}
 }
 
-func (_c *mockStore_DeleteAgents_Call) Run(run func(ctx context.Context, agentIDs []string)) *mockStore_DeleteAgents_Call {
+func (_c *mockStore_DeleteAlert_Call) Run(run func(ctx context.Context, name string)) *mockStore_DeleteAlert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *mockStore_DeleteAgents_Call) Return(_a0 []*model.Agent, _a1 error) *mockStore_DeleteAgents_Call {
+func (_c *mockStore_DeleteAlert_Call) Return(_a0 *model.Alert, _a1 error) *mockStore_DeleteAlert_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockStore_DeleteAgents_Call) RunAndReturn(run func(context.Context, []string) ([]*model.Agent, error)) *mockStore_DeleteAgents_Call {
+func (_c *mockStore_DeleteAlert_Call) RunAndReturn(run func(context.Context, string) (*model.Alert, error)) *mockStore_DeleteAlert_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1265,6 +1747,61 @@ func (_c *mockStore_DeleteResources_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteSnapshot provides a mock function with given fields: ctx, id
+func (_m *mockStore) DeleteSnapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Snapshot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Snapshot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_DeleteSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSnapshot'
+type mockStore_DeleteSnapshot_Call struct {
+	*mock.Call
+}
+
+// DeleteSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *mockStore_Expecter) DeleteSnapshot(ctx interface{}, id interface{}) *mockStore_DeleteSnapshot_Call {
+	return &mockStore_DeleteSnapshot_Call{Call: _e.mock.On("DeleteSnapshot", ctx, id)}
+}
+
+func (_c *mockStore_DeleteSnapshot_Call) Run(run func(ctx context.Context, id string)) *mockStore_DeleteSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_DeleteSnapshot_Call) Return(_a0 *model.Snapshot, _a1 error) *mockStore_DeleteSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_DeleteSnapshot_Call) RunAndReturn(run func(context.Context, string) (*model.Snapshot, error)) *mockStore_DeleteSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteSource provides a mock function with given fields: ctx, name
 func (_m *mockStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
 	ret := _m.Called(ctx, name)
@@ -1375,6 +1912,116 @@ func (_c *mockStore_DeleteSourceType_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DeleteUser provides a mock function with given fields: ctx, name
+func (_m *mockStore) DeleteUser(ctx context.Context, name string) (*model.User, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type mockStore_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockStore_Expecter) DeleteUser(ctx interface{}, name interface{}) *mockStore_DeleteUser_Call {
+	return &mockStore_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, name)}
+}
+
+func (_c *mockStore_DeleteUser_Call) Run(run func(ctx context.Context, name string)) *mockStore_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_DeleteUser_Call) Return(_a0 *model.User, _a1 error) *mockStore_DeleteUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_DeleteUser_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *mockStore_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, name
+func (_m *mockStore) DeleteWebhook(ctx context.Context, name string) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type mockStore_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockStore_Expecter) DeleteWebhook(ctx interface{}, name interface{}) *mockStore_DeleteWebhook_Call {
+	return &mockStore_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, name)}
+}
+
+func (_c *mockStore_DeleteWebhook_Call) Run(run func(ctx context.Context, name string)) *mockStore_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_DeleteWebhook_Call) Return(_a0 *model.Webhook, _a1 error) *mockStore_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) (*model.Webhook, error)) *mockStore_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Destination provides a mock function with given fields: ctx, name
 func (_m *mockStore) Destination(ctx context.Context, name string) (*model.Destination, error) {
 	ret := _m.Called(ctx, name)
@@ -2097,12 +2744,164 @@ func (_c *mockStore_ResumeRollout_Call) Run(run func(ctx context.Context, config
 	return _c
 }
 
-func (_c *mockStore_ResumeRollout_Call) Return(_a0 *model.Configuration, _a1 error) *mockStore_ResumeRollout_Call {
+func (_c *mockStore_ResumeRollout_Call) Return(_a0 *model.Configuration, _a1 error) *mockStore_ResumeRollout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_ResumeRollout_Call) RunAndReturn(run func(context.Context, string) (*model.Configuration, error)) *mockStore_ResumeRollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *mockStore) SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Snapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockStore_SaveSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSnapshot'
+type mockStore_SaveSnapshot_Call struct {
+	*mock.Call
+}
+
+// SaveSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *model.Snapshot
+func (_e *mockStore_Expecter) SaveSnapshot(ctx interface{}, snapshot interface{}) *mockStore_SaveSnapshot_Call {
+	return &mockStore_SaveSnapshot_Call{Call: _e.mock.On("SaveSnapshot", ctx, snapshot)}
+}
+
+func (_c *mockStore_SaveSnapshot_Call) Run(run func(ctx context.Context, snapshot *model.Snapshot)) *mockStore_SaveSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Snapshot))
+	})
+	return _c
+}
+
+func (_c *mockStore_SaveSnapshot_Call) Return(_a0 error) *mockStore_SaveSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockStore_SaveSnapshot_Call) RunAndReturn(run func(context.Context, *model.Snapshot) error) *mockStore_SaveSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Snapshot provides a mock function with given fields: ctx, id
+func (_m *mockStore) Snapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Snapshot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Snapshot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Snapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshot'
+type mockStore_Snapshot_Call struct {
+	*mock.Call
+}
+
+// Snapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *mockStore_Expecter) Snapshot(ctx interface{}, id interface{}) *mockStore_Snapshot_Call {
+	return &mockStore_Snapshot_Call{Call: _e.mock.On("Snapshot", ctx, id)}
+}
+
+func (_c *mockStore_Snapshot_Call) Run(run func(ctx context.Context, id string)) *mockStore_Snapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_Snapshot_Call) Return(_a0 *model.Snapshot, _a1 error) *mockStore_Snapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Snapshot_Call) RunAndReturn(run func(context.Context, string) (*model.Snapshot, error)) *mockStore_Snapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Snapshots provides a mock function with given fields: ctx
+func (_m *mockStore) Snapshots(ctx context.Context) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Snapshot, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Snapshot); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Snapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshots'
+type mockStore_Snapshots_Call struct {
+	*mock.Call
+}
+
+// Snapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStore_Expecter) Snapshots(ctx interface{}) *mockStore_Snapshots_Call {
+	return &mockStore_Snapshots_Call{Call: _e.mock.On("Snapshots", ctx)}
+}
+
+func (_c *mockStore_Snapshots_Call) Run(run func(ctx context.Context)) *mockStore_Snapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStore_Snapshots_Call) Return(_a0 []*model.Snapshot, _a1 error) *mockStore_Snapshots_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockStore_ResumeRollout_Call) RunAndReturn(run func(context.Context, string) (*model.Configuration, error)) *mockStore_ResumeRollout_Call {
+func (_c *mockStore_Snapshots_Call) RunAndReturn(run func(context.Context) ([]*model.Snapshot, error)) *mockStore_Snapshots_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2537,6 +3336,62 @@ func (_c *mockStore_UpdateAgents_Call) RunAndReturn(run func(context.Context, []
 	return _c
 }
 
+// UpdateAlertStatus provides a mock function with given fields: ctx, name, status
+func (_m *mockStore) UpdateAlertStatus(ctx context.Context, name string, status model.AlertStatus) (*model.Alert, error) {
+	ret := _m.Called(ctx, name, status)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AlertStatus) (*model.Alert, error)); ok {
+		return rf(ctx, name, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AlertStatus) *model.Alert); ok {
+		r0 = rf(ctx, name, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.AlertStatus) error); ok {
+		r1 = rf(ctx, name, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_UpdateAlertStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlertStatus'
+type mockStore_UpdateAlertStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateAlertStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - status model.AlertStatus
+func (_e *mockStore_Expecter) UpdateAlertStatus(ctx interface{}, name interface{}, status interface{}) *mockStore_UpdateAlertStatus_Call {
+	return &mockStore_UpdateAlertStatus_Call{Call: _e.mock.On("UpdateAlertStatus", ctx, name, status)}
+}
+
+func (_c *mockStore_UpdateAlertStatus_Call) Run(run func(ctx context.Context, name string, status model.AlertStatus)) *mockStore_UpdateAlertStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.AlertStatus))
+	})
+	return _c
+}
+
+func (_c *mockStore_UpdateAlertStatus_Call) Return(_a0 *model.Alert, _a1 error) *mockStore_UpdateAlertStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_UpdateAlertStatus_Call) RunAndReturn(run func(context.Context, string, model.AlertStatus) (*model.Alert, error)) *mockStore_UpdateAlertStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateAllRollouts provides a mock function with given fields: ctx
 func (_m *mockStore) UpdateAllRollouts(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -2795,6 +3650,49 @@ func (_c *mockStore_Updates_Call) RunAndReturn(run func(context.Context) eventbu
 	return _c
 }
 
+// UpsertAPIKey provides a mock function with given fields: ctx, apiKey
+func (_m *mockStore) UpsertAPIKey(ctx context.Context, apiKey *model.APIKey) error {
+	ret := _m.Called(ctx, apiKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.APIKey) error); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockStore_UpsertAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertAPIKey'
+type mockStore_UpsertAPIKey_Call struct {
+	*mock.Call
+}
+
+// UpsertAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey *model.APIKey
+func (_e *mockStore_Expecter) UpsertAPIKey(ctx interface{}, apiKey interface{}) *mockStore_UpsertAPIKey_Call {
+	return &mockStore_UpsertAPIKey_Call{Call: _e.mock.On("UpsertAPIKey", ctx, apiKey)}
+}
+
+func (_c *mockStore_UpsertAPIKey_Call) Run(run func(ctx context.Context, apiKey *model.APIKey)) *mockStore_UpsertAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.APIKey))
+	})
+	return _c
+}
+
+func (_c *mockStore_UpsertAPIKey_Call) Return(_a0 error) *mockStore_UpsertAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockStore_UpsertAPIKey_Call) RunAndReturn(run func(context.Context, *model.APIKey) error) *mockStore_UpsertAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertAgent provides a mock function with given fields: ctx, agentID, updater
 func (_m *mockStore) UpsertAgent(ctx context.Context, agentID string, updater AgentUpdater) (*model.Agent, error) {
 	ret := _m.Called(ctx, agentID, updater)
@@ -2907,6 +3805,104 @@ func (_c *mockStore_UpsertAgents_Call) RunAndReturn(run func(context.Context, []
 	return _c
 }
 
+// UpsertUser provides a mock function with given fields: ctx, user
+func (_m *mockStore) UpsertUser(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockStore_UpsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUser'
+type mockStore_UpsertUser_Call struct {
+	*mock.Call
+}
+
+// UpsertUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+func (_e *mockStore_Expecter) UpsertUser(ctx interface{}, user interface{}) *mockStore_UpsertUser_Call {
+	return &mockStore_UpsertUser_Call{Call: _e.mock.On("UpsertUser", ctx, user)}
+}
+
+func (_c *mockStore_UpsertUser_Call) Run(run func(ctx context.Context, user *model.User)) *mockStore_UpsertUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User))
+	})
+	return _c
+}
+
+func (_c *mockStore_UpsertUser_Call) Return(_a0 error) *mockStore_UpsertUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockStore_UpsertUser_Call) RunAndReturn(run func(context.Context, *model.User) error) *mockStore_UpsertUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// User provides a mock function with given fields: ctx, name
+func (_m *mockStore) User(ctx context.Context, name string) (*model.User, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_User_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'User'
+type mockStore_User_Call struct {
+	*mock.Call
+}
+
+// User is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockStore_Expecter) User(ctx interface{}, name interface{}) *mockStore_User_Call {
+	return &mockStore_User_Call{Call: _e.mock.On("User", ctx, name)}
+}
+
+func (_c *mockStore_User_Call) Run(run func(ctx context.Context, name string)) *mockStore_User_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_User_Call) Return(_a0 *model.User, _a1 error) *mockStore_User_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_User_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *mockStore_User_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserSessions provides a mock function with given fields:
 func (_m *mockStore) UserSessions() sessions.Store {
 	ret := _m.Called()
@@ -2950,6 +3946,169 @@ func (_c *mockStore_UserSessions_Call) RunAndReturn(run func() sessions.Store) *
 	return _c
 }
 
+// Users provides a mock function with given fields: ctx
+func (_m *mockStore) Users(ctx context.Context) ([]*model.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Users_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Users'
+type mockStore_Users_Call struct {
+	*mock.Call
+}
+
+// Users is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStore_Expecter) Users(ctx interface{}) *mockStore_Users_Call {
+	return &mockStore_Users_Call{Call: _e.mock.On("Users", ctx)}
+}
+
+func (_c *mockStore_Users_Call) Run(run func(ctx context.Context)) *mockStore_Users_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStore_Users_Call) Return(_a0 []*model.User, _a1 error) *mockStore_Users_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Users_Call) RunAndReturn(run func(context.Context) ([]*model.User, error)) *mockStore_Users_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhook provides a mock function with given fields: ctx, name
+func (_m *mockStore) Webhook(ctx context.Context, name string) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Webhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhook'
+type mockStore_Webhook_Call struct {
+	*mock.Call
+}
+
+// Webhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *mockStore_Expecter) Webhook(ctx interface{}, name interface{}) *mockStore_Webhook_Call {
+	return &mockStore_Webhook_Call{Call: _e.mock.On("Webhook", ctx, name)}
+}
+
+func (_c *mockStore_Webhook_Call) Run(run func(ctx context.Context, name string)) *mockStore_Webhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockStore_Webhook_Call) Return(_a0 *model.Webhook, _a1 error) *mockStore_Webhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Webhook_Call) RunAndReturn(run func(context.Context, string) (*model.Webhook, error)) *mockStore_Webhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhooks provides a mock function with given fields: ctx
+func (_m *mockStore) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockStore_Webhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhooks'
+type mockStore_Webhooks_Call struct {
+	*mock.Call
+}
+
+// Webhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockStore_Expecter) Webhooks(ctx interface{}) *mockStore_Webhooks_Call {
+	return &mockStore_Webhooks_Call{Call: _e.mock.On("Webhooks", ctx)}
+}
+
+func (_c *mockStore_Webhooks_Call) Run(run func(ctx context.Context)) *mockStore_Webhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockStore_Webhooks_Call) Return(_a0 []*model.Webhook, _a1 error) *mockStore_Webhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockStore_Webhooks_Call) RunAndReturn(run func(context.Context) ([]*model.Webhook, error)) *mockStore_Webhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockStore creates a new instance of mockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockStore(t interface {
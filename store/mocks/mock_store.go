@@ -34,6 +34,214 @@ func (_m *MockStore) EXPECT() *MockStore_Expecter {
 	return &MockStore_Expecter{mock: &_m.Mock}
 }
 
+// APIKey provides a mock function with given fields: ctx, id
+func (_m *MockStore) APIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_APIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKey'
+type MockStore_APIKey_Call struct {
+	*mock.Call
+}
+
+// APIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) APIKey(ctx interface{}, id interface{}) *MockStore_APIKey_Call {
+	return &MockStore_APIKey_Call{Call: _e.mock.On("APIKey", ctx, id)}
+}
+
+func (_c *MockStore_APIKey_Call) Run(run func(ctx context.Context, id string)) *MockStore_APIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_APIKey_Call) Return(_a0 *model.APIKey, _a1 error) *MockStore_APIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_APIKey_Call) RunAndReturn(run func(context.Context, string) (*model.APIKey, error)) *MockStore_APIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// APIKeys provides a mock function with given fields: ctx
+func (_m *MockStore) APIKeys(ctx context.Context) ([]*model.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.APIKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_APIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APIKeys'
+type MockStore_APIKeys_Call struct {
+	*mock.Call
+}
+
+// APIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) APIKeys(ctx interface{}) *MockStore_APIKeys_Call {
+	return &MockStore_APIKeys_Call{Call: _e.mock.On("APIKeys", ctx)}
+}
+
+func (_c *MockStore_APIKeys_Call) Run(run func(ctx context.Context)) *MockStore_APIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_APIKeys_Call) Return(_a0 []*model.APIKey, _a1 error) *MockStore_APIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_APIKeys_Call) RunAndReturn(run func(context.Context) ([]*model.APIKey, error)) *MockStore_APIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddAuditEvents provides a mock function with given fields: ctx, events
+func (_m *MockStore) AddAuditEvents(ctx context.Context, events []*model.AuditEvent) error {
+	ret := _m.Called(ctx, events)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AuditEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_AddAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEvents'
+type MockStore_AddAuditEvents_Call struct {
+	*mock.Call
+}
+
+// AddAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []*model.AuditEvent
+func (_e *MockStore_Expecter) AddAuditEvents(ctx interface{}, events interface{}) *MockStore_AddAuditEvents_Call {
+	return &MockStore_AddAuditEvents_Call{Call: _e.mock.On("AddAuditEvents", ctx, events)}
+}
+
+func (_c *MockStore_AddAuditEvents_Call) Run(run func(ctx context.Context, events []*model.AuditEvent)) *MockStore_AddAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*model.AuditEvent))
+	})
+	return _c
+}
+
+func (_c *MockStore_AddAuditEvents_Call) Return(_a0 error) *MockStore_AddAuditEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_AddAuditEvents_Call) RunAndReturn(run func(context.Context, []*model.AuditEvent) error) *MockStore_AddAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddWebhookDelivery provides a mock function with given fields: ctx, name, delivery
+func (_m *MockStore) AddWebhookDelivery(ctx context.Context, name string, delivery model.WebhookDelivery) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name, delivery)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.WebhookDelivery) (*model.Webhook, error)); ok {
+		return rf(ctx, name, delivery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.WebhookDelivery) *model.Webhook); ok {
+		r0 = rf(ctx, name, delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.WebhookDelivery) error); ok {
+		r1 = rf(ctx, name, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_AddWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWebhookDelivery'
+type MockStore_AddWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// AddWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - delivery model.WebhookDelivery
+func (_e *MockStore_Expecter) AddWebhookDelivery(ctx interface{}, name interface{}, delivery interface{}) *MockStore_AddWebhookDelivery_Call {
+	return &MockStore_AddWebhookDelivery_Call{Call: _e.mock.On("AddWebhookDelivery", ctx, name, delivery)}
+}
+
+func (_c *MockStore_AddWebhookDelivery_Call) Run(run func(ctx context.Context, name string, delivery model.WebhookDelivery)) *MockStore_AddWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *MockStore_AddWebhookDelivery_Call) Return(_a0 *model.Webhook, _a1 error) *MockStore_AddWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_AddWebhookDelivery_Call) RunAndReturn(run func(context.Context, string, model.WebhookDelivery) (*model.Webhook, error)) *MockStore_AddWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Agent provides a mock function with given fields: ctx, id
 func (_m *MockStore) Agent(ctx context.Context, id string) (*model.Agent, error) {
 	ret := _m.Called(ctx, id)
@@ -488,6 +696,115 @@ func (_c *MockStore_AgentsIDsMatchingConfiguration_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// Alert provides a mock function with given fields: ctx, name
+func (_m *MockStore) Alert(ctx context.Context, name string) (*model.Alert, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Alert, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Alert); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Alert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Alert'
+type MockStore_Alert_Call struct {
+	*mock.Call
+}
+
+// Alert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) Alert(ctx interface{}, name interface{}) *MockStore_Alert_Call {
+	return &MockStore_Alert_Call{Call: _e.mock.On("Alert", ctx, name)}
+}
+
+func (_c *MockStore_Alert_Call) Run(run func(ctx context.Context, name string)) *MockStore_Alert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Alert_Call) Return(_a0 *model.Alert, _a1 error) *MockStore_Alert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Alert_Call) RunAndReturn(run func(context.Context, string) (*model.Alert, error)) *MockStore_Alert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Alerts provides a mock function with given fields: ctx
+func (_m *MockStore) Alerts(ctx context.Context) ([]*model.Alert, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Alert, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Alert); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Alerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Alerts'
+type MockStore_Alerts_Call struct {
+	*mock.Call
+}
+
+// Alerts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) Alerts(ctx interface{}) *MockStore_Alerts_Call {
+	return &MockStore_Alerts_Call{Call: _e.mock.On("Alerts", ctx)}
+}
+
+func (_c *MockStore_Alerts_Call) Run(run func(ctx context.Context)) *MockStore_Alerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_Alerts_Call) Return(_a0 []*model.Alert, _a1 error) *MockStore_Alerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Alerts_Call) RunAndReturn(run func(context.Context) ([]*model.Alert, error)) *MockStore_Alerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ApplyResources provides a mock function with given fields: ctx, resources
 func (_m *MockStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
 	ret := _m.Called(ctx, resources)
@@ -543,6 +860,61 @@ func (_c *MockStore_ApplyResources_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// AuditEvents provides a mock function with given fields: ctx, filter
+func (_m *MockStore) AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*model.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) ([]*model.AuditEvent, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditEventFilter) []*model.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_AuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEvents'
+type MockStore_AuditEvents_Call struct {
+	*mock.Call
+}
+
+// AuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.AuditEventFilter
+func (_e *MockStore_Expecter) AuditEvents(ctx interface{}, filter interface{}) *MockStore_AuditEvents_Call {
+	return &MockStore_AuditEvents_Call{Call: _e.mock.On("AuditEvents", ctx, filter)}
+}
+
+func (_c *MockStore_AuditEvents_Call) Run(run func(ctx context.Context, filter model.AuditEventFilter)) *MockStore_AuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.AuditEventFilter))
+	})
+	return _c
+}
+
+func (_c *MockStore_AuditEvents_Call) Return(_a0 []*model.AuditEvent, _a1 error) *MockStore_AuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_AuditEvents_Call) RunAndReturn(run func(context.Context, model.AuditEventFilter) ([]*model.AuditEvent, error)) *MockStore_AuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupDisconnectedAgents provides a mock function with given fields: ctx, since
 func (_m *MockStore) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
 	ret := _m.Called(ctx, since)
@@ -827,6 +1199,61 @@ func (_c *MockStore_Configurations_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteAPIKey provides a mock function with given fields: ctx, id
+func (_m *MockStore) DeleteAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIKey'
+type MockStore_DeleteAPIKey_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) DeleteAPIKey(ctx interface{}, id interface{}) *MockStore_DeleteAPIKey_Call {
+	return &MockStore_DeleteAPIKey_Call{Call: _e.mock.On("DeleteAPIKey", ctx, id)}
+}
+
+func (_c *MockStore_DeleteAPIKey_Call) Run(run func(ctx context.Context, id string)) *MockStore_DeleteAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteAPIKey_Call) Return(_a0 *model.APIKey, _a1 error) *MockStore_DeleteAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteAPIKey_Call) RunAndReturn(run func(context.Context, string) (*model.APIKey, error)) *MockStore_DeleteAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteAgentVersion provides a mock function with given fields: ctx, name
 func (_m *MockStore) DeleteAgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
 	ret := _m.Called(ctx, name)
@@ -888,19 +1315,74 @@ func (_m *MockStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mo
 
 	var r0 []*model.Agent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Agent, error)); ok {
-		return rf(ctx, agentIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Agent, error)); ok {
+		return rf(ctx, agentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.Agent); ok {
+		r0 = rf(ctx, agentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Agent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, agentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteAgents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAgents'
+type MockStore_DeleteAgents_Call struct {
+	*mock.Call
+}
+
+// DeleteAgents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - agentIDs []string
+func (_e *MockStore_Expecter) DeleteAgents(ctx interface{}, agentIDs interface{}) *MockStore_DeleteAgents_Call {
+	return &MockStore_DeleteAgents_Call{Call: _e.mock.On("DeleteAgents", ctx, agentIDs)}
+}
+
+func (_c *MockStore_DeleteAgents_Call) Run(run func(ctx context.Context, agentIDs []string)) *MockStore_DeleteAgents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteAgents_Call) Return(_a0 []*model.Agent, _a1 error) *MockStore_DeleteAgents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteAgents_Call) RunAndReturn(run func(context.Context, []string) ([]*model.Agent, error)) *MockStore_DeleteAgents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlert provides a mock function with given fields: ctx, name
+func (_m *MockStore) DeleteAlert(ctx context.Context, name string) (*model.Alert, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Alert, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.Agent); ok {
-		r0 = rf(ctx, agentIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Alert); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Agent)
+			r0 = ret.Get(0).(*model.Alert)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, agentIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -908,31 +1390,31 @@ func (_m *MockStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mo
 	return r0, r1
 }
 
-// MockStore_DeleteAgents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAgents'
-type MockStore_DeleteAgents_Call struct {
+// MockStore_DeleteAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlert'
+type MockStore_DeleteAlert_Call struct {
 	*mock.Call
 }
 
-// DeleteAgents is a helper method to define mock.On call
+// DeleteAlert is a helper method to define mock.On call
 //   - ctx context.Context
-//   - agentIDs []string
-func (_e *MockStore_Expecter) DeleteAgents(ctx interface{}, agentIDs interface{}) *MockStore_DeleteAgents_Call {
-	return &MockStore_DeleteAgents_Call{Call: _e.mock.On("DeleteAgents", ctx, agentIDs)}
+//   - name string
+func (_e *MockStore_Expecter) DeleteAlert(ctx interface{}, name interface{}) *MockStore_DeleteAlert_Call {
+	return &MockStore_DeleteAlert_Call{Call: _e.mock.On("DeleteAlert", ctx, name)}
 }
 
-func (_c *MockStore_DeleteAgents_Call) Run(run func(ctx context.Context, agentIDs []string)) *MockStore_DeleteAgents_Call {
+func (_c *MockStore_DeleteAlert_Call) Run(run func(ctx context.Context, name string)) *MockStore_DeleteAlert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_DeleteAgents_Call) Return(_a0 []*model.Agent, _a1 error) *MockStore_DeleteAgents_Call {
+func (_c *MockStore_DeleteAlert_Call) Return(_a0 *model.Alert, _a1 error) *MockStore_DeleteAlert_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_DeleteAgents_Call) RunAndReturn(run func(context.Context, []string) ([]*model.Agent, error)) *MockStore_DeleteAgents_Call {
+func (_c *MockStore_DeleteAlert_Call) RunAndReturn(run func(context.Context, string) (*model.Alert, error)) *MockStore_DeleteAlert_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1267,6 +1749,61 @@ func (_c *MockStore_DeleteResources_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteSnapshot provides a mock function with given fields: ctx, id
+func (_m *MockStore) DeleteSnapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Snapshot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Snapshot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSnapshot'
+type MockStore_DeleteSnapshot_Call struct {
+	*mock.Call
+}
+
+// DeleteSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) DeleteSnapshot(ctx interface{}, id interface{}) *MockStore_DeleteSnapshot_Call {
+	return &MockStore_DeleteSnapshot_Call{Call: _e.mock.On("DeleteSnapshot", ctx, id)}
+}
+
+func (_c *MockStore_DeleteSnapshot_Call) Run(run func(ctx context.Context, id string)) *MockStore_DeleteSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteSnapshot_Call) Return(_a0 *model.Snapshot, _a1 error) *MockStore_DeleteSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteSnapshot_Call) RunAndReturn(run func(context.Context, string) (*model.Snapshot, error)) *MockStore_DeleteSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteSource provides a mock function with given fields: ctx, name
 func (_m *MockStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
 	ret := _m.Called(ctx, name)
@@ -1377,6 +1914,116 @@ func (_c *MockStore_DeleteSourceType_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// DeleteUser provides a mock function with given fields: ctx, name
+func (_m *MockStore) DeleteUser(ctx context.Context, name string) (*model.User, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockStore_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) DeleteUser(ctx interface{}, name interface{}) *MockStore_DeleteUser_Call {
+	return &MockStore_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, name)}
+}
+
+func (_c *MockStore_DeleteUser_Call) Run(run func(ctx context.Context, name string)) *MockStore_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteUser_Call) Return(_a0 *model.User, _a1 error) *MockStore_DeleteUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteUser_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockStore_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, name
+func (_m *MockStore) DeleteWebhook(ctx context.Context, name string) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockStore_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) DeleteWebhook(ctx interface{}, name interface{}) *MockStore_DeleteWebhook_Call {
+	return &MockStore_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, name)}
+}
+
+func (_c *MockStore_DeleteWebhook_Call) Run(run func(ctx context.Context, name string)) *MockStore_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhook_Call) Return(_a0 *model.Webhook, _a1 error) *MockStore_DeleteWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string) (*model.Webhook, error)) *MockStore_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Destination provides a mock function with given fields: ctx, name
 func (_m *MockStore) Destination(ctx context.Context, name string) (*model.Destination, error) {
 	ret := _m.Called(ctx, name)
@@ -2099,12 +2746,164 @@ func (_c *MockStore_ResumeRollout_Call) Run(run func(ctx context.Context, config
 	return _c
 }
 
-func (_c *MockStore_ResumeRollout_Call) Return(_a0 *model.Configuration, _a1 error) *MockStore_ResumeRollout_Call {
+func (_c *MockStore_ResumeRollout_Call) Return(_a0 *model.Configuration, _a1 error) *MockStore_ResumeRollout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ResumeRollout_Call) RunAndReturn(run func(context.Context, string) (*model.Configuration, error)) *MockStore_ResumeRollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *MockStore) SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Snapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_SaveSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSnapshot'
+type MockStore_SaveSnapshot_Call struct {
+	*mock.Call
+}
+
+// SaveSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *model.Snapshot
+func (_e *MockStore_Expecter) SaveSnapshot(ctx interface{}, snapshot interface{}) *MockStore_SaveSnapshot_Call {
+	return &MockStore_SaveSnapshot_Call{Call: _e.mock.On("SaveSnapshot", ctx, snapshot)}
+}
+
+func (_c *MockStore_SaveSnapshot_Call) Run(run func(ctx context.Context, snapshot *model.Snapshot)) *MockStore_SaveSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Snapshot))
+	})
+	return _c
+}
+
+func (_c *MockStore_SaveSnapshot_Call) Return(_a0 error) *MockStore_SaveSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_SaveSnapshot_Call) RunAndReturn(run func(context.Context, *model.Snapshot) error) *MockStore_SaveSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Snapshot provides a mock function with given fields: ctx, id
+func (_m *MockStore) Snapshot(ctx context.Context, id string) (*model.Snapshot, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Snapshot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Snapshot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Snapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshot'
+type MockStore_Snapshot_Call struct {
+	*mock.Call
+}
+
+// Snapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStore_Expecter) Snapshot(ctx interface{}, id interface{}) *MockStore_Snapshot_Call {
+	return &MockStore_Snapshot_Call{Call: _e.mock.On("Snapshot", ctx, id)}
+}
+
+func (_c *MockStore_Snapshot_Call) Run(run func(ctx context.Context, id string)) *MockStore_Snapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Snapshot_Call) Return(_a0 *model.Snapshot, _a1 error) *MockStore_Snapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Snapshot_Call) RunAndReturn(run func(context.Context, string) (*model.Snapshot, error)) *MockStore_Snapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Snapshots provides a mock function with given fields: ctx
+func (_m *MockStore) Snapshots(ctx context.Context) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Snapshot, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Snapshot); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Snapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshots'
+type MockStore_Snapshots_Call struct {
+	*mock.Call
+}
+
+// Snapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) Snapshots(ctx interface{}) *MockStore_Snapshots_Call {
+	return &MockStore_Snapshots_Call{Call: _e.mock.On("Snapshots", ctx)}
+}
+
+func (_c *MockStore_Snapshots_Call) Run(run func(ctx context.Context)) *MockStore_Snapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_Snapshots_Call) Return(_a0 []*model.Snapshot, _a1 error) *MockStore_Snapshots_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ResumeRollout_Call) RunAndReturn(run func(context.Context, string) (*model.Configuration, error)) *MockStore_ResumeRollout_Call {
+func (_c *MockStore_Snapshots_Call) RunAndReturn(run func(context.Context) ([]*model.Snapshot, error)) *MockStore_Snapshots_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2539,6 +3338,62 @@ func (_c *MockStore_UpdateAgents_Call) RunAndReturn(run func(context.Context, []
 	return _c
 }
 
+// UpdateAlertStatus provides a mock function with given fields: ctx, name, status
+func (_m *MockStore) UpdateAlertStatus(ctx context.Context, name string, status model.AlertStatus) (*model.Alert, error) {
+	ret := _m.Called(ctx, name, status)
+
+	var r0 *model.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AlertStatus) (*model.Alert, error)); ok {
+		return rf(ctx, name, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AlertStatus) *model.Alert); ok {
+		r0 = rf(ctx, name, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.AlertStatus) error); ok {
+		r1 = rf(ctx, name, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_UpdateAlertStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlertStatus'
+type MockStore_UpdateAlertStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateAlertStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - status model.AlertStatus
+func (_e *MockStore_Expecter) UpdateAlertStatus(ctx interface{}, name interface{}, status interface{}) *MockStore_UpdateAlertStatus_Call {
+	return &MockStore_UpdateAlertStatus_Call{Call: _e.mock.On("UpdateAlertStatus", ctx, name, status)}
+}
+
+func (_c *MockStore_UpdateAlertStatus_Call) Run(run func(ctx context.Context, name string, status model.AlertStatus)) *MockStore_UpdateAlertStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.AlertStatus))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateAlertStatus_Call) Return(_a0 *model.Alert, _a1 error) *MockStore_UpdateAlertStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_UpdateAlertStatus_Call) RunAndReturn(run func(context.Context, string, model.AlertStatus) (*model.Alert, error)) *MockStore_UpdateAlertStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateAllRollouts provides a mock function with given fields: ctx
 func (_m *MockStore) UpdateAllRollouts(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -2797,6 +3652,49 @@ func (_c *MockStore_Updates_Call) RunAndReturn(run func(context.Context) eventbu
 	return _c
 }
 
+// UpsertAPIKey provides a mock function with given fields: ctx, apiKey
+func (_m *MockStore) UpsertAPIKey(ctx context.Context, apiKey *model.APIKey) error {
+	ret := _m.Called(ctx, apiKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.APIKey) error); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpsertAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertAPIKey'
+type MockStore_UpsertAPIKey_Call struct {
+	*mock.Call
+}
+
+// UpsertAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey *model.APIKey
+func (_e *MockStore_Expecter) UpsertAPIKey(ctx interface{}, apiKey interface{}) *MockStore_UpsertAPIKey_Call {
+	return &MockStore_UpsertAPIKey_Call{Call: _e.mock.On("UpsertAPIKey", ctx, apiKey)}
+}
+
+func (_c *MockStore_UpsertAPIKey_Call) Run(run func(ctx context.Context, apiKey *model.APIKey)) *MockStore_UpsertAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.APIKey))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertAPIKey_Call) Return(_a0 error) *MockStore_UpsertAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpsertAPIKey_Call) RunAndReturn(run func(context.Context, *model.APIKey) error) *MockStore_UpsertAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertAgent provides a mock function with given fields: ctx, agentID, updater
 func (_m *MockStore) UpsertAgent(ctx context.Context, agentID string, updater store.AgentUpdater) (*model.Agent, error) {
 	ret := _m.Called(ctx, agentID, updater)
@@ -2909,6 +3807,104 @@ func (_c *MockStore_UpsertAgents_Call) RunAndReturn(run func(context.Context, []
 	return _c
 }
 
+// UpsertUser provides a mock function with given fields: ctx, user
+func (_m *MockStore) UpsertUser(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUser'
+type MockStore_UpsertUser_Call struct {
+	*mock.Call
+}
+
+// UpsertUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+func (_e *MockStore_Expecter) UpsertUser(ctx interface{}, user interface{}) *MockStore_UpsertUser_Call {
+	return &MockStore_UpsertUser_Call{Call: _e.mock.On("UpsertUser", ctx, user)}
+}
+
+func (_c *MockStore_UpsertUser_Call) Run(run func(ctx context.Context, user *model.User)) *MockStore_UpsertUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertUser_Call) Return(_a0 error) *MockStore_UpsertUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpsertUser_Call) RunAndReturn(run func(context.Context, *model.User) error) *MockStore_UpsertUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// User provides a mock function with given fields: ctx, name
+func (_m *MockStore) User(ctx context.Context, name string) (*model.User, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_User_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'User'
+type MockStore_User_Call struct {
+	*mock.Call
+}
+
+// User is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) User(ctx interface{}, name interface{}) *MockStore_User_Call {
+	return &MockStore_User_Call{Call: _e.mock.On("User", ctx, name)}
+}
+
+func (_c *MockStore_User_Call) Run(run func(ctx context.Context, name string)) *MockStore_User_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_User_Call) Return(_a0 *model.User, _a1 error) *MockStore_User_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_User_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *MockStore_User_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserSessions provides a mock function with given fields:
 func (_m *MockStore) UserSessions() sessions.Store {
 	ret := _m.Called()
@@ -2952,6 +3948,169 @@ func (_c *MockStore_UserSessions_Call) RunAndReturn(run func() sessions.Store) *
 	return _c
 }
 
+// Users provides a mock function with given fields: ctx
+func (_m *MockStore) Users(ctx context.Context) ([]*model.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Users_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Users'
+type MockStore_Users_Call struct {
+	*mock.Call
+}
+
+// Users is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) Users(ctx interface{}) *MockStore_Users_Call {
+	return &MockStore_Users_Call{Call: _e.mock.On("Users", ctx)}
+}
+
+func (_c *MockStore_Users_Call) Run(run func(ctx context.Context)) *MockStore_Users_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_Users_Call) Return(_a0 []*model.User, _a1 error) *MockStore_Users_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Users_Call) RunAndReturn(run func(context.Context) ([]*model.User, error)) *MockStore_Users_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhook provides a mock function with given fields: ctx, name
+func (_m *MockStore) Webhook(ctx context.Context, name string) (*model.Webhook, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Webhook, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Webhook); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Webhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhook'
+type MockStore_Webhook_Call struct {
+	*mock.Call
+}
+
+// Webhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStore_Expecter) Webhook(ctx interface{}, name interface{}) *MockStore_Webhook_Call {
+	return &MockStore_Webhook_Call{Call: _e.mock.On("Webhook", ctx, name)}
+}
+
+func (_c *MockStore_Webhook_Call) Run(run func(ctx context.Context, name string)) *MockStore_Webhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_Webhook_Call) Return(_a0 *model.Webhook, _a1 error) *MockStore_Webhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Webhook_Call) RunAndReturn(run func(context.Context, string) (*model.Webhook, error)) *MockStore_Webhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhooks provides a mock function with given fields: ctx
+func (_m *MockStore) Webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Webhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhooks'
+type MockStore_Webhooks_Call struct {
+	*mock.Call
+}
+
+// Webhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) Webhooks(ctx interface{}) *MockStore_Webhooks_Call {
+	return &MockStore_Webhooks_Call{Call: _e.mock.On("Webhooks", ctx)}
+}
+
+func (_c *MockStore_Webhooks_Call) Run(run func(ctx context.Context)) *MockStore_Webhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_Webhooks_Call) Return(_a0 []*model.Webhook, _a1 error) *MockStore_Webhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Webhooks_Call) RunAndReturn(run func(context.Context) ([]*model.Webhook, error)) *MockStore_Webhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStore(t interface {
@@ -0,0 +1,315 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	// register the postgres driver with database/sql
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/eventbus"
+	"github.com/observiq/bindplane-op/model"
+	modelSearch "github.com/observiq/bindplane-op/model/search"
+	"github.com/observiq/bindplane-op/store/search"
+)
+
+// table names
+const (
+	TableBuckets      = "buckets"
+	TableBucketValues = "bucket_values"
+	TableAuditEvents  = "audit_events"
+)
+
+// postgresSchema creates the tables used by the postgres store. Everything except audit events is stored in the
+// buckets of a postgresDB so that the postgres store shares BoltstoreCore with boltstore. Audit events have their own
+// table so that they can be filtered by an index.
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS ` + TableBuckets + ` (
+		path TEXT NOT NULL PRIMARY KEY
+	)`,
+	`CREATE TABLE IF NOT EXISTS ` + TableBucketValues + ` (
+		bucket TEXT NOT NULL,
+		key BYTEA NOT NULL,
+		value BYTEA NOT NULL,
+		PRIMARY KEY (bucket, key)
+	)`,
+	`CREATE TABLE IF NOT EXISTS ` + TableAuditEvents + ` (
+		id TEXT NOT NULL PRIMARY KEY,
+		data JSON NOT NULL
+	)`,
+	// audit events are queried by actor or resource, most recent first. time ranges use the primary key.
+	`CREATE INDEX IF NOT EXISTS audit_events_actor_id ON ` + TableAuditEvents + ` ((data->>'actor'), id)`,
+	`CREATE INDEX IF NOT EXISTS audit_events_resource_id ON ` + TableAuditEvents + ` ((lower(data->>'resourceKind')), (data->>'resourceName'), id)`,
+}
+
+// postgresStore is a boltstore that uses a postgresDB and stores audit events in their own table
+type postgresStore struct {
+	*boltstore
+	db *sql.DB
+}
+
+var _ Store = (*postgresStore)(nil)
+var _ ArchiveStore = (*postgresStore)(nil)
+var _ BackupStore = (*postgresStore)(nil)
+var _ RestoreStore = (*postgresStore)(nil)
+var _ EncryptionKeyRotator = (*postgresStore)(nil)
+
+// NewPostgresStore returns a new store that implements the store.Store interface using postgres. Unlike boltstore,
+// multiple BindPlane servers can share the same postgres database.
+func NewPostgresStore(ctx context.Context, db *sql.DB, options Options, logger *zap.Logger) Store {
+	store := &postgresStore{
+		boltstore: newBoltstoreCore(ctx, NewPostgresDB(db), options, logger),
+		db:        db,
+	}
+
+	// agents are not disconnected on startup because other servers may still be connected to them
+
+	if !options.DisableMeasurementsCleanup {
+		// start the timer that runs cleanup on measurements
+		store.StartMeasurements(ctx)
+	}
+	if options.AuditEventsRetention > 0 {
+		startAuditEventsCleanup(ctx, store, options.AuditEventsRetention, logger)
+	}
+	if options.EventBroadcast != nil {
+		// updates may come from other servers sharing the database and those changes need to be indexed here
+		store.startIndexingUpdates(ctx)
+	} else {
+		logger.Warn("postgres store is using a local event broadcast, the search indexes of servers sharing the database will not be kept in sync")
+	}
+	SeedSearchIndexes(ctx, store, logger)
+
+	return store
+}
+
+// startIndexingUpdates subscribes to updates and applies them to the search indexes until the context is done. Updates
+// made by this server are already indexed but indexing them again is harmless.
+func (s *postgresStore) startIndexingUpdates(ctx context.Context) {
+	updates, unsubscribe := eventbus.Subscribe(ctx, s.StoreUpdates.Updates())
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				s.indexUpdates(ctx, u)
+			}
+		}
+	}()
+}
+
+// indexUpdates applies the agent and configuration changes in the updates to the search indexes
+func (s *postgresStore) indexUpdates(ctx context.Context, updates BasicEventUpdates) {
+	for _, event := range updates.Agents() {
+		if err := indexEvent(ctx, s.agentIndex, event); err != nil {
+			s.Logger.Error("failed to index agent", zap.String("id", event.Item.ID), zap.Error(err))
+		}
+	}
+	for _, event := range updates.Configurations() {
+		if err := indexEvent(ctx, s.configurationIndex, event); err != nil {
+			s.Logger.Error("failed to index configuration", zap.String("name", event.Item.Name()), zap.Error(err))
+		}
+	}
+}
+
+func indexEvent[T interface {
+	model.HasUniqueKey
+	modelSearch.Indexed
+}](ctx context.Context, index search.Index, event Event[T]) error {
+	if event.Type == EventTypeRemove {
+		return index.Remove(ctx, event.Item)
+	}
+	return index.Upsert(ctx, event.Item)
+}
+
+// InitPostgresDB opens a connection to the postgres database at the specified connection string and creates the
+// tables and buckets used by the store if they do not already exist. It will return an error if the database cannot
+// be reached.
+func InitPostgresDB(ctx context.Context, connectionString string, maxConnections int) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening postgres connection: %w", err)
+	}
+	if maxConnections > 0 {
+		db.SetMaxOpenConns(maxConnections)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to connect to postgres: %w", err)
+	}
+
+	if err := createPostgresSchema(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := initBuckets(NewPostgresDB(db)); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func createPostgresSchema(ctx context.Context, db *sql.DB) error {
+	for _, statement := range postgresSchema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("unable to create postgres tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// update runs the function in a transaction which is committed if the function does not return an error and rolled
+// back otherwise.
+func (s *postgresStore) update(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Clear clears the db store of resources, agents, measurements, users, api keys, audit events, and snapshots. Mostly used for testing.
+func (s *postgresStore) Clear() {
+	s.boltstore.Clear()
+	if _, err := s.db.ExecContext(context.Background(), "DELETE FROM "+TableAuditEvents); err != nil {
+		s.Logger.Error("failed to clear audit events", zap.Error(err))
+	}
+}
+
+// AddAuditEvents saves the audit events
+func (s *postgresStore) AddAuditEvents(ctx context.Context, events []*model.AuditEvent) error {
+	err := s.update(ctx, func(tx *sql.Tx) error {
+		for _, event := range events {
+			data, err := jsoniter.Marshal(event)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO "+TableAuditEvents+" (id, data) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data",
+				event.ID, data)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("add audit events: %w", err)
+	}
+	return nil
+}
+
+// AuditEvents returns the audit events that match the filter, starting with the most recent
+func (s *postgresStore) AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	query, args := auditEventsQuery(filter)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*model.AuditEvent{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("audit events: %w", err)
+		}
+		event := &model.AuditEvent{}
+		if err := jsoniter.Unmarshal(data, event); err != nil {
+			return nil, fmt.Errorf("audit events: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("audit events: %w", err)
+	}
+	return events, nil
+}
+
+// auditEventsQuery returns the query and arguments that select the audit events matching the filter. Event IDs sort by
+// time, so the time range is a range of IDs and ordering by ID returns the most recent events first.
+func auditEventsQuery(filter model.AuditEventFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Actor != "" {
+		where("data->>'actor' = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		where("data->>'action' = $%d", string(filter.Action))
+	}
+	if filter.ResourceKind != "" {
+		// kinds are compared without case, like AuditEventFilter.Matches
+		where("lower(data->>'resourceKind') = lower($%d)", string(filter.ResourceKind))
+	}
+	if filter.ResourceName != "" {
+		where("data->>'resourceName' = $%d", filter.ResourceName)
+	}
+	if !filter.Since.IsZero() {
+		where("id >= $%d", model.OrderedIDPrefix(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		where("id < $%d", model.OrderedIDPrefix(filter.Until))
+	}
+
+	query := "SELECT data FROM " + TableAuditEvents
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return query, args
+}
+
+// deleteAuditEventsBefore removes the audit events recorded before the time
+func (s *postgresStore) deleteAuditEventsBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM "+TableAuditEvents+" WHERE id < $1", model.OrderedIDPrefix(before))
+	if err != nil {
+		return 0, fmt.Errorf("delete audit events: %w", err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete audit events: %w", err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,186 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+// +build integration
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/config"
+	"github.com/observiq/bindplane-op/eventbus"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store/search"
+)
+
+// postgresContainer starts a postgres container and returns the configuration used to connect to it
+func postgresContainer(t *testing.T) config.Postgres {
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:15-alpine",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "bindplane",
+				"POSTGRES_PASSWORD": "password",
+				"POSTGRES_DB":       "bindplane",
+			},
+			WaitingFor: wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	return config.Postgres{
+		Host:     host,
+		Port:     port.Port(),
+		Database: "bindplane",
+		Username: "bindplane",
+		Password: "password",
+		SSLMode:  "disable",
+	}
+}
+
+// newTestPostgresStore creates a new database for the test and returns a store using it
+func newTestPostgresStore(ctx context.Context, t *testing.T, cfg config.Postgres) Store {
+	admin, err := sql.Open("postgres", cfg.ConnectionString())
+	require.NoError(t, err)
+	defer admin.Close()
+
+	cfg.Database = strings.ToLower(strings.NewReplacer("/", "_", "-", "_", " ", "_").Replace(t.Name()))
+	_, err = admin.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %q", cfg.Database))
+	require.NoError(t, err)
+
+	db, err := InitPostgresDB(ctx, cfg.ConnectionString(), 10)
+	require.NoError(t, err)
+
+	return NewPostgresStore(ctx, db, testOptions, zap.NewNop())
+}
+
+func TestPostgresStore(t *testing.T) {
+	cfg := postgresContainer(t)
+
+	for _, test := range sharedStoreTests() {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			store := newTestPostgresStore(ctx, t, cfg)
+			defer store.Close()
+
+			test.run(ctx, t, store)
+		})
+	}
+}
+
+func TestPostgresStoreEncryption(t *testing.T) {
+	cfg := postgresContainer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := InitPostgresDB(ctx, cfg.ConnectionString(), 10)
+	require.NoError(t, err)
+	defer db.Close()
+
+	newStore := func(cipher *ParameterCipher) Store {
+		options := testOptions
+		options.ParameterCipher = cipher
+		options.DisableMeasurementsCleanup = true
+		return NewPostgresStore(ctx, db, options, zap.NewNop())
+	}
+	stored := func() string {
+		var data strings.Builder
+		rows, err := db.QueryContext(ctx, "SELECT value FROM "+TableBucketValues+" WHERE bucket = $1 OR bucket = $2",
+			postgresBucketPath("", []byte(BucketResources)), postgresBucketPath("", []byte(BucketArchive)))
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var value []byte
+			require.NoError(t, rows.Scan(&value))
+			data.Write(value)
+		}
+		require.NoError(t, rows.Err())
+		return data.String()
+	}
+
+	runEncryptionTests(ctx, t, newStore, stored)
+}
+
+func TestPostgresStoreEventBroadcast(t *testing.T) {
+	cfg := postgresContainer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	options := testOptions
+	options.EventBroadcast = BuildPostgresEventBroadcast(cfg.ConnectionString())
+
+	newStore := func() Store {
+		db, err := InitPostgresDB(ctx, cfg.ConnectionString(), 10)
+		require.NoError(t, err)
+		return NewPostgresStore(ctx, db, options, zap.NewNop())
+	}
+	node1 := newStore()
+	defer node1.Close()
+	node2 := newStore()
+	defer node2.Close()
+
+	updates, unsubscribe := eventbus.Subscribe(ctx, node2.Updates(ctx))
+	defer unsubscribe()
+
+	// give the listeners time to connect
+	time.Sleep(time.Second)
+
+	_, err := node1.UpsertAgent(ctx, "1", func(current *model.Agent) {
+		current.Name = "remote"
+		current.Labels = model.LabelsFromValidatedMap(map[string]string{"env": "remote"})
+	})
+	require.NoError(t, err)
+
+	select {
+	case u := <-updates:
+		require.True(t, u.Agents().Contains("1", EventTypeInsert))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for update from node1")
+	}
+
+	// the agent from node1 is indexed on node2
+	require.Eventually(t, func() bool {
+		agents, err := node2.Agents(ctx, WithQuery(search.ParseQuery("env:remote")))
+		return err == nil && len(agents) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+}
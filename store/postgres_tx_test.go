@@ -0,0 +1,323 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// These tests use sqlmock to check the transactions, locks, and queries of postgresDB and the postgres store without a
+// database. The rest of the postgres store is BoltstoreCore which is covered by the boltstore and mapstore tests and
+// by the shared store tests in postgres_test.go which require docker.
+
+var (
+	lockUpdate        = regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")
+	selectBucket      = regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM " + TableBuckets + " WHERE path = $1)")
+	insertBucket      = regexp.QuoteMeta("INSERT INTO " + TableBuckets + " (path) VALUES ($1) ON CONFLICT DO NOTHING")
+	selectBucketValue = regexp.QuoteMeta("SELECT value FROM " + TableBucketValues + " WHERE bucket = $1 AND key = $2")
+	insertBucketValue = regexp.QuoteMeta("INSERT INTO " + TableBucketValues + " (bucket, key, value)")
+	deleteBucketValue = regexp.QuoteMeta("DELETE FROM " + TableBucketValues + " WHERE bucket = $1 AND key = $2")
+	selectCursor      = regexp.QuoteMeta("SELECT key, value FROM " + TableBucketValues + " WHERE bucket = $1")
+
+	// resourcesPath is the path of the resources bucket, the hex encoding of "Resources"
+	resourcesPath = "/5265736f7572636573"
+)
+
+// newMockPostgresDB returns a postgresDB using sqlmock
+func newMockPostgresDB(t *testing.T) (BucketDB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		mock.ExpectClose()
+		require.NoError(t, db.Close())
+	})
+	return NewPostgresDB(db), mock
+}
+
+// newMockPostgresStore returns a postgres store using sqlmock. Search indexes are not seeded from the database.
+func newMockPostgresStore(ctx context.Context, t *testing.T) (*postgresStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	require.NoError(t, err)
+
+	store := &postgresStore{
+		boltstore: newBoltstoreCore(ctx, NewPostgresDB(db), testOptions, zap.NewNop()),
+		db:        db,
+	}
+	t.Cleanup(func() {
+		mock.ExpectClose()
+		require.NoError(t, store.Close())
+	})
+	return store, mock
+}
+
+// mockBucketExists expects the query for the existence of the bucket
+func mockBucketExists(mock sqlmock.Sqlmock, path string, exists bool) {
+	mock.ExpectQuery(selectBucket).WithArgs(path).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+func TestPostgresDBUpdate(t *testing.T) {
+	t.Run("commits", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WithArgs(postgresUpdateLock).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockBucketExists(mock, resourcesPath, false)
+		mock.ExpectExec(insertBucket).WithArgs(resourcesPath).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertBucketValue).WithArgs(resourcesPath, []byte("key"), []byte("value")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := db.Update(func(tx BucketTx) error {
+			b, err := tx.CreateBucketIfNotExists([]byte(BucketResources))
+			if err != nil {
+				return err
+			}
+			// the existence of the bucket is cached for the transaction
+			require.NotNil(t, tx.Bucket([]byte(BucketResources)))
+			return b.Put([]byte("key"), []byte("value"))
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockBucketExists(mock, resourcesPath, true)
+		mock.ExpectExec(insertBucketValue).WillReturnError(errors.New("conflict"))
+		mock.ExpectRollback()
+
+		err := db.Update(func(tx BucketTx) error {
+			return tx.Bucket([]byte(BucketResources)).Put([]byte("key"), []byte("value"))
+		})
+		require.EqualError(t, err, "conflict")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back on query error", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockBucketExists(mock, resourcesPath, true)
+		mock.ExpectQuery(selectBucketValue).WithArgs(resourcesPath, []byte("key")).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		// Get cannot return the error so it is returned by Update
+		err := db.Update(func(tx BucketTx) error {
+			require.Nil(t, tx.Bucket([]byte(BucketResources)).Get([]byte("key")))
+			return nil
+		})
+		require.EqualError(t, err, "connection reset")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WillReturnError(errors.New("canceled"))
+		mock.ExpectRollback()
+
+		err := db.Update(func(_ BucketTx) error { return nil })
+		require.EqualError(t, err, "unable to lock database: canceled")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit().WillReturnError(errors.New("serialization failure"))
+
+		err := db.Update(func(_ BucketTx) error { return nil })
+		require.EqualError(t, err, "unable to commit transaction: serialization failure")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresDBView(t *testing.T) {
+	t.Run("is read only", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mockBucketExists(mock, resourcesPath, true)
+		mock.ExpectRollback()
+
+		err := db.View(func(tx BucketTx) error {
+			return tx.Bucket([]byte(BucketResources)).Put([]byte("key"), []byte("value"))
+		})
+		require.ErrorIs(t, err, errPostgresTxNotWritable)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing bucket", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mockBucketExists(mock, resourcesPath, false)
+		mock.ExpectCommit()
+
+		err := db.View(func(tx BucketTx) error {
+			require.Nil(t, tx.Bucket([]byte(BucketResources)))
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresDBCursor(t *testing.T) {
+	rows := func(keys ...string) *sqlmock.Rows {
+		r := sqlmock.NewRows([]string{"key", "value"})
+		for _, k := range keys {
+			r.AddRow([]byte(k), []byte("value-"+k))
+		}
+		return r
+	}
+
+	t.Run("reads pages", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mockBucketExists(mock, resourcesPath, true)
+		mock.ExpectQuery(selectCursor+regexp.QuoteMeta(" AND key >= $2 ORDER BY key ASC LIMIT 100")).
+			WithArgs(resourcesPath, []byte("b")).
+			WillReturnRows(rows("b", "c"))
+		// the next page starts after the last key of the first page
+		mock.ExpectQuery(selectCursor+regexp.QuoteMeta(" AND key > $2 ORDER BY key ASC LIMIT 100")).
+			WithArgs(resourcesPath, []byte("c")).
+			WillReturnRows(rows())
+		mock.ExpectQuery(selectCursor + regexp.QuoteMeta(" ORDER BY key DESC LIMIT 1")).
+			WithArgs(resourcesPath).
+			WillReturnRows(rows("c"))
+		mock.ExpectQuery(selectCursor+regexp.QuoteMeta(" AND key < $2 ORDER BY key DESC LIMIT 1")).
+			WithArgs(resourcesPath, []byte("c")).
+			WillReturnRows(rows("b"))
+		mock.ExpectCommit()
+
+		err := db.View(func(tx BucketTx) error {
+			c := tx.Bucket([]byte(BucketResources)).Cursor()
+			var keys []string
+			for k, v := c.Seek([]byte("b")); k != nil; k, v = c.Next() {
+				require.Equal(t, "value-"+string(k), string(v))
+				keys = append(keys, string(k))
+			}
+			require.Equal(t, []string{"b", "c"}, keys)
+
+			k, _ := c.Last()
+			require.Equal(t, "c", string(k))
+			k, _ = c.Prev()
+			require.Equal(t, "b", string(k))
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reads the page again after a write", func(t *testing.T) {
+		db, mock := newMockPostgresDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(lockUpdate).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockBucketExists(mock, resourcesPath, true)
+		mock.ExpectQuery(selectCursor + regexp.QuoteMeta(" ORDER BY key ASC LIMIT 100")).
+			WithArgs(resourcesPath).
+			WillReturnRows(rows("a", "b", "c"))
+		mock.ExpectExec(deleteBucketValue).WithArgs(resourcesPath, []byte("a")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(selectCursor+regexp.QuoteMeta(" AND key > $2 ORDER BY key ASC LIMIT 100")).
+			WithArgs(resourcesPath, []byte("a")).
+			WillReturnRows(rows("b", "c"))
+		mock.ExpectCommit()
+
+		err := db.Update(func(tx BucketTx) error {
+			c := tx.Bucket([]byte(BucketResources)).Cursor()
+			k, _ := c.First()
+			require.Equal(t, "a", string(k))
+			require.NoError(t, c.Delete())
+			k, _ = c.Next()
+			require.Equal(t, "b", string(k))
+			k, _ = c.Next()
+			require.Equal(t, "c", string(k))
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgresStoreAuditEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	since := time.Unix(0, 1000)
+	until := time.Unix(0, 2000)
+
+	t.Run("filters and limit are in the query", func(t *testing.T) {
+		store, mock := newMockPostgresStore(ctx, t)
+		event := model.NewAuditEvent("ci", "", model.AuditActionUpdate, model.KindConfiguration, "linux", 2)
+		data, err := jsoniter.Marshal(event)
+		require.NoError(t, err)
+
+		query := "SELECT data FROM " + TableAuditEvents + " WHERE data->>'actor' = $1 AND data->>'action' = $2" +
+			" AND lower(data->>'resourceKind') = lower($3) AND data->>'resourceName' = $4 AND id >= $5 AND id < $6" +
+			" ORDER BY id DESC LIMIT $7"
+		mock.ExpectQuery("^"+regexp.QuoteMeta(query)+"$").
+			WithArgs("ci", "update", "configuration", "linux", "0000000000000001000", "0000000000000002000", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+
+		events, err := store.AuditEvents(ctx, model.AuditEventFilter{
+			Actor:        "ci",
+			Action:       model.AuditActionUpdate,
+			ResourceKind: "configuration",
+			ResourceName: "linux",
+			Since:        since,
+			Until:        until,
+			Limit:        10,
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, event.ID, events[0].ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		store, mock := newMockPostgresStore(ctx, t)
+		mock.ExpectQuery("^" + regexp.QuoteMeta("SELECT data FROM "+TableAuditEvents+" ORDER BY id DESC") + "$").
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"data"}))
+
+		events, err := store.AuditEvents(ctx, model.AuditEventFilter{})
+		require.NoError(t, err)
+		require.Empty(t, events)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete before", func(t *testing.T) {
+		store, mock := newMockPostgresStore(ctx, t)
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + TableAuditEvents + " WHERE id < $1")).
+			WithArgs("0000000000000001000").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		count, err := store.deleteAuditEventsBefore(ctx, since)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,389 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	errPostgresBucketNotFound = errors.New("bucket not found")
+	errPostgresTxNotWritable  = errors.New("tx not writable")
+)
+
+// postgresUpdateLock is the key of the advisory lock held by update transactions
+const postgresUpdateLock = 0x62696e64706c616e // "bindplan"
+
+// postgresCursorPageSize is the number of keys read at a time by a cursor moving forward
+const postgresCursorPageSize = 100
+
+// postgresDB is a BucketDB that stores buckets in postgres. Like bbolt, updates are serialized and each transaction
+// sees a consistent snapshot of the database. Updates take a transaction level advisory lock so that they are also
+// serialized across BindPlane servers sharing the database. Views use a read only repeatable read transaction and are
+// never blocked by an update.
+//
+// Buckets are identified by their path, the hex encoded names of the bucket and its parents separated by "/".
+type postgresDB struct {
+	db *sql.DB
+}
+
+var _ BucketDB = (*postgresDB)(nil)
+
+// NewPostgresDB returns a BucketDB that uses the postgres database. The tables must have been created by InitPostgresDB.
+func NewPostgresDB(db *sql.DB) BucketDB {
+	return &postgresDB{db: db}
+}
+
+func (d *postgresDB) View(fn func(tx BucketTx) error) error {
+	return d.run(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, false, fn)
+}
+
+func (d *postgresDB) Update(fn func(tx BucketTx) error) error {
+	return d.run(nil, true, fn)
+}
+
+func (d *postgresDB) Close() error {
+	return d.db.Close()
+}
+
+// run executes the function in a transaction which is committed if neither the function nor any of the queries made
+// by it fail and rolled back otherwise
+func (d *postgresDB) run(opts *sql.TxOptions, writable bool, fn func(tx BucketTx) error) error {
+	ctx := context.Background()
+	sqlTx, err := d.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	tx := &postgresTx{
+		ctx:      ctx,
+		tx:       sqlTx,
+		writable: writable,
+		buckets:  map[string]bool{},
+	}
+	if writable {
+		if _, err := sqlTx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresUpdateLock); err != nil {
+			_ = sqlTx.Rollback()
+			return fmt.Errorf("unable to lock database: %w", err)
+		}
+	}
+
+	err = fn(tx)
+	if err == nil {
+		// Get and the cursor methods cannot return errors so the first one is returned here
+		err = tx.err
+	}
+	if err != nil {
+		_ = sqlTx.Rollback()
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// postgresTx is a transaction on a postgresDB
+type postgresTx struct {
+	ctx      context.Context
+	tx       *sql.Tx
+	writable bool
+
+	// err is the first error from a method that cannot return one
+	err error
+
+	// buckets caches the existence of buckets by path
+	buckets map[string]bool
+
+	// version is incremented by each write so that cursors know to read their next page again
+	version int
+}
+
+var _ BucketTx = (*postgresTx)(nil)
+
+func (tx *postgresTx) Bucket(name []byte) Bucket {
+	return tx.bucket(postgresBucketPath("", name))
+}
+
+func (tx *postgresTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	return tx.createBucketIfNotExists("", name)
+}
+
+func (tx *postgresTx) DeleteBucket(name []byte) error {
+	if !tx.writable {
+		return errPostgresTxNotWritable
+	}
+	path := postgresBucketPath("", name)
+	if tx.bucket(path) == nil {
+		if tx.err != nil {
+			return tx.err
+		}
+		return errPostgresBucketNotFound
+	}
+
+	// nested bucket paths start with the path and "/". hex encoded names never contain LIKE wildcards.
+	nested := path + "/%"
+	if _, err := tx.tx.ExecContext(tx.ctx, "DELETE FROM "+TableBucketValues+" WHERE bucket = $1 OR bucket LIKE $2", path, nested); err != nil {
+		return err
+	}
+	if _, err := tx.tx.ExecContext(tx.ctx, "DELETE FROM "+TableBuckets+" WHERE path = $1 OR path LIKE $2", path, nested); err != nil {
+		return err
+	}
+	tx.buckets = map[string]bool{}
+	tx.version++
+	return nil
+}
+
+// fail records the first error from a method that cannot return one
+func (tx *postgresTx) fail(err error) {
+	if tx.err == nil {
+		tx.err = err
+	}
+}
+
+// bucket returns the bucket at the path or nil if it does not exist
+func (tx *postgresTx) bucket(path string) Bucket {
+	exists, ok := tx.buckets[path]
+	if !ok {
+		err := tx.tx.QueryRowContext(tx.ctx, "SELECT EXISTS (SELECT 1 FROM "+TableBuckets+" WHERE path = $1)", path).Scan(&exists)
+		if err != nil {
+			tx.fail(err)
+			return nil
+		}
+		tx.buckets[path] = exists
+	}
+	if !exists {
+		return nil
+	}
+	return &postgresBucket{tx: tx, path: path}
+}
+
+func (tx *postgresTx) createBucketIfNotExists(parentPath string, name []byte) (Bucket, error) {
+	if !tx.writable {
+		return nil, errPostgresTxNotWritable
+	}
+	path := postgresBucketPath(parentPath, name)
+	if b := tx.bucket(path); b != nil {
+		return b, nil
+	}
+	if tx.err != nil {
+		return nil, tx.err
+	}
+	if _, err := tx.tx.ExecContext(tx.ctx, "INSERT INTO "+TableBuckets+" (path) VALUES ($1) ON CONFLICT DO NOTHING", path); err != nil {
+		return nil, err
+	}
+	tx.buckets[path] = true
+	return &postgresBucket{tx: tx, path: path}, nil
+}
+
+// postgresBucketPath returns the path of the bucket with the name in the parent bucket
+func postgresBucketPath(parentPath string, name []byte) string {
+	return parentPath + "/" + hex.EncodeToString(name)
+}
+
+// postgresBucket is a Bucket in a postgresTx
+type postgresBucket struct {
+	tx   *postgresTx
+	path string
+}
+
+var _ Bucket = (*postgresBucket)(nil)
+
+func (b *postgresBucket) Get(key []byte) []byte {
+	var value []byte
+	err := b.tx.tx.QueryRowContext(b.tx.ctx, "SELECT value FROM "+TableBucketValues+" WHERE bucket = $1 AND key = $2", b.path, key).Scan(&value)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		b.tx.fail(err)
+		return nil
+	case value == nil:
+		// like bbolt, an empty value is not nil
+		return []byte{}
+	}
+	return value
+}
+
+func (b *postgresBucket) Put(key []byte, value []byte) error {
+	if !b.tx.writable {
+		return errPostgresTxNotWritable
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := b.tx.tx.ExecContext(b.tx.ctx,
+		"INSERT INTO "+TableBucketValues+" (bucket, key, value) VALUES ($1, $2, $3) ON CONFLICT (bucket, key) DO UPDATE SET value = EXCLUDED.value",
+		b.path, key, value)
+	if err != nil {
+		return err
+	}
+	b.tx.version++
+	return nil
+}
+
+func (b *postgresBucket) Delete(key []byte) error {
+	if !b.tx.writable {
+		return errPostgresTxNotWritable
+	}
+	if _, err := b.tx.tx.ExecContext(b.tx.ctx, "DELETE FROM "+TableBucketValues+" WHERE bucket = $1 AND key = $2", b.path, key); err != nil {
+		return err
+	}
+	b.tx.version++
+	return nil
+}
+
+func (b *postgresBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return b.tx.err
+}
+
+func (b *postgresBucket) Cursor() BucketCursor {
+	return &postgresCursor{bucket: b}
+}
+
+func (b *postgresBucket) Bucket(name []byte) Bucket {
+	return b.tx.bucket(postgresBucketPath(b.path, name))
+}
+
+func (b *postgresBucket) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	return b.tx.createBucketIfNotExists(b.path, name)
+}
+
+// postgresKeyValue is a key and value read by a postgresCursor
+type postgresKeyValue struct {
+	key   []byte
+	value []byte
+}
+
+// postgresCursor is a BucketCursor on a postgresBucket. Like memoryCursor, it keeps the current key so that the bucket
+// can be modified while iterating. Moving forward reads a page of keys at a time and the page is read again if the
+// transaction has written anything since it was read.
+type postgresCursor struct {
+	bucket *postgresBucket
+	key    []byte
+	valid  bool
+
+	// page contains the keys after key that were read when the transaction was at pageVersion
+	page        []postgresKeyValue
+	pageVersion int
+}
+
+var _ BucketCursor = (*postgresCursor)(nil)
+
+// query reads the keys and values of the bucket matching the condition in the order and returns them
+func (c *postgresCursor) query(condition, order string, limit int, args ...any) []postgresKeyValue {
+	tx := c.bucket.tx
+	query := "SELECT key, value FROM " + TableBucketValues + " WHERE bucket = $1" + condition + " ORDER BY key " + order + fmt.Sprintf(" LIMIT %d", limit)
+	rows, err := tx.tx.QueryContext(tx.ctx, query, append([]any{c.bucket.path}, args...)...)
+	if err != nil {
+		tx.fail(err)
+		return nil
+	}
+	defer rows.Close()
+
+	var result []postgresKeyValue
+	for rows.Next() {
+		var kv postgresKeyValue
+		if err := rows.Scan(&kv.key, &kv.value); err != nil {
+			tx.fail(err)
+			return nil
+		}
+		if kv.value == nil {
+			kv.value = []byte{}
+		}
+		result = append(result, kv)
+	}
+	if err := rows.Err(); err != nil {
+		tx.fail(err)
+		return nil
+	}
+	return result
+}
+
+// forward moves the cursor to the first key matching the condition and reads the page of keys after it
+func (c *postgresCursor) forward(condition string, args ...any) ([]byte, []byte) {
+	c.page = c.query(condition, "ASC", postgresCursorPageSize, args...)
+	c.pageVersion = c.bucket.tx.version
+	return c.next()
+}
+
+// next moves the cursor to the first key of the page
+func (c *postgresCursor) next() ([]byte, []byte) {
+	if len(c.page) == 0 {
+		c.key, c.valid = nil, false
+		return nil, nil
+	}
+	kv := c.page[0]
+	c.page = c.page[1:]
+	c.key, c.valid = kv.key, true
+	return kv.key, kv.value
+}
+
+// at moves the cursor to the only key of the result
+func (c *postgresCursor) at(result []postgresKeyValue) ([]byte, []byte) {
+	c.page = nil
+	if len(result) == 0 {
+		c.key, c.valid = nil, false
+		return nil, nil
+	}
+	c.key, c.valid = result[0].key, true
+	return result[0].key, result[0].value
+}
+
+func (c *postgresCursor) First() ([]byte, []byte) {
+	return c.forward("")
+}
+
+func (c *postgresCursor) Last() ([]byte, []byte) {
+	return c.at(c.query("", "DESC", 1))
+}
+
+func (c *postgresCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.forward(" AND key >= $2", seek)
+}
+
+func (c *postgresCursor) Next() ([]byte, []byte) {
+	if !c.valid {
+		return nil, nil
+	}
+	if len(c.page) > 0 && c.pageVersion == c.bucket.tx.version {
+		return c.next()
+	}
+	return c.forward(" AND key > $2", c.key)
+}
+
+func (c *postgresCursor) Prev() ([]byte, []byte) {
+	if !c.valid {
+		return nil, nil
+	}
+	return c.at(c.query(" AND key < $2", "DESC", 1, c.key))
+}
+
+func (c *postgresCursor) Delete() error {
+	if !c.valid {
+		return nil
+	}
+	return c.bucket.Delete(c.key)
+}
@@ -21,9 +21,14 @@ import (
 	"time"
 
 	"github.com/observiq/bindplane-op/otlp/record"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
+var mp metric.Meter = otel.Meter("store/stats")
+
 // MeasurementBatchFlushInterval is the number of seconds to wait before flushing batch.
 var MeasurementBatchFlushInterval = 5 * time.Second
 
@@ -44,6 +49,7 @@ type DefaultBatcher struct {
 	metricChan   chan []*record.Metric
 	batchChan    chan []*record.Metric
 	logger       *zap.Logger
+	metrics      metric.Registration
 
 	wg     sync.WaitGroup
 	ctx    context.Context
@@ -63,6 +69,8 @@ func NewDefaultBatcher(ctx context.Context, logger *zap.Logger, measurements Mea
 		cancel:       cancel,
 	}
 
+	batcher.setupMetrics()
+
 	// Spin off workers
 	batcher.wg.Add(2)
 	go batcher.saveWorker()
@@ -71,6 +79,26 @@ func NewDefaultBatcher(ctx context.Context, logger *zap.Logger, measurements Mea
 	return batcher
 }
 
+// setupMetrics reports the number of pending metrics and batches waiting to be accepted and saved
+func (d *DefaultBatcher) setupMetrics() {
+	queueDepth, err := mp.Int64ObservableGauge("measurement_batcher_queue_depth",
+		metric.WithDescription("Number of metrics and batches waiting in the measurement batcher queues"),
+	)
+	if err != nil {
+		d.logger.Warn("failed to create measurement_batcher_queue_depth metric", zap.Error(err))
+		return
+	}
+
+	d.metrics, err = mp.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(queueDepth, int64(len(d.metricChan)), metric.WithAttributes(attribute.String("queue", "accept")))
+		o.ObserveInt64(queueDepth, int64(len(d.batchChan)), metric.WithAttributes(attribute.String("queue", "save")))
+		return nil
+	}, queueDepth)
+	if err != nil {
+		d.logger.Warn("failed to register measurement_batcher_queue_depth callback", zap.Error(err))
+	}
+}
+
 // AcceptMetrics accepts metrics to be batched
 func (d *DefaultBatcher) AcceptMetrics(ctx context.Context, metrics []*record.Metric) error {
 	select {
@@ -84,6 +112,12 @@ func (d *DefaultBatcher) AcceptMetrics(ctx context.Context, metrics []*record.Me
 
 // Shutdown shuts down the batcher
 func (d *DefaultBatcher) Shutdown(ctx context.Context) error {
+	if d.metrics != nil {
+		if err := d.metrics.Unregister(); err != nil {
+			d.logger.Warn("failed to unregister measurement_batcher_queue_depth callback", zap.Error(err))
+		}
+	}
+
 	doneChan := make(chan struct{})
 
 	go func() {
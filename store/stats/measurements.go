@@ -51,22 +51,10 @@ func WithPeriod(period time.Duration) QueryOption {
 	}
 }
 
-// GetDurationFromPeriod returns the rollup duration for a given query period
-func GetDurationFromPeriod(opts QueryOptions) time.Duration {
-	switch opts.Period {
-	case 10 * time.Second:
-		return 1 * time.Second
-	case 1 * time.Minute:
-		return 10 * time.Second
-	case 5 * time.Minute:
-		return 1 * time.Minute
-	case 1 * time.Hour:
-		return 5 * time.Minute
-	case 24 * time.Hour:
-		return 1 * time.Hour
-	default:
-		return 1 * time.Hour
-	}
+// GetDurationFromPeriod returns the rollup duration for a given query period. It is the interval of the retention tier
+// that keeps measurements for the period, see TierForPeriod.
+func GetDurationFromPeriod(tiers []RetentionTier, opts QueryOptions) time.Duration {
+	return TierForPeriod(tiers, opts.Period).Interval
 }
 
 // MetricData is returned by Measurements when metrics are requested for agents and configurations
@@ -104,6 +92,10 @@ type Measurements interface {
 	// processor with the prefix "throughputmeasurement/_d1_"
 	OverviewMetrics(ctx context.Context, options ...QueryOption) (MetricData, error)
 
+	// QueryMeasurements returns the rates of the measurements of agents in the time range of the query as time series.
+	// The query should be validated before it is passed to QueryMeasurements.
+	QueryMeasurements(ctx context.Context, query MeasurementsQuery) ([]*TimeSeries, error)
+
 	// SaveAgentMetrics saves new metrics. These metrics will be aggregated to determine metrics associated with agents and configurations.
 	SaveAgentMetrics(ctx context.Context, metrics []*record.Metric) error
 
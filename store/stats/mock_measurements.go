@@ -156,6 +156,32 @@ func (_m *mockMeasurements) ProcessMetrics(ctx context.Context) error {
 	return r0
 }
 
+// QueryMeasurements provides a mock function with given fields: ctx, query
+func (_m *mockMeasurements) QueryMeasurements(ctx context.Context, query MeasurementsQuery) ([]*TimeSeries, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*TimeSeries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, MeasurementsQuery) ([]*TimeSeries, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, MeasurementsQuery) []*TimeSeries); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TimeSeries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, MeasurementsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveAgentMetrics provides a mock function with given fields: ctx, metrics
 func (_m *mockMeasurements) SaveAgentMetrics(ctx context.Context, metrics []*record.Metric) error {
 	ret := _m.Called(ctx, metrics)
@@ -0,0 +1,283 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-op/otlp/record"
+)
+
+// Dimensions of measurements that can be used to group the time series returned by a MeasurementsQuery
+const (
+	GroupByAgent         = "agent"
+	GroupByConfiguration = "configuration"
+	GroupByProcessor     = "processor"
+	GroupByPosition      = "position"
+	GroupByPipelineType  = "pipelineType"
+	GroupByResource      = "resource"
+)
+
+// GroupByDimensions are the dimensions supported by MeasurementsQuery.GroupBy
+var GroupByDimensions = []string{
+	GroupByAgent,
+	GroupByConfiguration,
+	GroupByProcessor,
+	GroupByPosition,
+	GroupByPipelineType,
+	GroupByResource,
+}
+
+// MaxQuerySteps is the maximum number of points in each time series returned by a MeasurementsQuery
+const MaxQuerySteps = 11000
+
+// MeasurementsQuery selects the measurements of agents in a time range and the rates calculated from them
+type MeasurementsQuery struct {
+	// Start and End are the time of the first and last possible point of each time series. There is a point every Step
+	// from Start to End with the rate over the Step ending at that time.
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+
+	// Metric is the name of one of the SupportedMetricNames. If it is empty, all supported metrics are returned.
+	Metric string
+
+	// GroupBy are the dimensions used to label the time series. The rates of measurements with the same values for these
+	// dimensions are summed. If it is empty, there is one time series for each metric with the total of all agents.
+	GroupBy []string
+
+	// Agents are the IDs of the agents to include. If it is empty, measurements of all agents are included, including
+	// agents that have since been deleted.
+	Agents []string
+}
+
+// TimeSeries is the rate of a metric in bytes per second for the values of the dimensions in Labels
+type TimeSeries struct {
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels"`
+	Points []TimeSeriesPoint `json:"points"`
+}
+
+// TimeSeriesPoint is the rate over the step of the query ending at Timestamp
+type TimeSeriesPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// Validate returns an error if the query is invalid
+func (q MeasurementsQuery) Validate() error {
+	if q.Start.IsZero() || q.End.IsZero() {
+		return fmt.Errorf("start and end are required")
+	}
+	if !q.End.After(q.Start) {
+		return fmt.Errorf("end must be after start")
+	}
+	if q.Step < 10*time.Second {
+		return fmt.Errorf("step must be at least 10s")
+	}
+	if steps := q.End.Sub(q.Start) / q.Step; steps >= MaxQuerySteps {
+		return fmt.Errorf("step is too small for the time range, a maximum of %d points are returned", MaxQuerySteps)
+	}
+	if q.Metric != "" && !supportedMetricName(q.Metric) {
+		return fmt.Errorf("unsupported metric: %s", q.Metric)
+	}
+	for _, dimension := range q.GroupBy {
+		if !validDimension(dimension) {
+			return fmt.Errorf("invalid groupBy dimension %s, expected one of %s", dimension, strings.Join(GroupByDimensions, ", "))
+		}
+	}
+	return nil
+}
+
+// Metrics returns the names of the metrics to query
+func (q MeasurementsQuery) Metrics() []string {
+	if q.Metric == "" {
+		return SupportedMetricNames
+	}
+	return []string{q.Metric}
+}
+
+// From returns the earliest time of the measurements needed to calculate the rate for the first point at Start. It is
+// truncated to the 10s intervals used to store measurements.
+func (q MeasurementsQuery) From() time.Time {
+	return q.Start.Add(-q.Step).Truncate(10 * time.Second)
+}
+
+// TimeSeries calculates the rates of the query from the measurements of agents. The value of a series at each point is
+// calculated from the latest measurement in the step ending at the point and the latest measurement in the previous
+// step. Points are omitted if there are no measurements to calculate a rate, e.g. the agent was offline or only
+// measurements for longer intervals have been retained.
+func (q MeasurementsQuery) TimeSeries(measurements []*record.Metric) []*TimeSeries {
+	// a point at each step from Start to End preceded by the step before Start
+	steps := int(q.End.Sub(q.Start)/q.Step) + 1
+	times := make([]time.Time, steps+1)
+	for i := range times {
+		times[i] = q.Start.Add(time.Duration(i-1) * q.Step)
+	}
+
+	// the measurements of each processor of a configuration on an agent
+	type measurementsKey struct {
+		metric, agent, configuration, processor string
+	}
+	grouped := map[measurementsKey][]*record.Metric{}
+	for _, m := range measurements {
+		if !q.includesMetric(m.Name) {
+			continue
+		}
+		if _, ok := Value(m); !ok {
+			continue
+		}
+		key := measurementsKey{m.Name, Agent(m), Configuration(m), Processor(m)}
+		grouped[key] = append(grouped[key], m)
+	}
+
+	type seriesKey struct {
+		metric, labels string
+	}
+	series := map[seriesKey]*TimeSeries{}
+	values := map[seriesKey]map[int]float64{}
+	for key, metrics := range grouped {
+		samples := stepSamples(times, q.Step, metrics)
+		labels := q.labels(metrics[0])
+		sk := seriesKey{key.metric, labelsString(labels)}
+		for i := 1; i < len(samples); i++ {
+			first, last := samples[i-1], samples[i]
+			if first == nil || last == nil {
+				continue
+			}
+			rate := rateBetween(first, last)
+			if rate == nil {
+				continue
+			}
+			if _, ok := series[sk]; !ok {
+				series[sk] = &TimeSeries{Metric: key.metric, Labels: labels}
+				values[sk] = map[int]float64{}
+			}
+			values[sk][i] += rate.Value
+		}
+	}
+
+	result := make([]*TimeSeries, 0, len(series))
+	for sk, s := range series {
+		indexes := make([]int, 0, len(values[sk]))
+		for i := range values[sk] {
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+		s.Points = make([]TimeSeriesPoint, 0, len(indexes))
+		for _, i := range indexes {
+			s.Points = append(s.Points, TimeSeriesPoint{
+				Timestamp: times[i],
+				Value:     math.Round(values[sk][i]*100) / 100,
+			})
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Metric != result[j].Metric {
+			return result[i].Metric < result[j].Metric
+		}
+		return labelsString(result[i].Labels) < labelsString(result[j].Labels)
+	})
+	return result
+}
+
+// includesMetric returns true if the metric is one of the Metrics of the query
+func (q MeasurementsQuery) includesMetric(name string) bool {
+	for _, metric := range q.Metrics() {
+		if name == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// labels returns the values of the GroupBy dimensions of the measurement
+func (q MeasurementsQuery) labels(m *record.Metric) map[string]string {
+	labels := map[string]string{}
+	position, pipelineType, name := ProcessorParsed(m)
+	for _, dimension := range q.GroupBy {
+		switch dimension {
+		case GroupByAgent:
+			labels[dimension] = Agent(m)
+		case GroupByConfiguration:
+			labels[dimension] = Configuration(m)
+		case GroupByProcessor:
+			labels[dimension] = Processor(m)
+		case GroupByPosition:
+			labels[dimension] = position
+		case GroupByPipelineType:
+			labels[dimension] = pipelineType
+		case GroupByResource:
+			labels[dimension] = name
+		}
+	}
+	return labels
+}
+
+// stepSamples returns the latest measurement in the step ending at each of the times or nil if there are none
+func stepSamples(times []time.Time, step time.Duration, metrics []*record.Metric) []*record.Metric {
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Timestamp.Before(metrics[j].Timestamp)
+	})
+	samples := make([]*record.Metric, len(times))
+	for _, m := range metrics {
+		// the index of the first time at or after the measurement
+		i := sort.Search(len(times), func(i int) bool {
+			return !times[i].Before(m.Timestamp)
+		})
+		if i < len(times) && times[i].Sub(m.Timestamp) < step {
+			samples[i] = m
+		}
+	}
+	return samples
+}
+
+// rateBetween returns the rate of change from the first to the last measurement or nil if it cannot be calculated
+func rateBetween(first, last *record.Metric) *RateMetric {
+	firstValue, _ := Value(first)
+	lastValue, _ := Value(last)
+	rate, err := Rate(
+		RateMetric{Timestamp: first.Timestamp, StartTimestamp: first.StartTimestamp, Value: firstValue},
+		RateMetric{Timestamp: last.Timestamp, StartTimestamp: last.StartTimestamp, Value: lastValue},
+	)
+	if err != nil {
+		return nil
+	}
+	return rate
+}
+
+// labelsString returns the labels as a string that can be used to compare and sort them
+func labelsString(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func validDimension(dimension string) bool {
+	for _, d := range GroupByDimensions {
+		if dimension == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/otlp/record"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurementsQueryValidate(t *testing.T) {
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := MeasurementsQuery{Start: start, End: start.Add(time.Hour), Step: time.Minute}
+
+	tests := []struct {
+		name        string
+		modify      func(q *MeasurementsQuery)
+		expectError string
+	}{
+		{
+			name:   "valid",
+			modify: func(q *MeasurementsQuery) {},
+		},
+		{
+			name: "metric and groupBy",
+			modify: func(q *MeasurementsQuery) {
+				q.Metric = LogDataSizeMetricName
+				q.GroupBy = []string{GroupByAgent, GroupByPipelineType}
+			},
+		},
+		{
+			name:        "missing start",
+			modify:      func(q *MeasurementsQuery) { q.Start = time.Time{} },
+			expectError: "start and end are required",
+		},
+		{
+			name:        "end before start",
+			modify:      func(q *MeasurementsQuery) { q.End = start.Add(-time.Hour) },
+			expectError: "end must be after start",
+		},
+		{
+			name:        "step less than 10s",
+			modify:      func(q *MeasurementsQuery) { q.Step = time.Second },
+			expectError: "step must be at least 10s",
+		},
+		{
+			name:        "too many steps",
+			modify:      func(q *MeasurementsQuery) { q.End = start.Add(30 * 24 * time.Hour) },
+			expectError: "step is too small for the time range",
+		},
+		{
+			name:        "unsupported metric",
+			modify:      func(q *MeasurementsQuery) { q.Metric = "otelcol_processor_other" },
+			expectError: "unsupported metric: otelcol_processor_other",
+		},
+		{
+			name:        "invalid groupBy",
+			modify:      func(q *MeasurementsQuery) { q.GroupBy = []string{"host"} },
+			expectError: "invalid groupBy dimension host",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := valid
+			test.modify(&q)
+			err := q.Validate()
+			if test.expectError != "" {
+				require.ErrorContains(t, err, test.expectError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMeasurementsQueryTimeSeries(t *testing.T) {
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	measurement := func(timestamp, startTimestamp time.Time, value float64) *record.Metric {
+		return &record.Metric{
+			Name:           LogDataSizeMetricName,
+			Timestamp:      timestamp,
+			StartTimestamp: startTimestamp,
+			Value:          value,
+			Attributes: map[string]interface{}{
+				AgentAttributeName:         "a1",
+				ConfigurationAttributeName: "c1",
+				ProcessorAttributeName:     "throughputmeasurement/_d1_logs_google",
+			},
+		}
+	}
+
+	query := MeasurementsQuery{
+		Start:   start,
+		End:     start.Add(3 * time.Minute),
+		Step:    time.Minute,
+		GroupBy: []string{GroupByPosition, GroupByResource},
+	}
+
+	// the counter is reset at 00:01:30, the latest measurement in each step is used
+	series := query.TimeSeries([]*record.Metric{
+		measurement(start.Add(-70*time.Second), start.Add(-time.Hour), 0),
+		measurement(start.Add(-30*time.Second), start.Add(-time.Hour), 600),
+		measurement(start.Add(-10*time.Second), start.Add(-time.Hour), 1200),
+		measurement(start.Add(50*time.Second), start.Add(-time.Hour), 7200),
+		measurement(start.Add(2*time.Minute), start.Add(90*time.Second), 300),
+		measurement(start.Add(3*time.Minute), start.Add(90*time.Second), 600),
+	})
+	require.Equal(t, []*TimeSeries{
+		{
+			Metric: LogDataSizeMetricName,
+			Labels: map[string]string{"position": "d1", "resource": "google"},
+			Points: []TimeSeriesPoint{
+				{Timestamp: start, Value: 20},
+				{Timestamp: start.Add(time.Minute), Value: 100},
+				{Timestamp: start.Add(2 * time.Minute), Value: 10},
+				{Timestamp: start.Add(3 * time.Minute), Value: 5},
+			},
+		},
+	}, series)
+}
@@ -0,0 +1,160 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetentionTier keeps one measurement per Interval for the Retention period. Measurements are stored at 10s
+// intervals, so a tier with a 10s Interval keeps all of the raw measurements.
+type RetentionTier struct {
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+// DefaultRetention are the retention tiers used if none are configured
+var DefaultRetention = []RetentionTier{
+	// keep all of the 10s measurements for 100 seconds
+	{Interval: 10 * time.Second, Retention: 100 * time.Second},
+	// for the last 10 minutes keep the minute data
+	{Interval: time.Minute, Retention: 10 * time.Minute},
+	// for the last 6 hours keep the 5 minute data
+	{Interval: 5 * time.Minute, Retention: 6 * time.Hour},
+	// for the last 24 hours keep the hourly data
+	{Interval: time.Hour, Retention: 24 * time.Hour},
+	// for the last 31 days keep the daily data
+	{Interval: 24 * time.Hour, Retention: 31 * 24 * time.Hour},
+}
+
+// Retained returns true if the measurement stored at ts should be kept at now because it is the measurement for the
+// interval of one of the tiers and it is within the retention of that tier.
+func Retained(tiers []RetentionTier, now, ts time.Time) bool {
+	for _, tier := range tiers {
+		if ts.Truncate(tier.Interval).Equal(ts) && now.Sub(ts) <= tier.Retention {
+			return true
+		}
+	}
+	return false
+}
+
+// RollupTimes returns the start of the interval of each tier that contains ts, excluding ts itself and intervals that
+// are no longer retained at now. If no measurement is stored at one of these times, the measurement at ts should be
+// copied there so that the tier has a measurement for the interval after the raw measurements are removed.
+func RollupTimes(tiers []RetentionTier, now, ts time.Time) []time.Time {
+	var times []time.Time
+	for _, tier := range tiers {
+		start := ts.Truncate(tier.Interval)
+		if start.Equal(ts) || now.Sub(start) > tier.Retention {
+			continue
+		}
+		times = append(times, start)
+	}
+	return times
+}
+
+// TierForPeriod returns the tier used to look back over the period. It is the tier with the shortest interval that
+// retains measurements for the whole period or, if no tier retains measurements that long, the tier with the longest
+// retention. DefaultRetention is used if there are no tiers.
+func TierForPeriod(tiers []RetentionTier, period time.Duration) RetentionTier {
+	if len(tiers) == 0 {
+		tiers = DefaultRetention
+	}
+	var result RetentionTier
+	found := false
+	for _, tier := range tiers {
+		if tier.Retention < period {
+			continue
+		}
+		if !found || tier.Interval < result.Interval {
+			result, found = tier, true
+		}
+	}
+	if found {
+		return result
+	}
+	for _, tier := range tiers {
+		if tier.Retention > result.Retention {
+			result = tier
+		}
+	}
+	return result
+}
+
+// ParsePeriod parses a period like 5m or 24h. In addition to the units supported by time.ParseDuration, d can be used
+// for a number of days, e.g. 7d or 30d.
+func ParsePeriod(period string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(period, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid period: %s", period)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil {
+		return 0, fmt.Errorf("invalid period: %s", period)
+	}
+	return d, nil
+}
+
+// ParseRetentionTier parses a retention tier of the form interval:retention, e.g. 1m:2d keeps one measurement per
+// minute for 2 days. The interval must be a multiple of 10s that evenly divides a day.
+func ParseRetentionTier(tier string) (RetentionTier, error) {
+	interval, retention, ok := strings.Cut(tier, ":")
+	if !ok {
+		return RetentionTier{}, fmt.Errorf("invalid retention tier %s, expected interval:retention", tier)
+	}
+	i, err := ParsePeriod(interval)
+	if err != nil {
+		return RetentionTier{}, fmt.Errorf("invalid retention tier %s: %w", tier, err)
+	}
+	r, err := ParsePeriod(retention)
+	if err != nil {
+		return RetentionTier{}, fmt.Errorf("invalid retention tier %s: %w", tier, err)
+	}
+	if i <= 0 || i%(10*time.Second) != 0 || (24*time.Hour)%i != 0 {
+		return RetentionTier{}, fmt.Errorf("invalid retention tier %s: interval must be a multiple of 10s that evenly divides 24h", tier)
+	}
+	if r < i {
+		return RetentionTier{}, fmt.Errorf("invalid retention tier %s: retention must be at least the interval", tier)
+	}
+	return RetentionTier{Interval: i, Retention: r}, nil
+}
+
+// ParseRetention parses the retention tiers, returning DefaultRetention if there are none. A 10s tier is required to
+// keep the raw measurements used for the most recent rates.
+func ParseRetention(tiers []string) ([]RetentionTier, error) {
+	if len(tiers) == 0 {
+		return DefaultRetention, nil
+	}
+	result := make([]RetentionTier, 0, len(tiers))
+	raw := false
+	for _, tier := range tiers {
+		t, err := ParseRetentionTier(tier)
+		if err != nil {
+			return nil, err
+		}
+		raw = raw || t.Interval == 10*time.Second
+		result = append(result, t)
+	}
+	if !raw {
+		return nil, fmt.Errorf("measurements retention must include a 10s tier for the raw measurements")
+	}
+	return result, nil
+}
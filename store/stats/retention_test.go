@@ -0,0 +1,223 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		period      string
+		expect      time.Duration
+		expectError bool
+	}{
+		{period: "10s", expect: 10 * time.Second},
+		{period: "24h", expect: 24 * time.Hour},
+		{period: "7d", expect: 7 * 24 * time.Hour},
+		{period: "30d", expect: 30 * 24 * time.Hour},
+		{period: "0d", expectError: true},
+		{period: "1.5d", expectError: true},
+		{period: "d", expectError: true},
+		{period: "week", expectError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.period, func(t *testing.T) {
+			d, err := ParsePeriod(test.period)
+			if test.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expect, d)
+		})
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		tiers       []string
+		expect      []RetentionTier
+		expectError string
+	}{
+		{
+			name:   "default",
+			expect: DefaultRetention,
+		},
+		{
+			name:  "tiers",
+			tiers: []string{"10s:1h", "1m:2d", "1h:90d"},
+			expect: []RetentionTier{
+				{Interval: 10 * time.Second, Retention: time.Hour},
+				{Interval: time.Minute, Retention: 2 * 24 * time.Hour},
+				{Interval: time.Hour, Retention: 90 * 24 * time.Hour},
+			},
+		},
+		{
+			name:        "missing retention",
+			tiers:       []string{"10s"},
+			expectError: "invalid retention tier 10s, expected interval:retention",
+		},
+		{
+			name:        "invalid interval",
+			tiers:       []string{"10x:1h"},
+			expectError: "invalid retention tier 10x:1h: invalid period: 10x",
+		},
+		{
+			name:        "invalid retention",
+			tiers:       []string{"10s:1x"},
+			expectError: "invalid retention tier 10s:1x: invalid period: 1x",
+		},
+		{
+			name:        "interval not a multiple of 10s",
+			tiers:       []string{"15s:1h"},
+			expectError: "interval must be a multiple of 10s that evenly divides 24h",
+		},
+		{
+			name:        "interval does not divide a day",
+			tiers:       []string{"7m:1d"},
+			expectError: "interval must be a multiple of 10s that evenly divides 24h",
+		},
+		{
+			name:        "retention less than interval",
+			tiers:       []string{"10s:1h", "1h:1m"},
+			expectError: "retention must be at least the interval",
+		},
+		{
+			name:        "missing raw tier",
+			tiers:       []string{"1m:1h"},
+			expectError: "measurements retention must include a 10s tier for the raw measurements",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tiers, err := ParseRetention(test.tiers)
+			if test.expectError != "" {
+				require.ErrorContains(t, err, test.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expect, tiers)
+		})
+	}
+}
+
+func TestRetained(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	tiers := []RetentionTier{
+		{Interval: 10 * time.Second, Retention: time.Hour},
+		{Interval: time.Minute, Retention: 2 * 24 * time.Hour},
+		{Interval: time.Hour, Retention: 90 * 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name   string
+		ts     time.Time
+		expect bool
+	}{
+		{name: "raw", ts: now.Add(-50 * time.Minute).Add(10 * time.Second), expect: true},
+		{name: "raw expired", ts: now.Add(-61 * time.Minute).Add(10 * time.Second), expect: false},
+		{name: "minute", ts: now.Add(-47 * time.Hour).Add(time.Minute), expect: true},
+		{name: "minute expired", ts: now.Add(-49 * time.Hour).Add(time.Minute), expect: false},
+		{name: "hour", ts: now.Add(-89 * 24 * time.Hour).Add(time.Hour), expect: true},
+		{name: "hour expired", ts: now.Add(-91 * 24 * time.Hour), expect: false},
+		{name: "retention inclusive", ts: now.Add(-90 * 24 * time.Hour), expect: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, Retained(tiers, now, test.ts))
+		})
+	}
+}
+
+func TestRollupTimes(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	tiers := []RetentionTier{
+		{Interval: 10 * time.Second, Retention: time.Hour},
+		{Interval: time.Minute, Retention: 2 * 24 * time.Hour},
+		{Interval: time.Hour, Retention: 90 * 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name   string
+		ts     time.Time
+		expect []time.Time
+	}{
+		{
+			name: "start of the hour",
+			ts:   now.Add(-2 * time.Hour),
+		},
+		{
+			name:   "start of a minute",
+			ts:     now.Add(-2 * time.Hour).Add(5 * time.Minute),
+			expect: []time.Time{now.Add(-2 * time.Hour)},
+		},
+		{
+			name:   "within a minute",
+			ts:     now.Add(-2 * time.Hour).Add(5*time.Minute + 30*time.Second),
+			expect: []time.Time{now.Add(-2 * time.Hour).Add(5 * time.Minute), now.Add(-2 * time.Hour)},
+		},
+		{
+			name:   "minute no longer retained",
+			ts:     now.Add(-72 * time.Hour).Add(5*time.Minute + 30*time.Second),
+			expect: []time.Time{now.Add(-72 * time.Hour)},
+		},
+		{
+			name: "hour no longer retained",
+			ts:   now.Add(-100 * 24 * time.Hour).Add(5*time.Minute + 30*time.Second),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, RollupTimes(tiers, now, test.ts))
+		})
+	}
+}
+
+func TestTierForPeriod(t *testing.T) {
+	tiers := []RetentionTier{
+		{Interval: 10 * time.Second, Retention: time.Hour},
+		{Interval: time.Minute, Retention: 2 * 24 * time.Hour},
+		{Interval: time.Hour, Retention: 90 * 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name   string
+		tiers  []RetentionTier
+		period time.Duration
+		expect RetentionTier
+	}{
+		{name: "raw", tiers: tiers, period: 5 * time.Minute, expect: tiers[0]},
+		{name: "retention of a tier", tiers: tiers, period: time.Hour, expect: tiers[0]},
+		{name: "longer than raw", tiers: tiers, period: 24 * time.Hour, expect: tiers[1]},
+		{name: "longest tier", tiers: tiers, period: 30 * 24 * time.Hour, expect: tiers[2]},
+		{name: "longer than retained", tiers: tiers, period: 365 * 24 * time.Hour, expect: tiers[2]},
+		{name: "default 1m", period: time.Minute, expect: DefaultRetention[0]},
+		{name: "default 5m", period: 5 * time.Minute, expect: DefaultRetention[1]},
+		{name: "default 1h", period: time.Hour, expect: DefaultRetention[2]},
+		{name: "default 24h", period: 24 * time.Hour, expect: DefaultRetention[3]},
+		{name: "default 7d", period: 7 * 24 * time.Hour, expect: DefaultRetention[4]},
+		{name: "default 30d", period: 30 * 24 * time.Hour, expect: DefaultRetention[4]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, TierForPeriod(test.tiers, test.period))
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/observiq/bindplane-op/otlp/record"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// ThroughputExpiration is the time after which the throughput of an agent is no longer exported if no new measurements
+// are received, e.g. because the agent disconnected or its configuration changed.
+var ThroughputExpiration = 10 * time.Minute
+
+// ThroughputMaxSeries is the maximum number of series exported. Measurements for new series are not exported once the
+// limit is reached until existing series expire.
+var ThroughputMaxSeries = 10000
+
+// ThroughputExporter is a MeasurementBatcher that exports the throughput measurements of agents as the
+// agent_throughput_bytes metric before passing them to the batcher. The metric is exported with the rest of the server
+// metrics, e.g. on the Prometheus /metrics endpoint or pushed to an OTLP endpoint. Each series is labeled with the
+// agent, configuration, position (s0, s1, d0, d1), telemetry type, and the name of the source or destination.
+//
+// Because of the agent label, the number of series grows with the number of agents: each agent adds a series for every
+// source and destination of its configuration and every telemetry type they measure, e.g. 1000 agents with 2 sources
+// and 2 destinations of logs and metrics export 8000 series. The number of series is limited to ThroughputMaxSeries.
+type ThroughputExporter struct {
+	MeasurementBatcher
+	logger  *zap.Logger
+	metrics metric.Registration
+
+	mtx    sync.Mutex
+	series map[throughputSeries]throughputValue
+}
+
+var _ MeasurementBatcher = (*ThroughputExporter)(nil)
+
+// throughputSeries identifies the measurements of a processor of a configuration on an agent
+type throughputSeries struct {
+	metric        string
+	agent         string
+	configuration string
+	processor     string
+}
+
+// throughputValue is the latest cumulative value of a series, its timestamp, and when it was received
+type throughputValue struct {
+	value     float64
+	timestamp time.Time
+	received  time.Time
+}
+
+// NewThroughputExporter returns a ThroughputExporter that passes measurements to the batcher
+func NewThroughputExporter(logger *zap.Logger, batcher MeasurementBatcher) *ThroughputExporter {
+	exporter := &ThroughputExporter{
+		MeasurementBatcher: batcher,
+		logger:             logger.Named("throughput_exporter"),
+		series:             map[throughputSeries]throughputValue{},
+	}
+	exporter.setupMetrics()
+	return exporter
+}
+
+// setupMetrics reports the latest value of each series that has not expired
+func (t *ThroughputExporter) setupMetrics() {
+	throughput, err := mp.Float64ObservableCounter("agent_throughput_bytes",
+		metric.WithDescription("Bytes of telemetry measured by the throughput measurement processors of agents"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		t.logger.Warn("failed to create agent_throughput_bytes metric", zap.Error(err))
+		return
+	}
+
+	t.metrics, err = mp.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+
+		expired := time.Now().Add(-ThroughputExpiration)
+		for series, value := range t.series {
+			if value.received.Before(expired) {
+				delete(t.series, series)
+				continue
+			}
+			position, telemetryType, name := ParseProcessorName(series.processor)
+			o.ObserveFloat64(throughput, value.value, metric.WithAttributes(
+				attribute.String(AgentAttributeName, series.agent),
+				attribute.String(ConfigurationAttributeName, series.configuration),
+				attribute.String("position", position),
+				attribute.String("telemetry_type", telemetryType),
+				attribute.String("resource", name),
+			))
+		}
+		return nil
+	}, throughput)
+	if err != nil {
+		t.logger.Warn("failed to register agent_throughput_bytes callback", zap.Error(err))
+	}
+}
+
+// AcceptMetrics records the latest value of each throughput measurement and passes the metrics to the batcher
+func (t *ThroughputExporter) AcceptMetrics(ctx context.Context, metrics []*record.Metric) error {
+	t.record(metrics)
+	return t.MeasurementBatcher.AcceptMetrics(ctx, metrics)
+}
+
+// Shutdown stops exporting throughput and shuts down the batcher
+func (t *ThroughputExporter) Shutdown(ctx context.Context) error {
+	if t.metrics != nil {
+		if err := t.metrics.Unregister(); err != nil {
+			t.logger.Warn("failed to unregister agent_throughput_bytes callback", zap.Error(err))
+		}
+	}
+	return t.MeasurementBatcher.Shutdown(ctx)
+}
+
+func (t *ThroughputExporter) record(metrics []*record.Metric) {
+	now := time.Now()
+	dropped := 0
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	for _, m := range metrics {
+		if !supportedMetricName(m.Name) {
+			continue
+		}
+		value, ok := Value(m)
+		if !ok {
+			continue
+		}
+		if position, _, _ := ProcessorParsed(m); position == "" {
+			continue
+		}
+		series := throughputSeries{
+			metric:        m.Name,
+			agent:         Agent(m),
+			configuration: Configuration(m),
+			processor:     Processor(m),
+		}
+		current, ok := t.series[series]
+		if !ok && len(t.series) >= ThroughputMaxSeries {
+			dropped++
+			continue
+		}
+		// ignore measurements older than the latest value, e.g. multiple measurements in a batch
+		if ok && current.timestamp.After(m.Timestamp) {
+			continue
+		}
+		t.series[series] = throughputValue{value: value, timestamp: m.Timestamp, received: now}
+	}
+
+	if dropped > 0 {
+		t.logger.Warn("agent_throughput_bytes series limit reached, dropping measurements",
+			zap.Int("limit", ThroughputMaxSeries), zap.Int("dropped", dropped))
+	}
+}
+
+func supportedMetricName(name string) bool {
+	for _, supported := range SupportedMetricNames {
+		if name == supported {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/otlp/record"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+// testBatcher records the metrics it accepts
+type testBatcher struct {
+	accepted []*record.Metric
+	shutdown bool
+}
+
+func (b *testBatcher) AcceptMetrics(_ context.Context, metrics []*record.Metric) error {
+	b.accepted = append(b.accepted, metrics...)
+	return nil
+}
+
+func (b *testBatcher) Shutdown(_ context.Context) error {
+	b.shutdown = true
+	return nil
+}
+
+func TestThroughputExporter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	now := time.Now()
+	throughputMetric := func(name, processor string, timestamp time.Time, value float64) *record.Metric {
+		return &record.Metric{
+			Name:      name,
+			Timestamp: timestamp,
+			Value:     value,
+			Attributes: map[string]interface{}{
+				AgentAttributeName:         "agent-1",
+				ConfigurationAttributeName: "config-1",
+				ProcessorAttributeName:     processor,
+			},
+		}
+	}
+
+	collect := func(t *testing.T) []metricdata.DataPoint[float64] {
+		rm := metricdata.ResourceMetrics{}
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == "agent_throughput_bytes" {
+					sum, ok := m.Data.(metricdata.Sum[float64])
+					require.True(t, ok)
+					require.True(t, sum.IsMonotonic)
+					return sum.DataPoints
+				}
+			}
+		}
+		return nil
+	}
+
+	batcher := &testBatcher{}
+	exporter := NewThroughputExporter(zap.NewNop(), batcher)
+
+	metrics := []*record.Metric{
+		throughputMetric(LogDataSizeMetricName, "throughputmeasurement/_d1_logs_google", now.Add(-10*time.Second), 100),
+		throughputMetric(LogDataSizeMetricName, "throughputmeasurement/_d1_logs_google", now, 200),
+		throughputMetric(LogDataSizeMetricName, "throughputmeasurement/_d1_logs_google", now.Add(-20*time.Second), 50),
+		throughputMetric(MetricDataSizeMetricName, "throughputmeasurement/_s0_metrics_host", now, 300),
+		throughputMetric("otelcol_processor_other", "throughputmeasurement/_s0_metrics_host", now, 400),
+		throughputMetric(TraceDataSizeMetricName, "batch", now, 500),
+	}
+	require.NoError(t, exporter.AcceptMetrics(context.Background(), metrics))
+	require.Equal(t, metrics, batcher.accepted, "all metrics are passed to the batcher")
+
+	points := collect(t)
+	require.Len(t, points, 2)
+
+	values := map[attribute.Set]float64{}
+	for _, point := range points {
+		values[point.Attributes] = point.Value
+	}
+	require.Equal(t, map[attribute.Set]float64{
+		attribute.NewSet(
+			attribute.String("agent", "agent-1"),
+			attribute.String("configuration", "config-1"),
+			attribute.String("position", "d1"),
+			attribute.String("telemetry_type", "logs"),
+			attribute.String("resource", "google"),
+		): 200,
+		attribute.NewSet(
+			attribute.String("agent", "agent-1"),
+			attribute.String("configuration", "config-1"),
+			attribute.String("position", "s0"),
+			attribute.String("telemetry_type", "metrics"),
+			attribute.String("resource", "host"),
+		): 300,
+	}, values)
+
+	t.Run("series expire", func(t *testing.T) {
+		expiration := ThroughputExpiration
+		defer func() { ThroughputExpiration = expiration }()
+		ThroughputExpiration = 0
+
+		require.Empty(t, collect(t))
+	})
+
+	t.Run("series are limited", func(t *testing.T) {
+		maxSeries := ThroughputMaxSeries
+		defer func() { ThroughputMaxSeries = maxSeries }()
+		ThroughputMaxSeries = 2
+
+		require.NoError(t, exporter.AcceptMetrics(context.Background(), []*record.Metric{
+			throughputMetric(LogDataSizeMetricName, "throughputmeasurement/_d1_logs_google", now, 200),
+			throughputMetric(LogDataSizeMetricName, "throughputmeasurement/_d0_logs_splunk", now, 100),
+			throughputMetric(MetricDataSizeMetricName, "throughputmeasurement/_s0_metrics_host", now, 300),
+			throughputMetric(MetricDataSizeMetricName, "throughputmeasurement/_s1_metrics_redis", now, 400),
+		}))
+		require.Len(t, collect(t), 2)
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		require.NoError(t, exporter.Shutdown(context.Background()))
+		require.True(t, batcher.shutdown)
+
+		require.NoError(t, exporter.AcceptMetrics(context.Background(), metrics))
+		require.Empty(t, collect(t))
+	})
+}
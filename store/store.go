@@ -48,8 +48,36 @@ type Options struct {
 	MaxEventsToMerge int
 	// DisableMeasurementsCleanup indicates that the store should not clean up measurements. This is useful for testing.
 	DisableMeasurementsCleanup bool
+	// MeasurementsRetention are the retention tiers used to roll up and clean up measurements. If it is empty,
+	// stats.DefaultRetention is used.
+	MeasurementsRetention []stats.RetentionTier
+	// AuditEventsRetention is how long audit events are kept. Older events are removed periodically. If it is 0, audit
+	// events are kept forever.
+	AuditEventsRetention time.Duration
 	// DisableRolloutUpdater indicates that the store should not update rollouts. This is useful for testing.
 	DisableRolloutUpdater bool
+	// EventBroadcast builds the broadcast used to deliver updates. If it is nil, updates are only delivered to
+	// subscribers on this server.
+	EventBroadcast BroadCastBuilder[BasicEventUpdates]
+	// ParameterCipher encrypts sensitive parameter values before they are written to the store. If it is nil, they are
+	// stored in plaintext. It is not used by the in-memory mapstore.
+	ParameterCipher *ParameterCipher
+}
+
+// eventBroadcast returns the configured EventBroadcast or the local broadcast if none is configured
+func (o Options) eventBroadcast() BroadCastBuilder[BasicEventUpdates] {
+	if o.EventBroadcast != nil {
+		return o.EventBroadcast
+	}
+	return BuildBasicEventBroadcast()
+}
+
+// measurementsRetention returns the configured MeasurementsRetention or the default retention if none is configured
+func (o Options) measurementsRetention() []stats.RetentionTier {
+	if len(o.MeasurementsRetention) > 0 {
+		return o.MeasurementsRetention
+	}
+	return stats.DefaultRetention
 }
 
 // Store handles interacting with a storage backend,
@@ -127,6 +155,26 @@ type Store interface {
 	DestinationType(ctx context.Context, name string) (*model.DestinationType, error)
 	DestinationTypes(ctx context.Context) ([]*model.DestinationType, error)
 	DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error)
+
+	// Alert returns the alert with the specified name. If the alert does not exist, nil is returned with no error.
+	Alert(ctx context.Context, name string) (*model.Alert, error)
+	// Alerts returns all alerts sorted by name
+	Alerts(ctx context.Context) ([]*model.Alert, error)
+	DeleteAlert(ctx context.Context, name string) (*model.Alert, error)
+	// UpdateAlertStatus replaces the status of the alert with the specified name without creating a new version of the
+	// alert or notifying subscribers. If the alert does not exist, nil is returned with no error.
+	UpdateAlertStatus(ctx context.Context, name string, status model.AlertStatus) (*model.Alert, error)
+
+	// Webhook returns the webhook with the specified name. If the webhook does not exist, nil is returned with no error.
+	Webhook(ctx context.Context, name string) (*model.Webhook, error)
+	// Webhooks returns all webhooks sorted by name
+	Webhooks(ctx context.Context) ([]*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, name string) (*model.Webhook, error)
+	// AddWebhookDelivery adds the delivery to the delivery log of the webhook with the specified name without creating
+	// a new version of the webhook or notifying subscribers. If the webhook does not exist, nil is returned with no
+	// error.
+	AddWebhookDelivery(ctx context.Context, name string, delivery model.WebhookDelivery) (*model.Webhook, error)
+
 	// ApplyResources inserts or updates the specified resources. The resulting status of each resource is returned. The
 	// resource may be modified as a result of the operation. If the caller needs to preserve the original resource,
 	// model.Clone can be used to create a copy.
@@ -172,6 +220,54 @@ type Store interface {
 	// UserSessions must implement the gorilla sessions.Store interface
 	UserSessions() sessions.Store
 
+	// User returns the user with the specified name. If the user does not exist, nil is returned with no error.
+	User(ctx context.Context, name string) (*model.User, error)
+
+	// Users returns all users sorted by name
+	Users(ctx context.Context) ([]*model.User, error)
+
+	// UpsertUser adds the user or replaces an existing user with the same name. It returns an error if the user is not
+	// valid.
+	UpsertUser(ctx context.Context, user *model.User) error
+
+	// DeleteUser removes the user with the specified name and returns it. If the user does not exist, nil is returned
+	// with no error.
+	DeleteUser(ctx context.Context, name string) (*model.User, error)
+
+	// APIKey returns the API key with the specified ID. If the key does not exist, nil is returned with no error.
+	APIKey(ctx context.Context, id string) (*model.APIKey, error)
+
+	// APIKeys returns all API keys sorted by ID
+	APIKeys(ctx context.Context) ([]*model.APIKey, error)
+
+	// UpsertAPIKey adds the API key or replaces an existing key with the same ID. It returns an error if the key is not
+	// valid.
+	UpsertAPIKey(ctx context.Context, apiKey *model.APIKey) error
+
+	// DeleteAPIKey removes the API key with the specified ID and returns it. If the key does not exist, nil is returned
+	// with no error.
+	DeleteAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+
+	// AddAuditEvents records the audit events
+	AddAuditEvents(ctx context.Context, events []*model.AuditEvent) error
+
+	// AuditEvents returns the audit events that match the filter, starting with the most recent
+	AuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error)
+
+	// SaveSnapshot saves the snapshot or replaces an existing snapshot with the same ID
+	SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error
+
+	// Snapshots returns the summaries of all snapshots without their data, starting with the most recent
+	Snapshots(ctx context.Context) ([]*model.Snapshot, error)
+
+	// Snapshot returns the snapshot with the specified ID including its data. If the snapshot does not exist, nil is
+	// returned with no error.
+	Snapshot(ctx context.Context, id string) (*model.Snapshot, error)
+
+	// DeleteSnapshot removes the snapshot with the specified ID and returns it. If the snapshot does not exist, nil is
+	// returned with no error.
+	DeleteSnapshot(ctx context.Context, id string) (*model.Snapshot, error)
+
 	// Measurements stores stats for agents and configurations
 	Measurements() stats.Measurements
 
@@ -194,6 +290,7 @@ type Store interface {
 	// For RolloutStatusError - it will increase the maxErrors of the
 	// rollout by the current number of errors + 1.
 	// For RolloutStatusStarted - it will pause the rollout.
+	// For a rollout paused after its canary phase - it will approve the canary phase.
 	ResumeRollout(ctx context.Context, configurationName string) (*model.Configuration, error)
 
 	// UpdateRollout updates a rollout in progress. Does nothing if the rollout does not have a RolloutStatusStarted
@@ -533,8 +630,9 @@ func Seed(ctx context.Context, store Store, logger *zap.Logger, files embed.FS,
 
 // UpdateRolloutMetrics finds the agent counts for the configuration, updates the rollout status on the configuration,
 // and returns a slice of the agent IDs that are waiting for a rollout and the number of those agents that should be
-// moved into the pending state.
-func UpdateRolloutMetrics(ctx context.Context, agentIndex search.Index, config *model.Configuration) ([]string, int, error) {
+// moved into the pending state. The throughput is used to evaluate the health check of the rollout and should be
+// measured with MeasureRolloutThroughput.
+func UpdateRolloutMetrics(ctx context.Context, agentIndex search.Index, config *model.Configuration, throughput model.RolloutThroughput) ([]string, int, error) {
 	nameAndVersion := config.NameAndVersion()
 	agentsComplete, err := FindAgents(ctx, agentIndex, model.FieldRolloutComplete, nameAndVersion)
 	if err != nil {
@@ -553,15 +651,146 @@ func UpdateRolloutMetrics(ctx context.Context, agentIndex search.Index, config *
 		return nil, 0, err
 	}
 
-	newAgentsPending := config.Status.Rollout.UpdateStatus(model.RolloutProgress{
+	// agents matching the canary selector are updated first
+	if selector := config.Status.Rollout.Options.CanarySelector; selector != nil && config.Status.Rollout.Phase == 0 {
+		var canary int
+		agentsWaiting, canary = canaryAgentsFirst(ctx, agentIndex, selector, agentsWaiting)
+		config.Status.Rollout.SetCanaryAgents(canary)
+	}
+
+	progress := model.RolloutProgress{
 		Completed: len(agentsComplete),
 		Errors:    len(agentsError),
 		Pending:   len(agentsPending),
 		Waiting:   len(agentsWaiting),
-	})
+	}
+
+	// pause or resume the rollout based on its StartAt and MaintenanceWindow and check the health of the current phase
+	// before advancing it
+	now := time.Now()
+	config.Status.Rollout.ApplySchedule(now)
+	config.Status.Rollout.ApplyHealthCheck(now, progress, throughput)
+
+	newAgentsPending := config.Status.Rollout.UpdateStatus(progress)
 	return agentsWaiting, newAgentsPending, nil
 }
 
+// canaryAgentsFirst orders the waiting agents so that agents matching the canary selector are first and returns the
+// number of matching agents
+func canaryAgentsFirst(ctx context.Context, agentIndex search.Index, selector *model.AgentSelector, agentsWaiting []string) ([]string, int) {
+	matching := map[string]bool{}
+	for _, id := range agentIndex.Select(ctx, selector.MatchLabels) {
+		matching[id] = true
+	}
+	ordered := make([]string, 0, len(agentsWaiting))
+	var others []string
+	for _, id := range agentsWaiting {
+		if matching[id] {
+			ordered = append(ordered, id)
+		} else {
+			others = append(others, id)
+		}
+	}
+	canary := len(ordered)
+	return append(ordered, others...), canary
+}
+
+// FindRolloutAgents returns the IDs of the agents that received the configuration name and version during its rollout
+// and the IDs of the agents that are still waiting for it. It is used to roll back a failed rollout.
+func FindRolloutAgents(ctx context.Context, agentIndex search.Index, nameAndVersion string) (received []string, waiting []string, err error) {
+	for _, field := range []string{model.FieldRolloutComplete, model.FieldRolloutError, model.FieldRolloutPending} {
+		ids, err := FindAgents(ctx, agentIndex, field, nameAndVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		received = append(received, ids...)
+	}
+	waiting, err = FindAgents(ctx, agentIndex, model.FieldRolloutWaiting, nameAndVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	return received, waiting, nil
+}
+
+// shouldRollback returns true if the rollout of the configuration just failed and should be rolled back
+func shouldRollback(config *model.Configuration, oldRolloutStatus model.RolloutStatus) bool {
+	rollout := config.Status.Rollout
+	return rollout.Status == model.RolloutStatusError && oldRolloutStatus != model.RolloutStatusError && rollout.Options.RollbackOnFailure
+}
+
+// rolloutHealthPeriod is the period of the measurements used for rollout health checks
+const rolloutHealthPeriod = 5 * time.Minute
+
+// agentMetrics is the part of stats.Measurements used for rollout health checks
+type agentMetrics interface {
+	AgentMetrics(ctx context.Context, ids []string, options ...stats.QueryOption) (stats.MetricData, error)
+}
+
+// MeasureRolloutThroughput measures the throughput used by the health check of a started rollout. The baseline
+// throughput of agents with the previous version is only measured until it has been recorded. Measurements are queried
+// separately from the transaction used to update the rollout.
+func MeasureRolloutThroughput(ctx context.Context, agentIndex search.Index, measurements agentMetrics, config *model.Configuration) (model.RolloutThroughput, error) {
+	result := model.RolloutThroughput{}
+	if config == nil {
+		return result, nil
+	}
+	rollout := config.Status.Rollout
+	if rollout.Options.HealthCheck == nil || rollout.Status != model.RolloutStatusStarted {
+		return result, nil
+	}
+
+	nameAndVersion := config.NameAndVersion()
+	if rollout.Health == nil || rollout.Health.Baseline == nil {
+		agentsWaiting, err := FindAgents(ctx, agentIndex, model.FieldRolloutWaiting, nameAndVersion)
+		if err != nil {
+			return result, err
+		}
+		if result.Previous, err = destinationThroughputPerAgent(ctx, measurements, agentsWaiting); err != nil {
+			return result, err
+		}
+	}
+
+	agentsComplete, err := FindAgents(ctx, agentIndex, model.FieldRolloutComplete, nameAndVersion)
+	if err != nil {
+		return result, err
+	}
+	result.Current, err = destinationThroughputPerAgent(ctx, measurements, agentsComplete)
+	return result, err
+}
+
+// destinationThroughputPerAgent returns the average throughput of destinations after processors of the agents that
+// reported measurements or nil if none of the agents reported measurements.
+func destinationThroughputPerAgent(ctx context.Context, measurements agentMetrics, agentIDs []string) (*float64, error) {
+	// AgentMetrics returns metrics for all agents if no IDs are specified
+	if len(agentIDs) == 0 {
+		return nil, nil
+	}
+	metrics, err := measurements.AgentMetrics(ctx, agentIDs, stats.WithPeriod(rolloutHealthPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	agentThroughput := map[string]float64{}
+	for _, m := range metrics {
+		if position, _, _ := stats.ProcessorParsed(m); position != string(model.MeasurementPositionDestinationAfterProcessors) {
+			continue
+		}
+		if value, ok := stats.Value(m); ok {
+			agentThroughput[stats.Agent(m)] += value
+		}
+	}
+	if len(agentThroughput) == 0 {
+		return nil, nil
+	}
+
+	total := 0.0
+	for _, throughput := range agentThroughput {
+		total += throughput
+	}
+	average := total / float64(len(agentThroughput))
+	return &average, nil
+}
+
 // SeedSearchIndexes seeds the search indexes with the current data in the store
 func SeedSearchIndexes(ctx context.Context, store Store, logger *zap.Logger) {
 	ctx, span := tracer.Start(ctx, "store/seedSearchIndexes")
@@ -705,6 +934,18 @@ func ApplySortOffsetAndLimit[T any](list []T, opts QueryOptions, fieldAccessor m
 	return ApplyOffsetAndLimit[T](list, opts)
 }
 
+// snapshotSummaries returns the summaries of the snapshots sorted by ID, starting with the most recent
+func snapshotSummaries(snapshots []*model.Snapshot) []*model.Snapshot {
+	summaries := make([]*model.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		summaries = append(summaries, snapshot.Summary())
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID > summaries[j].ID
+	})
+	return summaries
+}
+
 // ApplyOffsetAndLimit applies the offset and limit options to the list.
 func ApplyOffsetAndLimit[T any](list []T, opts QueryOptions) []T {
 	if opts.Offset != 0 {
@@ -26,6 +26,27 @@ export const LoginPage: React.FC = () => {
     }
   }, [navigate]);
 
+  // The server redirects here with the user after a single sign-on login.
+  useEffect(() => {
+    const ssoUser = new URLSearchParams(window.location.search).get("user");
+    if (ssoUser == null) {
+      return;
+    }
+
+    async function completeSSOLogin(user: string) {
+      const resp = await fetch("/verify");
+      if (!resp.ok) {
+        setInvalidCreds(true);
+        return;
+      }
+
+      localStorage.setItem("user", user);
+      navigate("/agents");
+    }
+
+    completeSSOLogin(ssoUser);
+  }, [navigate]);
+
   async function handleLogin(e: React.FormEvent<HTMLFormElement>) {
     e.preventDefault();
 
@@ -0,0 +1,241 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhooks delivers store updates to the URLs of Webhook resources.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/observiq/bindplane-op/eventbus"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("webhooks")
+
+// Dispatcher subscribes to store updates and delivers the events matching each Webhook to its URL
+type Dispatcher interface {
+	// Start subscribes to store updates and starts delivering events
+	Start(ctx context.Context)
+
+	// Stop unsubscribes from store updates and waits for deliveries in progress to finish or for the context to be
+	// done. Deliveries waiting to be retried are abandoned.
+	Stop(ctx context.Context) error
+}
+
+type dispatcher struct {
+	store  store.Store
+	sender *sender
+	logger *zap.Logger
+
+	// now is replaced in tests
+	now func() time.Time
+
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+}
+
+var _ Dispatcher = (*dispatcher)(nil)
+
+// NewDispatcher returns a new Dispatcher for the webhooks in the store
+func NewDispatcher(s store.Store, logger *zap.Logger) Dispatcher {
+	return &dispatcher{
+		store:  s,
+		sender: newSender(),
+		logger: logger.Named("webhooks"),
+		now:    time.Now,
+	}
+}
+
+// Start subscribes to store updates. Updates are already merged by the store so each delivery may contain several
+// events.
+func (d *dispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancelCause(ctx)
+
+	// subscribe before starting the goroutine so that no updates are missed after Start returns
+	updates, unsubscribe := eventbus.Subscribe(ctx, d.store.Updates(ctx), eventbus.WithChannel(make(chan store.BasicEventUpdates, 1_000)))
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				d.dispatch(ctx, u)
+			}
+		}
+	}()
+}
+
+// Stop stops delivering events
+func (d *dispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return errors.New("webhook dispatcher was not started")
+	}
+	d.cancel(errors.New("stop called"))
+
+	doneChan := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(doneChan)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-doneChan:
+		return nil
+	}
+}
+
+// dispatch starts a delivery to each webhook with events matching the updates. Deliveries happen concurrently so that
+// a slow or failing webhook doesn't delay the others.
+func (d *dispatcher) dispatch(ctx context.Context, updates store.BasicEventUpdates) {
+	ctx, span := tracer.Start(ctx, "webhooks/dispatch")
+	defer span.End()
+
+	events := webhookEvents(updates)
+	if len(events) == 0 {
+		return
+	}
+
+	// the secrets are needed to sign the payloads
+	webhooks, err := d.store.Webhooks(model.ContextWithoutSensitiveParameterMasking(ctx))
+	if err != nil {
+		d.logger.Error("failed to get webhooks", zap.Error(err))
+		return
+	}
+
+	for _, webhook := range webhooks {
+		matching := matchingEvents(webhook, events)
+		if len(matching) == 0 {
+			continue
+		}
+		payload := &model.WebhookPayload{
+			ID:      uuid.NewString(),
+			Webhook: webhook.Name(),
+			Time:    d.now(),
+			Events:  matching,
+		}
+
+		d.wg.Add(1)
+		go func(webhook *model.Webhook) {
+			defer d.wg.Done()
+			d.deliver(ctx, webhook, payload)
+		}(webhook)
+	}
+}
+
+// deliver sends the payload to the webhook and adds the result to its delivery log
+func (d *dispatcher) deliver(ctx context.Context, webhook *model.Webhook, payload *model.WebhookPayload) {
+	delivery := d.sender.send(ctx, &webhook.Spec, payload)
+	delivery.Time = d.now()
+	if !delivery.Succeeded() {
+		d.logger.Error("failed to deliver webhook",
+			zap.String("webhook", webhook.Name()),
+			zap.String("delivery", delivery.ID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.String("error", delivery.Error))
+	}
+
+	// record the delivery even if the dispatcher is stopping
+	if _, err := d.store.AddWebhookDelivery(context.Background(), webhook.Name(), delivery); err != nil {
+		d.logger.Error("failed to add webhook delivery", zap.String("webhook", webhook.Name()), zap.Error(err))
+	}
+}
+
+// ----------------------------------------------------------------------
+// events
+
+// labeledEvent is a WebhookEvent with the labels of its item, used to match webhook selectors
+type labeledEvent struct {
+	model.WebhookEvent
+	labels model.Labels
+}
+
+type labeledItem interface {
+	model.HasUniqueKey
+	GetLabels() model.Labels
+}
+
+var webhookEventTypes = map[store.EventType]model.WebhookEventType{
+	store.EventTypeInsert:  model.WebhookEventInsert,
+	store.EventTypeUpdate:  model.WebhookEventUpdate,
+	store.EventTypeRemove:  model.WebhookEventRemove,
+	store.EventTypeLabel:   model.WebhookEventLabel,
+	store.EventTypeRollout: model.WebhookEventRollout,
+}
+
+// webhookEvents returns the events of all kinds in the updates
+func webhookEvents(updates store.BasicEventUpdates) []labeledEvent {
+	var events []labeledEvent
+	events = appendEvents(events, model.KindAgent, updates.Agents())
+	events = appendEvents(events, model.KindAgentVersion, updates.AgentVersions())
+	events = appendEvents(events, model.KindConfiguration, updates.Configurations())
+	events = appendEvents(events, model.KindSource, updates.Sources())
+	events = appendEvents(events, model.KindSourceType, updates.SourceTypes())
+	events = appendEvents(events, model.KindProcessor, updates.Processors())
+	events = appendEvents(events, model.KindProcessorType, updates.ProcessorTypes())
+	events = appendEvents(events, model.KindDestination, updates.Destinations())
+	events = appendEvents(events, model.KindDestinationType, updates.DestinationTypes())
+	return events
+}
+
+// appendEvents appends the events of one kind sorted by name
+func appendEvents[T labeledItem](events []labeledEvent, kind model.Kind, updates store.Events[T]) []labeledEvent {
+	keys := updates.Keys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		event := updates[key]
+		eventType, ok := webhookEventTypes[event.Type]
+		if !ok {
+			continue
+		}
+		events = append(events, labeledEvent{
+			WebhookEvent: model.WebhookEvent{
+				Kind: kind,
+				Type: eventType,
+				Name: event.Item.UniqueKey(),
+				Item: event.Item,
+			},
+			labels: event.Item.GetLabels(),
+		})
+	}
+	return events
+}
+
+// matchingEvents returns the events that match the spec of the webhook
+func matchingEvents(webhook *model.Webhook, events []labeledEvent) []model.WebhookEvent {
+	var matching []model.WebhookEvent
+	for _, event := range events {
+		if webhook.Spec.Matches(event.Kind, event.Type, event.labels) {
+			matching = append(matching, event.WebhookEvent)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,205 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/observiq/bindplane-op/store"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testReceiver records the requests received by a webhook
+type testReceiver struct {
+	mtx      sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	// failures is the number of requests that fail before requests succeed
+	failures int
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func (r *testReceiver) count() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return len(r.requests)
+}
+
+func newTestDispatcher(t *testing.T) (store.Store, *dispatcher) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s := store.NewMapStore(ctx, store.Options{SessionsSecret: "super-secret-key", MaxEventsToMerge: 1, DisableRolloutUpdater: true}, zap.NewNop())
+	t.Cleanup(func() { s.Close() })
+
+	d := NewDispatcher(s, zap.NewNop()).(*dispatcher)
+	d.sender.initialBackoff = time.Millisecond
+	d.Start(ctx)
+	t.Cleanup(func() { require.NoError(t, d.Stop(context.Background())) })
+	return s, d
+}
+
+func testConfiguration(name string, labels map[string]string) *model.Configuration {
+	configuration := model.NewConfiguration(name)
+	configuration.Metadata.Labels = model.LabelsFromValidatedMap(labels)
+	return configuration
+}
+
+func TestDispatcherDeliversMatchingEvents(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestDispatcher(t)
+
+	receiver := &testReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	webhook := model.NewWebhook("prod-configurations", model.WebhookSpec{
+		URL:        server.URL,
+		Secret:     "shh",
+		Kinds:      []model.Kind{model.KindConfiguration},
+		EventTypes: []model.WebhookEventType{model.WebhookEventInsert},
+		Selector:   &model.AgentSelector{MatchLabels: model.MatchLabels{"env": "prod"}},
+	})
+	_, err := s.ApplyResources(ctx, []model.Resource{webhook})
+	require.NoError(t, err)
+
+	_, err = s.ApplyResources(ctx, []model.Resource{testConfiguration("dev", map[string]string{"env": "dev"})})
+	require.NoError(t, err)
+	_, err = s.ApplyResources(ctx, []model.Resource{testConfiguration("prod", map[string]string{"env": "prod"})})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return receiver.count() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	receiver.mtx.Lock()
+	req, body := receiver.requests[0], receiver.bodies[0]
+	receiver.mtx.Unlock()
+
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	require.Equal(t, Sign("shh", body), req.Header.Get(SignatureHeader))
+
+	var payload model.WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, "prod-configurations", payload.Webhook)
+	require.Equal(t, payload.ID, req.Header.Get(DeliveryHeader))
+	require.Len(t, payload.Events, 1)
+	require.Equal(t, model.KindConfiguration, payload.Events[0].Kind)
+	require.Equal(t, model.WebhookEventInsert, payload.Events[0].Type)
+	require.Equal(t, "prod", payload.Events[0].Name)
+
+	require.Eventually(t, func() bool {
+		webhook, err := s.Webhook(ctx, "prod-configurations")
+		return err == nil && len(webhook.Status.Deliveries) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	webhook, err = s.Webhook(ctx, "prod-configurations")
+	require.NoError(t, err)
+	delivery := webhook.Status.Deliveries[0]
+	require.Equal(t, payload.ID, delivery.ID)
+	require.True(t, delivery.Succeeded())
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, 1, delivery.Events)
+	require.Equal(t, http.StatusOK, delivery.StatusCode)
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestDispatcher(t)
+
+	tests := []struct {
+		name           string
+		failures       int
+		maxRetries     int
+		expectAttempts int
+		expectError    string
+	}{
+		{
+			name:           "succeeds after retry",
+			failures:       2,
+			maxRetries:     2,
+			expectAttempts: 3,
+		},
+		{
+			name:           "fails after retries",
+			failures:       3,
+			maxRetries:     1,
+			expectAttempts: 2,
+			expectError:    "unexpected response status 503 Service Unavailable",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receiver := &testReceiver{failures: test.failures}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			maxRetries := test.maxRetries
+			webhook := model.NewWebhook("agents", model.WebhookSpec{
+				URL:        server.URL,
+				Kinds:      []model.Kind{model.KindAgent},
+				MaxRetries: &maxRetries,
+			})
+			_, err := s.ApplyResources(ctx, []model.Resource{webhook})
+			require.NoError(t, err)
+
+			_, err = s.UpsertAgent(ctx, test.name, func(a *model.Agent) { a.Status = model.Connected })
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				webhook, err := s.Webhook(ctx, "agents")
+				return err == nil && len(webhook.Status.Deliveries) > 0
+			}, 2*time.Second, 10*time.Millisecond)
+
+			webhook, err = s.Webhook(ctx, "agents")
+			require.NoError(t, err)
+			delivery := webhook.Status.Deliveries[0]
+			require.Equal(t, test.expectAttempts, delivery.Attempts)
+			require.Equal(t, test.expectAttempts, receiver.count())
+			require.Equal(t, test.expectError, delivery.Error)
+
+			_, err = s.DeleteWebhook(ctx, "agents")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStopDispatcherNoStart(t *testing.T) {
+	d := NewDispatcher(nil, zap.NewNop())
+	require.ErrorContains(t, d.Stop(context.Background()), "was not started")
+}
+
+func TestSign(t *testing.T) {
+	// echo -n body | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=dc46983557fea127b43af721467eb9b3fde2338fe3e14f51952aa8478c13d355", Sign("secret", []byte("body")))
+}
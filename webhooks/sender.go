@@ -0,0 +1,132 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the body as "sha256=<hex>" if the webhook has a secret
+	SignatureHeader = "X-BindPlane-Signature"
+
+	// DeliveryHeader contains the ID of the payload. It is the same for each attempt to deliver the payload.
+	DeliveryHeader = "X-BindPlane-Delivery"
+
+	// requestTimeout is the timeout of each attempt
+	requestTimeout = 10 * time.Second
+
+	// initialBackoff is the delay before the first retry. It doubles with each retry up to maxBackoff.
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+// Sign returns the value of the SignatureHeader for the body signed with the secret. Receivers can use it to verify
+// that a payload was sent by BindPlane.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type sender struct {
+	client         *http.Client
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newSender() *sender {
+	return &sender{
+		client:         &http.Client{Timeout: requestTimeout},
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+	}
+}
+
+// send posts the payload to the url of the spec, retrying failed attempts with exponential backoff. It returns the
+// result of the delivery. Retries stop early if the context is done.
+func (s *sender) send(ctx context.Context, spec *model.WebhookSpec, payload *model.WebhookPayload) model.WebhookDelivery {
+	delivery := model.WebhookDelivery{
+		ID:     payload.ID,
+		Events: len(payload.Events),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		delivery.Error = fmt.Sprintf("failed to marshal payload: %s", err)
+		return delivery
+	}
+
+	backoff := s.initialBackoff
+	maxAttempts := spec.MaxRetriesOrDefault() + 1
+	for {
+		delivery.Attempts++
+		delivery.StatusCode, err = s.post(ctx, spec, payload.ID, body)
+		if err == nil {
+			delivery.Error = ""
+			return delivery
+		}
+		delivery.Error = err.Error()
+
+		if delivery.Attempts >= maxAttempts {
+			return delivery
+		}
+		select {
+		case <-ctx.Done():
+			return delivery
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// post makes a single attempt to deliver the body and returns the status code of the response
+func (s *sender) post(ctx context.Context, spec *model.WebhookSpec, id string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, id)
+	if spec.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(spec.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}